@@ -47,6 +47,11 @@ func main() {
 	notifRepo := repository.NewNotificationRepository(db)
 	adminRepo := repository.NewAdminRepository(db)
 	actionRepo := repository.NewUserActionRepository(db)
+	healthRepo := repository.NewExchangeHealthRepository(db)
+	scraperHealthRepo := repository.NewScraperHealthRepository(db)
+	scraperRunRepo := repository.NewScraperRunRepository(db)
+	revisionRepo := repository.NewOpportunityRevisionRepository(db)
+	scraperAnomalyRepo := repository.NewScraperAnomalyRepository(db)
 
 	// Create default admin if environment variables are set
 	if username := os.Getenv("ADMIN_DEFAULT_USERNAME"); username != "" {
@@ -72,6 +77,11 @@ func main() {
 		notifRepo,
 		adminRepo,
 		actionRepo,
+		healthRepo,
+		scraperHealthRepo,
+		scraperRunRepo,
+		revisionRepo,
+		scraperAnomalyRepo,
 	)
 
 	// Start server in goroutine
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/arbitrage"
+	"crypto-opportunities-bot/internal/arbitrage/websocket"
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/repository"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Backtest арбітражного детектора по записаних ордербуках:
+//
+//	go run ./cmd/backtest -from 2026-10-01 -to 2026-10-03 -min-profit 0.5 -amount 2000
+//
+// Параметри, не вказані у флагах, беруться з секції arbitrage конфігу.
+func main() {
+	configPath := flag.String("config", "./configs", "config directory")
+	dataDir := flag.String("data", "", "order book recordings directory (default: arbitrage.record_dir)")
+	fromFlag := flag.String("from", "", "start of range (2006-01-02 or RFC3339)")
+	toFlag := flag.String("to", "", "end of range (2006-01-02 or RFC3339)")
+	exchanges := flag.String("exchanges", "", "comma separated exchanges (default: all recorded)")
+
+	minProfit := flag.Float64("min-profit", -1, "override min_profit_percent")
+	maxSlippage := flag.Float64("max-slippage", -1, "override max_slippage")
+	amount := flag.Float64("amount", -1, "override amount (USD)")
+	maxSpread := flag.Float64("max-spread", -1, "override max_spread_percent")
+	minVolume := flag.Float64("min-volume", -1, "override min_volume_24h")
+	latency := flag.Duration("latency", 0, "delay between alert and simulated fill (e.g. 30s)")
+
+	compare := flag.Bool("compare", false, "compare with alerts stored in the database")
+	jsonOutput := flag.Bool("json", false, "print result as JSON")
+	flag.Parse()
+
+	arbCfg, err := config.LoadArbitrageConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	overrideFloat(&arbCfg.MinProfitPercent, *minProfit)
+	overrideFloat(&arbCfg.MaxSlippage, *maxSlippage)
+	overrideFloat(&arbCfg.Amount, *amount)
+	overrideFloat(&arbCfg.MaxSpreadPercent, *maxSpread)
+	overrideFloat(&arbCfg.MinVolume24h, *minVolume)
+
+	from, err := parseTime(*fromFlag, false)
+	if err != nil {
+		log.Fatalf("Invalid -from: %v", err)
+	}
+	to, err := parseTime(*toFlag, true)
+	if err != nil {
+		log.Fatalf("Invalid -to: %v", err)
+	}
+
+	dir := *dataDir
+	if dir == "" {
+		dir = arbCfg.RecordDir
+	}
+
+	var exchangeList []string
+	if *exchanges != "" {
+		exchangeList = strings.Split(*exchanges, ",")
+	}
+
+	paths, err := websocket.RecordingFiles(dir, from, to, exchangeList)
+	if err != nil {
+		log.Fatalf("Failed to list recordings: %v", err)
+	}
+	if len(paths) == 0 {
+		log.Fatalf("No recordings found in %s for the selected range", dir)
+	}
+
+	log.Printf("🧪 Backtesting %d recordings: min profit %.2f%%, max slippage %.2f%%, amount $%.0f, max spread %.2f%%, latency %s",
+		len(paths), arbCfg.MinProfitPercent, arbCfg.MaxSlippage, arbCfg.Amount, arbCfg.MaxSpreadPercent, *latency)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	backtester := arbitrage.NewBacktester(arbitrage.NewCalculator(), arbCfg, arbitrage.BacktestOptions{
+		From:        from,
+		To:          to,
+		FillLatency: *latency,
+	})
+
+	result, err := backtester.Run(ctx, paths)
+	if err != nil {
+		log.Fatalf("Backtest failed: %v", err)
+	}
+
+	var production map[string]int64
+	if *compare {
+		production, err = loadProductionAlerts(*configPath, result.From, result.To)
+		if err != nil {
+			log.Printf("⚠️ Failed to load production alerts: %v", err)
+		}
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			log.Fatalf("Failed to encode result: %v", err)
+		}
+		return
+	}
+
+	printReport(result, production)
+}
+
+// loadProductionAlerts рахує алерти, які реально створив бот за період
+func loadProductionAlerts(configPath string, from, to time.Time) (map[string]int64, error) {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := repository.InitDatabase(cfg.Database, cfg.App)
+	if err != nil {
+		return nil, err
+	}
+	defer repository.CloseDatabase(db)
+
+	return repository.NewArbitrageRepository(db).CountByPairBetween(from, to)
+}
+
+func printReport(result *arbitrage.BacktestResult, production map[string]int64) {
+	total := result.Total
+
+	fmt.Printf("\n📊 Backtest %s → %s (%d order book updates)\n\n",
+		result.From.Format(time.RFC3339), result.To.Format(time.RFC3339), result.Records)
+
+	fmt.Printf("Alerts fired:       %d (+%d suppressed by dedup TTL)\n", result.Alerts, result.Suppressed)
+	fmt.Printf("Filled:             %d (%d profitable)\n", total.Filled, total.Profitable)
+	fmt.Printf("Simulated P&L:      $%.2f\n", total.PnLUSD)
+	fmt.Printf("Avg detected profit %.3f%%\n", total.AvgDetectedProfit)
+	fmt.Printf("Avg lifetime:       %s\n", total.AvgLifetime.Round(time.Millisecond))
+
+	if len(result.Rejections) > 0 {
+		fmt.Printf("\nRejected checks:\n")
+		for _, reason := range sortedKeys(result.Rejections) {
+			fmt.Printf("  %-20s %d\n", reason, result.Rejections[reason])
+		}
+	}
+
+	printBreakdown("By pair", result.ByPair, production)
+	printBreakdown("By exchange pair", result.ByExchangePair, nil)
+}
+
+func printBreakdown(title string, rows map[string]*arbitrage.BacktestBreakdown, production map[string]int64) {
+	if len(rows) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return rows[keys[i]].PnLUSD > rows[keys[j]].PnLUSD
+	})
+
+	fmt.Printf("\n%s:\n", title)
+	fmt.Printf("  %-22s %7s %7s %7s %11s %9s %10s", "", "alerts", "filled", "profit", "P&L $", "avg %", "lifetime")
+	if production != nil {
+		fmt.Printf(" %6s", "prod")
+	}
+	fmt.Println()
+
+	for _, key := range keys {
+		row := rows[key]
+		fmt.Printf("  %-22s %7d %7d %7d %11.2f %9.3f %10s",
+			key, row.Alerts, row.Filled, row.Profitable, row.PnLUSD, row.AvgDetectedProfit, row.AvgLifetime.Round(time.Second))
+		if production != nil {
+			fmt.Printf(" %6d", production[key])
+		}
+		fmt.Println()
+	}
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func overrideFloat(target *float64, value float64) {
+	if value >= 0 {
+		*target = value
+	}
+}
+
+// parseTime приймає дату (2006-01-02) або RFC3339. Дата в -to означає кінець дня
+func parseTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected 2006-01-02 or RFC3339, got %q", value)
+	}
+
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return t, nil
+}
@@ -66,6 +66,17 @@ func main() {
 	analyticsRepo := repository.NewAnalyticsRepository(db)
 	referralRepo := repository.NewReferralRepository(db)
 	whaleRepo := repository.NewWhaleRepository(db)
+	feeRepo := repository.NewFeeRepository(db)
+	healthRepo := repository.NewExchangeHealthRepository(db)
+	fundingRepo := repository.NewFundingRepository(db)
+	clientTradeRepo := repository.NewClientTradeRepository(db)
+	clientStatsRepo := repository.NewClientStatisticsRepository(db)
+	priceAlertRepo := repository.NewPriceAlertRepository(db)
+	scraperHealthRepo := repository.NewScraperHealthRepository(db)
+	scraperRunRepo := repository.NewScraperRunRepository(db)
+	revisionRepo := repository.NewOpportunityRevisionRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	scraperAnomalyRepo := repository.NewScraperAnomalyRepository(db)
 
 	botAPI, err := tgbotapi.NewBotAPI(cfg.Telegram.BotToken)
 	if err != nil {
@@ -83,7 +94,11 @@ func main() {
 		arbRepo,
 		defiRepo,
 		whaleRepo,
+		feeRepo,
+		actionRepo,
 	)
+	notificationService.SetAdminIDs(cfg.Telegram.AdminIDs)
+	notificationService.SetArbitrageMinProfit(cfg.Arbitrage.MinProfitPercent)
 	log.Printf("✅ Notification service initialized")
 
 	// Analytics service
@@ -128,7 +143,15 @@ func main() {
 	referralService := referral.NewService(referralRepo, userRepo, subsRepo)
 	log.Printf("✅ Referral service initialized")
 
-	scraperService := scraper.NewScraperService(oppRepo)
+	scraperService := scraper.NewScraperService(oppRepo, scraperHealthRepo, scraperRunRepo, revisionRepo, projectRepo, scraperAnomalyRepo)
+	scraperService.SetRunConfig(scraper.RunConfig{
+		Concurrency:      cfg.Scraper.Concurrency,
+		Timeout:          time.Duration(cfg.Scraper.Timeout) * time.Second,
+		Retries:          cfg.Scraper.Retries,
+		RetryBackoff:     time.Duration(cfg.Scraper.RetryBackoff) * time.Second,
+		FailureThreshold: cfg.Scraper.FailureThreshold,
+		ProbeInterval:    time.Duration(cfg.Scraper.ProbeInterval) * time.Minute,
+	})
 	scraperService.RegisterScraper(scraper.NewBinanceScraper())
 	scraperService.RegisterScraper(scraper.NewBybitScraper())
 	scraperService.RegisterScraper(scraper.NewOKXScraper())
@@ -136,6 +159,19 @@ func main() {
 	scraperService.RegisterScraper(scraper.NewKrakenScraper())
 	log.Printf("✅ Registered 5 exchange scrapers (Binance, Bybit, OKX, Gate.io, Kraken)")
 
+	// Додаткові біржі з YAML описів (configs/scrapers)
+	if cfg.Scraper.DefinitionsDir != "" {
+		definitions, err := scraper.LoadDefinitions(cfg.Scraper.DefinitionsDir)
+		if err != nil {
+			log.Printf("⚠️ Failed to load scraper definitions: %v", err)
+		}
+
+		for _, def := range definitions {
+			scraperService.RegisterScraper(scraper.NewDefinitionScraper(def))
+			log.Printf("✅ Registered %s scraper from definition", def.Exchange)
+		}
+	}
+
 	scraperService.OnNewOpportunity(func(opp *models.Opportunity) {
 		log.Printf("📢 Creating notifications for: %s", opp.Title)
 		if err := notificationService.CreateOpportunityNotifications(opp); err != nil {
@@ -143,7 +179,21 @@ func main() {
 		}
 	})
 
+	scraperService.OnOpportunityUpdated(func(opp *models.Opportunity, revision *models.OpportunityRevision) {
+		log.Printf("📢 Creating update notifications for: %s", opp.Title)
+		if err := notificationService.CreateOpportunityUpdateNotifications(opp, revision); err != nil {
+			log.Printf("❌ Failed to create update notifications: %v", err)
+		}
+	})
+
+	scraperService.OnScraperAnomaly(func(exchange string, anomalies []*models.ScraperAnomaly) {
+		if err := notificationService.SendScraperAnomalyAlert(exchange, anomalies); err != nil {
+			log.Printf("❌ Failed to send scraper anomaly alert: %v", err)
+		}
+	})
+
 	// DeFi Scraper (Premium feature)
+	var defiScraper *scraper.DeFiScraper
 	if cfg.DeFi.Enabled {
 		defiScraperConfig := scraper.DeFiScraperConfig{
 			Chains:       cfg.DeFi.Chains,
@@ -154,7 +204,7 @@ func main() {
 			MinVolume24h: cfg.DeFi.MinVolume24h,
 		}
 
-		defiScraper := scraper.NewDeFiScraper(defiRepo, defiScraperConfig)
+		defiScraper = scraper.NewDeFiScraper(defiRepo, defiScraperConfig)
 
 		// Wire DeFi callbacks to notification system
 		defiScraper.OnNewDeFi(func(defi *models.DeFiOpportunity) {
@@ -237,6 +287,14 @@ func main() {
 	}
 	log.Printf("✅ Scraper scheduler started")
 
+	// Ручні запуски з admin API
+	scraperJobRunner := scraper.NewJobRunner(scraperService, scraperRunRepo)
+	if defiScraper != nil {
+		scraperJobRunner.RegisterStandalone(defiScraper)
+	}
+	scraperJobRunner.Start(10 * time.Second)
+	defer scraperJobRunner.Stop()
+
 	if cfg.App.Environment == "development" {
 		log.Println("Running initial scraping...")
 		if err := scraperScheduler.RunNow(); err != nil {
@@ -256,7 +314,7 @@ func main() {
 	defer digestScheduler.Stop()
 
 	// Cleanup Scheduler (daily at 2:00 AM)
-	cleanupScheduler := cleanup.NewScheduler(oppRepo, arbRepo, defiRepo, notifRepo, nil)
+	cleanupScheduler := cleanup.NewScheduler(oppRepo, arbRepo, fundingRepo, defiRepo, notifRepo, scraperRunRepo, nil)
 	if err := cleanupScheduler.Start(); err != nil {
 		log.Fatalf("Failed to start cleanup scheduler: %v", err)
 	}
@@ -285,11 +343,11 @@ func main() {
 	var arbitrageDetector *arbitrage.Detector
 	var premiumWatcher *time.Ticker
 	if cfg.Arbitrage.Enabled {
-		arbitrageDetector = startArbitrageMonitoring(cfg, arbRepo, userRepo, notificationService)
+		arbitrageDetector = startArbitrageMonitoring(cfg, arbRepo, userRepo, prefsRepo, feeRepo, healthRepo, fundingRepo, clientTradeRepo, clientStatsRepo, priceAlertRepo, notificationService)
 
 		// If arbitrage didn't start (no premium users), start watcher
 		if arbitrageDetector == nil {
-			premiumWatcher = startPremiumWatcher(cfg, arbRepo, userRepo, notificationService, &arbitrageDetector)
+			premiumWatcher = startPremiumWatcher(cfg, arbRepo, userRepo, prefsRepo, feeRepo, healthRepo, fundingRepo, clientTradeRepo, clientStatsRepo, priceAlertRepo, notificationService, &arbitrageDetector)
 		}
 	} else {
 		log.Printf("⚠️ Arbitrage monitoring disabled in config")
@@ -302,7 +360,7 @@ func main() {
 		defer premiumWatcher.Stop()
 	}
 
-	telegramBot, err := bot.NewBot(cfg, userRepo, prefsRepo, oppRepo, actionRepo, subsRepo, arbRepo, defiRepo, whaleRepo, paymentService, referralService)
+	telegramBot, err := bot.NewBot(cfg, userRepo, prefsRepo, oppRepo, actionRepo, subsRepo, arbRepo, defiRepo, whaleRepo, feeRepo, clientStatsRepo, priceAlertRepo, fundingRepo, paymentService, referralService)
 	if err != nil {
 		log.Fatalf("Failed to create bot: %v", err)
 	}
@@ -372,6 +430,13 @@ func startArbitrageMonitoring(
 	cfg *config.Config,
 	arbRepo repository.ArbitrageRepository,
 	userRepo repository.UserRepository,
+	prefsRepo repository.UserPreferencesRepository,
+	feeRepo repository.FeeRepository,
+	healthRepo repository.ExchangeHealthRepository,
+	fundingRepo repository.FundingRepository,
+	clientTradeRepo repository.ClientTradeRepository,
+	clientStatsRepo repository.ClientStatisticsRepository,
+	priceAlertRepo repository.PriceAlertRepository,
 	notificationService *notification.Service,
 ) *arbitrage.Detector {
 	// Перевірити чи є Premium користувачі
@@ -391,10 +456,23 @@ func startArbitrageMonitoring(
 
 	// Create OrderBook Manager
 	obManager := arbitrage.NewOrderBookManager()
+	obManager.SetHealthConfig(arbitrage.NewBookHealthConfig(&cfg.Arbitrage))
+	obManager.SetQuoteConfig(arbitrage.NewQuoteConfig(&cfg.Arbitrage))
 
 	// Initialize WebSocket managers for each exchange
 	ctx := context.Background()
 
+	// Recorder (запис ордербуків для replay/бектестів)
+	var recorder *websocket.Recorder
+	if cfg.Arbitrage.RecordEnabled {
+		recorder, err = websocket.NewRecorder(cfg.Arbitrage.RecordDir, cfg.Arbitrage.RecordDepth)
+		if err != nil {
+			log.Printf("⚠️ Order book recording disabled: %v", err)
+		} else {
+			log.Printf("📼 Recording order books to %s", cfg.Arbitrage.RecordDir)
+		}
+	}
+
 	for _, exchange := range cfg.Arbitrage.Exchanges {
 		var wsManager websocket.Manager
 
@@ -405,26 +483,35 @@ func startArbitrageMonitoring(
 			wsManager = websocket.NewBybitManager()
 		case "okx":
 			wsManager = websocket.NewOKXManager()
+		case "gateio":
+			wsManager = websocket.NewGateIOManager()
+		case "kraken":
+			wsManager = websocket.NewKrakenManager()
 		default:
 			log.Printf("⚠️ Unknown exchange: %s", exchange)
 			continue
 		}
 
+		if recorder != nil {
+			wsManager = websocket.NewRecordingManager(wsManager, recorder)
+		}
+
 		// Connect
 		if err := wsManager.Connect(ctx); err != nil {
 			log.Printf("❌ Failed to connect to %s: %v", exchange, err)
 			continue
 		}
 
-		// Subscribe to all pairs at once
-		if err := wsManager.Subscribe(cfg.Arbitrage.Pairs); err != nil {
+		// Subscribe to all pairs at once (+ пари в додаткових валютах котирування біржі)
+		pairs := cfg.Arbitrage.ExchangeSubscriptionPairs(exchange)
+		if err := wsManager.Subscribe(pairs); err != nil {
 			log.Printf("⚠️ Failed to subscribe to pairs on %s: %v", exchange, err)
 			continue
 		}
 
 		// Register with OrderBook Manager
 		obManager.RegisterExchange(exchange, wsManager)
-		log.Printf("✅ Connected to %s WebSocket (%d pairs)", exchange, len(cfg.Arbitrage.Pairs))
+		log.Printf("✅ Connected to %s WebSocket (%d pairs)", exchange, len(pairs))
 	}
 
 	// Validate that at least one exchange connected successfully
@@ -437,9 +524,46 @@ func startArbitrageMonitoring(
 
 	log.Printf("📊 Successfully connected to %d exchanges: %v", len(connectedExchanges), connectedExchanges)
 
+	// Стан ордербуків бірж для admin API (/arbitrage/exchanges)
+	arbitrage.NewBookHealthReporter(obManager, healthRepo).Start(30 * time.Second)
+
+	// Динамічний набір пар (топ спільних ринків за 24h обсягом)
+	var universe *arbitrage.SymbolUniverse
+	if cfg.Arbitrage.UniverseEnabled {
+		interval := time.Duration(cfg.Arbitrage.UniverseInterval) * time.Minute
+		if interval <= 0 {
+			interval = 30 * time.Minute
+		}
+
+		universe = arbitrage.NewSymbolUniverse(obManager, arbitrage.DefaultMarketFetchers(), &cfg.Arbitrage)
+		universe.Start(interval)
+	}
+
 	// Create Calculator
 	calculator := arbitrage.NewCalculator()
 
+	// Fee sync (trading/withdrawal fees з публічних endpoints бірж)
+	if cfg.Arbitrage.FeeSyncEnabled {
+		interval := time.Duration(cfg.Arbitrage.FeeSyncInterval) * time.Minute
+		if interval <= 0 {
+			interval = 6 * time.Hour
+		}
+
+		// Набір пар змінюється динамічно - withdrawal fees потрібні для всіх валют
+		currencies := arbitrage.CurrenciesFromPairs(cfg.Arbitrage.SubscriptionPairs())
+		if cfg.Arbitrage.UniverseEnabled {
+			currencies = nil
+		}
+
+		feeSyncer := arbitrage.NewFeeSyncer(
+			calculator,
+			feeRepo,
+			arbitrage.DefaultFeeFetchers(),
+			currencies,
+		)
+		feeSyncer.Start(interval)
+	}
+
 	// Create Deduplicator
 	deduplicator := arbitrage.NewDeduplicator(time.Duration(cfg.Arbitrage.DeduplicateTTL) * time.Minute)
 
@@ -453,6 +577,23 @@ func startArbitrageMonitoring(
 		&cfg.Arbitrage,
 	)
 
+	// Universe перепідписує біржі - зупиняється раніше за з'єднання детектора
+	if universe != nil {
+		detector.OnStop(universe.Stop)
+	}
+
+	// Transfer status (чи відкриті депозити/виведення в мережах)
+	if cfg.Arbitrage.TransferCheckEnabled {
+		interval := time.Duration(cfg.Arbitrage.TransferStatusInterval) * time.Minute
+		if interval <= 0 {
+			interval = 10 * time.Minute
+		}
+
+		transferStatus := arbitrage.NewTransferStatusProvider(arbitrage.DefaultTransferStatusFetchers())
+		transferStatus.Start(interval)
+		detector.SetTransferStatusProvider(transferStatus)
+	}
+
 	// Wire detector callbacks to notification system
 	detector.OnArbitrageDetected(func(arb *models.ArbitrageOpportunity) {
 		log.Printf("🔥 Arbitrage detected: %s (%.2f%% profit)", arb.Pair, arb.NetProfitPercent)
@@ -466,18 +607,126 @@ func startArbitrageMonitoring(
 	// Start detector
 	detector.Start()
 
+	// Funding rate / basis (spot ціни з obManager)
+	if cfg.Funding.Enabled {
+		fundingDetector := startFundingMonitoring(cfg, obManager, calculator, fundingRepo, notificationService)
+		detector.OnStop(fundingDetector.Stop)
+	}
+
+	// Розбіжності цін між біржами та depeg стейблкоїнів (mid prices з obManager)
+	if cfg.PriceAlerts.Enabled {
+		priceMonitor := startPriceMonitoring(cfg, obManager, priceAlertRepo, notificationService)
+		detector.OnStop(priceMonitor.Stop)
+	}
+
+	// Paper trading (симуляція доставлених алертів по ордербуках obManager)
+	if cfg.PaperTrading.Enabled {
+		paperTrader := arbitrage.NewPaperTrader(obManager, arbRepo, prefsRepo, clientTradeRepo, clientStatsRepo, feeRepo, &cfg.PaperTrading)
+		notificationService.OnDelivered(paperTrader.OnDelivered)
+		log.Printf("📝 Paper trading enabled (latency %dms)", cfg.PaperTrading.Latency)
+	}
+
 	log.Printf("✅ Arbitrage monitoring started")
 	log.Printf("   Pairs: %v", cfg.Arbitrage.Pairs)
+	if len(cfg.Arbitrage.ExchangeQuotes) > 0 {
+		log.Printf("   Quotes: %v → %s", cfg.Arbitrage.ExchangeQuotes, cfg.Arbitrage.GetReferenceQuote())
+	}
+	if cfg.Arbitrage.UniverseEnabled {
+		log.Printf("   Universe: top %d %s pairs on %d+ exchanges", cfg.Arbitrage.UniverseMaxSymbols, cfg.Arbitrage.GetReferenceQuote(), cfg.Arbitrage.UniverseMinExchanges)
+	}
 	log.Printf("   Exchanges: %v", cfg.Arbitrage.Exchanges)
 	log.Printf("   Min Profit: %.2f%%", cfg.Arbitrage.MinProfitPercent)
+	if cfg.Arbitrage.TriangularEnabled {
+		log.Printf("   Triangular: start %v, extra pairs %v", cfg.Arbitrage.TriangularStartCurrencies, cfg.Arbitrage.TriangularPairs)
+	}
 
 	return detector
 }
 
+func startFundingMonitoring(
+	cfg *config.Config,
+	obManager *arbitrage.OrderBookManager,
+	calculator *arbitrage.Calculator,
+	fundingRepo repository.FundingRepository,
+	notificationService *notification.Service,
+) *arbitrage.FundingDetector {
+	symbols := cfg.Funding.Pairs
+	if len(symbols) == 0 {
+		symbols = cfg.Arbitrage.Pairs
+	}
+
+	fundingDetector := arbitrage.NewFundingDetector(obManager, calculator, fundingRepo, &cfg.Funding, symbols)
+	ctx := context.Background()
+
+	for _, exchange := range cfg.Funding.Exchanges {
+		var perpManager websocket.PerpManager
+
+		switch exchange {
+		case "binance":
+			perpManager = websocket.NewBinancePerpManager()
+		case "bybit":
+			perpManager = websocket.NewBybitPerpManager()
+		case "okx":
+			perpManager = websocket.NewOKXPerpManager()
+		default:
+			log.Printf("⚠️ Perpetuals not supported for exchange: %s", exchange)
+			continue
+		}
+
+		if err := perpManager.Connect(ctx); err != nil {
+			log.Printf("❌ Failed to connect to %s perpetuals: %v", exchange, err)
+			continue
+		}
+
+		if err := perpManager.Subscribe(symbols); err != nil {
+			log.Printf("⚠️ Failed to subscribe to perpetuals on %s: %v", exchange, err)
+			continue
+		}
+
+		fundingDetector.RegisterExchange(exchange, perpManager)
+	}
+
+	fundingDetector.OnFundingDetected(func(opp *models.FundingOpportunity) {
+		if err := notificationService.CreateFundingNotifications(opp); err != nil {
+			log.Printf("❌ Failed to create funding notifications: %v", err)
+		}
+	})
+
+	fundingDetector.Start()
+
+	return fundingDetector
+}
+
+func startPriceMonitoring(
+	cfg *config.Config,
+	obManager *arbitrage.OrderBookManager,
+	priceAlertRepo repository.PriceAlertRepository,
+	notificationService *notification.Service,
+) *arbitrage.PriceMonitor {
+	priceMonitor := arbitrage.NewPriceMonitor(obManager, priceAlertRepo, &cfg.PriceAlerts, cfg.DepegPairs())
+
+	priceMonitor.OnAlert(func(alert *models.PriceAlert) {
+		if err := notificationService.CreatePriceAlertNotifications(alert); err != nil {
+			log.Printf("❌ Failed to create price alert notifications: %v", err)
+		}
+	})
+
+	priceMonitor.Start()
+
+	return priceMonitor
+}
+
 func startPremiumWatcher(
 	cfg *config.Config,
 	arbRepo repository.ArbitrageRepository,
 	userRepo repository.UserRepository,
+	prefsRepo repository.UserPreferencesRepository,
+	feeRepo repository.FeeRepository,
+	healthRepo repository.ExchangeHealthRepository,
+	fundingRepo repository.FundingRepository,
+	clientTradeRepo repository.ClientTradeRepository,
+	clientStatsRepo repository.ClientStatisticsRepository,
+	priceAlertRepo repository.PriceAlertRepository,
 	notificationService *notification.Service,
 	detectorPtr **arbitrage.Detector,
 ) *time.Ticker {
@@ -503,7 +752,7 @@ func startPremiumWatcher(
 				log.Printf("🎉 Premium user detected! Starting arbitrage monitoring...")
 
 				// Start arbitrage monitoring
-				detector := startArbitrageMonitoring(cfg, arbRepo, userRepo, notificationService)
+				detector := startArbitrageMonitoring(cfg, arbRepo, userRepo, prefsRepo, feeRepo, healthRepo, fundingRepo, clientTradeRepo, clientStatsRepo, priceAlertRepo, notificationService)
 				if detector != nil {
 					*detectorPtr = detector
 					log.Printf("✅ Arbitrage monitoring started successfully")
@@ -2,6 +2,7 @@ package analytics
 
 import (
 	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
 	"testing"
 	"time"
 )
@@ -175,7 +176,13 @@ func (m *mockActionRepo) CountByUserAndType(userID uint, actionType string, oppo
 	return 1, nil
 }
 
-type mockUserRepo struct{}
+func (m *mockActionRepo) GetUserIDsByOpportunity(opportunityID uint, actionType string) ([]uint, error) {
+	return []uint{}, nil
+}
+
+type mockUserRepo struct {
+	repository.UserRepository
+}
 
 func (m *mockUserRepo) GetByID(id uint) (*models.User, error) {
 	return &models.User{
@@ -184,7 +191,9 @@ func (m *mockUserRepo) GetByID(id uint) (*models.User, error) {
 	}, nil
 }
 
-type mockOppRepo struct{}
+type mockOppRepo struct {
+	repository.OpportunityRepository
+}
 
 func (m *mockOppRepo) ListActive(limit, offset int) ([]*models.Opportunity, error) {
 	return []*models.Opportunity{}, nil
@@ -4,22 +4,31 @@ import (
 	"crypto-opportunities-bot/internal/repository"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 )
 
 // ArbitrageHandler обробляє запити пов'язані з arbitrage
 type ArbitrageHandler struct {
-	arbRepo repository.ArbitrageRepository
+	arbRepo    repository.ArbitrageRepository
+	healthRepo repository.ExchangeHealthRepository
 }
 
 // NewArbitrageHandler створює новий ArbitrageHandler
-func NewArbitrageHandler(arbRepo repository.ArbitrageRepository) *ArbitrageHandler {
+func NewArbitrageHandler(
+	arbRepo repository.ArbitrageRepository,
+	healthRepo repository.ExchangeHealthRepository,
+) *ArbitrageHandler {
 	return &ArbitrageHandler{
-		arbRepo: arbRepo,
+		arbRepo:    arbRepo,
+		healthRepo: healthRepo,
 	}
 }
 
+// healthReportMaxAge якщо бот не оновлював стан довше - дані неактуальні
+const healthReportMaxAge = 2 * time.Minute
+
 // ListArbitrage повертає список arbitrage opportunities
 func (h *ArbitrageHandler) ListArbitrage(w http.ResponseWriter, r *http.Request) {
 	// Parse pagination
@@ -132,16 +141,61 @@ func (h *ArbitrageHandler) GetArbitrageStats(w http.ResponseWriter, r *http.Requ
 	respondJSON(w, http.StatusOK, stats)
 }
 
-// GetExchangeStatus повертає статус підключених бірж
-// TODO: This requires access to the arbitrage detector which runs in the bot
-// For now, return placeholder data
+// GetExchangeStatus повертає статус підключених бірж та їх ордербуків.
+// Детектор працює в процесі бота, тому стан береться зі знімка, який бот
+// періодично зберігає в БД
 func (h *ArbitrageHandler) GetExchangeStatus(w http.ResponseWriter, r *http.Request) {
-	// This would require access to the running arbitrage detector
-	// which is in the bot process, not the API process
-	// For now, return basic info
+	exchanges, err := h.healthRepo.List()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch exchange status")
+		return
+	}
+
+	botOnline := len(exchanges) > 0
+	summary := map[string]int{}
+
+	for _, exchange := range exchanges {
+		if exchange.IsReportStale(healthReportMaxAge) {
+			botOnline = false
+		}
+		summary[exchange.Status]++
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"exchanges":  exchanges,
+		"summary":    summary,
+		"bot_online": botOnline,
+	})
+}
+
+// GetExchangeTelemetry повертає телеметрію WebSocket фідів бірж (затримка,
+// частота оновлень, перепідключення, остання помилка) з останнього знімка бота
+func (h *ArbitrageHandler) GetExchangeTelemetry(w http.ResponseWriter, r *http.Request) {
+	exchanges, err := h.healthRepo.List()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch exchange telemetry")
+		return
+	}
+
+	botOnline := len(exchanges) > 0
+	telemetry := make([]map[string]interface{}, 0, len(exchanges))
+
+	for _, exchange := range exchanges {
+		if exchange.IsReportStale(healthReportMaxAge) {
+			botOnline = false
+		}
+
+		telemetry = append(telemetry, map[string]interface{}{
+			"exchange":    exchange.Exchange,
+			"connected":   exchange.Connected,
+			"status":      exchange.Status,
+			"reported_at": exchange.ReportedAt,
+			"telemetry":   exchange.Telemetry,
+		})
+	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"message": "Exchange status requires integration with bot process",
-		"note":    "This endpoint will be implemented when API and bot are integrated",
+		"exchanges":  telemetry,
+		"bot_online": botOnline,
 	})
 }
@@ -132,6 +132,12 @@ func (h *ClientAPIHandler) UpdateTrade(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Paper трейди симулюються сервером
+	if trade.IsPaper {
+		respondError(w, http.StatusForbidden, "Paper trades cannot be updated by client")
+		return
+	}
+
 	// Parse request
 	var req UpdateTradeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -13,13 +13,18 @@ import (
 
 // OpportunityHandler обробляє запити пов'язані з opportunities
 type OpportunityHandler struct {
-	oppRepo repository.OpportunityRepository
+	oppRepo      repository.OpportunityRepository
+	revisionRepo repository.OpportunityRevisionRepository
 }
 
 // NewOpportunityHandler створює новий OpportunityHandler
-func NewOpportunityHandler(oppRepo repository.OpportunityRepository) *OpportunityHandler {
+func NewOpportunityHandler(
+	oppRepo repository.OpportunityRepository,
+	revisionRepo repository.OpportunityRevisionRepository,
+) *OpportunityHandler {
 	return &OpportunityHandler{
-		oppRepo: oppRepo,
+		oppRepo:      oppRepo,
+		revisionRepo: revisionRepo,
 	}
 }
 
@@ -100,6 +105,58 @@ func (h *OpportunityHandler) GetOpportunity(w http.ResponseWriter, r *http.Reque
 	respondJSON(w, http.StatusOK, opp)
 }
 
+// GetOpportunityRevisions повертає історію змін opportunity, виявлених скраперами
+func (h *OpportunityHandler) GetOpportunityRevisions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid opportunity ID")
+		return
+	}
+
+	if opp, err := h.oppRepo.GetByID(uint(id)); err != nil || opp == nil {
+		respondError(w, http.StatusNotFound, "Opportunity not found")
+		return
+	}
+
+	limit := parseIntQuery(r, "limit", 50)
+	if limit > 500 {
+		limit = 500
+	}
+
+	revisions, err := h.revisionRepo.ListByOpportunity(uint(id), limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch opportunity revisions")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"opportunity_id": id,
+		"revisions":      revisions,
+		"count":          len(revisions),
+	})
+}
+
+// ListRevisions повертає останні зміни по всіх opportunities (material=true - лише важливі)
+func (h *OpportunityHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	limit := parseIntQuery(r, "limit", 50)
+	if limit > 500 {
+		limit = 500
+	}
+
+	materialOnly, _ := strconv.ParseBool(r.URL.Query().Get("material"))
+
+	revisions, err := h.revisionRepo.ListRecent(materialOnly, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch opportunity revisions")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"revisions": revisions,
+		"count":     len(revisions),
+	})
+}
+
 // CreateOpportunity створює новий opportunity вручну
 func (h *OpportunityHandler) CreateOpportunity(w http.ResponseWriter, r *http.Request) {
 	var req struct {
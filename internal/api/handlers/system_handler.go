@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"crypto-opportunities-bot/internal/api/middleware"
+	"crypto-opportunities-bot/internal/models"
 	"crypto-opportunities-bot/internal/repository"
 	"net/http"
 	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -11,12 +16,15 @@ import (
 
 // SystemHandler обробляє системні запити
 type SystemHandler struct {
-	userRepo  repository.UserRepository
-	oppRepo   repository.OpportunityRepository
-	arbRepo   repository.ArbitrageRepository
-	defiRepo  repository.DeFiRepository
-	notifRepo repository.NotificationRepository
-	startTime time.Time
+	userRepo           repository.UserRepository
+	oppRepo            repository.OpportunityRepository
+	arbRepo            repository.ArbitrageRepository
+	defiRepo           repository.DeFiRepository
+	notifRepo          repository.NotificationRepository
+	scraperHealthRepo  repository.ScraperHealthRepository
+	scraperRunRepo     repository.ScraperRunRepository
+	scraperAnomalyRepo repository.ScraperAnomalyRepository
+	startTime          time.Time
 }
 
 // NewSystemHandler створює новий SystemHandler
@@ -26,14 +34,20 @@ func NewSystemHandler(
 	arbRepo repository.ArbitrageRepository,
 	defiRepo repository.DeFiRepository,
 	notifRepo repository.NotificationRepository,
+	scraperHealthRepo repository.ScraperHealthRepository,
+	scraperRunRepo repository.ScraperRunRepository,
+	scraperAnomalyRepo repository.ScraperAnomalyRepository,
 ) *SystemHandler {
 	return &SystemHandler{
-		userRepo:  userRepo,
-		oppRepo:   oppRepo,
-		arbRepo:   arbRepo,
-		defiRepo:  defiRepo,
-		notifRepo: notifRepo,
-		startTime: time.Now(),
+		userRepo:           userRepo,
+		oppRepo:            oppRepo,
+		arbRepo:            arbRepo,
+		defiRepo:           defiRepo,
+		notifRepo:          notifRepo,
+		scraperHealthRepo:  scraperHealthRepo,
+		scraperRunRepo:     scraperRunRepo,
+		scraperAnomalyRepo: scraperAnomalyRepo,
+		startTime:          time.Now(),
 	}
 }
 
@@ -91,91 +105,248 @@ func (h *SystemHandler) GetSystemStatus(w http.ResponseWriter, r *http.Request)
 	respondJSON(w, http.StatusOK, status)
 }
 
-// TriggerScraper запускає конкретний scraper
+// scraperSchedule розклад scraper.Scheduler у процесі бота
+const scraperSchedule = "*/5 * * * *"
+
+// TriggerScraper ставить в чергу запуск конкретного scraper. Виконує його
+// процес бота (опитує чергу кожні 10 секунд)
 func (h *SystemHandler) TriggerScraper(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	scraperName := vars["name"]
+	scraperName := strings.ToLower(mux.Vars(r)["name"])
+
+	registered, err := h.registeredScrapers()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch registered scrapers")
+		return
+	}
 
-	// Validate scraper name
-	validScrapers := map[string]bool{
-		"binance": true,
-		"bybit":   true,
-		"defi":    true,
+	if len(registered) == 0 {
+		respondError(w, http.StatusServiceUnavailable, "Bot has not reported its scrapers yet")
+		return
 	}
 
-	if !validScrapers[scraperName] {
-		respondError(w, http.StatusBadRequest, "Invalid scraper name. Valid options: binance, bybit, defi")
+	if !slices.Contains(registered, scraperName) {
+		respondError(w, http.StatusBadRequest, "Invalid scraper name. Valid options: "+strings.Join(registered, ", "))
 		return
 	}
 
-	// In a real implementation, you would trigger the actual scraper here
-	// For now, we'll just return a success message
-	// TODO: Implement actual scraper triggering via channels or service calls
+	h.enqueueScraperJob(w, r, scraperName)
+}
+
+// TriggerAllScrapers ставить в чергу запуск всіх зареєстрованих scrapers
+func (h *SystemHandler) TriggerAllScrapers(w http.ResponseWriter, r *http.Request) {
+	h.enqueueScraperJob(w, r, "")
+}
+
+func (h *SystemHandler) enqueueScraperJob(w http.ResponseWriter, r *http.Request, exchange string) {
+	job := &models.ScraperJob{
+		Exchange: exchange,
+		Status:   models.ScraperJobPending,
+	}
+
+	if claims := middleware.GetUserFromContext(r.Context()); claims != nil {
+		job.RequestedBy = claims.Username
+	}
+
+	if err := h.scraperRunRepo.CreateJob(job); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to queue scraper job")
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message": "Scraper job queued",
+		"job":     job,
+	})
+}
+
+// GetScraperJob повертає стан завдання на запуск scrapers
+func (h *SystemHandler) GetScraperJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid job ID")
+		return
+	}
+
+	job, err := h.scraperRunRepo.GetJob(uint(id))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	runs, err := h.scraperRunRepo.ListJobRuns(job.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch job runs")
+		return
+	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"message":      "Scraper triggered successfully",
-		"scraper":      scraperName,
-		"triggered_at": time.Now(),
-		"note":         "Manual scraper triggering will be implemented with scraper service integration",
+		"job":  job,
+		"runs": runs,
 	})
 }
 
-// TriggerAllScrapers запускає всі scrapers
-func (h *SystemHandler) TriggerAllScrapers(w http.ResponseWriter, r *http.Request) {
-	scrapers := []string{"binance", "bybit", "defi"}
+// GetScraperRuns повертає історію запусків (?exchange=binance&limit=50)
+func (h *SystemHandler) GetScraperRuns(w http.ResponseWriter, r *http.Request) {
+	limit := parseIntQuery(r, "limit", 50)
+	if limit > 500 {
+		limit = 500
+	}
 
-	// In a real implementation, you would trigger all scrapers here
-	// TODO: Implement actual scraper triggering
+	runs, err := h.scraperRunRepo.ListRuns(strings.ToLower(r.URL.Query().Get("exchange")), limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch scraper runs")
+		return
+	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"message":      "All scrapers triggered successfully",
-		"scrapers":     scrapers,
-		"triggered_at": time.Now(),
-		"note":         "Manual scraper triggering will be implemented with scraper service integration",
+		"runs":  runs,
+		"count": len(runs),
 	})
 }
 
-// GetScraperStatus повертає статус scrapers
+// GetScraperAnomalies повертає виявлені аномалії результату scrapers
+// (?exchange=&limit=) та поточні baseline
+func (h *SystemHandler) GetScraperAnomalies(w http.ResponseWriter, r *http.Request) {
+	limit := parseIntQuery(r, "limit", 50)
+	if limit > 500 {
+		limit = 500
+	}
+
+	anomalies, err := h.scraperAnomalyRepo.ListAnomalies(strings.ToLower(r.URL.Query().Get("exchange")), limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch scraper anomalies")
+		return
+	}
+
+	baselines, err := h.scraperAnomalyRepo.ListBaselines()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch scraper baselines")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"anomalies": anomalies,
+		"baselines": baselines,
+		"count":     len(anomalies),
+	})
+}
+
+// GetScraperStatus повертає статус scrapers: останній запуск, circuit breaker
+// та останні ручні завдання
 func (h *SystemHandler) GetScraperStatus(w http.ResponseWriter, r *http.Request) {
-	// In a real implementation, you would get actual scraper status
-	// For now, return mock data
+	health, err := h.scraperHealthRepo.List()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch scraper health")
+		return
+	}
 
-	status := map[string]interface{}{
-		"scrapers": []map[string]interface{}{
-			{
-				"name":        "binance",
-				"status":      "active",
-				"last_run":    time.Now().Add(-5 * time.Minute),
-				"next_run":    time.Now().Add(5 * time.Minute),
-				"success_rate": 98.5,
-				"total_runs":  1440,
-			},
-			{
-				"name":        "bybit",
-				"status":      "active",
-				"last_run":    time.Now().Add(-5 * time.Minute),
-				"next_run":    time.Now().Add(5 * time.Minute),
-				"success_rate": 97.2,
-				"total_runs":  1440,
-			},
-			{
-				"name":        "defi",
-				"status":      "active",
-				"last_run":    time.Now().Add(-30 * time.Minute),
-				"next_run":    time.Now().Add(30 * time.Minute),
-				"success_rate": 95.8,
-				"total_runs":  48,
-			},
-		},
-		"schedule": map[string]string{
-			"binance": "*/5 * * * *",  // Every 5 minutes
-			"bybit":   "*/5 * * * *",  // Every 5 minutes
-			"defi":    "0 * * * *",    // Every hour
-		},
-		"note": "Scraper status tracking will be enhanced with actual metrics",
+	latestRuns, err := h.scraperRunRepo.LatestRuns()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch scraper runs")
+		return
 	}
 
-	respondJSON(w, http.StatusOK, status)
+	jobs, err := h.scraperRunRepo.ListJobs(10)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch scraper jobs")
+		return
+	}
+
+	lastRun := make(map[string]*models.ScraperRun, len(latestRuns))
+	for _, run := range latestRuns {
+		lastRun[run.Exchange] = run
+	}
+
+	now := time.Now()
+	nextRun := now.Truncate(5 * time.Minute).Add(5 * time.Minute)
+	botOnline := len(health) > 0
+
+	scrapers := make([]map[string]interface{}, 0, len(health))
+	for _, scraper := range health {
+		if now.Sub(scraper.ReportedAt) > scraperHealthMaxAge {
+			botOnline = false
+		}
+
+		scrapers = append(scrapers, map[string]interface{}{
+			"name":                 scraper.Exchange,
+			"state":                scraper.State,
+			"success_rate":         scraper.SuccessRate(),
+			"total_runs":           scraper.TotalRuns,
+			"total_failures":       scraper.TotalFailures,
+			"consecutive_failures": scraper.ConsecutiveFailures,
+			"last_success_at":      scraper.LastSuccessAt,
+			"last_run":             lastRun[scraper.Exchange],
+			"next_run":             nextRun,
+			"next_probe_at":        scraper.NextProbeAt,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"scrapers":    scrapers,
+		"schedule":    scraperSchedule,
+		"recent_jobs": jobs,
+		"bot_online":  botOnline,
+	})
+}
+
+// defiScraperName DeFi scraper працює поза scraper.Service і не має рядка в
+// ScraperHealth, але бот виконує його завдання (вимкнений DeFi - job failed)
+const defiScraperName = "defi"
+
+// registeredScrapers назви scrapers, про які звітує бот, та DeFi
+func (h *SystemHandler) registeredScrapers() ([]string, error) {
+	health, err := h.scraperHealthRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(health) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(health)+1)
+	for _, scraper := range health {
+		names = append(names, scraper.Exchange)
+	}
+
+	return append(names, defiScraperName), nil
+}
+
+// scraperHealthMaxAge після цього бот вважається зупиненим (запуски кожні 5 хвилин)
+const scraperHealthMaxAge = 15 * time.Minute
+
+// GetScraperHealth повертає стан circuit breaker та останнього запуску кожного скрапера
+func (h *SystemHandler) GetScraperHealth(w http.ResponseWriter, r *http.Request) {
+	scrapers, err := h.scraperHealthRepo.List()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch scraper health")
+		return
+	}
+
+	botOnline := len(scrapers) > 0
+	states := map[string]int{
+		models.ScraperCircuitClosed:   0,
+		models.ScraperCircuitOpen:     0,
+		models.ScraperCircuitHalfOpen: 0,
+	}
+	items := make([]map[string]interface{}, 0, len(scrapers))
+
+	for _, scraper := range scrapers {
+		if time.Since(scraper.ReportedAt) > scraperHealthMaxAge {
+			botOnline = false
+		}
+		states[scraper.State]++
+
+		items = append(items, map[string]interface{}{
+			"health":       scraper,
+			"success_rate": scraper.SuccessRate(),
+		})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"scrapers":   items,
+		"states":     states,
+		"bot_online": botOnline,
+	})
 }
 
 // ClearCache очищає кеш (якщо використовується Redis)
@@ -24,13 +24,18 @@ type Server struct {
 	router     *mux.Router
 
 	// Repositories
-	userRepo   repository.UserRepository
-	oppRepo    repository.OpportunityRepository
-	arbRepo    repository.ArbitrageRepository
-	defiRepo   repository.DeFiRepository
-	notifRepo  repository.NotificationRepository
-	adminRepo  repository.AdminRepository
-	actionRepo repository.UserActionRepository
+	userRepo           repository.UserRepository
+	oppRepo            repository.OpportunityRepository
+	arbRepo            repository.ArbitrageRepository
+	defiRepo           repository.DeFiRepository
+	notifRepo          repository.NotificationRepository
+	adminRepo          repository.AdminRepository
+	actionRepo         repository.UserActionRepository
+	healthRepo         repository.ExchangeHealthRepository
+	scraperHealthRepo  repository.ScraperHealthRepository
+	scraperRunRepo     repository.ScraperRunRepository
+	revisionRepo       repository.OpportunityRevisionRepository
+	scraperAnomalyRepo repository.ScraperAnomalyRepository
 
 	// Auth & Middleware
 	jwtManager  *auth.JWTManager
@@ -64,16 +69,26 @@ func NewServer(
 	notifRepo repository.NotificationRepository,
 	adminRepo repository.AdminRepository,
 	actionRepo repository.UserActionRepository,
+	healthRepo repository.ExchangeHealthRepository,
+	scraperHealthRepo repository.ScraperHealthRepository,
+	scraperRunRepo repository.ScraperRunRepository,
+	revisionRepo repository.OpportunityRevisionRepository,
+	scraperAnomalyRepo repository.ScraperAnomalyRepository,
 ) *Server {
 	s := &Server{
-		config:     cfg,
-		userRepo:   userRepo,
-		oppRepo:    oppRepo,
-		arbRepo:    arbRepo,
-		defiRepo:   defiRepo,
-		notifRepo:  notifRepo,
-		adminRepo:  adminRepo,
-		actionRepo: actionRepo,
+		config:             cfg,
+		userRepo:           userRepo,
+		oppRepo:            oppRepo,
+		arbRepo:            arbRepo,
+		defiRepo:           defiRepo,
+		notifRepo:          notifRepo,
+		adminRepo:          adminRepo,
+		actionRepo:         actionRepo,
+		healthRepo:         healthRepo,
+		scraperHealthRepo:  scraperHealthRepo,
+		scraperRunRepo:     scraperRunRepo,
+		revisionRepo:       revisionRepo,
+		scraperAnomalyRepo: scraperAnomalyRepo,
 	}
 
 	// Initialize JWT Manager
@@ -87,11 +102,11 @@ func NewServer(
 	s.authHandler = handlers.NewAuthHandler(adminRepo, s.jwtManager)
 	s.userHandler = handlers.NewUserHandler(userRepo, actionRepo, notifRepo)
 	s.statsHandler = handlers.NewStatsHandler(userRepo, oppRepo, arbRepo, defiRepo, notifRepo)
-	s.oppHandler = handlers.NewOpportunityHandler(oppRepo)
-	s.arbHandler = handlers.NewArbitrageHandler(arbRepo)
+	s.oppHandler = handlers.NewOpportunityHandler(oppRepo, revisionRepo)
+	s.arbHandler = handlers.NewArbitrageHandler(arbRepo, healthRepo)
 	s.defiHandler = handlers.NewDeFiHandler(defiRepo)
 	s.notifHandler = handlers.NewNotificationHandler(notifRepo, userRepo, oppRepo)
-	s.systemHandler = handlers.NewSystemHandler(userRepo, oppRepo, arbRepo, defiRepo, notifRepo)
+	s.systemHandler = handlers.NewSystemHandler(userRepo, oppRepo, arbRepo, defiRepo, notifRepo, scraperHealthRepo, scraperRunRepo, scraperAnomalyRepo)
 	s.broadcastHandler = handlers.NewBroadcastHandler(userRepo)
 
 	// Initialize WebSocket
@@ -104,6 +119,8 @@ func NewServer(
 		arbRepo,
 		defiRepo,
 		notifRepo,
+		healthRepo,
+		scraperAnomalyRepo,
 	)
 
 	// Setup router
@@ -158,7 +175,9 @@ func (s *Server) setupRouter() {
 
 	// Opportunities management (viewer+ for GET, admin+ for modifications)
 	protected.HandleFunc("/opportunities", s.oppHandler.ListOpportunities).Methods("GET")
+	protected.HandleFunc("/opportunities/revisions", s.oppHandler.ListRevisions).Methods("GET")
 	protected.HandleFunc("/opportunities/{id}", s.oppHandler.GetOpportunity).Methods("GET")
+	protected.HandleFunc("/opportunities/{id}/revisions", s.oppHandler.GetOpportunityRevisions).Methods("GET")
 	adminRoutes.HandleFunc("/opportunities", s.oppHandler.CreateOpportunity).Methods("POST")
 	adminRoutes.HandleFunc("/opportunities/{id}", s.oppHandler.UpdateOpportunity).Methods("PUT")
 	adminRoutes.HandleFunc("/opportunities/{id}", s.oppHandler.DeleteOpportunity).Methods("DELETE")
@@ -169,6 +188,7 @@ func (s *Server) setupRouter() {
 	protected.HandleFunc("/arbitrage/{id}", s.arbHandler.GetArbitrage).Methods("GET")
 	protected.HandleFunc("/arbitrage/stats", s.arbHandler.GetArbitrageStats).Methods("GET")
 	protected.HandleFunc("/arbitrage/exchanges", s.arbHandler.GetExchangeStatus).Methods("GET")
+	protected.HandleFunc("/arbitrage/exchanges/telemetry", s.arbHandler.GetExchangeTelemetry).Methods("GET")
 
 	// DeFi management (viewer+)
 	protected.HandleFunc("/defi", s.defiHandler.ListDeFi).Methods("GET")
@@ -196,6 +216,10 @@ func (s *Server) setupRouter() {
 	adminRoutes.HandleFunc("/system/scrapers/trigger", s.systemHandler.TriggerAllScrapers).Methods("POST")
 	adminRoutes.HandleFunc("/system/scrapers/{name}/trigger", s.systemHandler.TriggerScraper).Methods("POST")
 	protected.HandleFunc("/system/scrapers/status", s.systemHandler.GetScraperStatus).Methods("GET")
+	protected.HandleFunc("/system/scrapers/health", s.systemHandler.GetScraperHealth).Methods("GET")
+	protected.HandleFunc("/system/scrapers/runs", s.systemHandler.GetScraperRuns).Methods("GET")
+	protected.HandleFunc("/system/scrapers/anomalies", s.systemHandler.GetScraperAnomalies).Methods("GET")
+	protected.HandleFunc("/system/scrapers/jobs/{id}", s.systemHandler.GetScraperJob).Methods("GET")
 	adminRoutes.HandleFunc("/system/cache/clear", s.systemHandler.ClearCache).Methods("POST")
 	adminRoutes.HandleFunc("/system/notifications/restart", s.systemHandler.RestartNotificationDispatcher).Methods("POST")
 
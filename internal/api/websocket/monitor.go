@@ -2,21 +2,26 @@ package websocket
 
 import (
 	"crypto-opportunities-bot/internal/repository"
+	"log"
 	"runtime"
 	"time"
 )
 
 // MonitorService periodically broadcasts system metrics
 type MonitorService struct {
-	hub       *Hub
-	userRepo  repository.UserRepository
-	oppRepo   repository.OpportunityRepository
-	arbRepo   repository.ArbitrageRepository
-	defiRepo  repository.DeFiRepository
-	notifRepo repository.NotificationRepository
-	startTime time.Time
-	ticker    *time.Ticker
-	stopChan  chan bool
+	hub         *Hub
+	userRepo    repository.UserRepository
+	oppRepo     repository.OpportunityRepository
+	arbRepo     repository.ArbitrageRepository
+	defiRepo    repository.DeFiRepository
+	notifRepo   repository.NotificationRepository
+	healthRepo  repository.ExchangeHealthRepository
+	anomalyRepo repository.ScraperAnomalyRepository
+	startTime   time.Time
+	ticker      *time.Ticker
+	stopChan    chan bool
+
+	lastAnomalyID uint // Last broadcast ScraperAnomaly
 }
 
 // NewMonitorService creates a new monitor service
@@ -27,21 +32,30 @@ func NewMonitorService(
 	arbRepo repository.ArbitrageRepository,
 	defiRepo repository.DeFiRepository,
 	notifRepo repository.NotificationRepository,
+	healthRepo repository.ExchangeHealthRepository,
+	anomalyRepo repository.ScraperAnomalyRepository,
 ) *MonitorService {
 	return &MonitorService{
-		hub:       hub,
-		userRepo:  userRepo,
-		oppRepo:   oppRepo,
-		arbRepo:   arbRepo,
-		defiRepo:  defiRepo,
-		notifRepo: notifRepo,
-		startTime: time.Now(),
-		stopChan:  make(chan bool),
+		hub:         hub,
+		userRepo:    userRepo,
+		oppRepo:     oppRepo,
+		arbRepo:     arbRepo,
+		defiRepo:    defiRepo,
+		notifRepo:   notifRepo,
+		healthRepo:  healthRepo,
+		anomalyRepo: anomalyRepo,
+		startTime:   time.Now(),
+		stopChan:    make(chan bool),
 	}
 }
 
 // Start begins broadcasting metrics
 func (m *MonitorService) Start(interval time.Duration) {
+	// Only broadcast anomalies detected after startup
+	if id, err := m.anomalyRepo.LatestAnomalyID(); err == nil {
+		m.lastAnomalyID = id
+	}
+
 	m.ticker = time.NewTicker(interval)
 	go m.run()
 }
@@ -60,6 +74,7 @@ func (m *MonitorService) run() {
 		select {
 		case <-m.ticker.C:
 			m.broadcastMetrics()
+			m.broadcastScraperAnomalies()
 		case <-m.stopChan:
 			return
 		}
@@ -77,6 +92,24 @@ func (m *MonitorService) broadcastMetrics() {
 	m.hub.BroadcastSystemMetrics(metrics)
 }
 
+// broadcastScraperAnomalies broadcasts anomalies recorded by the bot's scrapers since the last tick
+func (m *MonitorService) broadcastScraperAnomalies() {
+	anomalies, err := m.anomalyRepo.ListAnomaliesAfter(m.lastAnomalyID, 100)
+	if err != nil {
+		log.Printf("⚠️ Failed to fetch scraper anomalies: %v", err)
+		return
+	}
+
+	for _, anomaly := range anomalies {
+		m.lastAnomalyID = anomaly.ID
+
+		// Cursor advances without clients, so reconnecting clients don't get stale alerts
+		if m.hub.GetClientCount() > 0 {
+			m.hub.BroadcastScraperEvent("anomaly", anomaly)
+		}
+	}
+}
+
 // collectMetrics gathers current system metrics
 func (m *MonitorService) collectMetrics() map[string]interface{} {
 	var mem runtime.MemStats
@@ -115,7 +148,33 @@ func (m *MonitorService) collectMetrics() map[string]interface{} {
 		"websocket": map[string]interface{}{
 			"connected_clients": m.hub.GetClientCount(),
 		},
+		"exchanges": m.collectExchangeTelemetry(),
+	}
+}
+
+// collectExchangeTelemetry gathers WebSocket feed telemetry from the bot's latest health snapshot
+func (m *MonitorService) collectExchangeTelemetry() map[string]interface{} {
+	exchanges := map[string]interface{}{}
+
+	health, err := m.healthRepo.List()
+	if err != nil {
+		return exchanges
 	}
+
+	for _, exchange := range health {
+		exchanges[exchange.Exchange] = map[string]interface{}{
+			"connected":           exchange.Connected,
+			"status":              exchange.Status,
+			"messages_per_second": exchange.Telemetry.MessagesPerSecond,
+			"latency_p50_ms":      exchange.Telemetry.LatencyP50Ms,
+			"latency_p99_ms":      exchange.Telemetry.LatencyP99Ms,
+			"reconnects":          exchange.Telemetry.Reconnects,
+			"last_error":          exchange.Telemetry.LastError,
+			"reported_at":         exchange.ReportedAt,
+		}
+	}
+
+	return exchanges
 }
 
 // BroadcastNotificationCreated broadcasts when a new notification is created
@@ -0,0 +1,371 @@
+package arbitrage
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/arbitrage/websocket"
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/models"
+	"time"
+)
+
+// BacktestOptions параметри симуляції поверх ArbitrageConfig
+type BacktestOptions struct {
+	From        time.Time     // нульовий = з початку запису
+	To          time.Time     // нульовий = до кінця запису
+	FillLatency time.Duration // затримка між алертом і виконанням (реакція людини)
+	StaleAfter  time.Duration // ордербук без оновлень довше - ігнорується
+}
+
+// BacktestAlert одна можливість, яку б знайшов Detector
+type BacktestAlert struct {
+	Pair         string
+	ExchangeBuy  string
+	ExchangeSell string
+
+	DetectedAt     time.Time
+	ClosedAt       time.Time
+	DetectedProfit float64 // net % на момент виявлення
+	PeakProfit     float64 // max net % поки спред існував
+	Suppressed     bool    // повторне відкриття в межах DeduplicateTTL - нотифікації б не було
+
+	Filled     bool
+	FillReason string  // чому виконання не вдалось
+	FillProfit float64 // net % після fees та slippage на момент виконання
+	PnLUSD     float64 // на config.Amount
+
+	fillAt time.Time
+}
+
+// Lifetime тривалість життя можливості
+func (a *BacktestAlert) Lifetime() time.Duration {
+	return a.ClosedAt.Sub(a.DetectedAt)
+}
+
+// BacktestBreakdown агрегати по парі або напрямку бірж
+type BacktestBreakdown struct {
+	Alerts            int
+	Filled            int
+	Profitable        int
+	PnLUSD            float64
+	AvgDetectedProfit float64
+	AvgLifetime       time.Duration
+
+	sumDetectedProfit float64
+	sumLifetime       time.Duration
+}
+
+func (b *BacktestBreakdown) add(alert *BacktestAlert) {
+	b.Alerts++
+	b.sumDetectedProfit += alert.DetectedProfit
+	b.sumLifetime += alert.Lifetime()
+	b.AvgDetectedProfit = b.sumDetectedProfit / float64(b.Alerts)
+	b.AvgLifetime = b.sumLifetime / time.Duration(b.Alerts)
+
+	if alert.Filled {
+		b.Filled++
+		b.PnLUSD += alert.PnLUSD
+		if alert.PnLUSD > 0 {
+			b.Profitable++
+		}
+	}
+}
+
+// BacktestResult результат прогону
+type BacktestResult struct {
+	From    time.Time
+	To      time.Time
+	Records int
+
+	Alerts     int // скільки нотифікацій було б надіслано
+	Suppressed int // повторні відкриття в межах DeduplicateTTL
+	Total      BacktestBreakdown
+
+	Rejections     map[string]int // причина -> кількість відхилених перевірок
+	ByPair         map[string]*BacktestBreakdown
+	ByExchangePair map[string]*BacktestBreakdown // "binance→bybit"
+
+	AlertsList []*BacktestAlert
+}
+
+// Backtester повторно проганяє виявлення міжбіржового арбітражу по записаних
+// ордербуках з альтернативним ArbitrageConfig. Час береться з записів, тому
+// результат не залежить від швидкості програвання. Статус мереж переказу
+// історично не зберігається і не враховується
+type Backtester struct {
+	detector *Detector // тільки calculateWithSlippage та фільтри
+	opts     BacktestOptions
+
+	books     map[string]map[string]*backtestBook // symbol -> exchange -> book
+	open      map[string]*BacktestAlert           // lifecycle key -> відкрита можливість
+	lastAlert map[string]time.Time                // lifecycle key -> остання нотифікація
+	pending   []*BacktestAlert                    // очікують виконання
+	now       time.Time
+
+	result *BacktestResult
+}
+
+type backtestBook struct {
+	ob *models.OrderBook
+	at time.Time // час запису
+}
+
+// NewBacktester створює новий Backtester
+func NewBacktester(calc *Calculator, cfg *config.ArbitrageConfig, opts BacktestOptions) *Backtester {
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = 5 * time.Second
+	}
+
+	return &Backtester{
+		detector:  &Detector{calculator: calc, config: cfg},
+		opts:      opts,
+		books:     make(map[string]map[string]*backtestBook),
+		open:      make(map[string]*BacktestAlert),
+		lastAlert: make(map[string]time.Time),
+		result: &BacktestResult{
+			Rejections:     make(map[string]int),
+			ByPair:         make(map[string]*BacktestBreakdown),
+			ByExchangePair: make(map[string]*BacktestBreakdown),
+		},
+	}
+}
+
+// Run програє файли запису і повертає результат
+func (b *Backtester) Run(ctx context.Context, paths []string) (*BacktestResult, error) {
+	err := websocket.MergeRecordings(ctx, paths, func(record *websocket.BookRecord) error {
+		b.Apply(record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Finish(), nil
+}
+
+// Apply застосовує один запис ордербуку та перевіряє символ
+func (b *Backtester) Apply(record *websocket.BookRecord) {
+	now := record.Timestamp()
+	if (!b.opts.From.IsZero() && now.Before(b.opts.From)) || (!b.opts.To.IsZero() && now.After(b.opts.To)) {
+		return
+	}
+
+	if b.result.From.IsZero() {
+		b.result.From = now
+	}
+	b.result.To = now
+	b.result.Records++
+	b.now = now
+
+	// Виконання відбувається по стану книг до цього запису
+	b.fillPending(now)
+
+	bySymbol, ok := b.books[record.Symbol]
+	if !ok {
+		bySymbol = make(map[string]*backtestBook)
+		b.books[record.Symbol] = bySymbol
+	}
+
+	book, ok := bySymbol[record.Exchange]
+	if !ok {
+		book = &backtestBook{ob: models.NewOrderBook(record.Exchange, record.Symbol)}
+		bySymbol[record.Exchange] = book
+	}
+
+	bids, asks := record.Levels()
+	book.ob.Update(bids, asks, record.UpdateID)
+	book.at = now
+
+	b.evaluate(record.Symbol, now)
+}
+
+// evaluate повторює логіку Detector.checkArbitrage: найкращий напрямок
+// відкривається, вже відкриті - переоцінюються і закриваються
+func (b *Backtester) evaluate(symbol string, now time.Time) {
+	fresh := make(map[string]*models.OrderBook)
+	for exchange, book := range b.books[symbol] {
+		if now.Sub(book.at) <= b.opts.StaleAfter {
+			fresh[exchange] = book.ob
+		}
+	}
+
+	candidates := make(map[string][2]string)
+
+	if buyExchange, sellExchange := bestExchanges(fresh); buyExchange != "" && buyExchange != sellExchange {
+		candidates[LifecycleKey(symbol, buyExchange, sellExchange)] = [2]string{buyExchange, sellExchange}
+	}
+	for key, alert := range b.open {
+		if alert.Pair == symbol {
+			candidates[key] = [2]string{alert.ExchangeBuy, alert.ExchangeSell}
+		}
+	}
+
+	for key, exchanges := range candidates {
+		opp := b.evaluatePair(symbol, exchanges[0], exchanges[1], fresh)
+		alert, isOpen := b.open[key]
+
+		switch {
+		case opp == nil && isOpen:
+			alert.ClosedAt = now
+			delete(b.open, key)
+		case opp == nil:
+		case isOpen:
+			if opp.NetProfitPercent > alert.PeakProfit {
+				alert.PeakProfit = opp.NetProfitPercent
+			}
+		default:
+			b.openAlert(key, opp, now)
+		}
+	}
+}
+
+// evaluatePair розраховує напрямок та застосовує фільтри Detector
+func (b *Backtester) evaluatePair(symbol, buyExchange, sellExchange string, fresh map[string]*models.OrderBook) *models.ArbitrageOpportunity {
+	buyOB, sellOB := fresh[buyExchange], fresh[sellExchange]
+	if buyOB == nil || sellOB == nil {
+		return nil
+	}
+
+	opp := b.detector.calculateWithSlippage(symbol, buyExchange, buyOB, sellExchange, sellOB)
+	if opp == nil {
+		return nil
+	}
+
+	if reason := b.detector.rejectReason(opp); reason != "" {
+		b.result.Rejections[reason]++
+		return nil
+	}
+
+	return opp
+}
+
+func (b *Backtester) openAlert(key string, opp *models.ArbitrageOpportunity, now time.Time) {
+	alert := &BacktestAlert{
+		Pair:           opp.Pair,
+		ExchangeBuy:    opp.ExchangeBuy,
+		ExchangeSell:   opp.ExchangeSell,
+		DetectedAt:     now,
+		DetectedProfit: opp.NetProfitPercent,
+		PeakProfit:     opp.NetProfitPercent,
+		fillAt:         now.Add(b.opts.FillLatency),
+	}
+
+	// Deduplicator: повторне відкриття протягом DeduplicateTTL не розсилається
+	ttl := time.Duration(b.detector.config.DeduplicateTTL) * time.Minute
+	if last, ok := b.lastAlert[key]; ok && now.Sub(last) < ttl {
+		alert.Suppressed = true
+	} else {
+		b.lastAlert[key] = now
+		b.pending = append(b.pending, alert)
+	}
+
+	b.open[key] = alert
+	b.result.AlertsList = append(b.result.AlertsList, alert)
+
+	if b.opts.FillLatency == 0 {
+		b.fillPending(now)
+	}
+}
+
+// fillPending виконує алерти, для яких минула FillLatency
+func (b *Backtester) fillPending(now time.Time) {
+	remaining := b.pending[:0]
+
+	for _, alert := range b.pending {
+		if alert.fillAt.After(now) {
+			remaining = append(remaining, alert)
+			continue
+		}
+		b.fill(alert)
+	}
+
+	b.pending = remaining
+}
+
+// fill симулює ринкові угоди на config.Amount по поточних книгах. Середні
+// ціни виконання вже містять slippage, тому окремо він не віднімається
+func (b *Backtester) fill(alert *BacktestAlert) {
+	amount := b.detector.config.Amount
+	calc := b.detector.calculator
+
+	buyBook := b.books[alert.Pair][alert.ExchangeBuy]
+	sellBook := b.books[alert.Pair][alert.ExchangeSell]
+	if buyBook == nil || sellBook == nil {
+		alert.FillReason = "no order book"
+		return
+	}
+
+	buy := buyBook.ob.CalculateSlippage("buy", amount)
+	sell := sellBook.ob.CalculateSlippage("sell", amount)
+	if buy == nil || !buy.Success || sell == nil || !sell.Success {
+		alert.FillReason = "insufficient liquidity"
+		return
+	}
+
+	base, _ := parsePair(alert.Pair)
+	withdrawalFeeUSD := calc.getWithdrawalFee(alert.ExchangeBuy, base) * buy.AveragePrice
+
+	gross := (sell.AveragePrice - buy.AveragePrice) / buy.AveragePrice * 100
+	fees := calc.getTradingFee(alert.ExchangeBuy) + calc.getTradingFee(alert.ExchangeSell) + withdrawalFeeUSD/amount*100
+
+	alert.Filled = true
+	alert.FillProfit = gross - fees
+	alert.PnLUSD = alert.FillProfit / 100 * amount
+}
+
+// Finish виконує алерти, що лишились, закриває відкриті та рахує агрегати
+func (b *Backtester) Finish() *BacktestResult {
+	for _, alert := range b.pending {
+		b.fill(alert)
+	}
+	b.pending = nil
+
+	for key, alert := range b.open {
+		alert.ClosedAt = b.now
+		delete(b.open, key)
+	}
+
+	result := b.result
+
+	for _, alert := range result.AlertsList {
+		if alert.Suppressed {
+			result.Suppressed++
+			continue
+		}
+		result.Alerts++
+
+		result.Total.add(alert)
+
+		if _, ok := result.ByPair[alert.Pair]; !ok {
+			result.ByPair[alert.Pair] = &BacktestBreakdown{}
+		}
+		result.ByPair[alert.Pair].add(alert)
+
+		direction := alert.ExchangeBuy + "→" + alert.ExchangeSell
+		if _, ok := result.ByExchangePair[direction]; !ok {
+			result.ByExchangePair[direction] = &BacktestBreakdown{}
+		}
+		result.ByExchangePair[direction].add(alert)
+	}
+
+	return result
+}
+
+// bestExchanges повертає біржу з найнижчим ask та біржу з найвищим bid
+func bestExchanges(books map[string]*models.OrderBook) (buyExchange, sellExchange string) {
+	var bestAsk, bestBid float64
+
+	for exchange, ob := range books {
+		if ask := ob.GetBestAsk(); ask != nil && (buyExchange == "" || ask.Price < bestAsk) {
+			buyExchange, bestAsk = exchange, ask.Price
+		}
+		if bid := ob.GetBestBid(); bid != nil && (sellExchange == "" || bid.Price > bestBid) {
+			sellExchange, bestBid = exchange, bid.Price
+		}
+	}
+
+	if buyExchange == "" || sellExchange == "" || bestBid <= bestAsk {
+		return "", ""
+	}
+
+	return buyExchange, sellExchange
+}
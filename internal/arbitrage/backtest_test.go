@@ -0,0 +1,66 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/arbitrage/websocket"
+	"crypto-opportunities-bot/internal/config"
+	"testing"
+	"time"
+)
+
+func bookRecord(at time.Time, exchange string, bid, ask float64) *websocket.BookRecord {
+	return &websocket.BookRecord{
+		Time:     at.UnixMilli(),
+		Exchange: exchange,
+		Symbol:   "BTC/USDT",
+		Bids:     [][2]float64{{bid, 5000}},
+		Asks:     [][2]float64{{ask, 5000}},
+	}
+}
+
+func TestBacktester(t *testing.T) {
+	cfg := &config.ArbitrageConfig{
+		MinProfitPercent: 0.3,
+		MaxSpreadPercent: 5,
+		MaxSlippage:      0.5,
+		Amount:           100000,
+		DeduplicateTTL:   3,
+	}
+
+	backtester := NewBacktester(NewCalculator(), cfg, BacktestOptions{FillLatency: 2 * time.Second})
+
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	records := []*websocket.BookRecord{
+		bookRecord(start, "binance", 99.9, 100),
+		bookRecord(start.Add(1*time.Second), "bybit", 101.5, 101.6), // спред ~1.5% - алерт
+		bookRecord(start.Add(3*time.Second), "binance", 99.9, 100),  // виконання після latency
+		bookRecord(start.Add(4*time.Second), "bybit", 100.0, 100.1), // спред зник
+		bookRecord(start.Add(5*time.Second), "binance", 99.9, 100),
+		bookRecord(start.Add(6*time.Second), "bybit", 101.5, 101.6), // повторне відкриття в межах TTL
+	}
+
+	for _, record := range records {
+		backtester.Apply(record)
+	}
+
+	result := backtester.Finish()
+
+	if result.Alerts != 1 || result.Suppressed != 1 {
+		t.Fatalf("Expected 1 alert and 1 suppressed, got %d and %d", result.Alerts, result.Suppressed)
+	}
+
+	if result.Total.Filled != 1 || result.Total.PnLUSD <= 0 {
+		t.Errorf("Expected profitable fill, got filled %d, P&L $%.2f", result.Total.Filled, result.Total.PnLUSD)
+	}
+
+	first := result.AlertsList[0]
+	if first.Lifetime() != 3*time.Second {
+		t.Errorf("Expected 3s lifetime, got %s", first.Lifetime())
+	}
+
+	if row := result.ByExchangePair["binance→bybit"]; row == nil || row.Alerts != 1 {
+		t.Errorf("Expected binance→bybit breakdown, got %+v", result.ByExchangePair)
+	}
+	if row := result.ByPair["BTC/USDT"]; row == nil || row.Filled != 1 {
+		t.Errorf("Expected BTC/USDT breakdown, got %+v", result.ByPair)
+	}
+}
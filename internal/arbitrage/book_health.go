@@ -0,0 +1,164 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"log"
+	"math"
+	"sort"
+	"time"
+)
+
+// Причини, з яких ордербук виключається з виявлення арбітражу
+const (
+	BookIssueStale    = "stale"    // Немає оновлень (біржа відключилась)
+	BookIssueCrossed  = "crossed"  // bid >= ask - книга розсинхронізована
+	BookIssueDeviated = "deviated" // Mid price далеко від медіани інших бірж
+	BookIssueShallow  = "shallow"  // Замало рівнів для розрахунку slippage
+)
+
+// minBooksForMedian мінімум бірж, щоб медіана мала сенс (з двома книгами
+// відхилення однакове для обох і нічого не говорить про те, яка з них хибна)
+const minBooksForMedian = 3
+
+// BookHealthConfig пороги перевірки ордербуків
+type BookHealthConfig struct {
+	MaxAge              time.Duration
+	MaxDeviationPercent float64 // 0 = не перевіряти
+	MinDepth            int     // рівнів з кожної сторони
+}
+
+// DefaultBookHealthConfig стандартні пороги
+func DefaultBookHealthConfig() BookHealthConfig {
+	return BookHealthConfig{
+		MaxAge:              5 * time.Second,
+		MaxDeviationPercent: 3,
+		MinDepth:            3,
+	}
+}
+
+// NewBookHealthConfig пороги з конфігу (нульові значення = стандартні)
+func NewBookHealthConfig(cfg *config.ArbitrageConfig) BookHealthConfig {
+	health := DefaultBookHealthConfig()
+
+	if cfg.BookMaxAge > 0 {
+		health.MaxAge = time.Duration(cfg.BookMaxAge) * time.Second
+	}
+	if cfg.BookMaxDeviation > 0 {
+		health.MaxDeviationPercent = cfg.BookMaxDeviation
+	}
+	if cfg.BookMinDepth > 0 {
+		health.MinDepth = cfg.BookMinDepth
+	}
+
+	return health
+}
+
+// bookIssue перевіряє ордербук. median - медіана mid price інших бірж (0 = невідома)
+func (c BookHealthConfig) bookIssue(ob *models.OrderBook, median float64) string {
+	if ob.IsStale(c.MaxAge) {
+		return BookIssueStale
+	}
+
+	bid, ask := ob.GetBestBid(), ob.GetBestAsk()
+	if bid == nil || ask == nil {
+		return BookIssueShallow
+	}
+
+	if bid.Price >= ask.Price {
+		return BookIssueCrossed
+	}
+
+	if bidDepth, askDepth := ob.GetDepth(); bidDepth < c.MinDepth || askDepth < c.MinDepth {
+		return BookIssueShallow
+	}
+
+	if median > 0 && c.MaxDeviationPercent > 0 {
+		mid := (bid.Price + ask.Price) / 2
+		if math.Abs(mid-median)/median*100 > c.MaxDeviationPercent {
+			return BookIssueDeviated
+		}
+	}
+
+	return ""
+}
+
+// medianMidPrice медіана mid price свіжих та не перехрещених книг
+func (c BookHealthConfig) medianMidPrice(books map[string]*models.OrderBook) float64 {
+	mids := make([]float64, 0, len(books))
+
+	for _, ob := range books {
+		if ob.IsStale(c.MaxAge) {
+			continue
+		}
+
+		bid, ask := ob.GetBestBid(), ob.GetBestAsk()
+		if bid == nil || ask == nil || bid.Price >= ask.Price {
+			continue
+		}
+
+		mids = append(mids, (bid.Price+ask.Price)/2)
+	}
+
+	if len(mids) < minBooksForMedian {
+		return 0
+	}
+
+	sort.Float64s(mids)
+
+	middle := len(mids) / 2
+	if len(mids)%2 == 0 {
+		return (mids[middle-1] + mids[middle]) / 2
+	}
+	return mids[middle]
+}
+
+// BookHealthReporter періодично зберігає стан ордербуків бірж у БД для admin API
+type BookHealthReporter struct {
+	obManager  *OrderBookManager
+	healthRepo repository.ExchangeHealthRepository
+	stopChan   chan struct{}
+}
+
+// NewBookHealthReporter створює новий BookHealthReporter
+func NewBookHealthReporter(obManager *OrderBookManager, healthRepo repository.ExchangeHealthRepository) *BookHealthReporter {
+	return &BookHealthReporter{
+		obManager:  obManager,
+		healthRepo: healthRepo,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Report зберігає поточний стан усіх бірж
+func (r *BookHealthReporter) Report() {
+	for _, health := range r.obManager.GetStats().Health {
+		if err := r.healthRepo.Upsert(health); err != nil {
+			log.Printf("❌ Failed to save %s book health: %v", health.Exchange, err)
+		}
+	}
+}
+
+// Start запускає збереження з інтервалом
+func (r *BookHealthReporter) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stopChan:
+				return
+			case <-ticker.C:
+				r.Report()
+			}
+		}
+	}()
+
+	log.Printf("✅ Book health reporter started (every %s)", interval)
+}
+
+// Stop зупиняє збереження
+func (r *BookHealthReporter) Stop() {
+	close(r.stopChan)
+}
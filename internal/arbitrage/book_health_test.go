@@ -0,0 +1,84 @@
+package arbitrage
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/arbitrage/websocket"
+	"crypto-opportunities-bot/internal/models"
+	"testing"
+)
+
+func newDepthBook(exchange string, bid, ask float64, levels int) *models.OrderBook {
+	bids := make([]models.PriceLevel, levels)
+	asks := make([]models.PriceLevel, levels)
+	for i := 0; i < levels; i++ {
+		bids[i] = models.PriceLevel{Price: bid - float64(i)*0.01, Quantity: 1}
+		asks[i] = models.PriceLevel{Price: ask + float64(i)*0.01, Quantity: 1}
+	}
+
+	ob := models.NewOrderBook(exchange, "SOL/USDT")
+	ob.Update(bids, asks, 1)
+	return ob
+}
+
+func TestOrderBookManagerExcludesUnhealthyBooks(t *testing.T) {
+	m := NewOrderBookManager()
+
+	replay := websocket.NewReplay(nil, 0)
+	for _, exchange := range []string{"binance", "bybit"} {
+		manager := replay.Manager(exchange)
+		manager.Connect(context.Background())
+		m.RegisterExchange(exchange, manager)
+	}
+
+	m.updateOrderBook("binance", "SOL/USDT", newDepthBook("binance", 100.0, 100.1, 5))
+	m.updateOrderBook("bybit", "SOL/USDT", newDepthBook("bybit", 100.05, 100.15, 5))
+	m.updateOrderBook("okx", "SOL/USDT", newDepthBook("okx", 99.95, 100.05, 5))
+	m.updateOrderBook("kraken", "SOL/USDT", newDepthBook("kraken", 101, 100.5, 5)) // crossed
+	m.updateOrderBook("gateio", "SOL/USDT", newDepthBook("gateio", 110, 110.1, 5)) // deviated
+	m.updateOrderBook("mexc", "SOL/USDT", newDepthBook("mexc", 100.01, 100.11, 1)) // shallow
+
+	books := m.GetAllOrderBooks("SOL/USDT")
+	if len(books) != 3 {
+		t.Fatalf("Expected 3 healthy books, got %d", len(books))
+	}
+	for _, exchange := range []string{"kraken", "gateio", "mexc"} {
+		if books[exchange] != nil || m.GetHealthyOrderBook(exchange, "SOL/USDT") != nil {
+			t.Errorf("Expected %s book to be excluded", exchange)
+		}
+	}
+
+	// Перехрещена книга kraken не повинна давати "найкращий" bid
+	best := m.GetBestPrices("SOL/USDT")
+	if best == nil || best.BestBid == nil || best.BestBid.Exchange != "bybit" {
+		t.Errorf("Expected best bid on bybit, got %+v", best)
+	}
+	if best == nil || best.BestAsk == nil || best.BestAsk.Exchange != "okx" {
+		t.Errorf("Expected best ask on okx, got %+v", best)
+	}
+
+	health := m.GetStats().Health
+	if health["binance"].Status != models.ExchangeHealthHealthy {
+		t.Errorf("Expected binance healthy, got %s", health["binance"].Status)
+	}
+	// Немає WebSocket менеджера = не підключена
+	if health["okx"].Status != models.ExchangeHealthDown {
+		t.Errorf("Expected disconnected okx down, got %s", health["okx"].Status)
+	}
+	if health["kraken"].CrossedBooks != 1 || health["gateio"].DeviatedBooks != 1 || health["mexc"].ShallowBooks != 1 {
+		t.Errorf("Unexpected issue counts: kraken %+v gateio %+v mexc %+v", health["kraken"], health["gateio"], health["mexc"])
+	}
+}
+
+func TestMedianMidPriceNeedsThreeBooks(t *testing.T) {
+	health := DefaultBookHealthConfig()
+
+	books := map[string]*models.OrderBook{
+		"binance": newDepthBook("binance", 100, 100.2, 3),
+		"gateio":  newDepthBook("gateio", 120, 120.2, 3),
+	}
+
+	// З двома книгами не можна визначити, яка з них хибна
+	if median := health.medianMidPrice(books); median != 0 {
+		t.Errorf("Expected no median for two books, got %.2f", median)
+	}
+}
@@ -1,14 +1,17 @@
 package arbitrage
 
 import (
+	"crypto-opportunities-bot/internal/models"
 	"fmt"
 	"math"
 	"strings"
+	"sync"
 )
 
 // Calculator розраховує арбітражні можливості з урахуванням fees та slippage
 type Calculator struct {
 	feeTable *FeeTable
+	mu       sync.RWMutex // FeeSyncer оновлює таблицю під час роботи Detector
 }
 
 // FeeTable таблиця комісій для різних бірж
@@ -78,13 +81,14 @@ type ArbitrageCalculation struct {
 
 	WithdrawalFee    float64 // в базовій валюті (BTC, ETH)
 	WithdrawalFeeUSD float64 // в доларах
+	ConversionFee    float64 // % конвертації між валютами котирування
+	RebalanceCostUSD float64 // ребаланс pre-funded балансів: base на біржу продажу, quote назад
 
 	GrossProfit      float64 // % без fees
 	TotalFeesPercent float64 // % всіх комісій
 	NetProfit        float64 // % після всіх комісій
 
-	ProfitOn1000USD   float64 // прибуток на $1000
-	RecommendedAmount float64 // рекомендована сума
+	ProfitOn1000USD float64 // прибуток на $1000
 
 	Volume24h     float64
 	SpreadPercent float64
@@ -130,38 +134,38 @@ func (c *Calculator) Calculate(
 	// Buy: 0.1%, Sell: 0.1%, Withdrawal: ~$X на $1000
 	totalFeesPercent := buyFee + sellFee + ((withdrawalFeeUSD / 1000) * 100)
 
+	// Pre-funded: замість виведення в кожній угоді баланси періодично вирівнюються
+	rebalanceCostUSD := withdrawalFeeUSD + c.getWithdrawalFee(sellExchange, quoteCurrency)
+
 	// Net profit
 	netProfit := grossProfit - totalFeesPercent
 
 	// Profit на $1000
 	profitOn1000 := (netProfit / 100) * 1000
 
-	// Recommended amount (залежить від ліквідності та profit)
-	recommendedAmount := c.calculateRecommendedAmount(volume24h, netProfit)
-
 	// Spread
 	midPrice := (buyPrice + sellPrice) / 2
 	spreadPercent := ((sellPrice - buyPrice) / midPrice) * 100
 
 	return &ArbitrageCalculation{
-		Pair:              pair,
-		BaseCurrency:      baseCurrency,
-		QuoteCurrency:     quoteCurrency,
-		BuyExchange:       buyExchange,
-		BuyPrice:          buyPrice,
-		BuyFee:            buyFee,
-		SellExchange:      sellExchange,
-		SellPrice:         sellPrice,
-		SellFee:           sellFee,
-		WithdrawalFee:     withdrawalFee,
-		WithdrawalFeeUSD:  withdrawalFeeUSD,
-		GrossProfit:       grossProfit,
-		TotalFeesPercent:  totalFeesPercent,
-		NetProfit:         netProfit,
-		ProfitOn1000USD:   profitOn1000,
-		RecommendedAmount: recommendedAmount,
-		Volume24h:         volume24h,
-		SpreadPercent:     spreadPercent,
+		Pair:             pair,
+		BaseCurrency:     baseCurrency,
+		QuoteCurrency:    quoteCurrency,
+		BuyExchange:      buyExchange,
+		BuyPrice:         buyPrice,
+		BuyFee:           buyFee,
+		SellExchange:     sellExchange,
+		SellPrice:        sellPrice,
+		SellFee:          sellFee,
+		WithdrawalFee:    withdrawalFee,
+		WithdrawalFeeUSD: withdrawalFeeUSD,
+		RebalanceCostUSD: rebalanceCostUSD,
+		GrossProfit:      grossProfit,
+		TotalFeesPercent: totalFeesPercent,
+		NetProfit:        netProfit,
+		ProfitOn1000USD:  profitOn1000,
+		Volume24h:        volume24h,
+		SpreadPercent:    spreadPercent,
 	}, nil
 }
 
@@ -192,8 +196,41 @@ func (c *Calculator) CalculateWithSlippage(
 	return calc, nil
 }
 
+// ApplyQuoteConversion додає вартість конвертації валюти котирування для ніг,
+// приведених OrderBookManager з іншої валюти (BTC/USD -> BTC/USDT): комісія
+// біржі ноги та половина спреду курсу. Якщо обидві ноги в одній валюті -
+// конвертація не потрібна
+func (c *Calculator) ApplyQuoteConversion(calc *ArbitrageCalculation, buyOB, sellOB *models.OrderBook) {
+	_, buyQuote := parsePair(nativeSymbol(buyOB))
+	_, sellQuote := parsePair(nativeSymbol(sellOB))
+	if buyQuote == sellQuote {
+		return
+	}
+
+	for _, ob := range []*models.OrderBook{buyOB, sellOB} {
+		if ob.IsConverted() {
+			calc.ConversionFee += c.getTradingFee(ob.Exchange) + ob.QuoteSpread
+		}
+	}
+
+	calc.TotalFeesPercent += calc.ConversionFee
+	calc.NetProfit -= calc.ConversionFee
+	calc.ProfitOn1000USD = (calc.NetProfit / 100) * 1000
+}
+
+// nativeSymbol символ книги на біржі
+func nativeSymbol(ob *models.OrderBook) string {
+	if ob.IsConverted() {
+		return ob.NativeSymbol
+	}
+	return ob.Symbol
+}
+
 // getTradingFee отримує комісію для біржі
 func (c *Calculator) getTradingFee(exchange string) float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	if fee, ok := c.feeTable.TradingFees[exchange]; ok {
 		return fee
 	}
@@ -202,6 +239,9 @@ func (c *Calculator) getTradingFee(exchange string) float64 {
 
 // getWithdrawalFee отримує комісію виведення
 func (c *Calculator) getWithdrawalFee(exchange, currency string) float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	if exchFees, ok := c.feeTable.WithdrawalFees[exchange]; ok {
 		if fee, ok := exchFees[currency]; ok {
 			return fee
@@ -224,30 +264,6 @@ func (c *Calculator) getWithdrawalFee(exchange, currency string) float64 {
 	return 0
 }
 
-// calculateRecommendedAmount розраховує безпечну суму для торгівлі
-func (c *Calculator) calculateRecommendedAmount(volume24h, netProfit float64) float64 {
-	// Базові правила:
-	// - Не більше 0.1% від 24h volume (щоб не рухати ринок)
-	// - Мінімум $100
-	// - Максимум $5000 (безпечна межа)
-
-	maxSafe := math.Min(volume24h*0.001, 5000) // 0.1% від volume або $5000
-	minSafe := 100.0
-
-	if maxSafe < minSafe {
-		return 0 // Недостатня ліквідність
-	}
-
-	// Якщо profit високий - можна більше
-	if netProfit >= 1.0 {
-		return math.Min(maxSafe, 2000)
-	} else if netProfit >= 0.5 {
-		return math.Min(maxSafe, 1000)
-	} else {
-		return math.Min(maxSafe, 500)
-	}
-}
-
 // parsePair розбирає пару "BTC/USDT" на base та quote
 func parsePair(pair string) (string, string) {
 	parts := strings.Split(pair, "/")
@@ -259,11 +275,17 @@ func parsePair(pair string) (string, string) {
 
 // UpdateTradingFee оновлює комісію для біржі
 func (c *Calculator) UpdateTradingFee(exchange string, fee float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.feeTable.TradingFees[exchange] = fee
 }
 
 // UpdateWithdrawalFee оновлює комісію виведення
 func (c *Calculator) UpdateWithdrawalFee(exchange, currency string, fee float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.feeTable.WithdrawalFees[exchange] == nil {
 		c.feeTable.WithdrawalFees[exchange] = make(map[string]float64)
 	}
@@ -279,3 +301,50 @@ func (c *Calculator) GetTradingFee(exchange string) float64 {
 func (c *Calculator) GetWithdrawalFee(exchange, currency string) float64 {
 	return c.getWithdrawalFee(exchange, currency)
 }
+
+// ApplyExchangeFees застосовує синхронізовані комісії до таблиці.
+// Trading fee = taker (арбітраж виконується market ордерами),
+// withdrawal fee = мережа за замовчуванням (або найдешевша, якщо default невідомий)
+func (c *Calculator) ApplyExchangeFees(fees []*models.ExchangeFee) int {
+	// exchange:currency -> обрана мережа
+	withdrawals := make(map[string]*models.ExchangeFee)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	applied := 0
+
+	for _, fee := range fees {
+		switch fee.FeeType {
+		case models.FeeTypeTrading:
+			if fee.TakerFee <= 0 {
+				continue
+			}
+			c.feeTable.TradingFees[fee.Exchange] = fee.TakerFee
+			applied++
+
+		case models.FeeTypeWithdrawal:
+			if fee.Currency == "" || fee.WithdrawalFee < 0 {
+				continue
+			}
+
+			key := fee.Exchange + ":" + fee.Currency
+			prev := withdrawals[key]
+			if prev == nil ||
+				(fee.IsDefault && !prev.IsDefault) ||
+				(fee.IsDefault == prev.IsDefault && fee.WithdrawalFee < prev.WithdrawalFee) {
+				withdrawals[key] = fee
+			}
+		}
+	}
+
+	for _, fee := range withdrawals {
+		if c.feeTable.WithdrawalFees[fee.Exchange] == nil {
+			c.feeTable.WithdrawalFees[fee.Exchange] = make(map[string]float64)
+		}
+		c.feeTable.WithdrawalFees[fee.Exchange][fee.Currency] = fee.WithdrawalFee
+		applied++
+	}
+
+	return applied
+}
@@ -85,12 +85,10 @@ func (d *Deduplicator) Clear() {
 	d.seen = make(map[string]time.Time)
 }
 
-// GenerateArbitrageID генерує унікальний ID для арбітражної можливості
-func GenerateArbitrageID(pair, buyExchange, sellExchange string, timestamp time.Time) string {
-	// Округлити timestamp до 3 хвилин (щоб схожі можливості мали один ID)
-	roundedTime := timestamp.Truncate(3 * time.Minute)
-
-	data := fmt.Sprintf("%s:%s:%s:%d", pair, buyExchange, sellExchange, roundedTime.Unix())
+// GenerateLifecycleID генерує унікальний ID одного життєвого циклу можливості
+// (key - LifecycleKey або TriangularLifecycleKey)
+func GenerateLifecycleID(key string, openedAt time.Time) string {
+	data := fmt.Sprintf("%s:%d", key, openedAt.UnixNano())
 	hash := md5.Sum([]byte(data))
 	return fmt.Sprintf("%x", hash)
 }
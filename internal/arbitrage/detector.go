@@ -17,8 +17,12 @@ type Detector struct {
 	userRepo     repository.UserRepository
 	config       *config.ArbitrageConfig
 	deduplicator *Deduplicator
+	lifecycle    *LifecycleTracker
+
+	transferStatus *TransferStatusProvider // nil = перевірка маршруту вимкнена
 
 	onOpportunity OpportunityCallback
+	stopHooks     []func() // Залежні компоненти, що зупиняються разом з детектором
 }
 
 // OpportunityCallback викликається при знаходженні можливості
@@ -40,80 +44,130 @@ func NewDetector(
 		userRepo:     userRepo,
 		config:       config,
 		deduplicator: deduplicator,
+		lifecycle:    NewLifecycleTracker(arbRepo, time.Duration(config.DeduplicateTTL)*time.Minute),
 	}
 }
 
 // Start запускає detector (підписується на оновлення orderbook)
 func (d *Detector) Start() {
+	d.lifecycle.Start()
+
 	// Підписуємось на оновлення OrderBook
 	d.obManager.OnUpdate(func(exchange, symbol string, ob *models.OrderBook) {
 		// Кожен раз коли оновлюється orderbook - перевіряємо арбітраж
-		go d.checkArbitrage(symbol)
+		// (книга в USD/USDC порівнюється під символом в USDT)
+		go d.checkArbitrage(d.obManager.ReferenceSymbol(symbol))
+
+		// Трикутний арбітраж в межах біржі
+		if d.config.TriangularEnabled {
+			go d.checkTriangular(exchange, symbol)
+		}
 	})
 
 	log.Println("✅ Arbitrage detector started (event-driven)")
 }
 
-// checkArbitrage перевіряє арбітражну можливість для символу
+// checkArbitrage перевіряє арбітражну можливість для символу та переоцінює
+// вже відкриті можливості символу (закриває ті, яких orderbook більше не показує)
 func (d *Detector) checkArbitrage(symbol string) {
+	confirmed := ""
+
 	// Отримати найкращі ціни з усіх бірж
 	bestPrices := d.obManager.GetBestPrices(symbol)
-	if bestPrices == nil {
-		return
-	}
 
-	// Перевірити чи є арбітраж
-	if !bestPrices.HasArbitrage() {
-		return
-	}
+	// Перевірити чи є арбітраж (на різних біржах)
+	if bestPrices != nil && bestPrices.HasArbitrage() &&
+		bestPrices.BestAsk.Exchange != bestPrices.BestBid.Exchange {
+		buyExchange := bestPrices.BestAsk.Exchange
+		sellExchange := bestPrices.BestBid.Exchange
 
-	buyExchange := bestPrices.BestAsk.Exchange
-	sellExchange := bestPrices.BestBid.Exchange
+		if opp := d.evaluatePair(symbol, buyExchange, sellExchange); opp != nil {
+			confirmed = LifecycleKey(symbol, buyExchange, sellExchange)
+			d.observe(confirmed, opp)
+		}
+	}
 
-	if buyExchange == sellExchange {
-		return // Та сама біржа
+	// Відкриті можливості по іншим напрямкам можуть бути вже не найкращими,
+	// але ще існувати - перевіряємо кожну окремо
+	for key, open := range d.lifecycle.OpenForPair(symbol) {
+		if key == confirmed {
+			continue
+		}
+
+		if opp := d.evaluatePair(symbol, open.ExchangeBuy, open.ExchangeSell); opp != nil {
+			d.observe(key, opp)
+		} else {
+			d.lifecycle.Close(key, CloseReasonSpreadClosed)
+		}
 	}
+}
 
-	// Отримати повні orderbook для розрахунку slippage
-	buyOB := d.obManager.GetOrderBook(buyExchange, symbol)
-	sellOB := d.obManager.GetOrderBook(sellExchange, symbol)
+// evaluatePair розраховує можливість buyExchange -> sellExchange за поточними
+// orderbook. Повертає nil, якщо можливості немає або вона не проходить фільтри
+func (d *Detector) evaluatePair(symbol, buyExchange, sellExchange string) *models.ArbitrageOpportunity {
+	// Отримати повні orderbook для розрахунку slippage (тільки придатні -
+	// застарілу книгу відключеної біржі не можна використовувати)
+	buyOB := d.obManager.GetHealthyOrderBook(buyExchange, symbol)
+	sellOB := d.obManager.GetHealthyOrderBook(sellExchange, symbol)
 
 	if buyOB == nil || sellOB == nil {
-		return
+		return nil
 	}
 
 	// Розрахувати з урахуванням slippage
 	opp := d.calculateWithSlippage(symbol, buyExchange, buyOB, sellExchange, sellOB)
 
 	if opp == nil {
-		return
+		return nil
 	}
 
 	// Фільтрація
 	if !d.shouldCreate(opp) {
-		return
+		return nil
 	}
 
-	// Deduplication
-	if d.deduplicator.IsDuplicate(opp.ExternalID) {
-		return
+	// Маршрут переказу base валюти ExchangeBuy -> ExchangeSell
+	if !d.applyTransferRoute(opp) {
+		return nil
 	}
 
+	return opp
+}
+
+// observe відкриває нову або оновлює відкриту можливість
+func (d *Detector) observe(key string, opp *models.ArbitrageOpportunity) {
+	d.lifecycle.Observe(key, opp, func(opp *models.ArbitrageOpportunity) bool {
+		return d.publish(key, opp)
+	})
+}
+
+// publish зберігає нову можливість та викликає callback. Повторне відкриття
+// того ж key протягом DeduplicateTTL (спред "мерехтить") не розсилається вдруге
+func (d *Detector) publish(key string, opp *models.ArbitrageOpportunity) bool {
 	// Зберегти в БД
 	if err := d.arbRepo.Create(opp); err != nil {
 		log.Printf("❌ Error creating arbitrage: %v", err)
-		return
+		return false
 	}
 
-	d.deduplicator.Add(opp.ExternalID)
+	// Deduplication нотифікацій
+	notify := !d.deduplicator.IsDuplicate(key)
+	d.deduplicator.Add(key)
 
-	log.Printf("🔥 NEW ARBITRAGE: %s | %s→%s | %.2f%% net profit | $%.2f on $1000",
-		opp.Pair, opp.ExchangeBuy, opp.ExchangeSell, opp.NetProfitPercent, opp.NetProfitUSD)
+	if opp.IsTriangular() {
+		log.Printf("🔺 NEW TRIANGULAR ARBITRAGE: %s | %s | %.2f%% net profit | $%.2f on $1000",
+			opp.ExchangeBuy, opp.Route, opp.NetProfitPercent, opp.NetProfitUSD)
+	} else {
+		log.Printf("🔥 NEW ARBITRAGE: %s | %s→%s | %.2f%% net profit | $%.2f on $1000",
+			opp.Pair, opp.ExchangeBuy, opp.ExchangeSell, opp.NetProfitPercent, opp.NetProfitUSD)
+	}
 
 	// Callback для створення нотифікацій
-	if d.onOpportunity != nil {
+	if notify && d.onOpportunity != nil {
 		go d.onOpportunity(opp)
 	}
+
+	return true
 }
 
 // calculateWithSlippage розраховує можливість з урахуванням slippage
@@ -167,75 +221,143 @@ func (d *Detector) calculateWithSlippage(
 		return nil
 	}
 
-	// Перевірити чи ще прибутково після slippage
-	if calc.NetProfit < d.config.MinProfitPercent {
-		return nil
-	}
+	// Конвертація між валютами котирування (BTC/USD -> BTC/USDT)
+	d.calculator.ApplyQuoteConversion(calc, buyOB, sellOB)
+
+	// Оптимальна сума та break-even за глибиною обох книг: з виведенням
+	// (transfer) та окремо для pre-funded балансів, де виведення немає
+	size := d.calculator.SolveTradeSize(buyExchange, buyOB, sellExchange, sellOB, calc.WithdrawalFee, calc.ConversionFee)
+	prefundedSize := d.calculator.SolveTradeSize(buyExchange, buyOB, sellExchange, sellOB, 0, calc.ConversionFee)
 
-	// Створити ArbitrageOpportunity
+	// Створити ArbitrageOpportunity (ExternalID та ExpiresAt задає LifecycleTracker)
 	now := time.Now()
-	deduplicateTime := time.Duration(d.config.DeduplicateTTL) * time.Minute
-
-	return &models.ArbitrageOpportunity{
-		Pair:              symbol,
-		BaseCurrency:      calc.BaseCurrency,
-		QuoteCurrency:     calc.QuoteCurrency,
-		ExchangeBuy:       buyExchange,
-		PriceBuy:          buyPrice,
-		VolumeBuy:         buySlippage.TotalQuantity,
-		ExchangeSell:      sellExchange,
-		PriceSell:         sellPrice,
-		VolumeSell:        sellSlippage.TotalQuantity,
-		ProfitPercent:     calc.GrossProfit,
-		ProfitUSD:         calc.ProfitOn1000USD,
-		TradingFeeBuy:     calc.BuyFee,
-		TradingFeeSell:    calc.SellFee,
-		WithdrawalFee:     calc.WithdrawalFee,
-		WithdrawalFeeUSD:  calc.WithdrawalFeeUSD,
-		TotalFeesPercent:  calc.TotalFeesPercent,
-		SlippageBuy:       buySlippage.SlippagePercent,
-		SlippageSell:      sellSlippage.SlippagePercent,
-		NetProfitPercent:  calc.NetProfit,
-		NetProfitUSD:      calc.ProfitOn1000USD,
-		Volume24h:         estimatedVolume,
-		SpreadPercent:     calc.SpreadPercent,
-		MinTradeAmount:    100,
-		MaxTradeAmount:    minAmount(buySlippage.AvailableLiquidityUSD, sellSlippage.AvailableLiquidityUSD),
-		RecommendedAmount: calc.RecommendedAmount,
-		DetectedAt:        now,
-		ExpiresAt:         now.Add(deduplicateTime),
-		IsNotified:        false,
-		ExternalID:        GenerateArbitrageID(symbol, buyExchange, sellExchange, now),
+
+	opp := &models.ArbitrageOpportunity{
+		Type:             models.ArbitrageTypeCrossExchange,
+		Pair:             symbol,
+		BaseCurrency:     calc.BaseCurrency,
+		QuoteCurrency:    calc.QuoteCurrency,
+		ExchangeBuy:      buyExchange,
+		PriceBuy:         buyPrice,
+		VolumeBuy:        buySlippage.TotalQuantity,
+		ExchangeSell:     sellExchange,
+		PriceSell:        sellPrice,
+		VolumeSell:       sellSlippage.TotalQuantity,
+		ProfitPercent:    calc.GrossProfit,
+		ProfitUSD:        calc.ProfitOn1000USD,
+		TradingFeeBuy:    calc.BuyFee,
+		TradingFeeSell:   calc.SellFee,
+		WithdrawalFee:    calc.WithdrawalFee,
+		WithdrawalFeeUSD: calc.WithdrawalFeeUSD,
+		RebalanceCostUSD: calc.RebalanceCostUSD,
+		TotalFeesPercent: calc.TotalFeesPercent,
+		ConversionFee:    calc.ConversionFee,
+		SymbolBuy:        buyOB.NativeSymbol,
+		SymbolSell:       sellOB.NativeSymbol,
+		SlippageBuy:      buySlippage.SlippagePercent,
+		SlippageSell:     sellSlippage.SlippagePercent,
+		NetProfitPercent: calc.NetProfit,
+		NetProfitUSD:     calc.ProfitOn1000USD,
+		Volume24h:        estimatedVolume,
+		SpreadPercent:    calc.SpreadPercent,
+		MinTradeAmount:   100,
+		MaxTradeAmount:   minAmount(buySlippage.AvailableLiquidityUSD, sellSlippage.AvailableLiquidityUSD),
+		OptimalAmount:    size.OptimalAmount,
+		OptimalProfitUSD: size.OptimalProfitUSD,
+		BreakEvenAmount:  size.BreakEvenAmount,
+		DetectedAt:       now,
+		IsNotified:       false,
+
+		PrefundedOptimalAmount:    prefundedSize.OptimalAmount,
+		PrefundedOptimalProfitUSD: prefundedSize.OptimalProfitUSD,
 	}
+	opp.PrefundedProfitPercent = opp.WithPrefunded(d.config.GetRebalanceTrades()).NetProfitPercent
+
+	// Перевірити чи ще прибутково після slippage (з переказом або з pre-funded балансів)
+	if opp.BestProfitPercent() < d.config.MinProfitPercent {
+		return nil
+	}
+
+	return opp
 }
 
+// Причини відхилення можливості фільтрами
+const (
+	RejectMinProfit        = "min_profit"
+	RejectLowVolume        = "low_volume"
+	RejectSuspiciousSpread = "suspicious_spread"
+	RejectLowLiquidity     = "low_liquidity"
+)
+
 // shouldCreate фільтрує можливості перед створенням
 func (d *Detector) shouldCreate(opp *models.ArbitrageOpportunity) bool {
-	// Min profit
-	if opp.NetProfitPercent < d.config.MinProfitPercent {
-		return false
+	switch d.rejectReason(opp) {
+	case "":
+		return true
+	case RejectLowVolume:
+		log.Printf("⚠️ Low volume for %s: $%.0f", opp.Pair, opp.Volume24h)
+	case RejectSuspiciousSpread:
+		log.Printf("⚠️ Suspicious spread for %s: %.2f%%", opp.Pair, opp.SpreadPercent)
+	}
+
+	return false
+}
+
+// rejectReason повертає причину відхилення можливості ("" = проходить фільтри)
+func (d *Detector) rejectReason(opp *models.ArbitrageOpportunity) string {
+	// Min profit (достатньо однієї моделі: переказ або pre-funded)
+	if opp.BestProfitPercent() < d.config.MinProfitPercent {
+		return RejectMinProfit
 	}
 
 	// Min volume
 	if d.config.MinVolume24h > 0 && opp.Volume24h < d.config.MinVolume24h {
-		log.Printf("⚠️ Low volume for %s: $%.0f", opp.Pair, opp.Volume24h)
-		return false
+		return RejectLowVolume
 	}
 
 	// Max spread (підозріло якщо занадто великий)
 	if opp.SpreadPercent > d.config.MaxSpreadPercent {
-		log.Printf("⚠️ Suspicious spread for %s: %.2f%%", opp.Pair, opp.SpreadPercent)
-		return false
+		return RejectSuspiciousSpread
 	}
 
-	// Recommended amount > 0
-	if opp.RecommendedAmount < 100 {
-		return false
+	// Оптимальна сума (хоча б в одній з моделей) не менше мінімальної угоди
+	if max(opp.OptimalAmount, opp.PrefundedOptimalAmount) < opp.MinTradeAmount {
+		return RejectLowLiquidity
+	}
+
+	return ""
+}
+
+// applyTransferRoute заповнює мережу та час переказу. Повертає false, якщо
+// маршрут закритий і можливість треба відкинути (режим "drop")
+func (d *Detector) applyTransferRoute(opp *models.ArbitrageOpportunity) bool {
+	if d.transferStatus == nil {
+		opp.TransferStatus = models.TransferStatusUnknown
+		return true
+	}
+
+	route := d.transferStatus.FindRoute(opp.BaseCurrency, opp.ExchangeBuy, opp.ExchangeSell)
+
+	opp.TransferNetwork = route.Network
+	opp.TransferStatus = route.Status
+	opp.TransferMinutes = route.EstimatedMinutes
+
+	if route.Status == models.TransferStatusClosed {
+		if !d.config.FlagClosedTransfers() {
+			log.Printf("🚫 Transfer route closed for %s %s→%s: %s",
+				opp.Pair, opp.ExchangeBuy, opp.ExchangeSell, route.Reason)
+			return false
+		}
 	}
 
 	return true
 }
 
+// SetTransferStatusProvider вмикає перевірку статусу депозитів/виведень
+func (d *Detector) SetTransferStatusProvider(provider *TransferStatusProvider) {
+	d.transferStatus = provider
+}
+
 // OnOpportunity встановлює callback для нових можливостей
 func (d *Detector) OnOpportunity(callback OpportunityCallback) {
 	d.onOpportunity = callback
@@ -246,12 +368,18 @@ func (d *Detector) OnArbitrageDetected(callback OpportunityCallback) {
 	d.OnOpportunity(callback)
 }
 
+// OnStop реєструє зупинку залежного компонента (funding, алерти цін тощо)
+func (d *Detector) OnStop(stop func()) {
+	d.stopHooks = append(d.stopHooks, stop)
+}
+
 // GetStats повертає статистику детектора
 func (d *Detector) GetStats() *DetectorStats {
 	activeCount, _ := d.arbRepo.CountActive()
 
 	return &DetectorStats{
 		ActiveOpportunities: int(activeCount),
+		OpenLifecycles:      d.lifecycle.OpenCount(),
 		CachedIDs:           d.deduplicator.Size(),
 		MinProfit:           d.config.MinProfitPercent,
 		MinVolume:           d.config.MinVolume24h,
@@ -259,18 +387,27 @@ func (d *Detector) GetStats() *DetectorStats {
 }
 
 func (d *Detector) Stop() {
+	// Спершу компоненти, що читають ордербуки детектора
+	for _, stop := range d.stopHooks {
+		stop()
+	}
+
 	// Clear update callback
 	d.obManager.OnUpdate(nil)
 
 	// Disconnect all WebSocket connections
 	d.obManager.DisconnectAll()
 
+	// Зафіксувати тривалість життя відкритих можливостей
+	d.lifecycle.Stop()
+
 	log.Println("✅ Arbitrage detector stopped")
 }
 
 // DetectorStats статистика детектора
 type DetectorStats struct {
 	ActiveOpportunities int
+	OpenLifecycles      int
 	CachedIDs           int
 	MinProfit           float64
 	MinVolume           float64
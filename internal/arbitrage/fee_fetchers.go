@@ -0,0 +1,243 @@
+package arbitrage
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FeeFetcher завантажує комісії біржі з публічних endpoints
+type FeeFetcher interface {
+	GetExchange() string
+	FetchFees(ctx context.Context) ([]*models.ExchangeFee, error)
+}
+
+// DefaultFeeFetchers повертає fetchers для бірж з публічними fee endpoints.
+// Bybit та OKX віддають комісії тільки через підписані (private) endpoints,
+// тому для них залишаються значення з FeeTable.
+func DefaultFeeFetchers() []FeeFetcher {
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	return []FeeFetcher{
+		&binanceFeeFetcher{httpClient: client},
+		&gateIOFeeFetcher{httpClient: client},
+		&krakenFeeFetcher{httpClient: client},
+	}
+}
+
+// fetchJSON виконує GET запит і декодує JSON відповідь
+func fetchJSON(ctx context.Context, client *http.Client, url string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return nil
+}
+
+// mostCommonFee повертає найчастішу комісію (базовий рівень для більшості пар)
+func mostCommonFee(fees []float64) float64 {
+	counts := make(map[float64]int)
+	best, bestCount := 0.0, 0
+
+	for _, fee := range fees {
+		counts[fee]++
+		if counts[fee] > bestCount || (counts[fee] == bestCount && fee < best) {
+			best, bestCount = fee, counts[fee]
+		}
+	}
+
+	return best
+}
+
+// binanceFeeFetcher - withdrawal fees по мережах (публічний endpoint сайту Binance)
+type binanceFeeFetcher struct {
+	httpClient *http.Client
+}
+
+func (f *binanceFeeFetcher) GetExchange() string {
+	return models.ExchangeBinance
+}
+
+func (f *binanceFeeFetcher) FetchFees(ctx context.Context) ([]*models.ExchangeFee, error) {
+	const url = "https://www.binance.com/bapi/capital/v1/public/capital/getNetworkCoinAll"
+
+	var resp struct {
+		Code string `json:"code"`
+		Data []struct {
+			Coin        string `json:"coin"`
+			NetworkList []struct {
+				Network        string `json:"network"`
+				WithdrawFee    string `json:"withdrawFee"`
+				WithdrawEnable bool   `json:"withdrawEnable"`
+				IsDefault      bool   `json:"isDefault"`
+			} `json:"networkList"`
+		} `json:"data"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, url, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Code != "000000" {
+		return nil, fmt.Errorf("binance API error: code %s", resp.Code)
+	}
+
+	now := time.Now()
+	var fees []*models.ExchangeFee
+
+	for _, coin := range resp.Data {
+		for _, network := range coin.NetworkList {
+			if !network.WithdrawEnable {
+				continue
+			}
+
+			fee, err := strconv.ParseFloat(network.WithdrawFee, 64)
+			if err != nil {
+				continue
+			}
+
+			fees = append(fees, &models.ExchangeFee{
+				Exchange:      models.ExchangeBinance,
+				FeeType:       models.FeeTypeWithdrawal,
+				Currency:      strings.ToUpper(coin.Coin),
+				Network:       network.Network,
+				WithdrawalFee: fee,
+				IsDefault:     network.IsDefault,
+				Source:        url,
+				FetchedAt:     now,
+			})
+		}
+	}
+
+	return fees, nil
+}
+
+// gateIOFeeFetcher - базова trading fee зі списку spot пар
+type gateIOFeeFetcher struct {
+	httpClient *http.Client
+}
+
+func (f *gateIOFeeFetcher) GetExchange() string {
+	return models.ExchangeGateIO
+}
+
+func (f *gateIOFeeFetcher) FetchFees(ctx context.Context) ([]*models.ExchangeFee, error) {
+	const url = "https://api.gateio.ws/api/v4/spot/currency_pairs"
+
+	var pairs []struct {
+		ID          string `json:"id"`
+		Fee         string `json:"fee"` // % (VIP0)
+		TradeStatus string `json:"trade_status"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, url, &pairs); err != nil {
+		return nil, err
+	}
+
+	var values []float64
+	for _, pair := range pairs {
+		if pair.TradeStatus != "tradable" {
+			continue
+		}
+		if fee, err := strconv.ParseFloat(pair.Fee, 64); err == nil && fee > 0 {
+			values = append(values, fee)
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no tradable pairs with fee")
+	}
+
+	fee := mostCommonFee(values)
+
+	return []*models.ExchangeFee{{
+		Exchange:  models.ExchangeGateIO,
+		FeeType:   models.FeeTypeTrading,
+		MakerFee:  fee,
+		TakerFee:  fee,
+		Source:    url,
+		FetchedAt: time.Now(),
+	}}, nil
+}
+
+// krakenFeeFetcher - базовий рівень maker/taker з публічної fee schedule пар
+type krakenFeeFetcher struct {
+	httpClient *http.Client
+}
+
+func (f *krakenFeeFetcher) GetExchange() string {
+	return models.ExchangeKraken
+}
+
+func (f *krakenFeeFetcher) FetchFees(ctx context.Context) ([]*models.ExchangeFee, error) {
+	const url = "https://api.kraken.com/0/public/AssetPairs"
+
+	var resp struct {
+		Error  []string `json:"error"`
+		Result map[string]struct {
+			WSName    string      `json:"wsname"`
+			Fees      [][]float64 `json:"fees"`       // [[volume, taker %], ...]
+			FeesMaker [][]float64 `json:"fees_maker"` // [[volume, maker %], ...]
+		} `json:"result"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, url, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Error) > 0 {
+		return nil, fmt.Errorf("kraken API error: %v", resp.Error)
+	}
+
+	var takers, makers []float64
+	for _, pair := range resp.Result {
+		if len(pair.Fees) > 0 && len(pair.Fees[0]) == 2 {
+			takers = append(takers, pair.Fees[0][1])
+		}
+		if len(pair.FeesMaker) > 0 && len(pair.FeesMaker[0]) == 2 {
+			makers = append(makers, pair.FeesMaker[0][1])
+		}
+	}
+
+	if len(takers) == 0 {
+		return nil, fmt.Errorf("no fee schedule in response")
+	}
+
+	return []*models.ExchangeFee{{
+		Exchange:  models.ExchangeKraken,
+		FeeType:   models.FeeTypeTrading,
+		MakerFee:  mostCommonFee(makers),
+		TakerFee:  mostCommonFee(takers),
+		Source:    url,
+		FetchedAt: time.Now(),
+	}}, nil
+}
@@ -0,0 +1,176 @@
+package arbitrage
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// FeeSyncer періодично синхронізує комісії бірж, зберігає їх у БД
+// та оновлює FeeTable калькулятора
+type FeeSyncer struct {
+	calculator *Calculator
+	feeRepo    repository.FeeRepository
+	fetchers   []FeeFetcher
+	currencies map[string]bool // withdrawal fees зберігаються тільки для цих валют
+	timeout    time.Duration
+	stopChan   chan struct{}
+}
+
+// NewFeeSyncer створює новий FeeSyncer. currencies обмежує withdrawal fees
+// валютами з торгових пар (порожній список = всі валюти)
+func NewFeeSyncer(
+	calculator *Calculator,
+	feeRepo repository.FeeRepository,
+	fetchers []FeeFetcher,
+	currencies []string,
+) *FeeSyncer {
+	currencySet := make(map[string]bool, len(currencies))
+	for _, currency := range currencies {
+		currencySet[strings.ToUpper(currency)] = true
+	}
+
+	return &FeeSyncer{
+		calculator: calculator,
+		feeRepo:    feeRepo,
+		fetchers:   fetchers,
+		currencies: currencySet,
+		timeout:    30 * time.Second,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// LoadPersisted застосовує останні збережені комісії (щоб після рестарту
+// не повертатись до захардкоджених значень до першої синхронізації)
+func (s *FeeSyncer) LoadPersisted() error {
+	fees, err := s.feeRepo.ListExchangeFees()
+	if err != nil {
+		return fmt.Errorf("failed to load exchange fees: %w", err)
+	}
+
+	applied := s.calculator.ApplyExchangeFees(fees)
+	log.Printf("💸 Loaded %d persisted exchange fees", applied)
+
+	return nil
+}
+
+// SyncAll завантажує комісії з усіх бірж, зберігає та застосовує їх
+func (s *FeeSyncer) SyncAll() error {
+	var errors []error
+	total := 0
+
+	for _, fetcher := range s.fetchers {
+		count, err := s.syncExchange(fetcher)
+		if err != nil {
+			log.Printf("❌ Fee sync failed for %s: %v", fetcher.GetExchange(), err)
+			errors = append(errors, fmt.Errorf("%s: %w", fetcher.GetExchange(), err))
+			continue
+		}
+
+		total += count
+	}
+
+	log.Printf("💸 Fee sync completed: %d fees updated", total)
+
+	if len(errors) == len(s.fetchers) && len(errors) > 0 {
+		return fmt.Errorf("all fee fetchers failed: %v", errors)
+	}
+
+	return nil
+}
+
+// syncExchange синхронізує комісії однієї біржі
+func (s *FeeSyncer) syncExchange(fetcher FeeFetcher) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	fees, err := fetcher.FetchFees(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	fees = s.filterFees(fees)
+
+	for _, fee := range fees {
+		if err := s.feeRepo.UpsertExchangeFee(fee); err != nil {
+			return 0, fmt.Errorf("failed to save fee: %w", err)
+		}
+	}
+
+	s.calculator.ApplyExchangeFees(fees)
+
+	return len(fees), nil
+}
+
+// filterFees відкидає withdrawal fees валют, які не торгуються
+func (s *FeeSyncer) filterFees(fees []*models.ExchangeFee) []*models.ExchangeFee {
+	if len(s.currencies) == 0 {
+		return fees
+	}
+
+	filtered := make([]*models.ExchangeFee, 0, len(fees))
+	for _, fee := range fees {
+		if fee.FeeType == models.FeeTypeWithdrawal && !s.currencies[fee.Currency] {
+			continue
+		}
+		filtered = append(filtered, fee)
+	}
+
+	return filtered
+}
+
+// Start запускає синхронізацію одразу та далі з інтервалом
+func (s *FeeSyncer) Start(interval time.Duration) {
+	if err := s.LoadPersisted(); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+
+	go func() {
+		if err := s.SyncAll(); err != nil {
+			log.Printf("❌ Fee sync error: %v", err)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stopChan:
+				return
+			case <-ticker.C:
+				if err := s.SyncAll(); err != nil {
+					log.Printf("❌ Fee sync error: %v", err)
+				}
+			}
+		}
+	}()
+
+	log.Printf("✅ Fee sync started (every %s)", interval)
+}
+
+// Stop зупиняє синхронізацію
+func (s *FeeSyncer) Stop() {
+	close(s.stopChan)
+}
+
+// CurrenciesFromPairs повертає унікальні валюти торгових пар
+func CurrenciesFromPairs(pairs []string) []string {
+	seen := make(map[string]bool)
+	var currencies []string
+
+	for _, pair := range pairs {
+		base, quote := parsePair(pair)
+		for _, currency := range []string{base, quote} {
+			if currency != "" && !seen[currency] {
+				seen[currency] = true
+				currencies = append(currencies, currency)
+			}
+		}
+	}
+
+	return currencies
+}
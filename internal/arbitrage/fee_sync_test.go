@@ -0,0 +1,221 @@
+package arbitrage
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyExchangeFees(t *testing.T) {
+	calc := NewCalculator()
+
+	applied := calc.ApplyExchangeFees([]*models.ExchangeFee{
+		{Exchange: "binance", FeeType: models.FeeTypeTrading, MakerFee: 0.075, TakerFee: 0.095},
+		{Exchange: "okx", FeeType: models.FeeTypeTrading, MakerFee: 0.08}, // без taker - ігнорується
+
+		// Default мережа має пріоритет над дешевшою
+		{Exchange: "binance", FeeType: models.FeeTypeWithdrawal, Currency: "USDT", Network: "TRX", WithdrawalFee: 1},
+		{Exchange: "binance", FeeType: models.FeeTypeWithdrawal, Currency: "USDT", Network: "ETH", WithdrawalFee: 3.2, IsDefault: true},
+		{Exchange: "binance", FeeType: models.FeeTypeWithdrawal, Currency: "USDT", Network: "BSC", WithdrawalFee: 0.8},
+
+		// Без default - найдешевша мережа
+		{Exchange: "kraken", FeeType: models.FeeTypeWithdrawal, Currency: "SOL", Network: "SOL", WithdrawalFee: 0.02},
+		{Exchange: "kraken", FeeType: models.FeeTypeWithdrawal, Currency: "SOL", Network: "BSC", WithdrawalFee: 0.005},
+
+		{Exchange: "kraken", FeeType: models.FeeTypeWithdrawal, Currency: "ETH", WithdrawalFee: -1},
+		{Exchange: "kraken", FeeType: models.FeeTypeWithdrawal, WithdrawalFee: 1},
+	})
+
+	if applied != 3 {
+		t.Errorf("Expected 3 applied fees, got %d", applied)
+	}
+
+	if fee := calc.GetTradingFee("binance"); fee != 0.095 {
+		t.Errorf("Expected binance taker fee 0.095, got %v", fee)
+	}
+	if fee := calc.GetTradingFee("okx"); fee != 0.08 {
+		t.Errorf("Expected okx fee from FeeTable without taker, got %v", fee)
+	}
+	if fee := calc.GetWithdrawalFee("binance", "USDT"); fee != 3.2 {
+		t.Errorf("Expected default network USDT fee 3.2, got %v", fee)
+	}
+	if fee := calc.GetWithdrawalFee("kraken", "SOL"); fee != 0.005 {
+		t.Errorf("Expected cheapest network SOL fee 0.005, got %v", fee)
+	}
+	if fee := calc.GetWithdrawalFee("kraken", "ETH"); fee < 0 {
+		t.Errorf("Expected negative withdrawal fee to be ignored, got %v", fee)
+	}
+}
+
+type fakeFeeRepo struct {
+	repository.FeeRepository
+	saved []*models.ExchangeFee
+}
+
+func (r *fakeFeeRepo) UpsertExchangeFee(fee *models.ExchangeFee) error {
+	r.saved = append(r.saved, fee)
+	return nil
+}
+
+func (r *fakeFeeRepo) ListExchangeFees() ([]*models.ExchangeFee, error) {
+	return r.saved, nil
+}
+
+type fakeFeeFetcher struct {
+	exchange string
+	fees     []*models.ExchangeFee
+	err      error
+}
+
+func (f *fakeFeeFetcher) GetExchange() string {
+	return f.exchange
+}
+
+func (f *fakeFeeFetcher) FetchFees(ctx context.Context) ([]*models.ExchangeFee, error) {
+	return f.fees, f.err
+}
+
+func TestFeeSyncerSyncAll(t *testing.T) {
+	repo := &fakeFeeRepo{}
+	calc := NewCalculator()
+
+	fetchers := []FeeFetcher{
+		&fakeFeeFetcher{exchange: "gateio", err: errors.New("bad status code: 503")},
+		&fakeFeeFetcher{exchange: "binance", fees: []*models.ExchangeFee{
+			{Exchange: "binance", FeeType: models.FeeTypeTrading, TakerFee: 0.09},
+			{Exchange: "binance", FeeType: models.FeeTypeWithdrawal, Currency: "BTC", WithdrawalFee: 0.0001, IsDefault: true},
+			{Exchange: "binance", FeeType: models.FeeTypeWithdrawal, Currency: "DOGE", WithdrawalFee: 4, IsDefault: true},
+		}},
+	}
+
+	syncer := NewFeeSyncer(calc, repo, fetchers, CurrenciesFromPairs([]string{"btc/usdt", "BTC/USDC"}))
+
+	if err := syncer.SyncAll(); err != nil {
+		t.Fatalf("Expected partial failure to be tolerated, got %v", err)
+	}
+
+	// Withdrawal fees валют, що не торгуються, не зберігаються
+	if len(repo.saved) != 2 {
+		t.Fatalf("Expected trading and BTC withdrawal fees saved, got %d", len(repo.saved))
+	}
+	if calc.GetTradingFee("binance") != 0.09 || calc.GetWithdrawalFee("binance", "BTC") != 0.0001 {
+		t.Errorf("Expected synced fees applied to calculator")
+	}
+
+	// Після рестарту застосовуються збережені комісії
+	restarted := NewCalculator()
+	if err := NewFeeSyncer(restarted, repo, nil, nil).LoadPersisted(); err != nil {
+		t.Fatalf("LoadPersisted failed: %v", err)
+	}
+	if restarted.GetTradingFee("binance") != 0.09 {
+		t.Errorf("Expected persisted fee after restart, got %v", restarted.GetTradingFee("binance"))
+	}
+
+	failing := NewFeeSyncer(calc, repo, fetchers[:1], nil)
+	if err := failing.SyncAll(); err == nil {
+		t.Error("Expected error when all fetchers fail")
+	}
+}
+
+// feeFixtureClient віддає записані відповіді з testdata/fees за шляхом запиту
+// (будь-який хост), щоб fetchers не виходили в мережу
+func feeFixtureClient(t *testing.T, files map[string]string) *http.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		body, err := os.ReadFile(filepath.Join("testdata", "fees", file))
+		if err != nil {
+			t.Errorf("Failed to read fixture %s: %v", file, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	client := server.Client()
+	client.Transport = rewriteHostTransport{target: target, next: client.Transport}
+
+	return client
+}
+
+type rewriteHostTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (t rewriteHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return t.next.RoundTrip(req)
+}
+
+func TestFeeFetchersParseRecordedPayloads(t *testing.T) {
+	client := feeFixtureClient(t, map[string]string{
+		"/bapi/capital/v1/public/capital/getNetworkCoinAll": "binance_network_coin_all.json",
+		"/api/v4/spot/currency_pairs":                       "gateio_currency_pairs.json",
+		"/0/public/AssetPairs":                              "kraken_asset_pairs.json",
+	})
+	ctx := context.Background()
+
+	binance, err := (&binanceFeeFetcher{httpClient: client}).FetchFees(ctx)
+	if err != nil {
+		t.Fatalf("Binance fetch failed: %v", err)
+	}
+
+	// Вимкнені мережі та нечислові комісії пропускаються, coin нормалізується
+	if len(binance) != 3 {
+		t.Fatalf("Expected 3 Binance withdrawal networks, got %d", len(binance))
+	}
+	networks := make(map[string]*models.ExchangeFee)
+	for _, fee := range binance {
+		networks[fee.Currency+":"+fee.Network] = fee
+	}
+	if usdt := networks["USDT:ETH"]; usdt == nil || usdt.WithdrawalFee != 3.2 || !usdt.IsDefault || usdt.FeeType != models.FeeTypeWithdrawal {
+		t.Errorf("Unexpected USDT ETH network fee: %+v", usdt)
+	}
+	if networks["USDT:BSC"] != nil || networks["BTC:LIGHTNING"] != nil {
+		t.Error("Expected disabled and unparsable networks to be skipped")
+	}
+
+	gateio, err := (&gateIOFeeFetcher{httpClient: client}).FetchFees(ctx)
+	if err != nil {
+		t.Fatalf("Gate.io fetch failed: %v", err)
+	}
+	// Базова комісія - найчастіша серед пар, що торгуються
+	if len(gateio) != 1 || gateio[0].TakerFee != 0.2 || gateio[0].MakerFee != 0.2 {
+		t.Errorf("Expected Gate.io base fee 0.2%%, got %+v", gateio[0])
+	}
+
+	kraken, err := (&krakenFeeFetcher{httpClient: client}).FetchFees(ctx)
+	if err != nil {
+		t.Fatalf("Kraken fetch failed: %v", err)
+	}
+	// Перший рівень fee schedule, найчастіший серед пар
+	if len(kraken) != 1 || kraken[0].TakerFee != 0.4 || kraken[0].MakerFee != 0.25 {
+		t.Errorf("Expected Kraken base tier 0.25/0.4, got %+v", kraken[0])
+	}
+
+	// Помилка API Binance
+	errClient := feeFixtureClient(t, map[string]string{
+		"/bapi/capital/v1/public/capital/getNetworkCoinAll": "gateio_currency_pairs.json",
+	})
+	if _, err := (&binanceFeeFetcher{httpClient: errClient}).FetchFees(ctx); err == nil {
+		t.Error("Expected error for unexpected Binance payload")
+	}
+}
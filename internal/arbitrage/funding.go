@@ -0,0 +1,336 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/arbitrage/websocket"
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	hoursPerYear = 24 * 365
+
+	// fundingMaxAge funding дані без оновлень довше - біржа відключена
+	fundingMaxAge = 2 * time.Minute
+
+	// defaultPerpTakerFee taker fee perpetual, якщо біржі немає в конфігу (%)
+	defaultPerpTakerFee = 0.06
+)
+
+// FundingCallback викликається при знаходженні funding можливості
+type FundingCallback func(*models.FundingOpportunity)
+
+// FundingDetector шукає дельта-нейтральні позиції на perpetual ф'ючерсах:
+//   - cash-and-carry: купівля spot + short perp при позитивному funding
+//   - funding differential: long perp на біржі з нижчим funding, short - з вищим
+//
+// Funding змінюється повільно, тому символи перевіряються з інтервалом,
+// а не на кожне оновлення ордербуку
+type FundingDetector struct {
+	obManager  *OrderBookManager // spot ордербуки
+	calculator *Calculator       // spot trading fees
+	repo       repository.FundingRepository
+	config     *config.FundingConfig
+	symbols    []string
+
+	perps map[string]websocket.PerpManager
+	mu    sync.RWMutex
+
+	deduplicator *Deduplicator
+	onDetected   FundingCallback
+	stopChan     chan struct{}
+	stopOnce     sync.Once
+}
+
+// NewFundingDetector створює новий FundingDetector
+func NewFundingDetector(
+	obManager *OrderBookManager,
+	calc *Calculator,
+	repo repository.FundingRepository,
+	cfg *config.FundingConfig,
+	symbols []string,
+) *FundingDetector {
+	return &FundingDetector{
+		obManager:    obManager,
+		calculator:   calc,
+		repo:         repo,
+		config:       cfg,
+		symbols:      symbols,
+		perps:        make(map[string]websocket.PerpManager),
+		deduplicator: NewDeduplicator(time.Duration(cfg.DeduplicateTTL) * time.Minute),
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// RegisterExchange реєструє perpetual Manager біржі
+func (d *FundingDetector) RegisterExchange(exchange string, manager websocket.PerpManager) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.perps[exchange] = manager
+}
+
+// OnFundingDetected встановлює callback для нових можливостей
+func (d *FundingDetector) OnFundingDetected(callback FundingCallback) {
+	d.onDetected = callback
+}
+
+// Start запускає періодичну перевірку
+func (d *FundingDetector) Start() {
+	interval := time.Duration(d.config.ScanInterval) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-d.stopChan:
+				return
+			case <-ticker.C:
+				d.Scan()
+			}
+		}
+	}()
+
+	log.Printf("✅ Funding detector started (every %s, min APR %.1f%%)", interval, d.config.MinAPR)
+}
+
+// Stop зупиняє перевірку та від'єднує perpetual з'єднання
+func (d *FundingDetector) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stopChan)
+
+		d.mu.RLock()
+		defer d.mu.RUnlock()
+
+		for exchange, manager := range d.perps {
+			if err := manager.Disconnect(); err != nil {
+				log.Printf("⚠️ Error disconnecting %s perp: %v", exchange, err)
+			}
+		}
+
+		log.Println("✅ Funding detector stopped")
+	})
+}
+
+// Scan перевіряє всі символи та публікує нові можливості
+func (d *FundingDetector) Scan() {
+	for _, symbol := range d.symbols {
+		for _, opp := range d.FindOpportunities(symbol) {
+			d.publish(opp)
+		}
+	}
+}
+
+// FindOpportunities повертає можливості символу, що проходять min APR
+// (найкращу cash-and-carry для кожної perp біржі та кожну пару бірж для differential)
+func (d *FundingDetector) FindOpportunities(symbol string) []*models.FundingOpportunity {
+	funding := d.freshFunding(symbol)
+	if len(funding) == 0 {
+		return nil
+	}
+
+	var result []*models.FundingOpportunity
+
+	// Cash-and-carry: spot з найвигіднішої біржі, short perp
+	spotBooks := d.obManager.GetAllOrderBooks(symbol)
+	for perpExchange, perp := range funding {
+		var best *models.FundingOpportunity
+
+		for spotExchange, ob := range spotBooks {
+			opp := d.calculateCashAndCarry(symbol, spotExchange, ob, perpExchange, perp)
+			if opp != nil && (best == nil || opp.NetAPR > best.NetAPR) {
+				best = opp
+			}
+		}
+
+		if best != nil && best.NetAPR >= d.config.MinAPR {
+			result = append(result, best)
+		}
+	}
+
+	// Funding differential між кожною парою perp бірж
+	exchanges := make([]string, 0, len(funding))
+	for exchange := range funding {
+		exchanges = append(exchanges, exchange)
+	}
+	sort.Strings(exchanges)
+
+	for i := 0; i < len(exchanges); i++ {
+		for j := i + 1; j < len(exchanges); j++ {
+			a, b := exchanges[i], exchanges[j]
+			longExchange, shortExchange := a, b
+			if funding[a].HourlyRate() > funding[b].HourlyRate() {
+				longExchange, shortExchange = b, a
+			}
+
+			opp := d.calculateFundingDiff(symbol, longExchange, funding[longExchange], shortExchange, funding[shortExchange])
+			if opp != nil && opp.NetAPR >= d.config.MinAPR {
+				result = append(result, opp)
+			}
+		}
+	}
+
+	return result
+}
+
+// freshFunding останні funding дані символу по біржах (без застарілих)
+func (d *FundingDetector) freshFunding(symbol string) map[string]*websocket.FundingData {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := make(map[string]*websocket.FundingData)
+	for exchange, manager := range d.perps {
+		funding := manager.GetFunding(symbol)
+		if funding == nil || funding.MarkPrice <= 0 || funding.IsStale(fundingMaxAge) {
+			continue
+		}
+		result[exchange] = funding
+	}
+
+	return result
+}
+
+// calculateCashAndCarry spot long на spotExchange + perp short на perpExchange.
+// Short отримує funding лише при позитивній ставці
+func (d *FundingDetector) calculateCashAndCarry(
+	symbol, spotExchange string, spotOB *models.OrderBook,
+	perpExchange string, perp *websocket.FundingData,
+) *models.FundingOpportunity {
+	if perp.FundingRate <= 0 {
+		return nil
+	}
+
+	buy := spotOB.CalculateSlippage("buy", d.amount())
+	if buy == nil || !buy.Success {
+		return nil
+	}
+
+	opp := &models.FundingOpportunity{
+		Type:          models.FundingTypeCashAndCarry,
+		Symbol:        symbol,
+		LongExchange:  spotExchange,
+		LongMarket:    models.MarketSpot,
+		LongPrice:     buy.AveragePrice,
+		ShortExchange: perpExchange,
+		ShortPrice:    perp.MarkPrice,
+		ShortFunding:  perp.FundingRate * 100,
+
+		// Spot - за ордербуком, perp short - оцінка з конфігу
+		SlippagePercent: buy.SlippagePercent + d.config.PerpSlippage,
+		// Вхід та вихід по обох ногах
+		TotalFeesPercent: 2 * (d.calculator.getTradingFee(spotExchange) + d.perpFee(perpExchange)),
+	}
+
+	d.finalize(opp, perp.HourlyRate(), perp)
+	return opp
+}
+
+// calculateFundingDiff perp long на longExchange + perp short на shortExchange
+func (d *FundingDetector) calculateFundingDiff(
+	symbol, longExchange string, long *websocket.FundingData,
+	shortExchange string, short *websocket.FundingData,
+) *models.FundingOpportunity {
+	hourly := short.HourlyRate() - long.HourlyRate()
+	if hourly <= 0 {
+		return nil
+	}
+
+	opp := &models.FundingOpportunity{
+		Type:          models.FundingTypeDifferential,
+		Symbol:        symbol,
+		LongExchange:  longExchange,
+		LongMarket:    models.MarketPerp,
+		LongPrice:     long.MarkPrice,
+		LongFunding:   long.FundingRate * 100,
+		ShortExchange: shortExchange,
+		ShortPrice:    short.MarkPrice,
+		ShortFunding:  short.FundingRate * 100,
+
+		SlippagePercent:  2 * d.config.PerpSlippage,
+		TotalFeesPercent: 2 * (d.perpFee(longExchange) + d.perpFee(shortExchange)),
+	}
+
+	d.finalize(opp, hourly, short)
+	return opp
+}
+
+// finalize рахує базис та річну дохідність. Fees та slippage сплачуються
+// один раз, тому розподіляються на HoldingDays:
+//
+//	net% за період = funding за період + базис - fees - slippage
+//	NetAPR = net% * 365 / HoldingDays
+func (d *FundingDetector) finalize(opp *models.FundingOpportunity, hourlyRate float64, short *websocket.FundingData) {
+	holdingDays := d.holdingDays()
+
+	opp.BasisPercent = (opp.ShortPrice - opp.LongPrice) / opp.LongPrice * 100
+	opp.FundingAPR = hourlyRate * hoursPerYear * 100
+	opp.HoldingDays = holdingDays
+	opp.Amount = d.amount()
+
+	fundingForHolding := opp.FundingAPR * float64(holdingDays) / 365
+	net := fundingForHolding + opp.BasisPercent - opp.TotalFeesPercent - opp.SlippagePercent
+	opp.NetAPR = net * 365 / float64(holdingDays)
+
+	now := time.Now()
+	opp.DetectedAt = now
+	opp.FundingIntervalHours = short.FundingIntervalHours
+	opp.NextFundingTime = short.NextFundingTime
+
+	// Можливість актуальна до наступного нарахування (далі ставка інша)
+	opp.ExpiresAt = short.NextFundingTime
+	if !opp.ExpiresAt.After(now) {
+		opp.ExpiresAt = now.Add(time.Duration(short.FundingIntervalHours * float64(time.Hour)))
+	}
+}
+
+// publish зберігає можливість і викликає callback (з дедуплікацією)
+func (d *FundingDetector) publish(opp *models.FundingOpportunity) {
+	key := fmt.Sprintf("%s:%s:%s:%s", opp.Type, opp.Symbol, opp.LongExchange, opp.ShortExchange)
+	if d.deduplicator.IsDuplicate(key) {
+		return
+	}
+
+	if err := d.repo.Create(opp); err != nil {
+		log.Printf("❌ Error creating funding opportunity: %v", err)
+		return
+	}
+	d.deduplicator.Add(key)
+
+	log.Printf("💸 NEW FUNDING: %s %s | long %s %s → short %s | %.1f%% APR net (funding %.1f%%, basis %.3f%%)",
+		opp.Type, opp.Symbol, opp.LongMarket, opp.LongExchange, opp.ShortExchange, opp.NetAPR, opp.FundingAPR, opp.BasisPercent)
+
+	if d.onDetected != nil {
+		go d.onDetected(opp)
+	}
+}
+
+func (d *FundingDetector) perpFee(exchange string) float64 {
+	if fee, ok := d.config.PerpTakerFees[exchange]; ok {
+		return fee
+	}
+	return defaultPerpTakerFee
+}
+
+func (d *FundingDetector) amount() float64 {
+	if d.config.Amount > 0 {
+		return d.config.Amount
+	}
+	return 1000
+}
+
+func (d *FundingDetector) holdingDays() int {
+	if d.config.HoldingDays > 0 {
+		return d.config.HoldingDays
+	}
+	return 7
+}
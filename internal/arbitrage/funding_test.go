@@ -0,0 +1,145 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/arbitrage/websocket"
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/models"
+	"math"
+	"testing"
+	"time"
+)
+
+// fakePerpManager повертає фіксовані funding дані
+type fakePerpManager struct {
+	websocket.PerpManager
+	funding *websocket.FundingData
+}
+
+func (m *fakePerpManager) GetFunding(symbol string) *websocket.FundingData {
+	return m.funding
+}
+
+func newTestFunding(mark, rate float64) *websocket.FundingData {
+	return &websocket.FundingData{
+		Symbol:               "BTC/USDT",
+		MarkPrice:            mark,
+		FundingRate:          rate,
+		FundingIntervalHours: 8,
+		NextFundingTime:      time.Now().Add(3 * time.Hour),
+		Timestamp:            time.Now(),
+	}
+}
+
+func TestFundingDetectorFindOpportunities(t *testing.T) {
+	obManager := NewOrderBookManager()
+	obManager.SetHealthConfig(BookHealthConfig{MaxAge: 5 * time.Second, MinDepth: 1})
+	obManager.updateOrderBook("binance", "BTC/USDT", newTestBook("BTC/USDT", 99.9, 100, 5000))
+	obManager.updateOrderBook("bybit", "BTC/USDT", newTestBook("BTC/USDT", 100.1, 100.2, 5000))
+
+	cfg := &config.FundingConfig{
+		MinAPR:        10,
+		HoldingDays:   7,
+		Amount:        1000,
+		PerpTakerFees: map[string]float64{"binance": 0.05, "bybit": 0.055, "okx": 0.05},
+	}
+
+	detector := NewFundingDetector(obManager, NewCalculator(), nil, cfg, []string{"BTC/USDT"})
+	detector.RegisterExchange("binance", &fakePerpManager{funding: newTestFunding(100.05, 0.0003)})
+	detector.RegisterExchange("bybit", &fakePerpManager{funding: newTestFunding(100.1, 0.0001)})
+	detector.RegisterExchange("okx", &fakePerpManager{funding: newTestFunding(100, -0.0001)})
+
+	found := make(map[string]*models.FundingOpportunity)
+	for _, opp := range detector.FindOpportunities("BTC/USDT") {
+		found[opp.Type+":"+opp.LongExchange+":"+opp.ShortExchange] = opp
+	}
+
+	// Spot binance + short binance perp: 32.85% funding APR, базис 0.05%,
+	// fees 2 * (0.1 + 0.05) розподілені на 7 днів
+	carry, ok := found["cash_and_carry:binance:binance"]
+	if !ok {
+		t.Fatalf("Expected cash-and-carry on binance, got %v", keys(found))
+	}
+	if math.Abs(carry.FundingAPR-32.85) > 0.01 || math.Abs(carry.NetAPR-19.81) > 0.01 {
+		t.Errorf("Unexpected carry yield: funding %.2f%%, net %.2f%%", carry.FundingAPR, carry.NetAPR)
+	}
+
+	// Bybit funding занизький, okx - від'ємний (short платить)
+	for key := range found {
+		if key == "cash_and_carry:binance:bybit" || key == "cash_and_carry:bybit:bybit" || key == "cash_and_carry:binance:okx" {
+			t.Errorf("Unexpected opportunity %s", key)
+		}
+	}
+
+	// Long okx (-0.01%) + short binance (0.03%)
+	diff, ok := found["funding_diff:okx:binance"]
+	if !ok {
+		t.Fatalf("Expected funding differential okx→binance, got %v", keys(found))
+	}
+	if math.Abs(diff.FundingAPR-43.8) > 0.01 || diff.NetAPR < cfg.MinAPR {
+		t.Errorf("Unexpected differential yield: funding %.2f%%, net %.2f%%", diff.FundingAPR, diff.NetAPR)
+	}
+
+	// binance/bybit: базис проти позиції з'їдає різницю funding
+	if _, ok := found["funding_diff:bybit:binance"]; ok {
+		t.Error("Expected bybit→binance differential below min APR")
+	}
+}
+
+func TestFundingDetectorPerpSlippage(t *testing.T) {
+	obManager := NewOrderBookManager()
+	obManager.SetHealthConfig(BookHealthConfig{MaxAge: 5 * time.Second, MinDepth: 1})
+	obManager.updateOrderBook("binance", "BTC/USDT", newTestBook("BTC/USDT", 99.9, 100, 5000))
+
+	cfg := &config.FundingConfig{
+		MinAPR:        10,
+		HoldingDays:   7,
+		Amount:        1000,
+		PerpTakerFees: map[string]float64{"binance": 0.05, "okx": 0.05},
+		PerpSlippage:  0.03,
+	}
+
+	detector := NewFundingDetector(obManager, NewCalculator(), nil, cfg, []string{"BTC/USDT"})
+	detector.RegisterExchange("binance", &fakePerpManager{funding: newTestFunding(100.05, 0.0003)})
+	detector.RegisterExchange("okx", &fakePerpManager{funding: newTestFunding(100, -0.0001)})
+
+	found := make(map[string]*models.FundingOpportunity)
+	for _, opp := range detector.FindOpportunities("BTC/USDT") {
+		found[opp.Type+":"+opp.LongExchange+":"+opp.ShortExchange] = opp
+	}
+
+	// Одна perp нога: 0.03% за 7 днів = -1.56% APR від 19.81%
+	carry, ok := found["cash_and_carry:binance:binance"]
+	if !ok {
+		t.Fatalf("Expected cash-and-carry on binance, got %v", keys(found))
+	}
+	if math.Abs(carry.NetAPR-18.25) > 0.01 {
+		t.Errorf("Expected perp slippage in carry net APR, got %.2f%%", carry.NetAPR)
+	}
+
+	// Дві perp ноги
+	diff, ok := found["funding_diff:okx:binance"]
+	if !ok {
+		t.Fatalf("Expected funding differential okx→binance, got %v", keys(found))
+	}
+	if math.Abs(diff.SlippagePercent-0.06) > 1e-9 {
+		t.Errorf("Expected 0.06%% slippage for two perp legs, got %.4f%%", diff.SlippagePercent)
+	}
+}
+
+func keys(m map[string]*models.FundingOpportunity) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
+
+func TestFundingDetectorStopTwice(t *testing.T) {
+	cfg := &config.FundingConfig{ScanInterval: 1}
+	detector := NewFundingDetector(NewOrderBookManager(), NewCalculator(), nil, cfg, nil)
+	detector.Start()
+
+	// Повторна зупинка (shutdown + детектор арбітражу) не панікує
+	detector.Stop()
+	detector.Stop()
+}
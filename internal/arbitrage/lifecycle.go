@@ -0,0 +1,343 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	CloseReasonSpreadClosed = "spread_closed" // Orderbook більше не показує спред
+	CloseReasonStale        = "stale"         // Немає підтверджень (orderbook не оновлюється)
+	CloseReasonShutdown     = "shutdown"      // Зупинка детектора
+	CloseReasonRestart      = "restart"       // Лишилась відкритою після рестарту
+)
+
+// OpenFunc зберігає нову можливість. Повертає false, якщо її не вдалось створити
+type OpenFunc func(opp *models.ArbitrageOpportunity) bool
+
+// LifecycleTracker веде можливість від відкриття до закриття: поки спред
+// присутній, оновлює peak/last прибуток, а коли зникає - фіксує тривалість життя.
+// Стан змінюється під mu, а запис у БД виконується після його звільнення
+type LifecycleTracker struct {
+	arbRepo repository.ArbitrageRepository
+	open    map[string]*trackedOpportunity // lifecycle key -> відкрита можливість
+	opening map[string]bool                // ключі, що зараз створюються через OpenFunc
+	mu      sync.Mutex
+
+	ttl             time.Duration // ExpiresAt = LastSeenAt + ttl
+	persistInterval time.Duration // як часто писати оновлення в БД
+	staleAfter      time.Duration // закрити, якщо стільки часу немає підтверджень
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+type trackedOpportunity struct {
+	opp         *models.ArbitrageOpportunity // власна копія трекера
+	lastPersist time.Time
+	version     int // номер останнього знімку для запису (під LifecycleTracker.mu)
+
+	writeMu sync.Mutex // записи однієї можливості йдуть по черзі
+	written int        // номер останнього записаного знімку (під writeMu)
+}
+
+// lifecycleWrite знімок можливості, зібраний під mu для запису в БД
+type lifecycleWrite struct {
+	tracked *trackedOpportunity
+	opp     models.ArbitrageOpportunity
+	version int
+	reason  string // причина закриття; порожня - оновлення відкритої
+}
+
+// NewLifecycleTracker створює новий LifecycleTracker
+func NewLifecycleTracker(arbRepo repository.ArbitrageRepository, ttl time.Duration) *LifecycleTracker {
+	return &LifecycleTracker{
+		arbRepo:         arbRepo,
+		open:            make(map[string]*trackedOpportunity),
+		opening:         make(map[string]bool),
+		ttl:             ttl,
+		persistInterval: 5 * time.Second,
+		staleAfter:      30 * time.Second,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// Start закриває можливості, що лишились відкритими з минулого запуску,
+// та запускає закриття застарілих
+func (t *LifecycleTracker) Start() {
+	if count, err := t.arbRepo.CloseOrphaned(CloseReasonRestart); err != nil {
+		log.Printf("⚠️ Failed to close orphaned opportunities: %v", err)
+	} else if count > 0 {
+		log.Printf("🧹 Closed %d opportunities left open after restart", count)
+	}
+
+	go func() {
+		ticker := time.NewTicker(t.staleAfter / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-t.stopChan:
+				return
+			case <-ticker.C:
+				t.closeStale()
+			}
+		}
+	}()
+}
+
+// Stop зупиняє трекер і закриває всі відкриті можливості
+func (t *LifecycleTracker) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.stopChan)
+	})
+
+	t.CloseAll(CloseReasonShutdown)
+}
+
+// Observe фіксує, що orderbook підтверджує можливість. Нова можливість
+// відкривається через open, існуюча - оновлюється
+func (t *LifecycleTracker) Observe(key string, opp *models.ArbitrageOpportunity, open OpenFunc) {
+	now := opp.DetectedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	t.mu.Lock()
+	if tracked, ok := t.open[key]; ok {
+		write, persist := t.update(tracked, opp, now)
+		t.mu.Unlock()
+
+		if persist {
+			t.write(write)
+		}
+		return
+	}
+
+	// Можливість вже створюється іншим підтвердженням
+	if t.opening[key] {
+		t.mu.Unlock()
+		return
+	}
+	t.opening[key] = true
+	t.mu.Unlock()
+
+	opp.Status = models.ArbitrageStatusOpen
+	opp.DetectedAt = now
+	opp.LastSeenAt = now
+	opp.ExpiresAt = now.Add(t.ttl)
+	opp.OpenProfitPercent = opp.NetProfitPercent
+	opp.PeakProfitPercent = opp.NetProfitPercent
+	opp.LastProfitPercent = opp.NetProfitPercent
+	opp.ExternalID = GenerateLifecycleID(key, now)
+
+	opened := open(opp)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.opening, key)
+	if !opened {
+		return
+	}
+
+	// opp передається в callback нотифікацій - трекер працює зі своєю копією
+	own := *opp
+	t.open[key] = &trackedOpportunity{opp: &own, lastPersist: now}
+}
+
+// update переносить свіжі ціни в відкриту можливість (під t.mu) і повертає
+// знімок, якщо його час зберегти
+func (t *LifecycleTracker) update(tracked *trackedOpportunity, current *models.ArbitrageOpportunity, now time.Time) (lifecycleWrite, bool) {
+	prev := tracked.opp
+	next := *current
+
+	// Ідентичність та історія лишаються від моменту відкриття
+	next.BaseModel = prev.BaseModel
+	next.ExternalID = prev.ExternalID
+	next.DetectedAt = prev.DetectedAt
+	next.IsNotified = prev.IsNotified
+	next.Status = models.ArbitrageStatusOpen
+	next.OpenProfitPercent = prev.OpenProfitPercent
+	next.PeakProfitPercent = prev.PeakProfitPercent
+	next.LastProfitPercent = current.NetProfitPercent
+	next.LastSeenAt = now
+	next.ExpiresAt = now.Add(t.ttl)
+	next.UpdateCount = prev.UpdateCount + 1
+
+	newPeak := current.NetProfitPercent > prev.PeakProfitPercent
+	if newPeak {
+		next.PeakProfitPercent = current.NetProfitPercent
+	}
+
+	tracked.opp = &next
+
+	if !newPeak && now.Sub(tracked.lastPersist) < t.persistInterval {
+		return lifecycleWrite{}, false
+	}
+
+	tracked.lastPersist = now
+	return t.snapshot(tracked, ""), true
+}
+
+// snapshot копія стану для запису поза t.mu (викликати під t.mu)
+func (t *LifecycleTracker) snapshot(tracked *trackedOpportunity, reason string) lifecycleWrite {
+	tracked.version++
+
+	return lifecycleWrite{
+		tracked: tracked,
+		opp:     *tracked.opp,
+		version: tracked.version,
+		reason:  reason,
+	}
+}
+
+// write зберігає знімок, якщо новіший (зокрема закриття) ще не записаний
+func (t *LifecycleTracker) write(w lifecycleWrite) {
+	w.tracked.writeMu.Lock()
+	defer w.tracked.writeMu.Unlock()
+
+	if w.version <= w.tracked.written {
+		return
+	}
+
+	opp := &w.opp
+	if err := t.arbRepo.UpdateLifecycle(opp); err != nil {
+		if w.reason == "" {
+			log.Printf("❌ Error updating arbitrage %d: %v", opp.ID, err)
+		} else {
+			log.Printf("❌ Error closing arbitrage %d: %v", opp.ID, err)
+		}
+		return
+	}
+	w.tracked.written = w.version
+
+	if w.reason == "" {
+		return
+	}
+
+	log.Printf("⏹ Arbitrage closed: %s %s→%s | lived %.1fs | peak %.2f%% → last %.2f%% (%s)",
+		opp.Pair, opp.ExchangeBuy, opp.ExchangeSell, opp.LifetimeSeconds,
+		opp.PeakProfitPercent, opp.LastProfitPercent, w.reason)
+}
+
+// Close закриває можливість (якщо вона відкрита) і фіксує тривалість життя
+func (t *LifecycleTracker) Close(key, reason string) {
+	t.mu.Lock()
+	write, ok := t.closeLocked(key, reason, time.Now())
+	t.mu.Unlock()
+
+	if ok {
+		t.write(write)
+	}
+}
+
+// closeLocked прибирає можливість з відкритих і повертає знімок закриття
+// (викликати під t.mu)
+func (t *LifecycleTracker) closeLocked(key, reason string, now time.Time) (lifecycleWrite, bool) {
+	tracked, ok := t.open[key]
+	if !ok {
+		return lifecycleWrite{}, false
+	}
+	delete(t.open, key)
+
+	// Знімок попереднього оновлення може ще записуватись - закриття змінює копію
+	opp := *tracked.opp
+
+	// Застаріла можливість закривається моментом останнього підтвердження
+	closedAt := now
+	if reason == CloseReasonStale || reason == CloseReasonShutdown {
+		closedAt = opp.LastSeenAt
+	}
+
+	opp.Status = models.ArbitrageStatusClosed
+	opp.ClosedAt = &closedAt
+	opp.CloseReason = reason
+	opp.ExpiresAt = closedAt
+	opp.LifetimeSeconds = closedAt.Sub(opp.DetectedAt).Seconds()
+
+	tracked.opp = &opp
+	return t.snapshot(tracked, reason), true
+}
+
+// closeStale закриває можливості без підтверджень довше за staleAfter
+func (t *LifecycleTracker) closeStale() {
+	t.mu.Lock()
+	var writes []lifecycleWrite
+	now := time.Now()
+	for key, tracked := range t.open {
+		if now.Sub(tracked.opp.LastSeenAt) > t.staleAfter {
+			if write, ok := t.closeLocked(key, CloseReasonStale, now); ok {
+				writes = append(writes, write)
+			}
+		}
+	}
+	t.mu.Unlock()
+
+	for _, write := range writes {
+		t.write(write)
+	}
+}
+
+// CloseAll закриває всі відкриті можливості
+func (t *LifecycleTracker) CloseAll(reason string) {
+	t.mu.Lock()
+	var writes []lifecycleWrite
+	now := time.Now()
+	for key := range t.open {
+		if write, ok := t.closeLocked(key, reason, now); ok {
+			writes = append(writes, write)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, write := range writes {
+		t.write(write)
+	}
+}
+
+// OpenForPair повертає ключі та копії відкритих міжбіржових можливостей пари
+func (t *LifecycleTracker) OpenForPair(pair string) map[string]models.ArbitrageOpportunity {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make(map[string]models.ArbitrageOpportunity)
+	for key, tracked := range t.open {
+		if !tracked.opp.IsTriangular() && tracked.opp.Pair == pair {
+			result[key] = *tracked.opp
+		}
+	}
+
+	return result
+}
+
+// IsOpen перевіряє чи можливість з ключем відкрита
+func (t *LifecycleTracker) IsOpen(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.open[key]
+	return ok
+}
+
+// OpenCount повертає кількість відкритих можливостей
+func (t *LifecycleTracker) OpenCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.open)
+}
+
+// LifecycleKey ключ міжбіржової можливості (пара + напрямок)
+func LifecycleKey(pair, buyExchange, sellExchange string) string {
+	return fmt.Sprintf("%s:%s:%s", pair, buyExchange, sellExchange)
+}
+
+// TriangularLifecycleKey ключ трикутного циклу на біржі
+func TriangularLifecycleKey(exchange, route string) string {
+	return fmt.Sprintf("triangular:%s:%s", exchange, route)
+}
@@ -0,0 +1,184 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// mockLifecycleRepo зберігає тільки останній стан кожної можливості
+type mockLifecycleRepo struct {
+	repository.ArbitrageRepository
+	saved map[uint]models.ArbitrageOpportunity
+}
+
+func (m *mockLifecycleRepo) UpdateLifecycle(arb *models.ArbitrageOpportunity) error {
+	m.saved[arb.ID] = *arb
+	return nil
+}
+
+func TestLifecycleTracker(t *testing.T) {
+	repo := &mockLifecycleRepo{saved: make(map[uint]models.ArbitrageOpportunity)}
+	tracker := NewLifecycleTracker(repo, 3*time.Minute)
+
+	key := LifecycleKey("BTC/USDT", "binance", "bybit")
+	opened := 0
+	open := func(opp *models.ArbitrageOpportunity) bool {
+		opened++
+		opp.ID = 1
+		return true
+	}
+
+	start := time.Now()
+	tracker.Observe(key, &models.ArbitrageOpportunity{Pair: "BTC/USDT", NetProfitPercent: 0.4, DetectedAt: start}, open)
+	tracker.Observe(key, &models.ArbitrageOpportunity{Pair: "BTC/USDT", NetProfitPercent: 0.7, DetectedAt: start.Add(2 * time.Second)}, open)
+	tracker.Observe(key, &models.ArbitrageOpportunity{Pair: "BTC/USDT", NetProfitPercent: 0.3, DetectedAt: start.Add(4 * time.Second)}, open)
+
+	if opened != 1 {
+		t.Fatalf("Expected opportunity to be opened once, got %d", opened)
+	}
+	if len(tracker.OpenForPair("BTC/USDT")) != 1 {
+		t.Fatal("Expected one open opportunity for BTC/USDT")
+	}
+
+	tracker.Close(key, CloseReasonSpreadClosed)
+
+	closed := repo.saved[1]
+	if closed.Status != models.ArbitrageStatusClosed || closed.ClosedAt == nil {
+		t.Fatalf("Expected closed opportunity, got status %q", closed.Status)
+	}
+	if closed.OpenProfitPercent != 0.4 || closed.PeakProfitPercent != 0.7 || closed.LastProfitPercent != 0.3 {
+		t.Errorf("Unexpected profits: open %.2f peak %.2f last %.2f",
+			closed.OpenProfitPercent, closed.PeakProfitPercent, closed.LastProfitPercent)
+	}
+	if closed.UpdateCount != 2 {
+		t.Errorf("Expected 2 updates, got %d", closed.UpdateCount)
+	}
+	if closed.LifetimeSeconds <= 0 || !closed.DetectedAt.Equal(start) {
+		t.Errorf("Expected lifetime measured from open, got %.2fs", closed.LifetimeSeconds)
+	}
+	if tracker.IsOpen(key) {
+		t.Error("Expected opportunity to be removed from tracker")
+	}
+}
+
+// reentrantLifecycleRepo звертається до трекера під час запису: якби запис
+// виконувався під mu трекера, це був би deadlock
+type reentrantLifecycleRepo struct {
+	repository.ArbitrageRepository
+	tracker *LifecycleTracker
+
+	mu    sync.Mutex
+	saved map[uint]models.ArbitrageOpportunity
+}
+
+func (r *reentrantLifecycleRepo) UpdateLifecycle(arb *models.ArbitrageOpportunity) error {
+	r.tracker.OpenCount()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.saved[arb.ID] = *arb
+	return nil
+}
+
+func TestLifecycleTrackerPersistsOutsideLock(t *testing.T) {
+	repo := &reentrantLifecycleRepo{saved: make(map[uint]models.ArbitrageOpportunity)}
+	tracker := NewLifecycleTracker(repo, 3*time.Minute)
+	repo.tracker = tracker
+
+	key := LifecycleKey("ETH/USDT", "okx", "kraken")
+	open := func(opp *models.ArbitrageOpportunity) bool {
+		if tracker.IsOpen(key) {
+			t.Error("Expected opportunity not tracked before it is created")
+		}
+		opp.ID = 7
+		return true
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		start := time.Now()
+		tracker.Observe(key, &models.ArbitrageOpportunity{Pair: "ETH/USDT", NetProfitPercent: 0.4, DetectedAt: start}, open)
+		tracker.Observe(key, &models.ArbitrageOpportunity{Pair: "ETH/USDT", NetProfitPercent: 0.9, DetectedAt: start.Add(time.Second)}, open)
+		tracker.CloseAll(CloseReasonShutdown)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected DB writes outside the tracker lock, tracker deadlocked")
+	}
+
+	if saved := repo.saved[7]; saved.Status != models.ArbitrageStatusClosed || saved.PeakProfitPercent != 0.9 {
+		t.Errorf("Expected closed opportunity with peak 0.9, got %q %.2f", saved.Status, saved.PeakProfitPercent)
+	}
+}
+
+func TestLifecycleTrackerSkipsOutdatedWrites(t *testing.T) {
+	repo := &mockLifecycleRepo{saved: make(map[uint]models.ArbitrageOpportunity)}
+	tracker := NewLifecycleTracker(repo, 3*time.Minute)
+
+	key := LifecycleKey("SOL/USDT", "binance", "gateio")
+	now := time.Now()
+	tracked := &trackedOpportunity{opp: &models.ArbitrageOpportunity{
+		BaseModel:         models.BaseModel{ID: 3},
+		Pair:              "SOL/USDT",
+		DetectedAt:        now,
+		PeakProfitPercent: 0.5,
+	}}
+	tracker.open[key] = tracked
+
+	update, persist := tracker.update(tracked, &models.ArbitrageOpportunity{NetProfitPercent: 0.8}, now.Add(time.Second))
+	if !persist {
+		t.Fatal("Expected new peak to be persisted")
+	}
+	closing, ok := tracker.closeLocked(key, CloseReasonSpreadClosed, now.Add(2*time.Second))
+	if !ok {
+		t.Fatal("Expected open opportunity to be closed")
+	}
+
+	// Оновлення дописується після закриття - закриття не перезаписується
+	tracker.write(closing)
+	tracker.write(update)
+
+	if saved := repo.saved[3]; saved.Status != models.ArbitrageStatusClosed || saved.CloseReason != CloseReasonSpreadClosed {
+		t.Errorf("Expected close to survive outdated update, got %q", saved.Status)
+	}
+	if update.opp.Status != models.ArbitrageStatusOpen {
+		t.Error("Expected update snapshot not to be changed by close")
+	}
+}
+
+func TestLifecycleTrackerOpensOnce(t *testing.T) {
+	repo := &mockLifecycleRepo{saved: make(map[uint]models.ArbitrageOpportunity)}
+	tracker := NewLifecycleTracker(repo, 3*time.Minute)
+
+	key := TriangularLifecycleKey("binance", "USDT→BTC→ETH→USDT")
+	var opened atomic.Int32
+	open := func(opp *models.ArbitrageOpportunity) bool {
+		opened.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		opp.ID = 1
+		return true
+	}
+
+	// Паралельні підтвердження під час створення не відкривають дублікат
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tracker.Observe(key, &models.ArbitrageOpportunity{NetProfitPercent: 0.2}, open)
+		}()
+	}
+	wg.Wait()
+
+	if opened.Load() != 1 || tracker.OpenCount() != 1 {
+		t.Errorf("Expected opportunity opened once, got %d opens and %d open", opened.Load(), tracker.OpenCount())
+	}
+}
@@ -0,0 +1,299 @@
+package arbitrage
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/arbitrage/websocket"
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarketInfo спотовий ринок біржі
+type MarketInfo struct {
+	Symbol    string  // "BTC/USDT"
+	Base      string  // BTC
+	Quote     string  // USDT
+	Volume24h float64 // обсяг за 24h у валюті котирування
+}
+
+// MarketFetcher завантажує список спотових ринків біржі з 24h обсягами
+type MarketFetcher interface {
+	GetExchange() string
+	FetchMarkets(ctx context.Context) ([]*MarketInfo, error)
+}
+
+// DefaultMarketFetchers повертає fetchers для всіх підтримуваних бірж
+func DefaultMarketFetchers() []MarketFetcher {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	return []MarketFetcher{
+		&binanceMarketFetcher{httpClient: client},
+		&bybitMarketFetcher{httpClient: client},
+		&okxMarketFetcher{httpClient: client},
+		&gateIOMarketFetcher{httpClient: client},
+		&krakenMarketFetcher{httpClient: client},
+	}
+}
+
+func newMarketInfo(base, quote string, volume float64) *MarketInfo {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+
+	return &MarketInfo{
+		Symbol:    base + "/" + quote,
+		Base:      base,
+		Quote:     quote,
+		Volume24h: volume,
+	}
+}
+
+// parseVolume парсить числовий рядок API (порожній або невалідний = 0)
+func parseVolume(value string) float64 {
+	volume, _ := strconv.ParseFloat(value, 64)
+	return volume
+}
+
+// binanceMarketFetcher - exchangeInfo (статус, base/quote) + 24h тікери
+type binanceMarketFetcher struct {
+	httpClient *http.Client
+}
+
+func (f *binanceMarketFetcher) GetExchange() string {
+	return models.ExchangeBinance
+}
+
+func (f *binanceMarketFetcher) FetchMarkets(ctx context.Context) ([]*MarketInfo, error) {
+	var info struct {
+		Symbols []struct {
+			Symbol     string `json:"symbol"`
+			Status     string `json:"status"`
+			BaseAsset  string `json:"baseAsset"`
+			QuoteAsset string `json:"quoteAsset"`
+		} `json:"symbols"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, "https://api.binance.com/api/v3/exchangeInfo?permissions=SPOT", &info); err != nil {
+		return nil, err
+	}
+
+	var tickers []struct {
+		Symbol      string `json:"symbol"`
+		QuoteVolume string `json:"quoteVolume"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, "https://api.binance.com/api/v3/ticker/24hr?type=MINI", &tickers); err != nil {
+		return nil, err
+	}
+
+	volumes := make(map[string]float64, len(tickers))
+	for _, ticker := range tickers {
+		volumes[ticker.Symbol] = parseVolume(ticker.QuoteVolume)
+	}
+
+	var markets []*MarketInfo
+	for _, symbol := range info.Symbols {
+		if symbol.Status != "TRADING" {
+			continue
+		}
+		markets = append(markets, newMarketInfo(symbol.BaseAsset, symbol.QuoteAsset, volumes[symbol.Symbol]))
+	}
+
+	return markets, nil
+}
+
+// bybitMarketFetcher - instruments-info (статус, base/quote) + тікери (turnover)
+type bybitMarketFetcher struct {
+	httpClient *http.Client
+}
+
+func (f *bybitMarketFetcher) GetExchange() string {
+	return models.ExchangeBybit
+}
+
+func (f *bybitMarketFetcher) FetchMarkets(ctx context.Context) ([]*MarketInfo, error) {
+	var instruments struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+		Result  struct {
+			List []struct {
+				Symbol    string `json:"symbol"`
+				BaseCoin  string `json:"baseCoin"`
+				QuoteCoin string `json:"quoteCoin"`
+				Status    string `json:"status"`
+			} `json:"list"`
+		} `json:"result"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, "https://api.bybit.com/v5/market/instruments-info?category=spot", &instruments); err != nil {
+		return nil, err
+	}
+	if instruments.RetCode != 0 {
+		return nil, fmt.Errorf("bybit API error: %s", instruments.RetMsg)
+	}
+
+	var tickers struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+		Result  struct {
+			List []struct {
+				Symbol      string `json:"symbol"`
+				Turnover24h string `json:"turnover24h"`
+			} `json:"list"`
+		} `json:"result"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, "https://api.bybit.com/v5/market/tickers?category=spot", &tickers); err != nil {
+		return nil, err
+	}
+	if tickers.RetCode != 0 {
+		return nil, fmt.Errorf("bybit API error: %s", tickers.RetMsg)
+	}
+
+	volumes := make(map[string]float64, len(tickers.Result.List))
+	for _, ticker := range tickers.Result.List {
+		volumes[ticker.Symbol] = parseVolume(ticker.Turnover24h)
+	}
+
+	var markets []*MarketInfo
+	for _, instrument := range instruments.Result.List {
+		if instrument.Status != "Trading" {
+			continue
+		}
+		markets = append(markets, newMarketInfo(instrument.BaseCoin, instrument.QuoteCoin, volumes[instrument.Symbol]))
+	}
+
+	return markets, nil
+}
+
+// okxMarketFetcher - спотові тікери (instId BTC-USDT, volCcy24h у валюті котирування)
+type okxMarketFetcher struct {
+	httpClient *http.Client
+}
+
+func (f *okxMarketFetcher) GetExchange() string {
+	return models.ExchangeOKX
+}
+
+func (f *okxMarketFetcher) FetchMarkets(ctx context.Context) ([]*MarketInfo, error) {
+	var resp struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+		Data []struct {
+			InstID    string `json:"instId"`
+			VolCcy24h string `json:"volCcy24h"`
+		} `json:"data"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, "https://www.okx.com/api/v5/market/tickers?instType=SPOT", &resp); err != nil {
+		return nil, err
+	}
+	if resp.Code != "0" {
+		return nil, fmt.Errorf("okx API error: %s", resp.Msg)
+	}
+
+	var markets []*MarketInfo
+	for _, ticker := range resp.Data {
+		parts := strings.Split(ticker.InstID, "-")
+		if len(parts) != 2 {
+			continue
+		}
+		markets = append(markets, newMarketInfo(parts[0], parts[1], parseVolume(ticker.VolCcy24h)))
+	}
+
+	return markets, nil
+}
+
+// gateIOMarketFetcher - спотові тікери (currency_pair BTC_USDT)
+type gateIOMarketFetcher struct {
+	httpClient *http.Client
+}
+
+func (f *gateIOMarketFetcher) GetExchange() string {
+	return models.ExchangeGateIO
+}
+
+func (f *gateIOMarketFetcher) FetchMarkets(ctx context.Context) ([]*MarketInfo, error) {
+	var tickers []struct {
+		CurrencyPair string `json:"currency_pair"`
+		QuoteVolume  string `json:"quote_volume"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, "https://api.gateio.ws/api/v4/spot/tickers", &tickers); err != nil {
+		return nil, err
+	}
+
+	var markets []*MarketInfo
+	for _, ticker := range tickers {
+		parts := strings.Split(ticker.CurrencyPair, "_")
+		if len(parts) != 2 {
+			continue
+		}
+		markets = append(markets, newMarketInfo(parts[0], parts[1], parseVolume(ticker.QuoteVolume)))
+	}
+
+	return markets, nil
+}
+
+// krakenMarketFetcher - AssetPairs (wsname XBT/USDT) + тікери (24h обсяг в base)
+type krakenMarketFetcher struct {
+	httpClient *http.Client
+}
+
+func (f *krakenMarketFetcher) GetExchange() string {
+	return models.ExchangeKraken
+}
+
+func (f *krakenMarketFetcher) FetchMarkets(ctx context.Context) ([]*MarketInfo, error) {
+	var pairs struct {
+		Error  []string `json:"error"`
+		Result map[string]struct {
+			WSName string `json:"wsname"`
+			Status string `json:"status"`
+		} `json:"result"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, "https://api.kraken.com/0/public/AssetPairs", &pairs); err != nil {
+		return nil, err
+	}
+	if len(pairs.Error) > 0 {
+		return nil, fmt.Errorf("kraken API error: %v", pairs.Error)
+	}
+
+	var tickers struct {
+		Error  []string `json:"error"`
+		Result map[string]struct {
+			Close  []string `json:"c"` // [price, lot volume]
+			Volume []string `json:"v"` // [today, last 24h]
+		} `json:"result"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, "https://api.kraken.com/0/public/Ticker", &tickers); err != nil {
+		return nil, err
+	}
+	if len(tickers.Error) > 0 {
+		return nil, fmt.Errorf("kraken API error: %v", tickers.Error)
+	}
+
+	var markets []*MarketInfo
+	for name, pair := range pairs.Result {
+		if pair.Status != "" && pair.Status != "online" {
+			continue
+		}
+
+		base, quote := parsePair(websocket.DenormalizeKrakenSymbol(pair.WSName))
+		if base == "" || quote == "" {
+			continue
+		}
+
+		var volume float64
+		if ticker, ok := tickers.Result[name]; ok && len(ticker.Close) > 0 && len(ticker.Volume) > 1 {
+			volume = parseVolume(ticker.Volume[1]) * parseVolume(ticker.Close[0])
+		}
+
+		markets = append(markets, newMarketInfo(base, quote, volume))
+	}
+
+	return markets, nil
+}
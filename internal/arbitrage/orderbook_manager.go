@@ -3,7 +3,9 @@ package arbitrage
 import (
 	"crypto-opportunities-bot/internal/arbitrage/websocket"
 	"crypto-opportunities-bot/internal/models"
+	"fmt"
 	"log"
+	"sort"
 	"sync"
 	"time"
 )
@@ -13,6 +15,8 @@ type OrderBookManager struct {
 	wsManagers map[string]websocket.Manager                     // exchange -> WebSocket Manager
 	orderbooks map[string]map[string]*models.OrderBook          // exchange -> symbol -> OrderBook
 	mu         sync.RWMutex
+	health     BookHealthConfig
+	quotes     QuoteConfig
 
 	onUpdate OrderBookUpdateCallback
 }
@@ -25,9 +29,36 @@ func NewOrderBookManager() *OrderBookManager {
 	return &OrderBookManager{
 		wsManagers: make(map[string]websocket.Manager),
 		orderbooks: make(map[string]map[string]*models.OrderBook),
+		health:     DefaultBookHealthConfig(),
+		quotes:     DefaultQuoteConfig(),
 	}
 }
 
+// SetHealthConfig встановлює пороги перевірки ордербуків
+func (m *OrderBookManager) SetHealthConfig(health BookHealthConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.health = health
+}
+
+// SetQuoteConfig встановлює валюти котирування, що приводяться до спільної
+func (m *OrderBookManager) SetQuoteConfig(quotes QuoteConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.quotes = quotes
+}
+
+// ReferenceSymbol символ, під яким книга порівнюється з іншими біржами
+// (BTC/USD -> BTC/USDT)
+func (m *OrderBookManager) ReferenceSymbol(symbol string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.quotes.ReferenceSymbol(symbol)
+}
+
 // RegisterExchange реєструє WebSocket Manager для біржі
 func (m *OrderBookManager) RegisterExchange(exchange string, manager websocket.Manager) {
 	m.mu.Lock()
@@ -44,6 +75,52 @@ func (m *OrderBookManager) RegisterExchange(exchange string, manager websocket.M
 	log.Printf("✅ Registered exchange: %s", exchange)
 }
 
+// SubscribeSymbols підписує біржу на додаткові символи
+func (m *OrderBookManager) SubscribeSymbols(exchange string, symbols []string) error {
+	m.mu.RLock()
+	manager, ok := m.wsManagers[exchange]
+	m.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("exchange %s not registered", exchange)
+	}
+
+	return manager.Subscribe(symbols)
+}
+
+// UnsubscribeSymbols відписує біржу від символів та прибирає їх ордербуки
+func (m *OrderBookManager) UnsubscribeSymbols(exchange string, symbols []string) error {
+	m.mu.RLock()
+	manager, ok := m.wsManagers[exchange]
+	m.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("exchange %s not registered", exchange)
+	}
+
+	if err := manager.Unsubscribe(symbols); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	for _, symbol := range symbols {
+		delete(m.orderbooks[exchange], symbol)
+	}
+	m.mu.Unlock()
+
+	return nil
+}
+
+// OnTicker встановлює callback для ticker усіх зареєстрованих бірж
+func (m *OrderBookManager) OnTicker(callback websocket.TickerCallback) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, manager := range m.wsManagers {
+		manager.OnTicker(callback)
+	}
+}
+
 // updateOrderBook оновлює OrderBook в пам'яті
 func (m *OrderBookManager) updateOrderBook(exchange, symbol string, ob *models.OrderBook) {
 	m.mu.Lock()
@@ -71,22 +148,97 @@ func (m *OrderBookManager) GetOrderBook(exchange, symbol string) *models.OrderBo
 	return nil
 }
 
+// GetHealthyOrderBook отримує OrderBook, якщо він придатний для арбітражу
+// (свіжий, не перехрещений, достатньо глибокий, без аномального відхилення ціни)
+func (m *OrderBookManager) GetHealthyOrderBook(exchange, symbol string) *models.OrderBook {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.healthyBooks(symbol)[exchange]
+}
+
+// healthyBooks повертає придатні ордербуки символу, включно з книгами
+// в еквівалентних валютах котирування (m.mu має бути захоплений)
+func (m *OrderBookManager) healthyBooks(symbol string) map[string]*models.OrderBook {
+	books := m.booksForSymbol(symbol)
+	m.addConvertedBooks(symbol, books)
+	median := m.health.medianMidPrice(books)
+
+	for exchange, ob := range books {
+		if m.health.bookIssue(ob, median) != "" {
+			delete(books, exchange)
+		}
+	}
+
+	return books
+}
+
+// GetSymbols повертає символи з orderbook'ами на біржі
+func (m *OrderBookManager) GetSymbols(exchange string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	symbols := make([]string, 0, len(m.orderbooks[exchange]))
+	for symbol := range m.orderbooks[exchange] {
+		symbols = append(symbols, symbol)
+	}
+
+	return symbols
+}
+
 // GetAllOrderBooks отримує всі OrderBook для символу з усіх бірж
 func (m *OrderBookManager) GetAllOrderBooks(symbol string) map[string]*models.OrderBook {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	result := make(map[string]*models.OrderBook)
+	return m.healthyBooks(symbol)
+}
 
-	for exchange, books := range m.orderbooks {
-		if ob, ok := books[symbol]; ok {
-			if !ob.IsStale(5 * time.Second) {
-				result[exchange] = ob
+// GetMidPrices mid prices символу по біржах, включно з конвертованими книгами.
+// На відміну від GetAllOrderBooks не відкидає книги з аномальним відхиленням
+// від медіани (лише застарілі та перехрещені) - саме їх шукає PriceMonitor
+func (m *OrderBookManager) GetMidPrices(symbol string) map[string]float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	books := m.booksForSymbol(symbol)
+	m.addConvertedBooks(symbol, books)
+
+	mids := make(map[string]float64, len(books))
+	for exchange, ob := range books {
+		issue := m.health.bookIssue(ob, 0)
+		if issue == BookIssueStale || issue == BookIssueCrossed {
+			continue
+		}
+
+		if mid := ob.GetMidPrice(); mid > 0 {
+			mids[exchange] = mid
+		}
+	}
+
+	return mids
+}
+
+// ReferenceSymbols всі символи бірж, приведені до Reference котирування (без дублікатів)
+func (m *OrderBookManager) ReferenceSymbols() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var symbols []string
+
+	for _, exchBooks := range m.orderbooks {
+		for symbol := range exchBooks {
+			reference := m.quotes.ReferenceSymbol(symbol)
+			if !seen[reference] {
+				seen[reference] = true
+				symbols = append(symbols, reference)
 			}
 		}
 	}
+	sort.Strings(symbols)
 
-	return result
+	return symbols
 }
 
 // OnUpdate встановлює callback для оновлень OrderBook
@@ -102,12 +254,7 @@ func (m *OrderBookManager) GetBestPrices(symbol string) *BestPrices {
 	var bestBid *ExchangePrice  // Найвища ціна купівлі (продаємо тут)
 	var bestAsk *ExchangePrice  // Найнижча ціна продажу (купуємо тут)
 
-	for exchange, books := range m.orderbooks {
-		ob := books[symbol]
-		if ob == nil || ob.IsStale(5*time.Second) {
-			continue
-		}
-
+	for exchange, ob := range m.healthyBooks(symbol) {
 		// Best bid (де продавати)
 		if bid := ob.GetBestBid(); bid != nil {
 			if bestBid == nil || bid.Price > bestBid.Price {
@@ -185,29 +332,97 @@ func (m *OrderBookManager) GetStats() *OrderBookStats {
 		OrderBooks:     make(map[string]int),
 		StaleBooks:     0,
 		FreshBooks:     0,
+		Health:         make(map[string]*models.ExchangeHealth),
+	}
+
+	now := time.Now()
+
+	for exchange, manager := range m.wsManagers {
+		stats.Health[exchange] = &models.ExchangeHealth{
+			Exchange:   exchange,
+			Connected:  manager.IsConnected(),
+			ReportedAt: now,
+			Telemetry:  manager.Telemetry().Snapshot(),
+		}
 	}
 
+	// Медіана рахується по символу через усі біржі
+	medians := make(map[string]float64)
+
 	for exchange, books := range m.orderbooks {
 		stats.OrderBooks[exchange] = len(books)
 
-		for _, ob := range books {
+		health, ok := stats.Health[exchange]
+		if !ok {
+			health = &models.ExchangeHealth{Exchange: exchange, ReportedAt: now}
+			stats.Health[exchange] = health
+		}
+
+		for symbol, ob := range books {
 			if ob.IsStale(10 * time.Second) {
 				stats.StaleBooks++
 			} else {
 				stats.FreshBooks++
 			}
+
+			median, ok := medians[symbol]
+			if !ok {
+				median = m.health.medianMidPrice(m.booksForSymbol(symbol))
+				medians[symbol] = median
+			}
+
+			health.Books++
+			if updated := ob.UpdatedAt(); updated.After(health.LastBookUpdate) {
+				health.LastBookUpdate = updated
+			}
+
+			switch m.health.bookIssue(ob, median) {
+			case "":
+				health.HealthyBooks++
+			case BookIssueStale:
+				health.StaleBooks++
+			case BookIssueCrossed:
+				health.CrossedBooks++
+			case BookIssueDeviated:
+				health.DeviatedBooks++
+			case BookIssueShallow:
+				health.ShallowBooks++
+			}
+		}
+	}
+
+	for _, health := range stats.Health {
+		switch {
+		case !health.Connected || health.HealthyBooks == 0:
+			health.Status = models.ExchangeHealthDown
+		case health.HealthyBooks < health.Books:
+			health.Status = models.ExchangeHealthDegraded
+		default:
+			health.Status = models.ExchangeHealthHealthy
 		}
 	}
 
 	return stats
 }
 
+// booksForSymbol ордербуки символу з усіх бірж (m.mu має бути захоплений)
+func (m *OrderBookManager) booksForSymbol(symbol string) map[string]*models.OrderBook {
+	books := make(map[string]*models.OrderBook)
+	for exchange, exchBooks := range m.orderbooks {
+		if ob, ok := exchBooks[symbol]; ok {
+			books[exchange] = ob
+		}
+	}
+	return books
+}
+
 // OrderBookStats статистика OrderBook Manager
 type OrderBookStats struct {
 	TotalExchanges int
 	OrderBooks     map[string]int // exchange -> count
 	FreshBooks     int
 	StaleBooks     int
+	Health         map[string]*models.ExchangeHealth // exchange -> стан ордербуків
 }
 
 // GetExchanges повертає список зареєстрованих бірж
@@ -243,12 +458,7 @@ func (m *OrderBookManager) GetLiquidity(symbol string, side string, maxLevels in
 
 	liquidity := make(map[string]float64)
 
-	for exchange, books := range m.orderbooks {
-		ob := books[symbol]
-		if ob == nil || ob.IsStale(5*time.Second) {
-			continue
-		}
-
+	for exchange, ob := range m.healthyBooks(symbol) {
 		liquidity[exchange] = ob.GetLiquidity(side, maxLevels)
 	}
 
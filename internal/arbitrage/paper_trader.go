@@ -0,0 +1,264 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"log"
+	"sync"
+	"time"
+)
+
+// defaultPaperAmount сума симульованої угоди, якщо не задана в конфігу (USD)
+const defaultPaperAmount = 1000
+
+// PaperTrader симулює виконання доставлених арбітражних алертів по живих
+// ордербуках для користувачів з увімкненим paper trading. Угода "виконується"
+// через затримку після доставки (реакція користувача + відправка ордерів),
+// результат записується як ClientTrade з IsPaper та в окрему ClientStatistics
+type PaperTrader struct {
+	obManager *OrderBookManager
+	arbRepo   repository.ArbitrageRepository
+	prefsRepo repository.UserPreferencesRepository
+	tradeRepo repository.ClientTradeRepository
+	statsRepo repository.ClientStatisticsRepository
+	feeRepo   repository.FeeRepository // VIP рівні користувача (nil = комісії з можливості)
+	config    *config.PaperTradingConfig
+
+	statsMu sync.Mutex // UpdateFromTrade - read-modify-write рядка статистики
+}
+
+// NewPaperTrader створює новий PaperTrader
+func NewPaperTrader(
+	obManager *OrderBookManager,
+	arbRepo repository.ArbitrageRepository,
+	prefsRepo repository.UserPreferencesRepository,
+	tradeRepo repository.ClientTradeRepository,
+	statsRepo repository.ClientStatisticsRepository,
+	feeRepo repository.FeeRepository,
+	cfg *config.PaperTradingConfig,
+) *PaperTrader {
+	return &PaperTrader{
+		obManager: obManager,
+		arbRepo:   arbRepo,
+		prefsRepo: prefsRepo,
+		tradeRepo: tradeRepo,
+		statsRepo: statsRepo,
+		feeRepo:   feeRepo,
+		config:    cfg,
+	}
+}
+
+// OnDelivered запускає симуляцію для доставленого арбітражного алерту
+// (callback notification.Service.OnDelivered)
+func (p *PaperTrader) OnDelivered(notification *models.Notification) {
+	if notification.Type != models.OpportunityTypeArbitrage {
+		return
+	}
+
+	arbID := notification.ArbitrageID()
+	if arbID == 0 {
+		return
+	}
+
+	prefs, err := p.prefsRepo.GetByUserID(notification.UserID)
+	if err != nil {
+		log.Printf("⚠️ Paper trading: failed to get preferences for user %d: %v", notification.UserID, err)
+		return
+	}
+
+	if prefs == nil || !prefs.PaperTrading {
+		return
+	}
+
+	opp, err := p.arbRepo.GetByID(arbID)
+	if err != nil {
+		log.Printf("⚠️ Paper trading: failed to get arbitrage %d: %v", arbID, err)
+		return
+	}
+
+	// Трикутний арбітраж - три угоди на одній біржі, симулюється лише міжбіржовий
+	if opp.IsTriangular() {
+		return
+	}
+
+	// Ті самі комісії, з якими користувач отримав алерт
+	opp = p.applyUserFeeTiers(notification.UserID, opp)
+
+	trade := &models.ClientTrade{
+		UserID:        notification.UserID,
+		OpportunityID: opp.ID,
+		Pair:          opp.Pair,
+		BuyExchange:   opp.ExchangeBuy,
+		SellExchange:  opp.ExchangeSell,
+		IsPaper:       true,
+		Status:        models.TradeStatusExecuting,
+	}
+
+	if err := p.tradeRepo.Create(trade); err != nil {
+		log.Printf("❌ Paper trading: failed to create trade for user %d: %v", notification.UserID, err)
+		return
+	}
+
+	deliveredAt := time.Now()
+	time.AfterFunc(time.Duration(p.config.Latency)*time.Millisecond, func() {
+		p.execute(trade, opp, prefs, deliveredAt)
+	})
+}
+
+// execute симулює угоду та оновлює статистику користувача
+func (p *PaperTrader) execute(
+	trade *models.ClientTrade,
+	opp *models.ArbitrageOpportunity,
+	prefs *models.UserPreferences,
+	deliveredAt time.Time,
+) {
+	p.simulate(trade, opp, prefs)
+
+	now := time.Now()
+	trade.ExecutionTimeMs = int(now.Sub(deliveredAt).Milliseconds())
+	trade.CompletedAt = &now
+
+	if err := p.tradeRepo.Update(trade); err != nil {
+		log.Printf("❌ Paper trading: failed to update trade %d: %v", trade.ID, err)
+		return
+	}
+
+	p.statsMu.Lock()
+	err := p.statsRepo.UpdateFromTrade(trade)
+	p.statsMu.Unlock()
+
+	if err != nil {
+		log.Printf("❌ Paper trading: failed to update statistics for user %d: %v", trade.UserID, err)
+	}
+
+	if trade.IsFailed() {
+		log.Printf("📝 Paper trade %s %s→%s for user %d failed: %s",
+			trade.Pair, trade.BuyExchange, trade.SellExchange, trade.UserID, trade.Error)
+		return
+	}
+
+	log.Printf("📝 Paper trade %s %s→%s for user %d: $%.2f (expected $%.2f)",
+		trade.Pair, trade.BuyExchange, trade.SellExchange, trade.UserID, trade.ActualProfit, trade.ExpectedProfit)
+}
+
+// simulate виконує угоду по поточних ордербуках: ринкова купівля на суму на
+// біржі купівлі, продаж купленої кількості на біржі продажу. В режимі transfer
+// комісія виведення зменшує продану кількість, в pre-funded - віднімається
+// частка вартості ребалансу
+func (p *PaperTrader) simulate(trade *models.ClientTrade, opp *models.ArbitrageOpportunity, prefs *models.UserPreferences) {
+	expected := opp
+	if prefs.IsPrefunded() {
+		expected = opp.WithPrefunded(prefs.RebalanceTrades)
+	}
+
+	amount := p.tradeAmount(expected, prefs)
+	trade.ExpectedProfit = amount * expected.NetProfitPercent / 100
+
+	buyOB := p.obManager.GetHealthyOrderBook(opp.ExchangeBuy, opp.Pair)
+	sellOB := p.obManager.GetHealthyOrderBook(opp.ExchangeSell, opp.Pair)
+	if buyOB == nil || sellOB == nil {
+		p.fail(trade, "order book unavailable")
+		return
+	}
+
+	buy := buyOB.CalculateSlippage("buy", amount)
+	if !buy.Success {
+		p.fail(trade, "insufficient buy liquidity")
+		return
+	}
+
+	quantity := buy.TotalQuantity
+	var rebalanceUSD float64
+
+	if prefs.IsPrefunded() {
+		trades := prefs.RebalanceTrades
+		if trades < 1 {
+			trades = models.DefaultRebalanceTrades
+		}
+		rebalanceUSD = opp.RebalanceCostUSD / float64(trades)
+	} else {
+		quantity -= opp.WithdrawalFee
+	}
+
+	if quantity <= 0 {
+		p.fail(trade, "withdrawal fee exceeds bought quantity")
+		return
+	}
+
+	proceeds, ok := sellQuantity(sellOB, quantity)
+	if !ok {
+		p.fail(trade, "insufficient sell liquidity")
+		return
+	}
+
+	cost := buy.TotalCost * (1 + (opp.TradingFeeBuy+opp.ConversionFee)/100)
+	revenue := proceeds * (1 - opp.TradingFeeSell/100)
+	profit := revenue - cost - rebalanceUSD
+
+	trade.Amount = buy.TotalQuantity
+	trade.BuyPrice = buy.AveragePrice
+	trade.SellPrice = proceeds / quantity
+	trade.ActualProfit = profit
+	trade.ActualProfitPercent = (profit / buy.TotalCost) * 100
+	trade.Status = models.TradeStatusCompleted
+}
+
+// applyUserFeeTiers перераховує можливість з taker fees користувача
+func (p *PaperTrader) applyUserFeeTiers(userID uint, opp *models.ArbitrageOpportunity) *models.ArbitrageOpportunity {
+	if p.feeRepo == nil {
+		return opp
+	}
+
+	tiers, err := p.feeRepo.GetUserFeeTiers(userID)
+	if err != nil {
+		log.Printf("⚠️ Paper trading: failed to get fee tiers for user %d: %v", userID, err)
+		return opp
+	}
+
+	return opp.WithUserFeeTiers(tiers)
+}
+
+// tradeAmount сума угоди: з конфігу, але не більше оптимальної за глибиною
+// ордербуків та ліміту інвестицій користувача
+func (p *PaperTrader) tradeAmount(opp *models.ArbitrageOpportunity, prefs *models.UserPreferences) float64 {
+	amount := p.config.Amount
+	if amount <= 0 {
+		amount = defaultPaperAmount
+	}
+
+	if opp.OptimalAmount > 0 && opp.OptimalAmount < amount {
+		amount = opp.OptimalAmount
+	}
+
+	if prefs.MaxInvestment > 0 && float64(prefs.MaxInvestment) < amount {
+		amount = float64(prefs.MaxInvestment)
+	}
+
+	return amount
+}
+
+func (p *PaperTrader) fail(trade *models.ClientTrade, reason string) {
+	trade.Status = models.TradeStatusFailed
+	trade.Error = reason
+}
+
+// sellQuantity продає кількість по bids ордербуку, повертає виручку (USD)
+func sellQuantity(ob *models.OrderBook, quantity float64) (float64, bool) {
+	bids, _, _ := ob.Snapshot(0)
+
+	var proceeds float64
+	remaining := quantity
+
+	for _, level := range bids {
+		if remaining <= 0 {
+			break
+		}
+
+		step := minAmount(level.Quantity, remaining)
+		proceeds += step * level.Price
+		remaining -= step
+	}
+
+	return proceeds, remaining <= 0
+}
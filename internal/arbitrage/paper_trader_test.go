@@ -0,0 +1,193 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"math"
+	"testing"
+	"time"
+)
+
+type fakePaperArbRepo struct {
+	repository.ArbitrageRepository
+	opp *models.ArbitrageOpportunity
+}
+
+func (r *fakePaperArbRepo) GetByID(id uint) (*models.ArbitrageOpportunity, error) {
+	return r.opp, nil
+}
+
+type fakePaperPrefsRepo struct {
+	repository.UserPreferencesRepository
+	prefs *models.UserPreferences
+}
+
+func (r *fakePaperPrefsRepo) GetByUserID(userID uint) (*models.UserPreferences, error) {
+	return r.prefs, nil
+}
+
+type fakePaperTradeRepo struct {
+	repository.ClientTradeRepository
+	created int
+}
+
+func (r *fakePaperTradeRepo) Create(trade *models.ClientTrade) error {
+	r.created++
+	trade.ID = uint(r.created)
+	return nil
+}
+
+func (r *fakePaperTradeRepo) Update(trade *models.ClientTrade) error {
+	return nil
+}
+
+type fakePaperStatsRepo struct {
+	repository.ClientStatisticsRepository
+	trades chan *models.ClientTrade
+}
+
+func (r *fakePaperStatsRepo) UpdateFromTrade(trade *models.ClientTrade) error {
+	r.trades <- trade
+	return nil
+}
+
+type fakePaperFeeRepo struct {
+	repository.FeeRepository
+	tiers []*models.UserFeeTier
+}
+
+func (r *fakePaperFeeRepo) GetUserFeeTiers(userID uint) ([]*models.UserFeeTier, error) {
+	return r.tiers, nil
+}
+
+func newPaperOpportunity() *models.ArbitrageOpportunity {
+	opp := &models.ArbitrageOpportunity{
+		Pair:             "XYZ/USDT",
+		ExchangeBuy:      "buyex",
+		ExchangeSell:     "sellex",
+		TradingFeeBuy:    0.1,
+		TradingFeeSell:   0.1,
+		WithdrawalFee:    0.05,
+		WithdrawalFeeUSD: 5,
+		RebalanceCostUSD: 6,
+		NetProfitPercent: 0.3,
+	}
+	opp.ID = 7
+	return opp
+}
+
+// newPaperBooks купівля по 100 на buyex, продаж по 101 на sellex
+func newPaperBooks(sellQuantity float64) *OrderBookManager {
+	m := NewOrderBookManager()
+	m.SetHealthConfig(BookHealthConfig{MaxAge: 5 * time.Second, MinDepth: 1})
+
+	m.updateOrderBook("buyex", "XYZ/USDT", newLadderBook("buyex",
+		[]models.PriceLevel{{Price: 99, Quantity: 20}},
+		[]models.PriceLevel{{Price: 100, Quantity: 20}},
+	))
+	m.updateOrderBook("sellex", "XYZ/USDT", newLadderBook("sellex",
+		[]models.PriceLevel{{Price: 101, Quantity: sellQuantity}},
+		[]models.PriceLevel{{Price: 102, Quantity: 20}},
+	))
+
+	return m
+}
+
+func TestPaperTraderSimulate(t *testing.T) {
+	m := newPaperBooks(20)
+
+	trader := NewPaperTrader(m, nil, nil, nil, nil, nil, &config.PaperTradingConfig{Amount: 1000})
+	opp := newPaperOpportunity()
+
+	// Transfer: 10 XYZ куплено, 0.05 XYZ йде на виведення
+	trade := &models.ClientTrade{}
+	trader.simulate(trade, opp, &models.UserPreferences{ArbitrageMode: models.ArbitrageModeTransfer})
+
+	expectedProfit := 9.95*101*0.999 - 1000*1.001
+	if trade.Status != models.TradeStatusCompleted || math.Abs(trade.ActualProfit-expectedProfit) > 1e-9 {
+		t.Fatalf("Expected completed trade with $%.4f, got %s $%.4f (%s)", expectedProfit, trade.Status, trade.ActualProfit, trade.Error)
+	}
+	if trade.Amount != 10 || trade.BuyPrice != 100 || math.Abs(trade.SellPrice-101) > 1e-9 {
+		t.Errorf("Expected 10 XYZ filled at 100/101, got %.4f at %.2f/%.2f", trade.Amount, trade.BuyPrice, trade.SellPrice)
+	}
+	if math.Abs(trade.ExpectedProfit-3) > 1e-9 {
+		t.Errorf("Expected signalled profit $3, got $%.4f", trade.ExpectedProfit)
+	}
+
+	// Pre-funded: продається вся кількість, ребаланс $6 на 20 угод
+	prefunded := &models.ClientTrade{}
+	trader.simulate(prefunded, opp, &models.UserPreferences{ArbitrageMode: models.ArbitrageModePrefunded, RebalanceTrades: 20})
+
+	expectedProfit = 10*101*0.999 - 1000*1.001 - 0.3
+	if math.Abs(prefunded.ActualProfit-expectedProfit) > 1e-9 {
+		t.Errorf("Expected pre-funded profit $%.4f, got $%.4f", expectedProfit, prefunded.ActualProfit)
+	}
+
+	// Ліміт інвестицій користувача
+	limited := &models.ClientTrade{}
+	trader.simulate(limited, opp, &models.UserPreferences{ArbitrageMode: models.ArbitrageModePrefunded, MaxInvestment: 500})
+	if limited.Amount != 5 {
+		t.Errorf("Expected 5 XYZ within $500 max investment, got %.4f", limited.Amount)
+	}
+
+	// Ліквідність зникла до виконання
+	trader.obManager = newPaperBooks(2)
+	failed := &models.ClientTrade{}
+	trader.simulate(failed, opp, &models.UserPreferences{})
+	if !failed.IsFailed() || failed.Error != "insufficient sell liquidity" {
+		t.Errorf("Expected failed trade on thin sell book, got %s (%s)", failed.Status, failed.Error)
+	}
+}
+
+func TestPaperTraderOnDelivered(t *testing.T) {
+	m := newPaperBooks(20)
+
+	prefsRepo := &fakePaperPrefsRepo{prefs: &models.UserPreferences{UserID: 1}}
+	tradeRepo := &fakePaperTradeRepo{}
+	statsRepo := &fakePaperStatsRepo{trades: make(chan *models.ClientTrade, 1)}
+
+	trader := NewPaperTrader(
+		m,
+		&fakePaperArbRepo{opp: newPaperOpportunity()},
+		prefsRepo,
+		tradeRepo,
+		statsRepo,
+		&fakePaperFeeRepo{tiers: []*models.UserFeeTier{{Exchange: "buyex", Tier: "VIP1", TakerFee: 0.05}}},
+		&config.PaperTradingConfig{Latency: 10, Amount: 1000},
+	)
+
+	// ID після читання з БД приходить як float64
+	notification := &models.Notification{
+		UserID:      1,
+		Type:        models.OpportunityTypeArbitrage,
+		Status:      models.NotificationStatusSent,
+		MessageData: models.JSONMap{"arbitrage_id": float64(7)},
+	}
+
+	// Користувач не увімкнув paper trading
+	trader.OnDelivered(notification)
+	if tradeRepo.created != 0 {
+		t.Fatalf("Expected no paper trade without opt-in, got %d", tradeRepo.created)
+	}
+
+	prefsRepo.prefs.PaperTrading = true
+	trader.OnDelivered(notification)
+
+	select {
+	case trade := <-statsRepo.trades:
+		if !trade.IsPaper || trade.OpportunityID != 7 || !trade.IsSuccessful() {
+			t.Errorf("Expected successful paper trade for opportunity 7, got %+v", trade)
+		}
+		// VIP рівень користувача на біржі купівлі: 0.05% замість 0.1%
+		expectedProfit := 9.95*101*0.999 - 1000*1.0005
+		if math.Abs(trade.ActualProfit-expectedProfit) > 1e-9 || math.Abs(trade.ExpectedProfit-3.5) > 1e-9 {
+			t.Errorf("Expected user fee tier in paper trade, got $%.4f (expected $%.4f)", trade.ActualProfit, trade.ExpectedProfit)
+		}
+		if trade.ExecutionTimeMs < 10 || trade.CompletedAt == nil {
+			t.Errorf("Expected execution after 10ms latency, got %dms", trade.ExecutionTimeMs)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected paper trade to be executed")
+	}
+}
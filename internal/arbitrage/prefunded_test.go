@@ -0,0 +1,106 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/models"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDetectorPrefundedProfit(t *testing.T) {
+	calc := NewCalculator()
+	calc.UpdateTradingFee("buyex", 0.1)
+	calc.UpdateTradingFee("sellex", 0.1)
+	calc.UpdateWithdrawalFee("buyex", "XYZ", 0.05)  // $5 на угоду
+	calc.UpdateWithdrawalFee("sellex", "USDT", 1.0) // повернення quote при ребалансі
+
+	cfg := &config.ArbitrageConfig{
+		MinProfitPercent: 0.3,
+		MaxSpreadPercent: 5,
+		MaxSlippage:      0.5,
+		Amount:           1000,
+		RebalanceTrades:  20,
+	}
+	detector := NewDetector(NewOrderBookManager(), calc, nil, nil, NewDeduplicator(time.Minute), cfg)
+
+	buyOB := newLadderBook("buyex", nil, []models.PriceLevel{{Price: 100, Quantity: 1000}})
+	sellOB := newLadderBook("sellex", []models.PriceLevel{{Price: 100.6, Quantity: 1000}}, nil)
+
+	// Валовий 0.6%, trading fees 0.2%: з виведенням ($5 = 0.5% на $1000) збиткова,
+	// з балансів - ребаланс $6 на 20 угод = 0.03%
+	opp := detector.calculateWithSlippage("XYZ/USDT", "buyex", buyOB, "sellex", sellOB)
+	if opp == nil {
+		t.Fatal("Expected opportunity profitable in pre-funded mode")
+	}
+
+	if math.Abs(opp.NetProfitPercent-(-0.1)) > 1e-9 {
+		t.Errorf("Expected transfer net profit -0.10%%, got %.4f%%", opp.NetProfitPercent)
+	}
+	if math.Abs(opp.PrefundedProfitPercent-0.37) > 1e-9 {
+		t.Errorf("Expected pre-funded net profit 0.37%%, got %.4f%%", opp.PrefundedProfitPercent)
+	}
+
+	// З переказом окупається лише на більшій сумі
+	if opp.BreakEvenAmount <= cfg.Amount || math.Abs(opp.OptimalAmount-100000) > 1e-6 {
+		t.Errorf("Expected break-even above $%.0f and full depth optimal, got break-even $%.2f optimal $%.2f",
+			cfg.Amount, opp.BreakEvenAmount, opp.OptimalAmount)
+	}
+	if reason := detector.rejectReason(opp); reason != "" {
+		t.Errorf("Expected opportunity to pass filters, got %s", reason)
+	}
+
+	// Персональний інтервал ребалансу: раз на 2 угоди = 0.3%
+	user := opp.WithPrefunded(2)
+	if !user.Prefunded || math.Abs(user.NetProfitPercent-0.1) > 1e-9 || math.Abs(user.NetProfitUSD-1) > 1e-9 {
+		t.Errorf("Expected 0.10%% ($1) with rebalance every 2 trades, got %+v", user)
+	}
+
+	cfg.MinProfitPercent = 0.4
+	if opp := detector.calculateWithSlippage("XYZ/USDT", "buyex", buyOB, "sellex", sellOB); opp != nil {
+		t.Errorf("Expected no opportunity above pre-funded profit, got %.4f%%", opp.BestProfitPercent())
+	}
+}
+
+func TestDetectorPrefundedTradeSize(t *testing.T) {
+	calc := NewCalculator()
+	calc.UpdateTradingFee("buyex", 0.1)
+	calc.UpdateTradingFee("sellex", 0.1)
+	calc.UpdateWithdrawalFee("buyex", "XYZ", 0.05) // $5 на угоду
+
+	cfg := &config.ArbitrageConfig{
+		MinProfitPercent: 0.3,
+		MaxSpreadPercent: 5,
+		MaxSlippage:      0.5,
+		Amount:           1000,
+		RebalanceTrades:  20,
+	}
+	detector := NewDetector(NewOrderBookManager(), calc, nil, nil, NewDeduplicator(time.Minute), cfg)
+
+	// Глибини вистачає лише на $1000 - виведення не окупається за жодної суми
+	buyOB := newLadderBook("buyex", nil, []models.PriceLevel{{Price: 100, Quantity: 10}})
+	sellOB := newLadderBook("sellex", []models.PriceLevel{{Price: 100.6, Quantity: 10}}, nil)
+
+	opp := detector.calculateWithSlippage("XYZ/USDT", "buyex", buyOB, "sellex", sellOB)
+	if opp == nil {
+		t.Fatal("Expected opportunity profitable in pre-funded mode")
+	}
+
+	// Обидва розміри зберігаються окремо
+	if opp.OptimalAmount != 0 || opp.OptimalProfitUSD != 0 {
+		t.Errorf("Expected no transfer optimal amount, got $%.2f ($%.2f)", opp.OptimalAmount, opp.OptimalProfitUSD)
+	}
+	if math.Abs(opp.PrefundedOptimalAmount-1000) > 1e-6 || opp.PrefundedOptimalProfitUSD <= 0 {
+		t.Errorf("Expected pre-funded optimal $1000 with profit, got $%.2f ($%.2f)",
+			opp.PrefundedOptimalAmount, opp.PrefundedOptimalProfitUSD)
+	}
+	if reason := detector.rejectReason(opp); reason != "" {
+		t.Errorf("Expected opportunity to pass filters, got %s", reason)
+	}
+
+	user := opp.WithPrefunded(cfg.RebalanceTrades)
+	if user.OptimalAmount != opp.PrefundedOptimalAmount || user.BreakEvenAmount != 0 {
+		t.Errorf("Expected pre-funded trade size for pre-funded user, got optimal $%.2f break-even $%.2f",
+			user.OptimalAmount, user.BreakEvenAmount)
+	}
+}
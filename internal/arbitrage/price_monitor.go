@@ -0,0 +1,268 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+// PriceAlertCallback викликається при новому алерті ціни
+type PriceAlertCallback func(*models.PriceAlert)
+
+// PriceMonitor стежить за mid prices ордербуків незалежно від прибутковості
+// арбітражу:
+//   - divergence: ціна біржі відхиляється від медіани всіх бірж більше порогу
+//   - depeg: стейблкоїн торгується далі порогу від $1
+//
+// Алерт створюється лише коли відхилення тримається довше Duration (короткі
+// сплески ліквідності ігноруються) і завершується, коли ціна повертається
+type PriceMonitor struct {
+	obManager   *OrderBookManager
+	repo        repository.PriceAlertRepository
+	config      *config.PriceAlertConfig
+	stablecoins []string
+
+	pending map[string]time.Time          // key -> початок відхилення (ще не алерт)
+	active  map[string]*models.PriceAlert // key -> відкритий алерт
+
+	deduplicator *Deduplicator
+	onAlert      PriceAlertCallback
+	stopChan     chan struct{}
+	stopOnce     sync.Once
+}
+
+// NewPriceMonitor створює новий PriceMonitor
+func NewPriceMonitor(
+	obManager *OrderBookManager,
+	repo repository.PriceAlertRepository,
+	cfg *config.PriceAlertConfig,
+	stablecoins []string,
+) *PriceMonitor {
+	ttl := time.Duration(cfg.DeduplicateTTL) * time.Minute
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+
+	return &PriceMonitor{
+		obManager:    obManager,
+		repo:         repo,
+		config:       cfg,
+		stablecoins:  stablecoins,
+		pending:      make(map[string]time.Time),
+		active:       make(map[string]*models.PriceAlert),
+		deduplicator: NewDeduplicator(ttl),
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// OnAlert встановлює callback для нових алертів
+func (m *PriceMonitor) OnAlert(callback PriceAlertCallback) {
+	m.onAlert = callback
+}
+
+// Start запускає періодичну перевірку
+func (m *PriceMonitor) Start() {
+	if closed, err := m.repo.ResolveOrphaned(); err != nil {
+		log.Printf("⚠️ Failed to resolve orphaned price alerts: %v", err)
+	} else if closed > 0 {
+		log.Printf("🧹 Resolved %d price alerts left open before restart", closed)
+	}
+
+	interval := time.Duration(m.config.ScanInterval) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.stopChan:
+				return
+			case now := <-ticker.C:
+				m.scan(now)
+			}
+		}
+	}()
+
+	log.Printf("✅ Price monitor started (every %s, divergence %.2f%%, depeg %.2f%%, stablecoins %v)",
+		interval, m.config.DivergencePercent, m.config.DepegPercent, m.stablecoins)
+}
+
+// Stop зупиняє перевірку
+func (m *PriceMonitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopChan)
+		log.Println("✅ Price monitor stopped")
+	})
+}
+
+// scan збирає поточні відхилення, відкриває алерти для тривалих та
+// завершує ті, що зникли
+func (m *PriceMonitor) scan(now time.Time) {
+	observed := make(map[string]*models.PriceAlert)
+
+	if m.config.DivergencePercent > 0 {
+		for _, symbol := range m.obManager.ReferenceSymbols() {
+			for _, alert := range m.findDivergences(symbol) {
+				observed[priceAlertKey(alert)] = alert
+			}
+		}
+	}
+
+	if m.config.DepegPercent > 0 {
+		for _, symbol := range m.stablecoins {
+			if alert := m.findDepeg(symbol); alert != nil {
+				observed[priceAlertKey(alert)] = alert
+			}
+		}
+	}
+
+	for key, alert := range observed {
+		if open, ok := m.active[key]; ok {
+			open.DeviationPercent = alert.DeviationPercent
+			open.PeakDeviation = math.Max(open.PeakDeviation, alert.AbsDeviation())
+			continue
+		}
+
+		startedAt, ok := m.pending[key]
+		if !ok {
+			startedAt = now
+			m.pending[key] = now
+		}
+
+		if now.Sub(startedAt) < m.duration() || m.deduplicator.IsDuplicate(key) {
+			continue
+		}
+
+		alert.StartedAt = startedAt
+		alert.DetectedAt = now
+		alert.PeakDeviation = alert.AbsDeviation()
+		m.publish(key, alert)
+	}
+
+	// Відхилення, яких більше немає
+	for key := range m.pending {
+		if _, ok := observed[key]; !ok {
+			delete(m.pending, key)
+		}
+	}
+
+	for key, alert := range m.active {
+		if _, ok := observed[key]; !ok {
+			m.resolve(key, alert, now)
+		}
+	}
+}
+
+// findDivergences біржі, mid price яких відхиляється від медіани всіх бірж
+// символу більше DivergencePercent
+func (m *PriceMonitor) findDivergences(symbol string) []*models.PriceAlert {
+	mids := m.obManager.GetMidPrices(symbol)
+	if len(mids) < minBooksForMedian {
+		return nil
+	}
+
+	values := make([]float64, 0, len(mids))
+	for _, mid := range mids {
+		values = append(values, mid)
+	}
+	reference := median(values)
+
+	var result []*models.PriceAlert
+	for exchange, mid := range mids {
+		deviation := (mid - reference) / reference * 100
+		if math.Abs(deviation) < m.config.DivergencePercent {
+			continue
+		}
+
+		result = append(result, &models.PriceAlert{
+			Type:             models.PriceAlertTypeDivergence,
+			Symbol:           symbol,
+			Exchange:         exchange,
+			Price:            mid,
+			ReferencePrice:   reference,
+			DeviationPercent: deviation,
+			Exchanges:        len(mids),
+		})
+	}
+
+	return result
+}
+
+// findDepeg медіанна ціна стейблкоїна по біржах, якщо вона далі DepegPercent від $1
+func (m *PriceMonitor) findDepeg(symbol string) *models.PriceAlert {
+	mids := m.obManager.GetMidPrices(symbol)
+	if len(mids) == 0 {
+		return nil
+	}
+
+	values := make([]float64, 0, len(mids))
+	for _, mid := range mids {
+		values = append(values, mid)
+	}
+	price := median(values)
+
+	deviation := (price - 1) * 100
+	if math.Abs(deviation) < m.config.DepegPercent {
+		return nil
+	}
+
+	return &models.PriceAlert{
+		Type:             models.PriceAlertTypeDepeg,
+		Symbol:           symbol,
+		Price:            price,
+		ReferencePrice:   1,
+		DeviationPercent: deviation,
+		Exchanges:        len(mids),
+	}
+}
+
+// publish зберігає алерт і викликає callback
+func (m *PriceMonitor) publish(key string, alert *models.PriceAlert) {
+	if err := m.repo.Create(alert); err != nil {
+		log.Printf("❌ Error creating price alert: %v", err)
+		return
+	}
+
+	delete(m.pending, key)
+	m.active[key] = alert
+	m.deduplicator.Add(key)
+
+	log.Printf("🚨 PRICE ALERT: %s %s %s | %.4f vs %.4f (%+.2f%%) for %s",
+		alert.Type, alert.Symbol, alert.Exchange, alert.Price, alert.ReferencePrice,
+		alert.DeviationPercent, alert.DetectedAt.Sub(alert.StartedAt).Round(time.Second))
+
+	if m.onAlert != nil {
+		snapshot := *alert
+		go m.onAlert(&snapshot)
+	}
+}
+
+// resolve завершує алерт, коли ціна повернулась в межі порогу
+func (m *PriceMonitor) resolve(key string, alert *models.PriceAlert, now time.Time) {
+	delete(m.active, key)
+	alert.ResolvedAt = &now
+
+	if err := m.repo.Resolve(alert); err != nil {
+		log.Printf("⚠️ Failed to resolve price alert %d: %v", alert.ID, err)
+	}
+
+	log.Printf("✅ Price alert resolved: %s %s %s after %s (peak %.2f%%)",
+		alert.Type, alert.Symbol, alert.Exchange, alert.Duration().Round(time.Second), alert.PeakDeviation)
+}
+
+func (m *PriceMonitor) duration() time.Duration {
+	return time.Duration(m.config.Duration) * time.Second
+}
+
+func priceAlertKey(alert *models.PriceAlert) string {
+	return fmt.Sprintf("%s:%s:%s", alert.Type, alert.Symbol, alert.Exchange)
+}
@@ -0,0 +1,109 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"testing"
+	"time"
+)
+
+type fakePriceAlertRepo struct {
+	repository.PriceAlertRepository
+	created  []*models.PriceAlert
+	resolved []*models.PriceAlert
+}
+
+func (r *fakePriceAlertRepo) Create(alert *models.PriceAlert) error {
+	r.created = append(r.created, alert)
+	alert.ID = uint(len(r.created))
+	return nil
+}
+
+func (r *fakePriceAlertRepo) Resolve(alert *models.PriceAlert) error {
+	r.resolved = append(r.resolved, alert)
+	return nil
+}
+
+func TestPriceMonitorScan(t *testing.T) {
+	m := NewOrderBookManager()
+	m.SetHealthConfig(BookHealthConfig{MaxAge: time.Minute, MinDepth: 1})
+
+	m.updateOrderBook("binance", "XYZ/USDT", newQuoteBook("binance", "XYZ/USDT", 99.9, 100.1))
+	m.updateOrderBook("bybit", "XYZ/USDT", newQuoteBook("bybit", "XYZ/USDT", 99.95, 100.15))
+	m.updateOrderBook("okx", "XYZ/USDT", newQuoteBook("okx", "XYZ/USDT", 94.9, 95.1))
+	m.updateOrderBook("binance", "USDC/USDT", newQuoteBook("binance", "USDC/USDT", 0.9899, 0.9901))
+
+	repo := &fakePriceAlertRepo{}
+	monitor := NewPriceMonitor(m, repo, &config.PriceAlertConfig{
+		DivergencePercent: 1.5,
+		DepegPercent:      0.5,
+		Duration:          60,
+	}, []string{"USDC/USDT"})
+
+	alerts := make(chan *models.PriceAlert, 2)
+	monitor.OnAlert(func(alert *models.PriceAlert) {
+		alerts <- alert
+	})
+
+	start := time.Now()
+
+	// Відхилення ще не тримається Duration
+	monitor.scan(start)
+	monitor.scan(start.Add(30 * time.Second))
+	if len(repo.created) != 0 {
+		t.Fatalf("Expected no alerts before duration, got %d", len(repo.created))
+	}
+
+	monitor.scan(start.Add(time.Minute))
+	if len(repo.created) != 2 {
+		t.Fatalf("Expected divergence and depeg alerts, got %d", len(repo.created))
+	}
+
+	for range repo.created {
+		select {
+		case alert := <-alerts:
+			if !alert.StartedAt.Equal(start) {
+				t.Errorf("Expected %s alert started at first observation", alert.Type)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected alert callback")
+		}
+	}
+
+	for _, alert := range repo.created {
+		switch alert.Type {
+		case models.PriceAlertTypeDivergence:
+			// Медіана 100.05 - відхиляється лише okx
+			if alert.Exchange != "okx" || alert.DeviationPercent > -4.9 || alert.Exchanges != 3 {
+				t.Errorf("Expected okx divergence ≈ -5%% across 3 exchanges, got %s %+.2f%% (%d)",
+					alert.Exchange, alert.DeviationPercent, alert.Exchanges)
+			}
+		case models.PriceAlertTypeDepeg:
+			if alert.Symbol != "USDC/USDT" || alert.DeviationPercent > -0.99 {
+				t.Errorf("Expected USDC depeg ≈ -1%%, got %s %+.2f%%", alert.Symbol, alert.DeviationPercent)
+			}
+		}
+	}
+
+	// Повторне спостереження не створює нових алертів
+	monitor.scan(start.Add(2 * time.Minute))
+	if len(repo.created) != 2 {
+		t.Errorf("Expected no duplicate alerts, got %d", len(repo.created))
+	}
+
+	// Ціни повернулись - алерти завершуються
+	m.updateOrderBook("okx", "XYZ/USDT", newQuoteBook("okx", "XYZ/USDT", 99.9, 100.1))
+	m.updateOrderBook("binance", "USDC/USDT", newQuoteBook("binance", "USDC/USDT", 0.9999, 1.0001))
+
+	monitor.scan(start.Add(3 * time.Minute))
+	if len(repo.resolved) != 2 {
+		t.Fatalf("Expected 2 resolved alerts, got %d", len(repo.resolved))
+	}
+
+	for _, alert := range repo.resolved {
+		if alert.IsActive() || alert.Duration() != 3*time.Minute {
+			t.Errorf("Expected %s alert resolved after 3m, got %s", alert.Type, alert.Duration())
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/models"
+	"sort"
+	"strings"
+)
+
+// QuoteConfig валюти котирування, які порівнюються між біржами.
+// Книги в Equivalents (USD, USDC, FDUSD) приводяться до Reference (USDT)
+// за живим курсом з ордербуків пар курсів (USDC/USDT або USDT/USD)
+type QuoteConfig struct {
+	Reference   string
+	Equivalents []string // порядок = пріоритет, якщо біржа має кілька котирувань
+}
+
+// DefaultQuoteConfig порівнює тільки однакові символи
+func DefaultQuoteConfig() QuoteConfig {
+	return QuoteConfig{Reference: "USDT"}
+}
+
+// NewQuoteConfig створює QuoteConfig з ArbitrageConfig (всі exchange_quotes)
+func NewQuoteConfig(cfg *config.ArbitrageConfig) QuoteConfig {
+	quotes := QuoteConfig{Reference: cfg.GetReferenceQuote()}
+
+	seen := map[string]bool{quotes.Reference: true}
+	for _, exchangeQuotes := range cfg.ExchangeQuotes {
+		for _, quote := range exchangeQuotes {
+			quote = strings.ToUpper(quote)
+			if !seen[quote] {
+				seen[quote] = true
+				quotes.Equivalents = append(quotes.Equivalents, quote)
+			}
+		}
+	}
+	sort.Strings(quotes.Equivalents)
+
+	return quotes
+}
+
+// IsEquivalent перевіряє чи валюта котирування конвертується в Reference
+func (q QuoteConfig) IsEquivalent(quote string) bool {
+	for _, equivalent := range q.Equivalents {
+		if equivalent == quote {
+			return true
+		}
+	}
+	return false
+}
+
+// ReferenceSymbol символ, з яким порівнюється книга біржі (BTC/USD -> BTC/USDT).
+// Пари курсів (USDT/USD, USDC/USDT) та інші символи не змінюються
+func (q QuoteConfig) ReferenceSymbol(symbol string) string {
+	base, quote := parsePair(symbol)
+	if base == "" || base == q.Reference || q.IsEquivalent(base) || !q.IsEquivalent(quote) {
+		return symbol
+	}
+	return base + "/" + q.Reference
+}
+
+// QuoteRate курс валюти котирування до Reference
+type QuoteRate struct {
+	Quote         string
+	Rate          float64 // ціна 1 Quote в Reference
+	SpreadPercent float64 // половина спреду книги курсу (вартість ринкової конвертації)
+}
+
+// quoteRate медіанний курс quote -> Reference з придатних книг QUOTE/REF
+// та REF/QUOTE усіх бірж (m.mu має бути захоплений)
+func (m *OrderBookManager) quoteRate(quote string) *QuoteRate {
+	var rates, spreads []float64
+
+	collect := func(symbol string, inverse bool) {
+		for _, ob := range m.booksForSymbol(symbol) {
+			issue := m.health.bookIssue(ob, 0)
+			if issue == BookIssueStale || issue == BookIssueCrossed {
+				continue
+			}
+
+			mid := ob.GetMidPrice()
+			if mid <= 0 {
+				continue
+			}
+
+			if inverse {
+				mid = 1 / mid
+			}
+			rates = append(rates, mid)
+			spreads = append(spreads, ob.GetSpreadPercent()/2)
+		}
+	}
+
+	collect(quote+"/"+m.quotes.Reference, false)
+	collect(m.quotes.Reference+"/"+quote, true)
+
+	if len(rates) == 0 {
+		return nil
+	}
+
+	return &QuoteRate{
+		Quote:         quote,
+		Rate:          median(rates),
+		SpreadPercent: median(spreads),
+	}
+}
+
+// addConvertedBooks додає книги символу в еквівалентних валютах котирування
+// для бірж без книги в Reference (m.mu має бути захоплений)
+func (m *OrderBookManager) addConvertedBooks(symbol string, books map[string]*models.OrderBook) {
+	base, quote := parsePair(symbol)
+	if base == "" || quote != m.quotes.Reference || m.quotes.IsEquivalent(base) {
+		return
+	}
+
+	for _, equivalent := range m.quotes.Equivalents {
+		native := m.booksForSymbol(base + "/" + equivalent)
+		if len(native) == 0 {
+			continue
+		}
+
+		rate := m.quoteRate(equivalent)
+		if rate == nil {
+			continue // Без живого курсу не порівнюємо (можливий depeg)
+		}
+
+		for exchange, ob := range native {
+			if _, ok := books[exchange]; ok {
+				continue
+			}
+			books[exchange] = ob.Converted(symbol, rate.Rate, rate.SpreadPercent)
+		}
+	}
+}
+
+// median медіана значень (values сортується)
+func median(values []float64) float64 {
+	sort.Float64s(values)
+
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
@@ -0,0 +1,98 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"math"
+	"testing"
+)
+
+func newQuoteBook(exchange, symbol string, bid, ask float64) *models.OrderBook {
+	bids := make([]models.PriceLevel, 5)
+	asks := make([]models.PriceLevel, 5)
+	for i := range bids {
+		bids[i] = models.PriceLevel{Price: bid * (1 - float64(i)*0.0001), Quantity: 10}
+		asks[i] = models.PriceLevel{Price: ask * (1 + float64(i)*0.0001), Quantity: 10}
+	}
+
+	ob := models.NewOrderBook(exchange, symbol)
+	ob.Update(bids, asks, 1)
+	return ob
+}
+
+func TestQuoteConfigReferenceSymbol(t *testing.T) {
+	quotes := QuoteConfig{Reference: "USDT", Equivalents: []string{"FDUSD", "USD", "USDC"}}
+
+	tests := map[string]string{
+		"BTC/USD":   "BTC/USDT",
+		"ETH/FDUSD": "ETH/USDT",
+		"BTC/USDT":  "BTC/USDT",
+		"USDT/USD":  "USDT/USD",  // пара курсу
+		"USDC/USDT": "USDC/USDT", // пара курсу
+		"ETH/BTC":   "ETH/BTC",
+		"XRP/EUR":   "XRP/EUR",
+	}
+
+	for symbol, expected := range tests {
+		if got := quotes.ReferenceSymbol(symbol); got != expected {
+			t.Errorf("ReferenceSymbol(%s): expected %s, got %s", symbol, expected, got)
+		}
+	}
+}
+
+func TestOrderBookManagerConvertsQuotes(t *testing.T) {
+	m := NewOrderBookManager()
+	m.SetQuoteConfig(QuoteConfig{Reference: "USDT", Equivalents: []string{"USD"}})
+
+	binanceOB := newQuoteBook("binance", "BTC/USDT", 60000, 60010)
+	m.updateOrderBook("binance", "BTC/USDT", binanceOB)
+	m.updateOrderBook("kraken", "BTC/USD", newQuoteBook("kraken", "BTC/USD", 60300, 60310))
+
+	// Без живого курсу USD книга не порівнюється
+	if books := m.GetAllOrderBooks("BTC/USDT"); len(books) != 1 {
+		t.Fatalf("Expected only native book without rate, got %d", len(books))
+	}
+
+	// 1 USDT = 1.002 USD -> 1 USD = ~0.998 USDT
+	m.updateOrderBook("kraken", "USDT/USD", newQuoteBook("kraken", "USDT/USD", 1.0019, 1.0021))
+
+	krakenOB := m.GetHealthyOrderBook("kraken", "BTC/USDT")
+	if krakenOB == nil || !krakenOB.IsConverted() || krakenOB.NativeSymbol != "BTC/USD" {
+		t.Fatalf("Expected converted kraken book, got %+v", krakenOB)
+	}
+
+	expectedBid := 60300 / 1.002
+	if bid := krakenOB.GetBestBid().Price; math.Abs(bid-expectedBid) > 0.01 {
+		t.Errorf("Expected converted bid %.2f, got %.2f", expectedBid, bid)
+	}
+
+	best := m.GetBestPrices("BTC/USDT")
+	if best == nil || best.BestBid.Exchange != "kraken" || best.BestAsk.Exchange != "binance" {
+		t.Fatalf("Expected buy on binance and sell on kraken, got %+v", best)
+	}
+
+	// Native книга має пріоритет над конвертованою
+	m.updateOrderBook("kraken", "BTC/USDT", newQuoteBook("kraken", "BTC/USDT", 60100, 60110))
+	if ob := m.GetHealthyOrderBook("kraken", "BTC/USDT"); ob == nil || ob.IsConverted() {
+		t.Errorf("Expected native kraken BTC/USDT book, got %+v", ob)
+	}
+
+	// Конвертація нараховує комісію kraken та половину спреду курсу
+	calc := NewCalculator()
+	result := &ArbitrageCalculation{NetProfit: 0.3, TotalFeesPercent: 0.2}
+	calc.ApplyQuoteConversion(result, binanceOB, krakenOB)
+
+	expectedFee := calc.getTradingFee("kraken") + krakenOB.QuoteSpread
+	if krakenOB.QuoteSpread <= 0 || math.Abs(result.ConversionFee-expectedFee) > 1e-9 {
+		t.Errorf("Expected conversion fee %.4f, got %.4f", expectedFee, result.ConversionFee)
+	}
+	if math.Abs(result.NetProfit-(0.3-expectedFee)) > 1e-9 {
+		t.Errorf("Expected net profit reduced by conversion fee, got %.4f", result.NetProfit)
+	}
+
+	// Обидві ноги в одній валюті - без конвертації
+	same := &ArbitrageCalculation{NetProfit: 0.3}
+	calc.ApplyQuoteConversion(same, binanceOB, binanceOB)
+	if same.ConversionFee != 0 || same.NetProfit != 0.3 {
+		t.Errorf("Expected no conversion for same quote, got %+v", same)
+	}
+}
@@ -0,0 +1,103 @@
+package arbitrage
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/arbitrage/websocket"
+	"crypto-opportunities-bot/internal/config"
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockReplayArbRepo зберігає створені можливості в пам'яті
+type mockReplayArbRepo struct {
+	repository.ArbitrageRepository
+	mu      sync.Mutex
+	created []*models.ArbitrageOpportunity
+}
+
+func (m *mockReplayArbRepo) Create(arb *models.ArbitrageOpportunity) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	arb.ID = uint(len(m.created) + 1)
+	m.created = append(m.created, arb)
+	return nil
+}
+
+func (m *mockReplayArbRepo) UpdateLifecycle(arb *models.ArbitrageOpportunity) error {
+	return nil
+}
+
+func (m *mockReplayArbRepo) CloseOrphaned(reason string) (int64, error) {
+	return 0, nil
+}
+
+func TestDetectorReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	recorder, err := websocket.NewRecorder(dir, 20)
+	if err != nil {
+		t.Fatalf("Failed to create recorder: %v", err)
+	}
+
+	// BTC на bybit дорожчий на ~1.5%
+	recorder.Record("binance", "BTC/USDT", newTestBook("BTC/USDT", 99.9, 100, 5000))
+	recorder.Record("bybit", "BTC/USDT", newTestBook("BTC/USDT", 101.5, 101.6, 5000))
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Failed to close recorder: %v", err)
+	}
+
+	paths, err := websocket.RecordingFiles(dir, time.Time{}, time.Time{}, nil)
+	if err != nil {
+		t.Fatalf("Failed to list recordings: %v", err)
+	}
+
+	replay := websocket.NewReplay(paths, 0)
+	obManager := NewOrderBookManager()
+	// Тестові книги мають по одному рівню
+	obManager.SetHealthConfig(BookHealthConfig{MaxAge: 5 * time.Second, MinDepth: 1})
+
+	for _, exchange := range []string{"binance", "bybit"} {
+		manager := replay.Manager(exchange)
+		manager.Connect(context.Background())
+		manager.Subscribe([]string{"BTC/USDT"})
+		obManager.RegisterExchange(exchange, manager)
+	}
+
+	cfg := &config.ArbitrageConfig{
+		MinProfitPercent: 0.3,
+		MaxSpreadPercent: 5,
+		MaxSlippage:      0.5,
+		Amount:           100000,
+		DeduplicateTTL:   3,
+	}
+
+	arbRepo := &mockReplayArbRepo{}
+	detector := NewDetector(obManager, NewCalculator(), arbRepo, nil, NewDeduplicator(time.Minute), cfg)
+
+	detected := make(chan *models.ArbitrageOpportunity, 10)
+	detector.OnOpportunity(func(opp *models.ArbitrageOpportunity) {
+		detected <- opp
+	})
+	detector.Start()
+	defer detector.Stop()
+
+	if err := replay.Run(context.Background()); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	select {
+	case opp := <-detected:
+		if opp.ExchangeBuy != "binance" || opp.ExchangeSell != "bybit" {
+			t.Errorf("Expected binance→bybit, got %s→%s", opp.ExchangeBuy, opp.ExchangeSell)
+		}
+		if opp.NetProfitPercent <= cfg.MinProfitPercent {
+			t.Errorf("Expected net profit above %.2f%%, got %.2f%%", cfg.MinProfitPercent, opp.NetProfitPercent)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected arbitrage opportunity from replayed order books")
+	}
+}
@@ -0,0 +1,110 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/models"
+)
+
+// TradeSize оптимальний розмір угоди за глибиною обох ордербуків
+type TradeSize struct {
+	OptimalAmount    float64 // $ на купівлю з максимальним абсолютним net profit (0 = неприбутково)
+	OptimalProfitUSD float64 // net profit на OptimalAmount
+	OptimalQuantity  float64 // куплено в базовій валюті
+	BreakEvenAmount  float64 // $ на купівлю, з якої угода окупає фіксовану вартість виведення
+}
+
+// SolveTradeSize проходить рівні asks біржі купівлі та bids біржі продажу
+// одночасно. Куплена кількість мінус комісія виведення продається на іншій
+// біржі, тому profit як функція суми:
+//
+//	profit = bids(q - w) * (1 - sellFee) - asks(q) * (1 + buyFee + extraFee)
+//
+// Ціни рівнів погіршуються, отже граничний profit спадає і максимум - там, де
+// він стає від'ємним. Break-even - перша сума, що покриває виведення (w).
+// withdrawal - комісія виведення в базовій валюті (0 для pre-funded балансів),
+// extraFeePercent - додаткові % від суми купівлі (конвертація котирування)
+func (c *Calculator) SolveTradeSize(
+	buyExchange string,
+	buyOB *models.OrderBook,
+	sellExchange string,
+	sellOB *models.OrderBook,
+	withdrawal float64,
+	extraFeePercent float64,
+) *TradeSize {
+	_, asks, _ := buyOB.Snapshot(0)
+	bids, _, _ := sellOB.Snapshot(0)
+
+	buyCost := 1 + (c.getTradingFee(buyExchange)+extraFeePercent)/100
+	sellGain := 1 - c.getTradingFee(sellExchange)/100
+
+	result := &TradeSize{}
+
+	var (
+		ask, bid         int
+		askLeft, bidLeft float64
+		spent, quantity  float64
+	)
+	if len(asks) > 0 {
+		askLeft = asks[0].Quantity
+	}
+	if len(bids) > 0 {
+		bidLeft = bids[0].Quantity
+	}
+
+	// Кількість на покриття комісії виведення купується, але не продається
+	for remaining := withdrawal; remaining > 0; {
+		if ask >= len(asks) {
+			return result // Недостатня ліквідність
+		}
+
+		step := minAmount(askLeft, remaining)
+		spent += step * asks[ask].Price
+		quantity += step
+		remaining -= step
+
+		if askLeft -= step; askLeft <= 0 {
+			if ask++; ask < len(asks) {
+				askLeft = asks[ask].Quantity
+			}
+		}
+	}
+
+	profit := -spent * buyCost
+
+	for ask < len(asks) && bid < len(bids) {
+		marginal := bids[bid].Price*sellGain - asks[ask].Price*buyCost
+		if marginal <= 0 {
+			break
+		}
+
+		step := minAmount(askLeft, bidLeft)
+
+		if profit < 0 && profit+marginal*step >= 0 && result.BreakEvenAmount == 0 {
+			result.BreakEvenAmount = spent + asks[ask].Price*(-profit/marginal)
+		}
+
+		spent += step * asks[ask].Price
+		quantity += step
+		profit += marginal * step
+
+		if askLeft -= step; askLeft <= 0 {
+			if ask++; ask < len(asks) {
+				askLeft = asks[ask].Quantity
+			}
+		}
+		if bidLeft -= step; bidLeft <= 0 {
+			if bid++; bid < len(bids) {
+				bidLeft = bids[bid].Quantity
+			}
+		}
+	}
+
+	if profit <= 0 {
+		return result
+	}
+
+	result.OptimalAmount = spent
+	result.OptimalProfitUSD = profit
+	result.OptimalQuantity = quantity
+
+	return result
+}
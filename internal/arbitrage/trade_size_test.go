@@ -0,0 +1,66 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"math"
+	"testing"
+)
+
+func newLadderBook(exchange string, bids, asks []models.PriceLevel) *models.OrderBook {
+	ob := models.NewOrderBook(exchange, "XYZ/USDT")
+	ob.Update(bids, asks, 1)
+	return ob
+}
+
+func TestSolveTradeSize(t *testing.T) {
+	calc := NewCalculator()
+	calc.UpdateTradingFee("buyex", 0.1)
+	calc.UpdateTradingFee("sellex", 0.1)
+
+	buyOB := newLadderBook("buyex", nil, []models.PriceLevel{
+		{Price: 100, Quantity: 1},
+		{Price: 100.5, Quantity: 1},
+		{Price: 101, Quantity: 2},
+	})
+	sellOB := newLadderBook("sellex", []models.PriceLevel{
+		{Price: 102, Quantity: 1},
+		{Price: 101.2, Quantity: 1},
+		{Price: 100.5, Quantity: 5},
+	}, nil)
+
+	size := calc.SolveTradeSize("buyex", buyOB, "sellex", sellOB, 0.01, 0)
+
+	// 101 -> 101.2 вже збиткове після fees: купуємо 2 XYZ за $200.5,
+	// 0.01 XYZ йде на виведення
+	if math.Abs(size.OptimalAmount-200.5) > 1e-9 || math.Abs(size.OptimalQuantity-2) > 1e-9 {
+		t.Errorf("Expected optimal $200.5 for 2 XYZ, got $%.4f for %.4f", size.OptimalAmount, size.OptimalQuantity)
+	}
+
+	// Продано 1.99 XYZ: (102*1 + 101.2*0.99) * 0.999 - 200.5 * 1.001
+	expectedProfit := (102+101.2*0.99)*0.999 - 200.5*1.001
+	if math.Abs(size.OptimalProfitUSD-expectedProfit) > 1e-9 {
+		t.Errorf("Expected optimal profit $%.4f, got $%.4f", expectedProfit, size.OptimalProfitUSD)
+	}
+
+	// Виведення ($1.001 з fee) окупається на першому рівні з граничним
+	// profit 102*0.999 - 100*1.001 за одиницю
+	expectedBreakEven := 1 + 100*(1.001/(102*0.999-100*1.001))
+	if math.Abs(size.BreakEvenAmount-expectedBreakEven) > 1e-9 {
+		t.Errorf("Expected break-even $%.4f, got $%.4f", expectedBreakEven, size.BreakEvenAmount)
+	}
+
+	// Конвертація котирування з'їдає весь спред
+	if size := calc.SolveTradeSize("buyex", buyOB, "sellex", sellOB, 0.01, 2); size.OptimalAmount != 0 || size.BreakEvenAmount != 0 {
+		t.Errorf("Expected unprofitable trade with conversion fee, got %+v", size)
+	}
+
+	// Комісія виведення більша за весь можливий profit
+	if size := calc.SolveTradeSize("buyex", buyOB, "sellex", sellOB, 0.5, 0); size.OptimalAmount != 0 {
+		t.Errorf("Expected no optimal amount when withdrawal is not covered, got %+v", size)
+	}
+
+	// Pre-funded: без виведення прибутково з першого долара
+	if size := calc.SolveTradeSize("buyex", buyOB, "sellex", sellOB, 0, 0); size.BreakEvenAmount != 0 || math.Abs(size.OptimalQuantity-2) > 1e-9 {
+		t.Errorf("Expected 2 XYZ without break-even for pre-funded balances, got %+v", size)
+	}
+}
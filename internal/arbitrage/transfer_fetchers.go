@@ -0,0 +1,131 @@
+package arbitrage
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTransferStatusFetchers повертає fetchers для бірж з публічним статусом мереж.
+// Для інших бірж маршрут має статус "unknown" і не блокується.
+func DefaultTransferStatusFetchers() []TransferStatusFetcher {
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	return []TransferStatusFetcher{
+		&binanceTransferFetcher{httpClient: client},
+		&gateIOTransferFetcher{httpClient: client},
+	}
+}
+
+// binanceTransferFetcher - стан мереж з публічного endpoint сайту Binance
+type binanceTransferFetcher struct {
+	httpClient *http.Client
+}
+
+func (f *binanceTransferFetcher) GetExchange() string {
+	return models.ExchangeBinance
+}
+
+func (f *binanceTransferFetcher) FetchNetworkStatuses(ctx context.Context) ([]*NetworkStatus, error) {
+	const url = "https://www.binance.com/bapi/capital/v1/public/capital/getNetworkCoinAll"
+
+	var resp struct {
+		Code string `json:"code"`
+		Data []struct {
+			Coin        string `json:"coin"`
+			NetworkList []struct {
+				Network              string `json:"network"`
+				WithdrawEnable       bool   `json:"withdrawEnable"`
+				DepositEnable        bool   `json:"depositEnable"`
+				IsDefault            bool   `json:"isDefault"`
+				MinConfirm           int    `json:"minConfirm"`
+				EstimatedArrivalTime int    `json:"estimatedArrivalTime"` // хвилини
+			} `json:"networkList"`
+		} `json:"data"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, url, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Code != "000000" {
+		return nil, fmt.Errorf("binance API error: code %s", resp.Code)
+	}
+
+	now := time.Now()
+	var statuses []*NetworkStatus
+
+	for _, coin := range resp.Data {
+		for _, network := range coin.NetworkList {
+			statuses = append(statuses, &NetworkStatus{
+				Exchange:        models.ExchangeBinance,
+				Asset:           strings.ToUpper(coin.Coin),
+				Network:         CanonicalNetwork(network.Network),
+				WithdrawEnabled: network.WithdrawEnable,
+				DepositEnabled:  network.DepositEnable,
+				Confirmations:   network.MinConfirm,
+				ArrivalMinutes:  float64(network.EstimatedArrivalTime),
+				IsDefault:       network.IsDefault,
+				UpdatedAt:       now,
+			})
+		}
+	}
+
+	return statuses, nil
+}
+
+// gateIOTransferFetcher - стан мереж зі списку валют Gate.io
+type gateIOTransferFetcher struct {
+	httpClient *http.Client
+}
+
+func (f *gateIOTransferFetcher) GetExchange() string {
+	return models.ExchangeGateIO
+}
+
+func (f *gateIOTransferFetcher) FetchNetworkStatuses(ctx context.Context) ([]*NetworkStatus, error) {
+	const url = "https://api.gateio.ws/api/v4/spot/currencies"
+
+	var currencies []struct {
+		Currency string `json:"currency"`
+		Delisted bool   `json:"delisted"`
+		Chain    string `json:"chain"`
+		Chains   []struct {
+			Name             string `json:"name"`
+			WithdrawDisabled bool   `json:"withdraw_disabled"`
+			WithdrawDelayed  bool   `json:"withdraw_delayed"`
+			DepositDisabled  bool   `json:"deposit_disabled"`
+		} `json:"chains"`
+	}
+
+	if err := fetchJSON(ctx, f.httpClient, url, &currencies); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var statuses []*NetworkStatus
+
+	for _, currency := range currencies {
+		if currency.Delisted {
+			continue
+		}
+
+		for _, chain := range currency.Chains {
+			statuses = append(statuses, &NetworkStatus{
+				Exchange: models.ExchangeGateIO,
+				Asset:    strings.ToUpper(currency.Currency),
+				Network:  CanonicalNetwork(chain.Name),
+				// Затримане виведення (ручна перевірка) для арбітражу рівнозначне закритому
+				WithdrawEnabled: !chain.WithdrawDisabled && !chain.WithdrawDelayed,
+				DepositEnabled:  !chain.DepositDisabled,
+				IsDefault:       chain.Name == currency.Chain,
+				UpdatedAt:       now,
+			})
+		}
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,371 @@
+package arbitrage
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NetworkStatus стан мережі для депозиту/виведення активу на біржі
+type NetworkStatus struct {
+	Exchange        string
+	Asset           string // BTC, USDT
+	Network         string // канонічна назва (ETH, BSC, TRX, ...)
+	WithdrawEnabled bool
+	DepositEnabled  bool
+	Confirmations   int     // кількість підтверджень для зарахування депозиту (0 = невідомо)
+	ArrivalMinutes  float64 // оцінка біржі часу зарахування (0 = невідомо)
+	IsDefault       bool
+	UpdatedAt       time.Time
+}
+
+// TransferRoute обраний маршрут переказу активу між біржами
+type TransferRoute struct {
+	Asset            string
+	FromExchange     string
+	ToExchange       string
+	Network          string
+	Status           string  // models.TransferStatusOpen / Closed / Unknown
+	EstimatedMinutes float64 // оцінка часу переказу (виведення + підтвердження)
+	Reason           string  // чому маршрут закритий
+}
+
+// TransferStatusFetcher завантажує стан мереж біржі
+type TransferStatusFetcher interface {
+	GetExchange() string
+	FetchNetworkStatuses(ctx context.Context) ([]*NetworkStatus, error)
+}
+
+// TransferStatusProvider відстежує по exchange/asset/network чи відкриті
+// депозити та виведення, і підбирає робочий маршрут переказу
+type TransferStatusProvider struct {
+	fetchers []TransferStatusFetcher
+	statuses map[string]map[string][]*NetworkStatus // exchange -> asset -> networks
+	updated  map[string]time.Time                   // exchange -> час останнього оновлення
+	maxAge   time.Duration
+	timeout  time.Duration
+	mu       sync.RWMutex
+	stopChan chan struct{}
+}
+
+// NewTransferStatusProvider створює новий TransferStatusProvider
+func NewTransferStatusProvider(fetchers []TransferStatusFetcher) *TransferStatusProvider {
+	return &TransferStatusProvider{
+		fetchers: fetchers,
+		statuses: make(map[string]map[string][]*NetworkStatus),
+		updated:  make(map[string]time.Time),
+		maxAge:   time.Hour,
+		timeout:  30 * time.Second,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Refresh оновлює стан мереж з усіх бірж
+func (p *TransferStatusProvider) Refresh() error {
+	var errors []error
+	total := 0
+
+	for _, fetcher := range p.fetchers {
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		statuses, err := fetcher.FetchNetworkStatuses(ctx)
+		cancel()
+
+		if err != nil {
+			log.Printf("❌ Transfer status refresh failed for %s: %v", fetcher.GetExchange(), err)
+			errors = append(errors, fmt.Errorf("%s: %w", fetcher.GetExchange(), err))
+			continue
+		}
+
+		p.SetStatuses(fetcher.GetExchange(), statuses)
+		total += len(statuses)
+	}
+
+	log.Printf("🔀 Transfer status refreshed: %d networks", total)
+
+	if len(errors) == len(p.fetchers) && len(errors) > 0 {
+		return fmt.Errorf("all transfer status fetchers failed: %v", errors)
+	}
+
+	return nil
+}
+
+// SetStatuses замінює стан мереж біржі
+func (p *TransferStatusProvider) SetStatuses(exchange string, statuses []*NetworkStatus) {
+	byAsset := make(map[string][]*NetworkStatus)
+	for _, status := range statuses {
+		asset := strings.ToUpper(status.Asset)
+		byAsset[asset] = append(byAsset[asset], status)
+	}
+
+	p.mu.Lock()
+	p.statuses[exchange] = byAsset
+	p.updated[exchange] = time.Now()
+	p.mu.Unlock()
+}
+
+// Start запускає оновлення одразу та далі з інтервалом
+func (p *TransferStatusProvider) Start(interval time.Duration) {
+	// Дані старші за 3 інтервали вважаються невідомими
+	p.mu.Lock()
+	p.maxAge = 3 * interval
+	p.mu.Unlock()
+
+	go func() {
+		if err := p.Refresh(); err != nil {
+			log.Printf("❌ Transfer status error: %v", err)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.stopChan:
+				return
+			case <-ticker.C:
+				if err := p.Refresh(); err != nil {
+					log.Printf("❌ Transfer status error: %v", err)
+				}
+			}
+		}
+	}()
+
+	log.Printf("✅ Transfer status provider started (every %s)", interval)
+}
+
+// Stop зупиняє оновлення
+func (p *TransferStatusProvider) Stop() {
+	close(p.stopChan)
+}
+
+// GetStatuses повертає мережі активу на біржі (nil якщо дані невідомі або застарілі)
+func (p *TransferStatusProvider) GetStatuses(exchange, asset string) []*NetworkStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	updated, ok := p.updated[exchange]
+	if !ok || time.Since(updated) > p.maxAge {
+		return nil
+	}
+
+	return p.statuses[exchange][strings.ToUpper(asset)]
+}
+
+// FindRoute підбирає найшвидшу мережу, де виведення з from і депозит на to відкриті
+func (p *TransferStatusProvider) FindRoute(asset, from, to string) *TransferRoute {
+	route := &TransferRoute{
+		Asset:        asset,
+		FromExchange: from,
+		ToExchange:   to,
+		Status:       models.TransferStatusUnknown,
+	}
+
+	fromNetworks := p.GetStatuses(from, asset)
+	toNetworks := p.GetStatuses(to, asset)
+
+	if fromNetworks != nil && !anyNetwork(fromNetworks, func(s *NetworkStatus) bool { return s.WithdrawEnabled }) {
+		route.Status = models.TransferStatusClosed
+		route.Reason = fmt.Sprintf("%s withdrawals suspended on %s", asset, from)
+		return route
+	}
+
+	if toNetworks != nil && !anyNetwork(toNetworks, func(s *NetworkStatus) bool { return s.DepositEnabled }) {
+		route.Status = models.TransferStatusClosed
+		route.Reason = fmt.Sprintf("%s deposits suspended on %s", asset, to)
+		return route
+	}
+
+	// Відома тільки одна сторона - пропонуємо мережу, але статус невідомий
+	if fromNetworks == nil || toNetworks == nil {
+		if fromNetworks != nil {
+			if best := fastestNetwork(fromNetworks, func(s *NetworkStatus) bool { return s.WithdrawEnabled }); best != nil {
+				route.Network = best.Network
+				route.EstimatedMinutes = estimateTransferMinutes(best, nil)
+			}
+		} else if toNetworks != nil {
+			if best := fastestNetwork(toNetworks, func(s *NetworkStatus) bool { return s.DepositEnabled }); best != nil {
+				route.Network = best.Network
+				route.EstimatedMinutes = estimateTransferMinutes(nil, best)
+			}
+		}
+		return route
+	}
+
+	// Обидві сторони відомі - шукаємо спільну відкриту мережу
+	deposits := make(map[string]*NetworkStatus, len(toNetworks))
+	for _, status := range toNetworks {
+		if status.DepositEnabled {
+			deposits[status.Network] = status
+		}
+	}
+
+	var best *NetworkStatus
+	bestMinutes := math.MaxFloat64
+
+	for _, withdrawal := range fromNetworks {
+		if !withdrawal.WithdrawEnabled {
+			continue
+		}
+
+		deposit, ok := deposits[withdrawal.Network]
+		if !ok {
+			continue
+		}
+
+		minutes := estimateTransferMinutes(withdrawal, deposit)
+		if minutes < bestMinutes || (minutes == bestMinutes && withdrawal.IsDefault) {
+			best, bestMinutes = withdrawal, minutes
+		}
+	}
+
+	if best == nil {
+		route.Status = models.TransferStatusClosed
+		route.Reason = fmt.Sprintf("no common open %s network between %s and %s", asset, from, to)
+		return route
+	}
+
+	route.Network = best.Network
+	route.EstimatedMinutes = bestMinutes
+	route.Status = models.TransferStatusOpen
+
+	return route
+}
+
+// fastestNetwork повертає найшвидшу відкриту мережу однієї сторони
+func fastestNetwork(networks []*NetworkStatus, isOpen func(*NetworkStatus) bool) *NetworkStatus {
+	var best *NetworkStatus
+	bestMinutes := math.MaxFloat64
+
+	for _, status := range networks {
+		if !isOpen(status) {
+			continue
+		}
+
+		minutes := estimateTransferMinutes(status, status)
+		if minutes < bestMinutes {
+			best, bestMinutes = status, minutes
+		}
+	}
+
+	return best
+}
+
+func anyNetwork(networks []*NetworkStatus, predicate func(*NetworkStatus) bool) bool {
+	for _, status := range networks {
+		if predicate(status) {
+			return true
+		}
+	}
+	return false
+}
+
+// Типовий час блоку (секунди) та кількість підтверджень для депозиту на біржу
+var networkBlockTimes = map[string]float64{
+	"BTC":      600,
+	"ETH":      12,
+	"BSC":      3,
+	"TRX":      3,
+	"SOL":      0.5,
+	"MATIC":    2,
+	"ARBITRUM": 0.3,
+	"OPTIMISM": 2,
+	"AVAXC":    2,
+	"XRP":      4,
+	"ADA":      20,
+	"DOT":      6,
+	"LTC":      150,
+	"BCH":      600,
+	"ATOM":     6,
+	"NEAR":     1,
+	"TON":      5,
+}
+
+var networkDefaultConfirmations = map[string]int{
+	"BTC":   2,
+	"ETH":   12,
+	"BSC":   15,
+	"TRX":   20,
+	"SOL":   1,
+	"MATIC": 128,
+	"LTC":   4,
+	"BCH":   6,
+	"ADA":   15,
+}
+
+const (
+	defaultBlockTimeSeconds     = 60.0
+	defaultConfirmations        = 10
+	withdrawalProcessingMinutes = 2.0 // обробка виведення біржею
+)
+
+// estimateTransferMinutes оцінює час: обробка виведення + підтвердження депозиту
+func estimateTransferMinutes(withdrawal, deposit *NetworkStatus) float64 {
+	network := ""
+	if withdrawal != nil {
+		network = withdrawal.Network
+	} else if deposit != nil {
+		network = deposit.Network
+	}
+
+	// Біржа сама оцінює час зарахування
+	if deposit != nil && deposit.ArrivalMinutes > 0 {
+		return withdrawalProcessingMinutes + deposit.ArrivalMinutes
+	}
+	if withdrawal != nil && withdrawal.ArrivalMinutes > 0 {
+		return withdrawalProcessingMinutes + withdrawal.ArrivalMinutes
+	}
+
+	confirmations := 0
+	if deposit != nil {
+		confirmations = deposit.Confirmations
+	}
+	if confirmations == 0 {
+		if c, ok := networkDefaultConfirmations[network]; ok {
+			confirmations = c
+		} else {
+			confirmations = defaultConfirmations
+		}
+	}
+
+	blockTime, ok := networkBlockTimes[network]
+	if !ok {
+		blockTime = defaultBlockTimeSeconds
+	}
+
+	return withdrawalProcessingMinutes + float64(confirmations)*blockTime/60
+}
+
+// networkAliases різні назви однієї мережі на біржах
+var networkAliases = map[string]string{
+	"ERC20":      "ETH",
+	"ETHEREUM":   "ETH",
+	"BEP20":      "BSC",
+	"BEP20(BSC)": "BSC",
+	"TRC20":      "TRX",
+	"TRON":       "TRX",
+	"SOLANA":     "SOL",
+	"POLYGON":    "MATIC",
+	"POL":        "MATIC",
+	"ARBEVM":     "ARBITRUM",
+	"ARB":        "ARBITRUM",
+	"ARBONE":     "ARBITRUM",
+	"OPETH":      "OPTIMISM",
+	"OP":         "OPTIMISM",
+	"AVAX_C":     "AVAXC",
+	"AVAX-C":     "AVAXC",
+	"CCHAIN":     "AVAXC",
+}
+
+// CanonicalNetwork нормалізує назву мережі (ERC20 -> ETH, TRC20 -> TRX)
+func CanonicalNetwork(network string) string {
+	normalized := strings.ToUpper(strings.TrimSpace(network))
+	if alias, ok := networkAliases[normalized]; ok {
+		return alias
+	}
+	return normalized
+}
@@ -0,0 +1,56 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"testing"
+)
+
+func TestFindRoute(t *testing.T) {
+	provider := NewTransferStatusProvider(nil)
+
+	provider.SetStatuses("binance", []*NetworkStatus{
+		{Asset: "USDT", Network: "ETH", WithdrawEnabled: true, DepositEnabled: true},
+		{Asset: "USDT", Network: "TRX", WithdrawEnabled: true, DepositEnabled: true},
+		{Asset: "SOL", Network: "SOL", WithdrawEnabled: false, DepositEnabled: true},
+	})
+	provider.SetStatuses("gateio", []*NetworkStatus{
+		{Asset: "USDT", Network: "ETH", WithdrawEnabled: true, DepositEnabled: true},
+		{Asset: "USDT", Network: "TRX", WithdrawEnabled: true, DepositEnabled: false},
+		{Asset: "SOL", Network: "SOL", WithdrawEnabled: true, DepositEnabled: true},
+	})
+
+	// TRX депозит на gateio закритий - лишається тільки ETH
+	route := provider.FindRoute("USDT", "binance", "gateio")
+	if route.Status != models.TransferStatusOpen || route.Network != "ETH" {
+		t.Errorf("Expected open ETH route, got %s %s", route.Status, route.Network)
+	}
+	if route.EstimatedMinutes <= 0 {
+		t.Error("Expected positive transfer time estimate")
+	}
+
+	// Виведення SOL з binance призупинене
+	if route := provider.FindRoute("SOL", "binance", "gateio"); route.Status != models.TransferStatusClosed {
+		t.Errorf("Expected closed route, got %s", route.Status)
+	}
+
+	// OKX не публікує статус мереж
+	if route := provider.FindRoute("USDT", "okx", "binance"); route.Status != models.TransferStatusUnknown {
+		t.Errorf("Expected unknown route, got %s", route.Status)
+	}
+}
+
+func TestCanonicalNetwork(t *testing.T) {
+	cases := map[string]string{
+		"ERC20":  "ETH",
+		"trc20":  "TRX",
+		"BEP20":  "BSC",
+		"ARBEVM": "ARBITRUM",
+		"SOL":    "SOL",
+	}
+
+	for input, expected := range cases {
+		if got := CanonicalNetwork(input); got != expected {
+			t.Errorf("CanonicalNetwork(%s) = %s, expected %s", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TriangularLeg один крок трикутного циклу
+type TriangularLeg struct {
+	Symbol string // "BTC/USDT"
+	Side   string // "buy" (купуємо base за quote) або "sell" (продаємо base за quote)
+	From   string // валюта яку віддаємо
+	To     string // валюта яку отримуємо
+}
+
+// TriangularCycle цикл з трьох угод на одній біржі (USDT → BTC → ETH → USDT)
+type TriangularCycle struct {
+	Exchange string
+	Legs     [3]TriangularLeg
+}
+
+// Route повертає маршрут валют циклу
+func (c *TriangularCycle) Route() string {
+	return fmt.Sprintf("%s → %s → %s → %s", c.Legs[0].From, c.Legs[1].From, c.Legs[2].From, c.Legs[2].To)
+}
+
+// Symbols повертає символи всіх угод циклу
+func (c *TriangularCycle) Symbols() []string {
+	return []string{c.Legs[0].Symbol, c.Legs[1].Symbol, c.Legs[2].Symbol}
+}
+
+// HasSymbol перевіряє чи цикл використовує символ
+func (c *TriangularCycle) HasSymbol(symbol string) bool {
+	for _, leg := range c.Legs {
+		if leg.Symbol == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// FindTriangularCycles знаходить всі цикли з трьох угод, які починаються
+// і закінчуються в startCurrency, серед символів доступних на біржі
+func FindTriangularCycles(exchange string, symbols []string, startCurrency string) []TriangularCycle {
+	// currency -> можливі угоди з цієї валюти
+	edges := make(map[string][]TriangularLeg)
+
+	sorted := append([]string(nil), symbols...)
+	sort.Strings(sorted)
+
+	for _, symbol := range sorted {
+		base, quote := parsePair(symbol)
+		if base == "" || base == quote {
+			continue
+		}
+
+		// quote -> base: купуємо base по ask
+		edges[quote] = append(edges[quote], TriangularLeg{Symbol: symbol, Side: "buy", From: quote, To: base})
+		// base -> quote: продаємо base по bid
+		edges[base] = append(edges[base], TriangularLeg{Symbol: symbol, Side: "sell", From: base, To: quote})
+	}
+
+	var cycles []TriangularCycle
+
+	for _, first := range edges[startCurrency] {
+		for _, second := range edges[first.To] {
+			if second.To == startCurrency || second.To == first.To {
+				continue
+			}
+
+			for _, third := range edges[second.To] {
+				if third.To != startCurrency {
+					continue
+				}
+
+				cycles = append(cycles, TriangularCycle{
+					Exchange: exchange,
+					Legs:     [3]TriangularLeg{first, second, third},
+				})
+			}
+		}
+	}
+
+	return cycles
+}
+
+// TriangularCalculation результат розрахунку трикутного арбітражу
+type TriangularCalculation struct {
+	Cycle TriangularCycle
+
+	StartAmount float64 // в стартовій валюті
+	EndAmount   float64 // в стартовій валюті після всіх угод, fees та slippage
+
+	LegPrices   [3]float64 // середня ціна виконання кожної угоди
+	LegSlippage [3]float64 // slippage кожної угоди в %
+	LegFees     [3]float64 // trading fee кожної угоди в %
+	LegQuantity [3]float64 // обсяг base валюти в кожній угоді
+
+	GrossProfit      float64 // % по найкращих цінах без fees
+	TotalFeesPercent float64 // % всіх витрат (fees + slippage)
+	NetProfit        float64 // % після всіх витрат
+}
+
+// CalculateTriangular симулює проходження циклу сумою startAmount (в стартовій валюті)
+// по orderbook'ах біржі. books: symbol -> OrderBook
+func (c *Calculator) CalculateTriangular(
+	cycle TriangularCycle,
+	books map[string]*models.OrderBook,
+	startAmount float64,
+) (*TriangularCalculation, error) {
+	if startAmount <= 0 {
+		return nil, fmt.Errorf("invalid start amount")
+	}
+
+	result := &TriangularCalculation{
+		Cycle:       cycle,
+		StartAmount: startAmount,
+	}
+
+	amount := startAmount // поточна сума у валюті leg.From
+	topOfBook := 1.0      // множник циклу по найкращих цінах без fees
+
+	for i, leg := range cycle.Legs {
+		ob := books[leg.Symbol]
+		if ob == nil {
+			return nil, fmt.Errorf("missing orderbook for %s", leg.Symbol)
+		}
+
+		// CalculateSlippage приймає суму в quote валюті
+		notional := amount
+		if leg.Side == "sell" {
+			bid := ob.GetBestBid()
+			if bid == nil || bid.Price <= 0 {
+				return nil, fmt.Errorf("empty bids for %s", leg.Symbol)
+			}
+			notional = amount * bid.Price
+		}
+
+		slippage := ob.CalculateSlippage(leg.Side, notional)
+		if slippage == nil || !slippage.Success || slippage.AveragePrice <= 0 {
+			return nil, fmt.Errorf("insufficient liquidity for %s", leg.Symbol)
+		}
+
+		fee := c.getTradingFee(cycle.Exchange)
+
+		if leg.Side == "buy" {
+			result.LegQuantity[i] = amount / slippage.AveragePrice
+			amount = result.LegQuantity[i]
+			topOfBook /= slippage.BestPrice
+		} else {
+			result.LegQuantity[i] = amount
+			amount = amount * slippage.AveragePrice
+			topOfBook *= slippage.BestPrice
+		}
+
+		// Fee списується з отриманої валюти
+		amount *= 1 - fee/100
+
+		result.LegPrices[i] = slippage.AveragePrice
+		result.LegSlippage[i] = math.Abs(slippage.SlippagePercent)
+		result.LegFees[i] = fee
+	}
+
+	result.EndAmount = amount
+	result.GrossProfit = (topOfBook - 1) * 100
+	result.NetProfit = (result.EndAmount - startAmount) / startAmount * 100
+	result.TotalFeesPercent = result.GrossProfit - result.NetProfit
+
+	return result, nil
+}
+
+// MaxLegSlippage повертає найбільший slippage серед угод циклу
+func (t *TriangularCalculation) MaxLegSlippage() float64 {
+	maxSlippage := 0.0
+	for _, s := range t.LegSlippage {
+		if s > maxSlippage {
+			maxSlippage = s
+		}
+	}
+	return maxSlippage
+}
+
+// isUSDLike перевіряє чи валюта еквівалентна доларам для розрахунку суми
+func isUSDLike(currency string) bool {
+	switch strings.ToUpper(currency) {
+	case "USD", "USDT", "USDC", "BUSD", "FDUSD":
+		return true
+	default:
+		return false
+	}
+}
+
+// checkTriangular перевіряє трикутні цикли біржі, які використовують оновлений символ
+func (d *Detector) checkTriangular(exchange, symbol string) {
+	symbols := d.obManager.GetSymbols(exchange)
+
+	for _, start := range d.config.TriangularStartCurrencies {
+		startAmount := d.triangularStartAmount(exchange, start)
+		if startAmount <= 0 {
+			continue
+		}
+
+		for _, cycle := range FindTriangularCycles(exchange, symbols, start) {
+			if !cycle.HasSymbol(symbol) {
+				continue
+			}
+
+			books := make(map[string]*models.OrderBook, 3)
+			for _, legSymbol := range cycle.Symbols() {
+				ob := d.obManager.GetHealthyOrderBook(exchange, legSymbol)
+				if ob == nil {
+					break
+				}
+				books[legSymbol] = ob
+			}
+			if len(books) < 3 {
+				continue
+			}
+
+			key := TriangularLifecycleKey(exchange, cycle.Route())
+
+			calc, err := d.calculator.CalculateTriangular(cycle, books, startAmount)
+			if err != nil || !d.shouldCreateTriangular(calc) {
+				d.lifecycle.Close(key, CloseReasonSpreadClosed)
+				continue
+			}
+
+			d.observe(key, d.buildTriangularOpportunity(calc))
+		}
+	}
+}
+
+// shouldCreateTriangular фільтрує трикутні можливості перед створенням
+func (d *Detector) shouldCreateTriangular(calc *TriangularCalculation) bool {
+	if calc.NetProfit < d.config.MinProfitPercent {
+		return false
+	}
+
+	if calc.MaxLegSlippage() > d.config.MaxSlippage {
+		return false
+	}
+
+	// Підозріло великий прибуток в межах однієї біржі - скоріше за все застарілий orderbook
+	if calc.GrossProfit > d.config.MaxSpreadPercent {
+		log.Printf("⚠️ Suspicious triangular profit on %s %s: %.2f%%",
+			calc.Cycle.Exchange, calc.Cycle.Route(), calc.GrossProfit)
+		return false
+	}
+
+	return true
+}
+
+// buildTriangularOpportunity створює ArbitrageOpportunity з розрахунку циклу
+func (d *Detector) buildTriangularOpportunity(calc *TriangularCalculation) *models.ArbitrageOpportunity {
+	cycle := calc.Cycle
+	now := time.Now()
+
+	return &models.ArbitrageOpportunity{
+		Type:             models.ArbitrageTypeTriangular,
+		Route:            cycle.Route(),
+		Pair:             strings.Join(cycle.Symbols(), ","),
+		BaseCurrency:     cycle.Legs[0].To,
+		QuoteCurrency:    cycle.Legs[0].From,
+		ExchangeBuy:      cycle.Exchange,
+		PriceBuy:         calc.LegPrices[0],
+		VolumeBuy:        calc.LegQuantity[0],
+		ExchangeSell:     cycle.Exchange,
+		PriceSell:        calc.LegPrices[2],
+		VolumeSell:       calc.LegQuantity[2],
+		ProfitPercent:    calc.GrossProfit,
+		ProfitUSD:        (calc.GrossProfit / 100) * 1000,
+		TradingFeeBuy:    calc.LegFees[0],
+		TradingFeeSell:   calc.LegFees[2],
+		TotalFeesPercent: calc.TotalFeesPercent,
+		SlippageBuy:      calc.LegSlippage[0],
+		SlippageSell:     calc.LegSlippage[1] + calc.LegSlippage[2],
+		NetProfitPercent: calc.NetProfit,
+		NetProfitUSD:     (calc.NetProfit / 100) * 1000,
+		SpreadPercent:    calc.GrossProfit,
+		MinTradeAmount:   100,
+		MaxTradeAmount:   d.config.Amount,
+		OptimalAmount:    d.config.Amount,
+		OptimalProfitUSD: (calc.NetProfit / 100) * d.config.Amount,
+		DetectedAt:       now,
+		IsNotified:       false,
+	}
+}
+
+// triangularStartAmount переводить config.Amount (USD) у стартову валюту циклу
+func (d *Detector) triangularStartAmount(exchange, currency string) float64 {
+	if isUSDLike(currency) {
+		return d.config.Amount
+	}
+
+	ob := d.obManager.GetOrderBook(exchange, currency+"/USDT")
+	if ob == nil {
+		return 0
+	}
+
+	mid := ob.GetMidPrice()
+	if mid <= 0 {
+		return 0
+	}
+
+	return d.config.Amount / mid
+}
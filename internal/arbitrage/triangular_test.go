@@ -0,0 +1,105 @@
+package arbitrage
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"math"
+	"testing"
+)
+
+func newTestBook(symbol string, bid, ask, qty float64) *models.OrderBook {
+	ob := models.NewOrderBook("binance", symbol)
+	ob.Update(
+		[]models.PriceLevel{{Price: bid, Quantity: qty}},
+		[]models.PriceLevel{{Price: ask, Quantity: qty}},
+		1,
+	)
+	return ob
+}
+
+func TestFindTriangularCycles(t *testing.T) {
+	symbols := []string{"BTC/USDT", "ETH/USDT", "ETH/BTC", "SOL/USDT"}
+
+	cycles := FindTriangularCycles("binance", symbols, "USDT")
+
+	// USDT → BTC → ETH → USDT та USDT → ETH → BTC → USDT
+	if len(cycles) != 2 {
+		t.Fatalf("Expected 2 cycles, got %d", len(cycles))
+	}
+
+	routes := map[string]bool{}
+	for _, c := range cycles {
+		routes[c.Route()] = true
+
+		if c.Legs[0].From != "USDT" || c.Legs[2].To != "USDT" {
+			t.Errorf("Cycle %s must start and end in USDT", c.Route())
+		}
+	}
+
+	for _, expected := range []string{"USDT → BTC → ETH → USDT", "USDT → ETH → BTC → USDT"} {
+		if !routes[expected] {
+			t.Errorf("Expected route %s", expected)
+		}
+	}
+}
+
+func TestCalculateTriangular(t *testing.T) {
+	calc := NewCalculator()
+
+	// ETH/BTC занижений: 3000/60000 = 0.05, а продається по 0.049
+	books := map[string]*models.OrderBook{
+		"BTC/USDT": newTestBook("BTC/USDT", 59990, 60000, 10),
+		"ETH/BTC":  newTestBook("ETH/BTC", 0.0489, 0.049, 1000),
+		"ETH/USDT": newTestBook("ETH/USDT", 3000, 3001, 1000),
+	}
+
+	cycles := FindTriangularCycles("binance", []string{"BTC/USDT", "ETH/BTC", "ETH/USDT"}, "USDT")
+
+	var profitable *TriangularCalculation
+	for _, cycle := range cycles {
+		result, err := calc.CalculateTriangular(cycle, books, 1000)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", cycle.Route(), err)
+		}
+		if cycle.Route() == "USDT → BTC → ETH → USDT" {
+			profitable = result
+		}
+	}
+
+	if profitable == nil {
+		t.Fatal("Expected USDT → BTC → ETH → USDT cycle")
+	}
+
+	// 1000 / 60000 / 0.049 * 3000 = 1020.41 → ~2.04% gross
+	expectedGross := (1.0/60000/0.049*3000 - 1) * 100
+	if math.Abs(profitable.GrossProfit-expectedGross) > 0.001 {
+		t.Errorf("Expected gross %.4f%%, got %.4f%%", expectedGross, profitable.GrossProfit)
+	}
+
+	// 3 угоди по 0.1% fee на binance
+	expectedNet := ((1.0/60000/0.049*3000)*math.Pow(0.999, 3) - 1) * 100
+	if math.Abs(profitable.NetProfit-expectedNet) > 0.001 {
+		t.Errorf("Expected net %.4f%%, got %.4f%%", expectedNet, profitable.NetProfit)
+	}
+
+	if profitable.NetProfit >= profitable.GrossProfit {
+		t.Error("Net profit must be lower than gross profit")
+	}
+}
+
+func TestCalculateTriangularInsufficientLiquidity(t *testing.T) {
+	calc := NewCalculator()
+
+	books := map[string]*models.OrderBook{
+		"BTC/USDT": newTestBook("BTC/USDT", 59990, 60000, 0.001), // $60 на рівні
+		"ETH/BTC":  newTestBook("ETH/BTC", 0.0489, 0.049, 1000),
+		"ETH/USDT": newTestBook("ETH/USDT", 3000, 3001, 1000),
+	}
+
+	cycles := FindTriangularCycles("binance", []string{"BTC/USDT", "ETH/BTC", "ETH/USDT"}, "USDT")
+
+	for _, cycle := range cycles {
+		if _, err := calc.CalculateTriangular(cycle, books, 1000); err == nil {
+			t.Errorf("Expected insufficient liquidity error for %s", cycle.Route())
+		}
+	}
+}
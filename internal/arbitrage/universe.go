@@ -0,0 +1,432 @@
+package arbitrage
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/arbitrage/websocket"
+	"crypto-opportunities-bot/internal/config"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultUniverseMaxSymbols   = 30
+	defaultUniverseMinExchanges = 2
+)
+
+// SymbolUniverse підтримує набір пар, на які підписані біржі, замість
+// статичного списку з конфігу: спільні ринки (лістинг щонайменше на
+// UniverseMinExchanges біржах), відсортовані за сумарним 24h обсягом.
+// Пари з конфігу закріплені, нові лістинги включаються поза рейтингом.
+// Обсяги з REST між оновленнями уточнюються WebSocket тікерами.
+//
+// Рейтинг ведеться по символах в ReferenceQuote: BTC/USD на Kraken
+// рахується як лістинг BTC/USDT, але підписка йде на символ біржі
+type SymbolUniverse struct {
+	obManager *OrderBookManager
+	fetchers  []MarketFetcher
+	config    *config.ArbitrageConfig
+	quotes    QuoteConfig
+	pinned    map[string]map[string]bool // exchange -> символи біржі з конфігу
+
+	listings   map[string]map[string]float64 // exchange -> символ біржі -> 24h обсяг (REST)
+	tickers    map[string]map[string]float64 // exchange -> символ біржі -> 24h обсяг (WebSocket)
+	listedAt   map[string]time.Time          // reference символ -> коли з'явився новий лістинг
+	subscribed map[string]map[string]bool    // exchange -> підписані символи біржі
+	selected   []string
+	mu         sync.Mutex
+
+	timeout  time.Duration
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// NewSymbolUniverse створює новий SymbolUniverse. Біржі мають бути вже
+// зареєстровані в obManager і підписані на ExchangeSubscriptionPairs
+func NewSymbolUniverse(obManager *OrderBookManager, fetchers []MarketFetcher, cfg *config.ArbitrageConfig) *SymbolUniverse {
+	pinned := make(map[string]map[string]bool)
+	subscribed := make(map[string]map[string]bool)
+
+	for _, exchange := range obManager.GetExchanges() {
+		pinned[exchange] = make(map[string]bool)
+		subscribed[exchange] = make(map[string]bool)
+
+		for _, symbol := range cfg.ExchangeSubscriptionPairs(exchange) {
+			pinned[exchange][symbol] = true
+			subscribed[exchange][symbol] = true
+		}
+	}
+
+	return &SymbolUniverse{
+		obManager:  obManager,
+		fetchers:   fetchers,
+		config:     cfg,
+		quotes:     NewQuoteConfig(cfg),
+		pinned:     pinned,
+		listings:   make(map[string]map[string]float64),
+		tickers:    make(map[string]map[string]float64),
+		listedAt:   make(map[string]time.Time),
+		subscribed: subscribed,
+		timeout:    60 * time.Second,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Start підключає тікери та запускає оновлення одразу і далі з інтервалом
+func (u *SymbolUniverse) Start(interval time.Duration) {
+	u.obManager.OnTicker(u.onTicker)
+
+	go func() {
+		if err := u.Refresh(); err != nil {
+			log.Printf("❌ Symbol universe error: %v", err)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-u.stopChan:
+				return
+			case <-ticker.C:
+				if err := u.Refresh(); err != nil {
+					log.Printf("❌ Symbol universe error: %v", err)
+				}
+			}
+		}
+	}()
+
+	log.Printf("✅ Symbol universe started (every %s, top %d %s pairs)", interval, u.maxSymbols(), u.quotes.Reference)
+}
+
+// Stop зупиняє оновлення
+func (u *SymbolUniverse) Stop() {
+	u.stopOnce.Do(func() {
+		close(u.stopChan)
+	})
+}
+
+// Symbols повертає поточний набір пар
+func (u *SymbolUniverse) Symbols() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return append([]string(nil), u.selected...)
+}
+
+// onTicker оновлює 24h обсяг підписаної пари
+func (u *SymbolUniverse) onTicker(exchange, symbol string, ticker *websocket.TickerData) {
+	// Bybit шле дельти без обсягу
+	if ticker == nil || ticker.Volume24h <= 0 {
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.tickers[exchange] == nil {
+		u.tickers[exchange] = make(map[string]float64)
+	}
+	u.tickers[exchange][symbol] = ticker.Volume24h
+}
+
+// Refresh завантажує ринки бірж, перераховує рейтинг та оновлює підписки
+func (u *SymbolUniverse) Refresh() error {
+	now := time.Now()
+	var errors []error
+
+	exchanges := make(map[string]bool)
+	for _, exchange := range u.obManager.GetExchanges() {
+		exchanges[exchange] = true
+	}
+
+	for _, fetcher := range u.fetchers {
+		exchange := fetcher.GetExchange()
+		if !exchanges[exchange] {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), u.timeout)
+		markets, err := fetcher.FetchMarkets(ctx)
+		cancel()
+
+		if err != nil {
+			// Залишається попередній лістинг біржі
+			log.Printf("❌ Market list refresh failed for %s: %v", exchange, err)
+			errors = append(errors, fmt.Errorf("%s: %w", exchange, err))
+			continue
+		}
+
+		u.setListing(exchange, markets, now)
+	}
+
+	u.mu.Lock()
+	if len(u.listings) == 0 {
+		u.mu.Unlock()
+		return fmt.Errorf("no market listings available: %v", errors)
+	}
+	selected := u.rank(now)
+	u.selected = selected
+	u.mu.Unlock()
+
+	u.apply(selected)
+
+	return nil
+}
+
+// setListing замінює лістинг біржі (ринки в ReferenceQuote та додаткових
+// валютах котирування біржі). Пара, що з'явилась на біржі з уже відомим
+// лістингом, вважається новим лістингом
+func (u *SymbolUniverse) setListing(exchange string, markets []*MarketInfo, now time.Time) {
+	quotes := map[string]bool{u.quotes.Reference: true}
+	for _, quote := range u.config.ExchangeQuotes[exchange] {
+		quotes[strings.ToUpper(quote)] = true
+	}
+
+	volumes := make(map[string]float64)
+	for _, market := range markets {
+		if quotes[market.Quote] {
+			volumes[market.Symbol] = market.Volume24h
+		}
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if previous, known := u.listings[exchange]; known {
+		listed := make(map[string]bool, len(previous))
+		for symbol := range previous {
+			listed[u.quotes.ReferenceSymbol(symbol)] = true
+		}
+
+		for symbol := range volumes {
+			if reference := u.quotes.ReferenceSymbol(symbol); !listed[reference] {
+				listed[reference] = true
+				u.listedAt[reference] = now
+				log.Printf("🆕 New listing: %s on %s", symbol, exchange)
+			}
+		}
+	}
+
+	u.listings[exchange] = volumes
+}
+
+// rank повертає новий набір пар (u.mu має бути захоплений):
+// закріплені, нові лістинги, далі топ за обсягом до UniverseMaxSymbols
+func (u *SymbolUniverse) rank(now time.Time) []string {
+	listedOn := make(map[string]int)
+	volumes := make(map[string]float64)
+
+	for exchange, listing := range u.listings {
+		exchangeVolumes := make(map[string]float64)
+		for symbol, volume := range listing {
+			if tickerVolume, ok := u.tickers[exchange][symbol]; ok {
+				volume = tickerVolume
+			}
+			exchangeVolumes[u.quotes.ReferenceSymbol(symbol)] += volume
+		}
+
+		for symbol, volume := range exchangeVolumes {
+			listedOn[symbol]++
+			volumes[symbol] += volume
+		}
+	}
+
+	minExchanges := u.config.UniverseMinExchanges
+	if minExchanges <= 0 {
+		minExchanges = defaultUniverseMinExchanges
+	}
+
+	selected := make([]string, 0, u.maxSymbols())
+	inSelected := make(map[string]bool)
+
+	for _, symbol := range u.config.SubscriptionPairs() {
+		if !inSelected[symbol] {
+			inSelected[symbol] = true
+			selected = append(selected, symbol)
+		}
+	}
+
+	newListingWindow := time.Duration(u.config.UniverseNewListingHours) * time.Hour
+	var newListings []string
+	for symbol, listedAt := range u.listedAt {
+		if now.Sub(listedAt) > newListingWindow {
+			delete(u.listedAt, symbol)
+			continue
+		}
+		if listedOn[symbol] >= minExchanges && !inSelected[symbol] {
+			newListings = append(newListings, symbol)
+		}
+	}
+	sort.Strings(newListings)
+
+	for _, symbol := range newListings {
+		inSelected[symbol] = true
+		selected = append(selected, symbol)
+	}
+
+	var ranked []string
+	for symbol, count := range listedOn {
+		if count >= minExchanges && volumes[symbol] >= u.config.UniverseMinVolume && !inSelected[symbol] {
+			ranked = append(ranked, symbol)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if volumes[ranked[i]] != volumes[ranked[j]] {
+			return volumes[ranked[i]] > volumes[ranked[j]]
+		}
+		return ranked[i] < ranked[j]
+	})
+
+	incumbent := make(map[string]bool, len(u.selected))
+	for _, symbol := range u.selected {
+		incumbent[symbol] = true
+	}
+
+	top := selectTop(ranked, incumbent, u.maxSymbols()-len(selected), u.config.UniverseRotationBuffer)
+
+	return append(selected, top...)
+}
+
+// selectTop бере перші slots пар рейтингу. Вже підписані пари, що випали
+// з топу не більше ніж на buffer позицій, витісняють новачків з кінця топу,
+// щоб підписки не смикались через дрібні зміни обсягів
+func selectTop(ranked []string, incumbent map[string]bool, slots, buffer int) []string {
+	if slots <= 0 {
+		return nil
+	}
+	if slots > len(ranked) {
+		slots = len(ranked)
+	}
+
+	top := append([]string(nil), ranked[:slots]...)
+
+	end := slots + buffer
+	if end > len(ranked) {
+		end = len(ranked)
+	}
+
+	pos := len(top) - 1
+	for _, symbol := range ranked[slots:end] {
+		if !incumbent[symbol] {
+			continue
+		}
+
+		for pos >= 0 && incumbent[top[pos]] {
+			pos--
+		}
+		if pos < 0 {
+			break
+		}
+
+		top[pos] = symbol
+		pos--
+	}
+
+	return top
+}
+
+// apply підписує та відписує біржі відповідно до нового набору. Пара
+// підписується лише на біржах з її лістингом, у всіх валютах котирування
+// біржі (закріплені з конфігу - завжди)
+func (u *SymbolUniverse) apply(selected []string) {
+	type change struct {
+		exchange       string
+		added, removed []string
+	}
+
+	inSelected := make(map[string]bool, len(selected))
+	for _, symbol := range selected {
+		inSelected[symbol] = true
+	}
+
+	var changes []change
+
+	u.mu.Lock()
+	for _, exchange := range u.obManager.GetExchanges() {
+		desired := make(map[string]bool)
+		for symbol := range u.pinned[exchange] {
+			desired[symbol] = true
+		}
+
+		if listing, known := u.listings[exchange]; known {
+			for symbol := range listing {
+				if inSelected[u.quotes.ReferenceSymbol(symbol)] {
+					desired[symbol] = true
+				}
+			}
+		} else {
+			// Лістинг невідомий - підписуємо як є
+			for _, symbol := range selected {
+				desired[symbol] = true
+			}
+		}
+
+		current := u.subscribed[exchange]
+		c := change{exchange: exchange}
+		for symbol := range desired {
+			if !current[symbol] {
+				c.added = append(c.added, symbol)
+			}
+		}
+		for symbol := range current {
+			if !desired[symbol] {
+				c.removed = append(c.removed, symbol)
+			}
+		}
+
+		if len(c.added) > 0 || len(c.removed) > 0 {
+			sort.Strings(c.added)
+			sort.Strings(c.removed)
+			changes = append(changes, c)
+		}
+	}
+	u.mu.Unlock()
+
+	for _, c := range changes {
+		var removed []string
+		if len(c.removed) > 0 {
+			if err := u.obManager.UnsubscribeSymbols(c.exchange, c.removed); err != nil {
+				// Повторна спроба при наступному оновленні
+				log.Printf("⚠️ Failed to unsubscribe %d pairs on %s: %v", len(c.removed), c.exchange, err)
+			} else {
+				removed = c.removed
+			}
+		}
+
+		if len(c.added) > 0 {
+			// Manager запам'ятовує символи навіть без з'єднання і підпише їх при перепідключенні
+			if err := u.obManager.SubscribeSymbols(c.exchange, c.added); err != nil {
+				log.Printf("⚠️ Failed to subscribe %d pairs on %s: %v", len(c.added), c.exchange, err)
+			}
+		}
+
+		u.mu.Lock()
+		if u.subscribed[c.exchange] == nil {
+			u.subscribed[c.exchange] = make(map[string]bool)
+		}
+		for _, symbol := range removed {
+			delete(u.subscribed[c.exchange], symbol)
+			delete(u.tickers[c.exchange], symbol)
+		}
+		for _, symbol := range c.added {
+			u.subscribed[c.exchange][symbol] = true
+		}
+		u.mu.Unlock()
+
+		log.Printf("🌐 Symbol universe %s: +%d %v, -%d %v",
+			c.exchange, len(c.added), c.added, len(removed), removed)
+	}
+
+	log.Printf("🌐 Symbol universe: %d pairs", len(selected))
+}
+
+func (u *SymbolUniverse) maxSymbols() int {
+	if u.config.UniverseMaxSymbols > 0 {
+		return u.config.UniverseMaxSymbols
+	}
+	return defaultUniverseMaxSymbols
+}
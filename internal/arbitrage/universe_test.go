@@ -0,0 +1,202 @@
+package arbitrage
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/arbitrage/websocket"
+	"crypto-opportunities-bot/internal/config"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// fakeMarketFetcher повертає заданий лістинг
+type fakeMarketFetcher struct {
+	exchange string
+	markets  []*MarketInfo
+}
+
+func (f *fakeMarketFetcher) GetExchange() string {
+	return f.exchange
+}
+
+func (f *fakeMarketFetcher) FetchMarkets(ctx context.Context) ([]*MarketInfo, error) {
+	return f.markets, nil
+}
+
+// fakeSubscriptionManager запам'ятовує підписки
+type fakeSubscriptionManager struct {
+	websocket.Manager
+	exchange string
+	symbols  map[string]bool
+}
+
+func newFakeSubscriptionManager(exchange string, symbols ...string) *fakeSubscriptionManager {
+	m := &fakeSubscriptionManager{exchange: exchange, symbols: make(map[string]bool)}
+	for _, symbol := range symbols {
+		m.symbols[symbol] = true
+	}
+	return m
+}
+
+func (m *fakeSubscriptionManager) GetExchange() string {
+	return m.exchange
+}
+
+func (m *fakeSubscriptionManager) IsConnected() bool {
+	return true
+}
+
+func (m *fakeSubscriptionManager) OnOrderBookUpdate(callback websocket.OrderBookCallback) {}
+
+func (m *fakeSubscriptionManager) OnTicker(callback websocket.TickerCallback) {}
+
+func (m *fakeSubscriptionManager) Subscribe(symbols []string) error {
+	for _, symbol := range symbols {
+		m.symbols[symbol] = true
+	}
+	return nil
+}
+
+func (m *fakeSubscriptionManager) Unsubscribe(symbols []string) error {
+	for _, symbol := range symbols {
+		delete(m.symbols, symbol)
+	}
+	return nil
+}
+
+func (m *fakeSubscriptionManager) list() []string {
+	var symbols []string
+	for symbol := range m.symbols {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+func markets(volumes map[string]float64) []*MarketInfo {
+	var result []*MarketInfo
+	for symbol, volume := range volumes {
+		base, quote := parsePair(symbol)
+		result = append(result, newMarketInfo(base, quote, volume))
+	}
+	return result
+}
+
+func TestSymbolUniverseRefresh(t *testing.T) {
+	binance := newFakeSubscriptionManager("binance", "BTC/USDT")
+	bybit := newFakeSubscriptionManager("bybit", "BTC/USDT")
+	kraken := newFakeSubscriptionManager("kraken", "BTC/USD", "BTC/USDT")
+
+	obManager := NewOrderBookManager()
+	obManager.RegisterExchange("binance", binance)
+	obManager.RegisterExchange("bybit", bybit)
+	obManager.RegisterExchange("kraken", kraken)
+
+	binanceFetcher := &fakeMarketFetcher{exchange: "binance", markets: markets(map[string]float64{
+		"BTC/USDT":  900e6,
+		"ETH/USDT":  500e6,
+		"SOL/USDT":  200e6,
+		"DOGE/USDT": 100e6,
+		"PEPE/USDT": 300e6, // тільки на одній біржі
+		"ETH/BTC":   50e6,  // інша валюта котирування
+	})}
+	bybitFetcher := &fakeMarketFetcher{exchange: "bybit", markets: markets(map[string]float64{
+		"BTC/USDT":  400e6,
+		"ETH/USDT":  200e6,
+		"SOL/USDT":  80e6,
+		"DOGE/USDT": 90e6,
+	})}
+	krakenFetcher := &fakeMarketFetcher{exchange: "kraken", markets: markets(map[string]float64{
+		"BTC/USD": 50e6,
+		"ETH/USD": 20e6, // рахується як ETH/USDT
+		"XRP/EUR": 10e6, // котирування не в exchange_quotes
+	})}
+
+	cfg := &config.ArbitrageConfig{
+		Pairs:                   []string{"BTC/USDT"},
+		ReferenceQuote:          "USDT",
+		ExchangeQuotes:          map[string][]string{"kraken": {"USD"}},
+		UniverseMaxSymbols:      3,
+		UniverseMinExchanges:    2,
+		UniverseRotationBuffer:  1,
+		UniverseNewListingHours: 24,
+	}
+
+	universe := NewSymbolUniverse(obManager, []MarketFetcher{binanceFetcher, bybitFetcher, krakenFetcher}, cfg)
+
+	if err := universe.Refresh(); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	// BTC закріплений, далі ETH (700M) та SOL (280M); DOGE (190M) за межею
+	expected := []string{"BTC/USDT", "ETH/USDT", "SOL/USDT"}
+	if got := universe.Symbols(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected universe %v, got %v", expected, got)
+	}
+	if got := binance.list(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected binance subscriptions %v, got %v", expected, got)
+	}
+
+	// Kraken підписаний на символи в USD (SOL/USD не лістингується)
+	expectedKraken := []string{"BTC/USD", "BTC/USDT", "ETH/USD"}
+	if got := kraken.list(); !reflect.DeepEqual(got, expectedKraken) {
+		t.Errorf("Expected kraken subscriptions %v, got %v", expectedKraken, got)
+	}
+
+	// DOGE обганяє SOL, але SOL в межах буфера ротації - залишається
+	universe.onTicker("binance", "DOGE/USDT", &websocket.TickerData{Volume24h: 250e6})
+	if err := universe.Refresh(); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if got := universe.Symbols(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected rotation buffer to keep %v, got %v", expected, got)
+	}
+
+	// Новий лістинг на bybit включається поза рейтингом
+	bybitFetcher.markets = append(bybitFetcher.markets, newMarketInfo("PEPE", "USDT", 1e6))
+	if err := universe.Refresh(); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	expected = []string{"BTC/USDT", "PEPE/USDT", "ETH/USDT"}
+	if got := universe.Symbols(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected new listing in universe %v, got %v", expected, got)
+	}
+
+	sorted := append([]string(nil), expected...)
+	sort.Strings(sorted)
+	if got := bybit.list(); !reflect.DeepEqual(got, sorted) {
+		t.Errorf("Expected bybit subscriptions %v, got %v", sorted, got)
+	}
+}
+
+func TestSelectTop(t *testing.T) {
+	ranked := []string{"A", "B", "C", "D", "E"}
+
+	tests := []struct {
+		name      string
+		incumbent []string
+		slots     int
+		buffer    int
+		expected  []string
+	}{
+		{"no incumbents", nil, 2, 2, []string{"A", "B"}},
+		{"incumbent within buffer replaces newcomer", []string{"A", "C"}, 2, 1, []string{"A", "C"}},
+		{"incumbent beyond buffer dropped", []string{"A", "D"}, 2, 1, []string{"A", "B"}},
+		{"all slots held by incumbents", []string{"A", "B", "C"}, 2, 2, []string{"A", "B"}},
+		{"fewer candidates than slots", nil, 10, 0, ranked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incumbent := make(map[string]bool)
+			for _, symbol := range tt.incumbent {
+				incumbent[symbol] = true
+			}
+
+			if got := selectTop(ranked, incumbent, tt.slots, tt.buffer); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
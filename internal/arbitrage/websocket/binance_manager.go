@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -14,12 +15,18 @@ import (
 )
 
 // BinanceManager управляє WebSocket з'єднанням з Binance
+// Ордербук синхронізується за diff-depth протоколом: REST snapshot + delta події (U/u)
 type BinanceManager struct {
 	wsURL             string
+	restURL           string
+	httpClient        *http.Client
 	conn              *websocket.Conn
+	writeMu           sync.Mutex // Subscribe/Unsubscribe (universe) та ping пишуть з різних горутин
 	symbols           []string
 	orderbooks        map[string]*models.OrderBook
 	mu                sync.RWMutex
+	syncStates        map[string]*binanceSyncState
+	syncMu            sync.Mutex
 	reconnectInterval time.Duration
 	pingInterval      time.Duration
 
@@ -27,6 +34,9 @@ type BinanceManager struct {
 	onOrderBookUpdate OrderBookCallback
 	onTicker          TickerCallback
 
+	telemetry *Telemetry
+
+	parent context.Context // Контекст власника: з нього створюється ctx при перепідключенні
 	ctx    context.Context
 	cancel context.CancelFunc
 
@@ -37,10 +47,14 @@ type BinanceManager struct {
 // NewBinanceManager створює новий Binance WebSocket Manager
 func NewBinanceManager() *BinanceManager {
 	return &BinanceManager{
-		wsURL:             "wss://stream.binance.com:9443/ws",
+		wsURL:             "wss://stream.binance.com:9443/stream", // combined streams: {"stream": ..., "data": ...}
+		restURL:           "https://api.binance.com/api/v3/depth",
+		httpClient:        &http.Client{Timeout: 10 * time.Second},
 		orderbooks:        make(map[string]*models.OrderBook),
+		syncStates:        make(map[string]*binanceSyncState),
 		reconnectInterval: 5 * time.Second,
 		pingInterval:      20 * time.Second,
+		telemetry:         NewTelemetry(),
 	}
 }
 
@@ -49,17 +63,25 @@ func (m *BinanceManager) GetExchange() string {
 	return "binance"
 }
 
+// Telemetry повертає метрики фіду
+func (m *BinanceManager) Telemetry() *Telemetry {
+	return m.telemetry
+}
+
 // Connect підключається до Binance WebSocket
 func (m *BinanceManager) Connect(ctx context.Context) error {
+	m.parent = ctx
 	m.ctx, m.cancel = context.WithCancel(ctx)
 
 	conn, _, err := websocket.DefaultDialer.Dial(m.wsURL, nil)
 	if err != nil {
+		m.telemetry.RecordError(err)
 		return fmt.Errorf("failed to connect to Binance WS: %w", err)
 	}
 
 	m.conn = conn
 	m.setConnected(true)
+	m.telemetry.RecordConnected()
 	log.Printf("✅ Connected to Binance WebSocket")
 
 	// Start message handler
@@ -81,6 +103,7 @@ func (m *BinanceManager) Disconnect() error {
 	}
 
 	m.setConnected(false)
+	m.telemetry.RecordDisconnected(nil)
 
 	if m.conn != nil {
 		return m.conn.Close()
@@ -92,7 +115,7 @@ func (m *BinanceManager) Disconnect() error {
 // Subscribe підписується на символи
 func (m *BinanceManager) Subscribe(symbols []string) error {
 	m.mu.Lock()
-	m.symbols = symbols
+	m.symbols = mergeSymbols(m.symbols, symbols)
 	m.mu.Unlock()
 
 	if !m.IsConnected() {
@@ -105,8 +128,8 @@ func (m *BinanceManager) Subscribe(symbols []string) error {
 	for _, symbol := range symbols {
 		symbolLower := m.formatSymbol(symbol)
 
-		// Subscribe to depth (orderbook) - 20 levels, 100ms updates
-		streams = append(streams, fmt.Sprintf("%s@depth20@100ms", symbolLower))
+		// Subscribe to diff depth (incremental orderbook updates), 100ms
+		streams = append(streams, fmt.Sprintf("%s@depth@100ms", symbolLower))
 
 		// Subscribe to ticker (24h stats)
 		streams = append(streams, fmt.Sprintf("%s@ticker", symbolLower))
@@ -120,7 +143,20 @@ func (m *BinanceManager) Subscribe(symbols []string) error {
 
 	log.Printf("🔔 Subscribing to %d streams on Binance", len(streams))
 
-	return m.conn.WriteJSON(subscribeMsg)
+	if err := m.writeJSON(subscribeMsg); err != nil {
+		return err
+	}
+
+	// Delta події буферизуються поки завантажується snapshot
+	for _, symbol := range symbols {
+		m.syncMu.Lock()
+		m.syncStates[symbol] = &binanceSyncState{}
+		m.syncMu.Unlock()
+
+		go m.resync(symbol)
+	}
+
+	return nil
 }
 
 // Unsubscribe відписується від символів
@@ -132,8 +168,12 @@ func (m *BinanceManager) Unsubscribe(symbols []string) error {
 	streams := []string{}
 	for _, symbol := range symbols {
 		symbolLower := m.formatSymbol(symbol)
-		streams = append(streams, fmt.Sprintf("%s@depth20@100ms", symbolLower))
+		streams = append(streams, fmt.Sprintf("%s@depth@100ms", symbolLower))
 		streams = append(streams, fmt.Sprintf("%s@ticker", symbolLower))
+
+		m.syncMu.Lock()
+		delete(m.syncStates, symbol)
+		m.syncMu.Unlock()
 	}
 
 	unsubscribeMsg := map[string]interface{}{
@@ -142,7 +182,20 @@ func (m *BinanceManager) Unsubscribe(symbols []string) error {
 		"id":     time.Now().Unix(),
 	}
 
-	return m.conn.WriteJSON(unsubscribeMsg)
+	if err := m.writeJSON(unsubscribeMsg); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.symbols = removeSymbols(m.symbols, symbols)
+	for _, symbol := range symbols {
+		delete(m.orderbooks, symbol)
+	}
+	m.mu.Unlock()
+
+	m.telemetry.ForgetSymbols(symbols)
+
+	return nil
 }
 
 // GetOrderBook отримує OrderBook для символу
@@ -176,6 +229,13 @@ func (m *BinanceManager) setConnected(status bool) {
 	m.connected = status
 }
 
+func (m *BinanceManager) writeJSON(v interface{}) error {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	return m.conn.WriteJSON(v)
+}
+
 // handleMessages обробляє вхідні WebSocket повідомлення
 func (m *BinanceManager) handleMessages() {
 	defer func() {
@@ -193,6 +253,7 @@ func (m *BinanceManager) handleMessages() {
 			if err != nil {
 				log.Printf("❌ WebSocket read error (Binance): %v", err)
 				m.setConnected(false)
+				m.telemetry.RecordDisconnected(err)
 				go m.reconnect()
 				return
 			}
@@ -228,7 +289,7 @@ func (m *BinanceManager) processMessage(message []byte) {
 	}
 }
 
-// processDepthUpdate обробляє оновлення ордербуку
+// processDepthUpdate обробляє diff-depth подію ордербуку
 func (m *BinanceManager) processDepthUpdate(stream string, data map[string]interface{}) {
 	// Parse symbol from stream
 	symbol := m.extractSymbol(stream)
@@ -236,29 +297,59 @@ func (m *BinanceManager) processDepthUpdate(stream string, data map[string]inter
 		return
 	}
 
-	// Parse bids and asks
-	bidsRaw, ok1 := data["bids"].([]interface{})
-	asksRaw, ok2 := data["asks"].([]interface{})
+	// Parse bids and asks (quantity = 0 означає видалення рівня)
+	bidsRaw, ok1 := data["b"].([]interface{})
+	asksRaw, ok2 := data["a"].([]interface{})
 
 	if !ok1 || !ok2 {
 		return
 	}
 
-	bids := m.parsePriceLevels(bidsRaw)
-	asks := m.parsePriceLevels(asksRaw)
+	ev := depthDelta{
+		FirstUpdateID: int64(parseFloat(data["U"])),
+		FinalUpdateID: int64(parseFloat(data["u"])),
+		Bids:          parseDeltaLevels(bidsRaw),
+		Asks:          parseDeltaLevels(asksRaw),
+	}
 
-	// Get or create orderbook
-	m.mu.Lock()
-	orderbook, exists := m.orderbooks[symbol]
-	if !exists {
-		orderbook = models.NewOrderBook("binance", symbol)
-		m.orderbooks[symbol] = orderbook
+	m.syncMu.Lock()
+	state, ok := m.syncStates[symbol]
+	if !ok {
+		m.syncMu.Unlock()
+		return // Не підписані на символ
+	}
+
+	// Snapshot ще не застосовано - буферизуємо
+	if !state.synced {
+		state.bufferDelta(ev)
+		m.syncMu.Unlock()
+		return
+	}
+
+	apply, err := state.accept(ev)
+	if err != nil {
+		log.Printf("⚠️ Binance %s: sequence gap (last=%d, U=%d), resyncing...", symbol, state.lastUpdateID, ev.FirstUpdateID)
+		m.telemetry.RecordError(fmt.Errorf("%s: %w", symbol, err))
+		state.synced = false
+		state.bridged = false
+		state.buffer = []depthDelta{ev}
+		m.syncMu.Unlock()
+
+		go m.resync(symbol)
+		return
+	}
+
+	if !apply {
+		m.syncMu.Unlock()
+		return
 	}
-	m.mu.Unlock()
 
-	// Update orderbook
-	updateID := int64(parseFloat(data["lastUpdateId"]))
-	orderbook.Update(bids, asks, updateID)
+	orderbook := m.getOrCreateOrderBook(symbol)
+	orderbook.ApplyDelta(ev.Bids, ev.Asks, ev.FinalUpdateID)
+	m.syncMu.Unlock()
+
+	// E - час події на біржі (мс)
+	m.telemetry.RecordMessage(symbol, msToTime(int64(parseFloat(data["E"]))))
 
 	// Trigger callback
 	if m.onOrderBookUpdate != nil {
@@ -266,6 +357,138 @@ func (m *BinanceManager) processDepthUpdate(stream string, data map[string]inter
 	}
 }
 
+// resync завантажує REST snapshot і застосовує буферизовані події
+func (m *BinanceManager) resync(symbol string) {
+	m.syncMu.Lock()
+	state, ok := m.syncStates[symbol]
+	if !ok || state.resyncing || state.synced {
+		m.syncMu.Unlock()
+		return
+	}
+	state.resyncing = true
+	m.syncMu.Unlock()
+
+	defer func() {
+		m.syncMu.Lock()
+		state.resyncing = false
+		m.syncMu.Unlock()
+	}()
+
+	for attempt := 1; attempt <= maxResyncAttempts; attempt++ {
+		if m.ctx != nil && m.ctx.Err() != nil {
+			return
+		}
+
+		bids, asks, lastUpdateID, err := m.fetchSnapshot(symbol)
+		if err != nil {
+			log.Printf("⚠️ Binance %s: snapshot error (attempt %d): %v", symbol, attempt, err)
+			time.Sleep(resyncRetryInterval)
+			continue
+		}
+
+		m.syncMu.Lock()
+		if m.syncStates[symbol] != state {
+			m.syncMu.Unlock()
+			return // Відписались або підписались заново
+		}
+
+		orderbook := m.getOrCreateOrderBook(symbol)
+		orderbook.Update(bids, asks, lastUpdateID)
+
+		state.lastUpdateID = lastUpdateID
+		state.bridged = false
+
+		gap := false
+		for _, ev := range state.buffer {
+			apply, err := state.accept(ev)
+			if err != nil {
+				gap = true
+				break
+			}
+			if apply {
+				orderbook.ApplyDelta(ev.Bids, ev.Asks, ev.FinalUpdateID)
+			}
+		}
+
+		if gap {
+			// Snapshot старіший за буфер - пробуємо ще раз
+			state.bridged = false
+			m.syncMu.Unlock()
+			time.Sleep(resyncRetryInterval)
+			continue
+		}
+
+		state.buffer = nil
+		state.synced = true
+		m.syncMu.Unlock()
+
+		log.Printf("✅ Binance %s: orderbook synced (lastUpdateId=%d)", symbol, state.lastUpdateID)
+
+		if m.onOrderBookUpdate != nil {
+			go m.onOrderBookUpdate("binance", symbol, orderbook)
+		}
+		return
+	}
+
+	// Delta події лише буферизуються поки книга не синхронізована - без нової
+	// серії спроб символ залишився б замороженим до перепідключення
+	log.Printf("❌ Binance %s: failed to sync orderbook after %d attempts, retrying in %s", symbol, maxResyncAttempts, resyncBackoff)
+	m.telemetry.RecordError(fmt.Errorf("%s: orderbook resync failed", symbol))
+	time.AfterFunc(resyncBackoff, func() {
+		m.resync(symbol)
+	})
+}
+
+// fetchSnapshot отримує REST snapshot ордербуку
+func (m *BinanceManager) fetchSnapshot(symbol string) ([]models.PriceLevel, []models.PriceLevel, int64, error) {
+	url := fmt.Sprintf("%s?symbol=%s&limit=1000", m.restURL, strings.ToUpper(m.formatSymbol(symbol)))
+
+	resp, err := m.httpClient.Get(url)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to fetch depth snapshot: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, 0, fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	var snapshot struct {
+		LastUpdateID int64           `json:"lastUpdateId"`
+		Bids         [][]interface{} `json:"bids"`
+		Asks         [][]interface{} `json:"asks"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to parse depth snapshot: %w", err)
+	}
+
+	bids := make([]interface{}, len(snapshot.Bids))
+	for i, level := range snapshot.Bids {
+		bids[i] = level
+	}
+	asks := make([]interface{}, len(snapshot.Asks))
+	for i, level := range snapshot.Asks {
+		asks[i] = level
+	}
+
+	return m.parsePriceLevels(bids), m.parsePriceLevels(asks), snapshot.LastUpdateID, nil
+}
+
+// getOrCreateOrderBook повертає OrderBook символу, створюючи за потреби
+func (m *BinanceManager) getOrCreateOrderBook(symbol string) *models.OrderBook {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	orderbook, exists := m.orderbooks[symbol]
+	if !exists {
+		orderbook = models.NewOrderBook("binance", symbol)
+		m.orderbooks[symbol] = orderbook
+	}
+
+	return orderbook
+}
+
 // processTickerUpdate обробляє оновлення тікера
 func (m *BinanceManager) processTickerUpdate(stream string, data map[string]interface{}) {
 	symbol := m.extractSymbol(stream)
@@ -314,7 +537,7 @@ func (m *BinanceManager) parsePriceLevels(levels []interface{}) []models.PriceLe
 
 // extractSymbol витягує символ зі stream name
 func (m *BinanceManager) extractSymbol(stream string) string {
-	// "btcusdt@depth20@100ms" -> "BTC/USDT"
+	// "btcusdt@depth@100ms" -> "BTC/USDT"
 	parts := strings.Split(stream, "@")
 	if len(parts) == 0 {
 		return ""
@@ -331,10 +554,20 @@ func (m *BinanceManager) formatSymbol(symbol string) string {
 	return strings.ToLower(strings.ReplaceAll(symbol, "/", ""))
 }
 
-// parseSymbol конвертує "btcusdt" -> "BTC/USDT"
+// parseSymbol конвертує "btcusdt" -> "BTC/USDT" (спершу за підписаними
+// символами, далі за відомими quote currencies)
 func (m *BinanceManager) parseSymbol(symbolLower string) string {
-	// Список популярних quote currencies
-	quotes := []string{"usdt", "busd", "usdc", "btc", "eth", "bnb"}
+	m.mu.RLock()
+	for _, symbol := range m.symbols {
+		if m.formatSymbol(symbol) == symbolLower {
+			m.mu.RUnlock()
+			return symbol
+		}
+	}
+	m.mu.RUnlock()
+
+	// Список популярних quote currencies (довші суфікси першими)
+	quotes := []string{"fdusd", "usdt", "busd", "usdc", "btc", "eth", "bnb"}
 
 	for _, quote := range quotes {
 		if strings.HasSuffix(symbolLower, quote) {
@@ -360,9 +593,14 @@ func (m *BinanceManager) ping() {
 				continue
 			}
 
-			if err := m.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+			m.writeMu.Lock()
+			err := m.conn.WriteMessage(websocket.PingMessage, nil)
+			m.writeMu.Unlock()
+
+			if err != nil {
 				log.Printf("⚠️ Ping error (Binance): %v", err)
 				m.setConnected(false)
+				m.telemetry.RecordDisconnected(err)
 				go m.reconnect()
 				return
 			}
@@ -414,7 +652,7 @@ func (m *BinanceManager) reconnect() {
 
 	time.Sleep(m.reconnectInterval)
 
-	if err := m.Connect(m.ctx); err != nil {
+	if err := m.Connect(m.parent); err != nil {
 		log.Printf("❌ Reconnection failed (Binance): %v", err)
 		// Retry after interval
 		time.Sleep(m.reconnectInterval)
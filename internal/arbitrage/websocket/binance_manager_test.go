@@ -0,0 +1,28 @@
+package websocket
+
+import "testing"
+
+func TestBinanceParseSymbol(t *testing.T) {
+	m := NewBinanceManager()
+	m.symbols = []string{"BTC/FDUSD", "ETH/USDC"}
+
+	tests := []struct {
+		stream string
+		want   string
+	}{
+		{"btcfdusd@depth@100ms", "BTC/FDUSD"},
+		{"ethusdc@ticker", "ETH/USDC"},
+		// Не підписані символи розбираються за суфіксом quote currency
+		{"solfdusd@depth@100ms", "SOL/FDUSD"},
+		{"solusdc@depth@100ms", "SOL/USDC"},
+		{"btcusdt@depth@100ms", "BTC/USDT"},
+		{"ethbtc@ticker", "ETH/BTC"},
+		{"xyzabc@ticker", ""},
+	}
+
+	for _, tt := range tests {
+		if got := m.extractSymbol(tt.stream); got != tt.want {
+			t.Errorf("extractSymbol(%q) = %q, want %q", tt.stream, got, tt.want)
+		}
+	}
+}
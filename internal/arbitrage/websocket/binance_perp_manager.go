@@ -0,0 +1,80 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// BinancePerpManager mark price та funding rate USDⓈ-M perpetual ф'ючерсів Binance
+// (stream <symbol>@markPrice@1s)
+type BinancePerpManager struct {
+	*perpStream
+}
+
+// NewBinancePerpManager створює новий Binance perpetual Manager
+func NewBinancePerpManager() *BinancePerpManager {
+	return &BinancePerpManager{perpStream: newPerpStream(binancePerpAdapter{})}
+}
+
+type binancePerpAdapter struct{}
+
+func (binancePerpAdapter) exchange() string {
+	return "binance"
+}
+
+func (binancePerpAdapter) url() string {
+	return "wss://fstream.binance.com/stream" // combined streams: {"stream": ..., "data": ...}
+}
+
+// nativeSymbol "BTC/USDT" -> "BTCUSDT"
+func (binancePerpAdapter) nativeSymbol(symbol string) string {
+	return strings.ToUpper(strings.ReplaceAll(symbol, "/", ""))
+}
+
+func (binancePerpAdapter) subscribeMessages(natives []string) []interface{} {
+	streams := make([]string, 0, len(natives))
+	for _, native := range natives {
+		streams = append(streams, fmt.Sprintf("%s@markPrice@1s", strings.ToLower(native)))
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"method": "SUBSCRIBE",
+			"params": streams,
+			"id":     1,
+		},
+	}
+}
+
+// parse обробляє markPriceUpdate:
+// {"e":"markPriceUpdate","s":"BTCUSDT","p":"mark","i":"index","r":"funding rate","T":next funding ms}
+func (binancePerpAdapter) parse(message []byte) []fundingUpdate {
+	var msg struct {
+		Stream string                 `json:"stream"`
+		Data   map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil || !strings.Contains(msg.Stream, "@markPrice") {
+		return nil
+	}
+
+	native, _ := msg.Data["s"].(string)
+	if native == "" {
+		return nil
+	}
+
+	return []fundingUpdate{{
+		native:          native,
+		markPrice:       parseFloat(msg.Data["p"]),
+		indexPrice:      parseFloat(msg.Data["i"]),
+		fundingRate:     optionalRate(msg.Data["r"]),
+		nextFundingTime: millisToTime(msg.Data["T"]),
+	}}
+}
+
+// ping Binance futures відповідає на WebSocket ping frames
+func (binancePerpAdapter) ping(conn *websocket.Conn) error {
+	return conn.WriteMessage(websocket.PingMessage, nil)
+}
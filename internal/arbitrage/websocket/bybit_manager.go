@@ -13,13 +13,20 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// bybitArgsPerRequest Bybit обмежує кількість топіків в одному subscribe
+const bybitArgsPerRequest = 10
+
 // BybitManager управляє WebSocket з'єднанням з Bybit
+// Ордербук: snapshot + delta повідомлення з послідовним update ID (u) та cross sequence (seq)
 type BybitManager struct {
 	wsURL             string
 	conn              *websocket.Conn
+	writeMu           sync.Mutex
 	symbols           []string
 	orderbooks        map[string]*models.OrderBook
 	mu                sync.RWMutex
+	syncStates        map[string]*bybitSyncState
+	syncMu            sync.Mutex
 	reconnectInterval time.Duration
 	pingInterval      time.Duration
 
@@ -27,6 +34,9 @@ type BybitManager struct {
 	onOrderBookUpdate OrderBookCallback
 	onTicker          TickerCallback
 
+	telemetry *Telemetry
+
+	parent context.Context // Контекст власника: з нього створюється ctx при перепідключенні
 	ctx    context.Context
 	cancel context.CancelFunc
 
@@ -39,8 +49,10 @@ func NewBybitManager() *BybitManager {
 	return &BybitManager{
 		wsURL:             "wss://stream.bybit.com/v5/public/spot",
 		orderbooks:        make(map[string]*models.OrderBook),
+		syncStates:        make(map[string]*bybitSyncState),
 		reconnectInterval: 5 * time.Second,
 		pingInterval:      20 * time.Second,
+		telemetry:         NewTelemetry(),
 	}
 }
 
@@ -49,17 +61,25 @@ func (m *BybitManager) GetExchange() string {
 	return "bybit"
 }
 
+// Telemetry повертає метрики фіду
+func (m *BybitManager) Telemetry() *Telemetry {
+	return m.telemetry
+}
+
 // Connect підключається до Bybit WebSocket
 func (m *BybitManager) Connect(ctx context.Context) error {
+	m.parent = ctx
 	m.ctx, m.cancel = context.WithCancel(ctx)
 
 	conn, _, err := websocket.DefaultDialer.Dial(m.wsURL, nil)
 	if err != nil {
+		m.telemetry.RecordError(err)
 		return fmt.Errorf("failed to connect to Bybit WS: %w", err)
 	}
 
 	m.conn = conn
 	m.setConnected(true)
+	m.telemetry.RecordConnected()
 	log.Printf("✅ Connected to Bybit WebSocket")
 
 	// Start message handler
@@ -81,6 +101,7 @@ func (m *BybitManager) Disconnect() error {
 	}
 
 	m.setConnected(false)
+	m.telemetry.RecordDisconnected(nil)
 
 	if m.conn != nil {
 		return m.conn.Close()
@@ -92,7 +113,7 @@ func (m *BybitManager) Disconnect() error {
 // Subscribe підписується на символи
 func (m *BybitManager) Subscribe(symbols []string) error {
 	m.mu.Lock()
-	m.symbols = symbols
+	m.symbols = mergeSymbols(m.symbols, symbols)
 	m.mu.Unlock()
 
 	if !m.IsConnected() {
@@ -110,17 +131,14 @@ func (m *BybitManager) Subscribe(symbols []string) error {
 		args = append(args, fmt.Sprintf("tickers.%s", normalized))
 	}
 
-	subMsg := map[string]interface{}{
-		"op":   "subscribe",
-		"args": args,
-	}
-
-	data, err := json.Marshal(subMsg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal subscribe message: %w", err)
+	// Чекаємо на snapshot для кожного символу
+	m.syncMu.Lock()
+	for _, symbol := range symbols {
+		m.syncStates[symbol] = &bybitSyncState{}
 	}
+	m.syncMu.Unlock()
 
-	if err := m.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+	if err := m.sendOp("subscribe", args); err != nil {
 		return fmt.Errorf("failed to send subscribe message: %w", err)
 	}
 
@@ -142,23 +160,61 @@ func (m *BybitManager) Unsubscribe(symbols []string) error {
 		args = append(args, fmt.Sprintf("tickers.%s", normalized))
 	}
 
-	unsubMsg := map[string]interface{}{
-		"op":   "unsubscribe",
-		"args": args,
+	m.syncMu.Lock()
+	for _, symbol := range symbols {
+		delete(m.syncStates, symbol)
 	}
+	m.syncMu.Unlock()
 
-	data, err := json.Marshal(unsubMsg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal unsubscribe message: %w", err)
+	if err := m.sendOp("unsubscribe", args); err != nil {
+		return fmt.Errorf("failed to send unsubscribe message: %w", err)
 	}
 
-	if err := m.conn.WriteMessage(websocket.TextMessage, data); err != nil {
-		return fmt.Errorf("failed to send unsubscribe message: %w", err)
+	m.mu.Lock()
+	m.symbols = removeSymbols(m.symbols, symbols)
+	for _, symbol := range symbols {
+		delete(m.orderbooks, symbol)
+	}
+	m.mu.Unlock()
+
+	m.telemetry.ForgetSymbols(symbols)
+
+	return nil
+}
+
+// sendOp надсилає subscribe/unsubscribe частинами (Bybit обмежує кількість
+// топіків в одному запиті)
+func (m *BybitManager) sendOp(op string, args []string) error {
+	for start := 0; start < len(args); start += bybitArgsPerRequest {
+		end := start + bybitArgsPerRequest
+		if end > len(args) {
+			end = len(args)
+		}
+
+		data, err := json.Marshal(map[string]interface{}{
+			"op":   op,
+			"args": args[start:end],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s message: %w", op, err)
+		}
+
+		if err := m.writeMessage(data); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// writeMessage серіалізує запис у з'єднання (gorilla не підтримує конкурентний запис)
+func (m *BybitManager) writeMessage(data []byte) error {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	return m.conn.WriteMessage(websocket.TextMessage, data)
+}
+
 // GetOrderBook отримує OrderBook для символу
 func (m *BybitManager) GetOrderBook(symbol string) *models.OrderBook {
 	m.mu.RLock()
@@ -208,6 +264,7 @@ func (m *BybitManager) handleMessages() {
 			if err != nil {
 				log.Printf("⚠️ Bybit read error: %v", err)
 				m.setConnected(false)
+				m.telemetry.RecordDisconnected(err)
 				return
 			}
 
@@ -241,17 +298,18 @@ func (m *BybitManager) processMessage(message []byte) {
 	}
 }
 
-// handleOrderBookUpdate обробляє оновлення OrderBook
+// handleOrderBookUpdate обробляє snapshot/delta оновлення OrderBook
 func (m *BybitManager) handleOrderBookUpdate(message []byte) {
 	var update struct {
-		Topic string `json:"topic"`
-		Type  string `json:"type"`
-		Data  struct {
-			Symbol    string          `json:"s"`
-			Bids      [][]interface{} `json:"b"`
-			Asks      [][]interface{} `json:"a"`
-			UpdateID  int64           `json:"u"`
-			Timestamp int64           `json:"t"`
+		Topic     string `json:"topic"`
+		Type      string `json:"type"` // "snapshot" або "delta"
+		Timestamp int64  `json:"ts"`   // Час формування повідомлення (мс)
+		Data      struct {
+			Symbol   string          `json:"s"`
+			Bids     [][]interface{} `json:"b"`
+			Asks     [][]interface{} `json:"a"`
+			UpdateID int64           `json:"u"`
+			Seq      int64           `json:"seq"`
 		} `json:"data"`
 	}
 
@@ -262,56 +320,110 @@ func (m *BybitManager) handleOrderBookUpdate(message []byte) {
 	// Parse symbol (BTCUSDT -> BTC/USDT)
 	symbol := denormalizeSymbol(update.Data.Symbol)
 
-	// Create OrderBook
-	ob := &models.OrderBook{
-		Exchange:   "bybit",
-		Symbol:     symbol,
-		LastUpdate: time.UnixMilli(update.Data.Timestamp),
-		Bids:       make([]models.PriceLevel, 0),
-		Asks:       make([]models.PriceLevel, 0),
+	bids := parseLevelArrays(update.Data.Bids)
+	asks := parseLevelArrays(update.Data.Asks)
+
+	m.syncMu.Lock()
+	state, ok := m.syncStates[symbol]
+	if !ok {
+		m.syncMu.Unlock()
+		return // Не підписані на символ
+	}
+
+	// Застаріле повідомлення (cross sequence не може зменшуватись)
+	if update.Data.Seq > 0 && update.Data.Seq < state.lastSeq {
+		m.syncMu.Unlock()
+		return
 	}
 
-	// Parse bids
-	for _, bid := range update.Data.Bids {
-		if len(bid) < 2 {
-			continue
+	ob := m.getOrCreateOrderBook(symbol)
+
+	// u = 1 означає snapshot після рестарту сервісу Bybit
+	if update.Type == "snapshot" || update.Data.UpdateID == 1 {
+		ob.Update(nonZeroLevels(bids), nonZeroLevels(asks), update.Data.UpdateID)
+		state.synced = true
+		state.resyncing = false
+	} else {
+		if !state.synced {
+			m.syncMu.Unlock()
+			return // Чекаємо на snapshot
 		}
 
-		price := parseFloat(bid[0])
-		quantity := parseFloat(bid[1])
+		if update.Data.UpdateID != state.lastUpdateID+1 {
+			log.Printf("⚠️ Bybit %s: sequence gap (last=%d, u=%d), resyncing...", symbol, state.lastUpdateID, update.Data.UpdateID)
+			m.telemetry.RecordError(fmt.Errorf("%s: %w", symbol, errSequenceGap))
+			state.synced = false
+			resyncing := state.resyncing
+			state.resyncing = true
+			m.syncMu.Unlock()
 
-		priceLevel := models.PriceLevel{
-			Price:    price,
-			Quantity: quantity,
+			if !resyncing {
+				go m.resync(symbol)
+			}
+			return
 		}
 
-		ob.Bids = append(ob.Bids, priceLevel)
+		ob.ApplyDelta(bids, asks, update.Data.UpdateID)
 	}
 
-	// Parse asks
-	for _, ask := range update.Data.Asks {
-		if len(ask) < 2 {
-			continue
-		}
+	state.lastUpdateID = update.Data.UpdateID
+	state.lastSeq = update.Data.Seq
+	m.syncMu.Unlock()
+
+	m.telemetry.RecordMessage(symbol, msToTime(update.Timestamp))
+
+	// Trigger callback
+	if m.onOrderBookUpdate != nil {
+		m.onOrderBookUpdate("bybit", symbol, ob)
+	}
+}
 
-		price := parseFloat(ask[0])
-		quantity := parseFloat(ask[1])
+// resync перепідписується на orderbook символу, щоб отримати новий snapshot
+func (m *BybitManager) resync(symbol string) {
+	topic := fmt.Sprintf("orderbook.50.%s", normalizeBybitSymbol(symbol))
 
-		ob.Asks = append(ob.Asks, models.PriceLevel{
-			Price:    price,
-			Quantity: quantity,
+	for _, op := range []string{"unsubscribe", "subscribe"} {
+		data, err := json.Marshal(map[string]interface{}{
+			"op":   op,
+			"args": []string{topic},
 		})
+		if err != nil {
+			return
+		}
+
+		if err := m.writeMessage(data); err != nil {
+			log.Printf("❌ Bybit %s: resync %s failed: %v", symbol, op, err)
+
+			m.syncMu.Lock()
+			if state, ok := m.syncStates[symbol]; ok {
+				state.resyncing = false
+			}
+			m.syncMu.Unlock()
+			return
+		}
 	}
+}
 
-	// Store OrderBook
+// getOrCreateOrderBook повертає OrderBook символу, створюючи за потреби
+func (m *BybitManager) getOrCreateOrderBook(symbol string) *models.OrderBook {
 	m.mu.Lock()
-	m.orderbooks[symbol] = ob
-	m.mu.Unlock()
+	defer m.mu.Unlock()
 
-	// Trigger callback
-	if m.onOrderBookUpdate != nil {
-		m.onOrderBookUpdate("bybit", symbol, ob)
+	ob, exists := m.orderbooks[symbol]
+	if !exists {
+		ob = models.NewOrderBook("bybit", symbol)
+		m.orderbooks[symbol] = ob
 	}
+
+	return ob
+}
+
+// bybitSyncState стан синхронізації ордербуку Bybit
+type bybitSyncState struct {
+	synced       bool
+	resyncing    bool
+	lastUpdateID int64 // u
+	lastSeq      int64 // seq
 }
 
 // handleTickerUpdate обробляє оновлення ticker
@@ -373,8 +485,9 @@ func (m *BybitManager) ping() {
 			}
 
 			data, _ := json.Marshal(pingMsg)
-			if err := m.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			if err := m.writeMessage(data); err != nil {
 				log.Printf("⚠️ Bybit ping error: %v", err)
+				m.telemetry.RecordError(err)
 			}
 		}
 	}
@@ -431,7 +544,7 @@ func (m *BybitManager) reconnect() error {
 
 	time.Sleep(2 * time.Second)
 
-	if err := m.Connect(m.ctx); err != nil {
+	if err := m.Connect(m.parent); err != nil {
 		return err
 	}
 
@@ -0,0 +1,88 @@
+package websocket
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// BybitPerpManager mark price та funding rate USDT perpetual (linear) Bybit
+// (topic tickers.<symbol>: snapshot, далі дельти лише зі зміненими полями)
+type BybitPerpManager struct {
+	*perpStream
+}
+
+// NewBybitPerpManager створює новий Bybit perpetual Manager
+func NewBybitPerpManager() *BybitPerpManager {
+	return &BybitPerpManager{perpStream: newPerpStream(bybitPerpAdapter{})}
+}
+
+type bybitPerpAdapter struct{}
+
+func (bybitPerpAdapter) exchange() string {
+	return "bybit"
+}
+
+func (bybitPerpAdapter) url() string {
+	return "wss://stream.bybit.com/v5/public/linear"
+}
+
+// nativeSymbol "BTC/USDT" -> "BTCUSDT"
+func (bybitPerpAdapter) nativeSymbol(symbol string) string {
+	return strings.ToUpper(strings.ReplaceAll(symbol, "/", ""))
+}
+
+func (bybitPerpAdapter) subscribeMessages(natives []string) []interface{} {
+	var messages []interface{}
+
+	for start := 0; start < len(natives); start += bybitArgsPerRequest {
+		end := start + bybitArgsPerRequest
+		if end > len(natives) {
+			end = len(natives)
+		}
+
+		args := make([]string, 0, end-start)
+		for _, native := range natives[start:end] {
+			args = append(args, "tickers."+native)
+		}
+
+		messages = append(messages, map[string]interface{}{
+			"op":   "subscribe",
+			"args": args,
+		})
+	}
+
+	return messages
+}
+
+// parse обробляє tickers: {"topic":"tickers.BTCUSDT","type":"snapshot|delta","data":{...}}
+func (bybitPerpAdapter) parse(message []byte) []fundingUpdate {
+	var msg struct {
+		Topic string                 `json:"topic"`
+		Data  map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil || !strings.HasPrefix(msg.Topic, "tickers.") {
+		return nil
+	}
+
+	update := fundingUpdate{
+		native:          strings.TrimPrefix(msg.Topic, "tickers."),
+		markPrice:       parseFloat(msg.Data["markPrice"]),
+		indexPrice:      parseFloat(msg.Data["indexPrice"]),
+		fundingRate:     optionalRate(msg.Data["fundingRate"]),
+		nextFundingTime: millisToTime(msg.Data["nextFundingTime"]),
+	}
+
+	// fundingIntervalHour присутній не у всіх версіях API
+	if interval := parseFloat(msg.Data["fundingIntervalHour"]); interval > 0 {
+		update.intervalHours = interval
+	}
+
+	return []fundingUpdate{update}
+}
+
+// ping Bybit очікує {"op":"ping"}
+func (bybitPerpAdapter) ping(conn *websocket.Conn) error {
+	return conn.WriteJSON(map[string]string{"op": "ping"})
+}
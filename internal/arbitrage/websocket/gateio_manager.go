@@ -0,0 +1,473 @@
+package websocket
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// gateIOBookDepth глибина limited-level ордербуку (5, 10, 20, 50, 100)
+	gateIOBookDepth = "20"
+
+	// gateIOBookInterval частота оновлень ордербуку (100ms або 1000ms)
+	gateIOBookInterval = "100ms"
+)
+
+// GateIOManager управляє WebSocket з'єднанням з Gate.io (API v4)
+// Ордербук: spot.order_book надсилає повний snapshot топ рівнів кожні 100ms
+type GateIOManager struct {
+	wsURL             string
+	conn              *websocket.Conn
+	writeMu           sync.Mutex
+	symbols           []string
+	orderbooks        map[string]*models.OrderBook
+	mu                sync.RWMutex
+	reconnectInterval time.Duration
+	pingInterval      time.Duration
+
+	// Callbacks
+	onOrderBookUpdate OrderBookCallback
+	onTicker          TickerCallback
+
+	telemetry *Telemetry
+
+	parent context.Context // Контекст власника: з нього створюється ctx при перепідключенні
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	connected bool
+	connMu    sync.RWMutex
+}
+
+// NewGateIOManager створює новий Gate.io WebSocket Manager
+func NewGateIOManager() *GateIOManager {
+	return &GateIOManager{
+		wsURL:             "wss://api.gateio.ws/ws/v4/",
+		orderbooks:        make(map[string]*models.OrderBook),
+		reconnectInterval: 5 * time.Second,
+		pingInterval:      20 * time.Second,
+		telemetry:         NewTelemetry(),
+	}
+}
+
+// GetExchange повертає назву біржі
+func (m *GateIOManager) GetExchange() string {
+	return "gateio"
+}
+
+// Telemetry повертає метрики фіду
+func (m *GateIOManager) Telemetry() *Telemetry {
+	return m.telemetry
+}
+
+// Connect підключається до Gate.io WebSocket
+func (m *GateIOManager) Connect(ctx context.Context) error {
+	m.parent = ctx
+	ctx, cancel := context.WithCancel(ctx)
+	m.ctx, m.cancel = ctx, cancel
+
+	conn, _, err := websocket.DefaultDialer.Dial(m.wsURL, nil)
+	if err != nil {
+		m.telemetry.RecordError(err)
+		return fmt.Errorf("failed to connect to Gate.io WS: %w", err)
+	}
+
+	m.writeMu.Lock()
+	m.conn = conn
+	m.writeMu.Unlock()
+
+	m.setConnected(true)
+	m.telemetry.RecordConnected()
+	log.Printf("✅ Connected to Gate.io WebSocket")
+
+	// Start message handler
+	go m.handleMessages(ctx, conn)
+
+	// Start ping/pong
+	go m.ping(ctx)
+
+	// Start connection watcher
+	go m.watchConnection(ctx)
+
+	return nil
+}
+
+// Disconnect від'єднується від WebSocket
+func (m *GateIOManager) Disconnect() error {
+	if m.cancel != nil {
+		m.cancel()
+	}
+
+	m.setConnected(false)
+	m.telemetry.RecordDisconnected(nil)
+
+	if m.conn != nil {
+		return m.conn.Close()
+	}
+
+	return nil
+}
+
+// Subscribe підписується на символи
+func (m *GateIOManager) Subscribe(symbols []string) error {
+	m.mu.Lock()
+	m.symbols = mergeSymbols(m.symbols, symbols)
+	m.mu.Unlock()
+
+	if !m.IsConnected() {
+		return fmt.Errorf("not connected")
+	}
+
+	pairs := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		// Normalize symbol: BTC/USDT -> BTC_USDT
+		normalized := normalizeGateIOSymbol(symbol)
+		pairs = append(pairs, normalized)
+
+		// spot.order_book приймає тільки одну пару на підписку
+		if err := m.sendRequest("spot.order_book", "subscribe", []string{normalized, gateIOBookDepth, gateIOBookInterval}); err != nil {
+			return fmt.Errorf("failed to send subscribe message: %w", err)
+		}
+	}
+
+	if err := m.sendRequest("spot.tickers", "subscribe", pairs); err != nil {
+		return fmt.Errorf("failed to send subscribe message: %w", err)
+	}
+
+	log.Printf("📡 Subscribed to %d symbols on Gate.io", len(symbols))
+	return nil
+}
+
+// Unsubscribe відписується від символів
+func (m *GateIOManager) Unsubscribe(symbols []string) error {
+	if !m.IsConnected() {
+		return fmt.Errorf("not connected")
+	}
+
+	pairs := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		normalized := normalizeGateIOSymbol(symbol)
+		pairs = append(pairs, normalized)
+
+		if err := m.sendRequest("spot.order_book", "unsubscribe", []string{normalized, gateIOBookDepth, gateIOBookInterval}); err != nil {
+			return fmt.Errorf("failed to send unsubscribe message: %w", err)
+		}
+	}
+
+	if err := m.sendRequest("spot.tickers", "unsubscribe", pairs); err != nil {
+		return fmt.Errorf("failed to send unsubscribe message: %w", err)
+	}
+
+	m.mu.Lock()
+	m.symbols = removeSymbols(m.symbols, symbols)
+	for _, symbol := range symbols {
+		delete(m.orderbooks, symbol)
+	}
+	m.mu.Unlock()
+
+	m.telemetry.ForgetSymbols(symbols)
+
+	return nil
+}
+
+// sendRequest надсилає запит у форматі Gate.io v4
+func (m *GateIOManager) sendRequest(channel, event string, payload []string) error {
+	req := map[string]interface{}{
+		"time":    time.Now().Unix(),
+		"channel": channel,
+	}
+	if event != "" {
+		req["event"] = event
+	}
+	if payload != nil {
+		req["payload"] = payload
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return m.writeMessage(data)
+}
+
+// writeMessage серіалізує запис у з'єднання (gorilla не підтримує конкурентний запис)
+func (m *GateIOManager) writeMessage(data []byte) error {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	return m.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// GetOrderBook отримує OrderBook для символу
+func (m *GateIOManager) GetOrderBook(symbol string) *models.OrderBook {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.orderbooks[symbol]
+}
+
+// OnOrderBookUpdate встановлює callback для оновлень OrderBook
+func (m *GateIOManager) OnOrderBookUpdate(callback OrderBookCallback) {
+	m.onOrderBookUpdate = callback
+}
+
+// OnTicker встановлює callback для ticker updates
+func (m *GateIOManager) OnTicker(callback TickerCallback) {
+	m.onTicker = callback
+}
+
+// IsConnected перевіряє статус з'єднання
+func (m *GateIOManager) IsConnected() bool {
+	m.connMu.RLock()
+	defer m.connMu.RUnlock()
+	return m.connected
+}
+
+// setConnected встановлює статус з'єднання
+func (m *GateIOManager) setConnected(connected bool) {
+	m.connMu.Lock()
+	m.connected = connected
+	m.connMu.Unlock()
+}
+
+// handleMessages обробляє вхідні повідомлення
+func (m *GateIOManager) handleMessages(ctx context.Context, conn *websocket.Conn) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("❌ Gate.io message handler panic: %v", r)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				log.Printf("⚠️ Gate.io read error: %v", err)
+				m.setConnected(false)
+				m.telemetry.RecordDisconnected(err)
+				return
+			}
+
+			m.processMessage(message)
+		}
+	}
+}
+
+// processMessage обробляє отримане повідомлення
+func (m *GateIOManager) processMessage(message []byte) {
+	var baseMsg struct {
+		Channel string `json:"channel"`
+		Event   string `json:"event"`
+		Error   *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if err := json.Unmarshal(message, &baseMsg); err != nil {
+		return
+	}
+
+	if baseMsg.Error != nil {
+		log.Printf("⚠️ Gate.io %s error: %s (code %d)", baseMsg.Channel, baseMsg.Error.Message, baseMsg.Error.Code)
+		return
+	}
+
+	// Subscription confirmation або pong
+	if baseMsg.Event != "update" {
+		return
+	}
+
+	switch baseMsg.Channel {
+	case "spot.order_book":
+		m.handleOrderBookUpdate(message)
+	case "spot.tickers":
+		m.handleTickerUpdate(message)
+	}
+}
+
+// handleOrderBookUpdate обробляє snapshot OrderBook
+func (m *GateIOManager) handleOrderBookUpdate(message []byte) {
+	var update struct {
+		Result struct {
+			Timestamp    int64           `json:"t"`
+			LastUpdateID int64           `json:"lastUpdateId"`
+			Symbol       string          `json:"s"`
+			Bids         [][]interface{} `json:"bids"`
+			Asks         [][]interface{} `json:"asks"`
+		} `json:"result"`
+	}
+
+	if err := json.Unmarshal(message, &update); err != nil {
+		return
+	}
+
+	// Parse symbol (BTC_USDT -> BTC/USDT)
+	symbol := denormalizeGateIOSymbol(update.Result.Symbol)
+
+	bids := nonZeroLevels(parseLevelArrays(update.Result.Bids))
+	asks := nonZeroLevels(parseLevelArrays(update.Result.Asks))
+
+	m.mu.Lock()
+	ob, exists := m.orderbooks[symbol]
+	if !exists {
+		ob = models.NewOrderBook("gateio", symbol)
+		m.orderbooks[symbol] = ob
+	}
+	m.mu.Unlock()
+
+	// Limited-level канал завжди надсилає повний snapshot
+	ob.Update(bids, asks, update.Result.LastUpdateID)
+	m.telemetry.RecordMessage(symbol, msToTime(update.Result.Timestamp))
+
+	// Trigger callback
+	if m.onOrderBookUpdate != nil {
+		m.onOrderBookUpdate("gateio", symbol, ob)
+	}
+}
+
+// handleTickerUpdate обробляє оновлення ticker
+func (m *GateIOManager) handleTickerUpdate(message []byte) {
+	var update struct {
+		Time   int64 `json:"time"`
+		Result struct {
+			CurrencyPair     string `json:"currency_pair"`
+			Last             string `json:"last"`
+			ChangePercentage string `json:"change_percentage"`
+			BaseVolume       string `json:"base_volume"`
+			QuoteVolume      string `json:"quote_volume"`
+		} `json:"result"`
+	}
+
+	if err := json.Unmarshal(message, &update); err != nil {
+		return
+	}
+
+	// Parse symbol
+	symbol := denormalizeGateIOSymbol(update.Result.CurrencyPair)
+
+	if m.onTicker != nil {
+		changePercent := parseFloat(update.Result.ChangePercentage)
+
+		tickerData := &TickerData{
+			Symbol:         symbol,
+			LastPrice:      parseFloat(update.Result.Last),
+			Volume24h:      parseFloat(update.Result.QuoteVolume), // Volume in quote currency (USDT)
+			PriceChange:    changePercent,
+			PriceChange24h: changePercent,
+			Timestamp:      time.Unix(update.Time, 0),
+		}
+
+		m.onTicker("gateio", symbol, tickerData)
+	}
+}
+
+// ping надсилає ping повідомлення
+func (m *GateIOManager) ping(ctx context.Context) {
+	ticker := time.NewTicker(m.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !m.IsConnected() {
+				continue
+			}
+
+			// Gate.io application-level ping (відповідь приходить в spot.pong)
+			if err := m.sendRequest("spot.ping", "", nil); err != nil {
+				log.Printf("⚠️ Gate.io ping error: %v", err)
+				m.telemetry.RecordError(err)
+			}
+		}
+	}
+}
+
+// watchConnection відслідковує стан з'єднання
+func (m *GateIOManager) watchConnection(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !m.IsConnected() {
+				log.Printf("⚠️ Gate.io connection lost, reconnecting...")
+				time.Sleep(m.reconnectInterval)
+
+				if err := m.reconnect(); err != nil {
+					log.Printf("❌ Gate.io reconnect failed: %v", err)
+				}
+			} else {
+				// Check for stale data
+				m.mu.RLock()
+				staleCount := 0
+				for _, ob := range m.orderbooks {
+					if ob.IsStale(30 * time.Second) {
+						staleCount++
+					}
+				}
+				total := len(m.orderbooks)
+				m.mu.RUnlock()
+
+				// If more than 50% orderbooks are stale, reconnect
+				if total > 0 && staleCount > total/2 {
+					log.Printf("⚠️ Gate.io: %d/%d orderbooks stale, reconnecting...", staleCount, total)
+					if err := m.reconnect(); err != nil {
+						log.Print(err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// reconnect перепідключається до WebSocket
+func (m *GateIOManager) reconnect() error {
+	if err := m.Disconnect(); err != nil {
+		log.Print(err)
+	}
+
+	time.Sleep(2 * time.Second)
+
+	if err := m.Connect(m.parent); err != nil {
+		return err
+	}
+
+	// Re-subscribe to symbols
+	m.mu.RLock()
+	symbols := m.symbols
+	m.mu.RUnlock()
+
+	if len(symbols) > 0 {
+		return m.Subscribe(symbols)
+	}
+
+	return nil
+}
+
+// normalizeGateIOSymbol нормалізує символ для Gate.io (BTC/USDT -> BTC_USDT)
+func normalizeGateIOSymbol(symbol string) string {
+	return strings.ReplaceAll(strings.ToUpper(symbol), "/", "_")
+}
+
+// denormalizeGateIOSymbol денормалізує символ з Gate.io (BTC_USDT -> BTC/USDT)
+func denormalizeGateIOSymbol(symbol string) string {
+	return strings.ReplaceAll(symbol, "_", "/")
+}
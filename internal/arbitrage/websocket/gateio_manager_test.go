@@ -0,0 +1,93 @@
+package websocket
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestGateIOSymbols(t *testing.T) {
+	if got := normalizeGateIOSymbol("btc/usdt"); got != "BTC_USDT" {
+		t.Errorf("normalizeGateIOSymbol = %q, want BTC_USDT", got)
+	}
+	if got := denormalizeGateIOSymbol("ETH_USDC"); got != "ETH/USDC" {
+		t.Errorf("denormalizeGateIOSymbol = %q, want ETH/USDC", got)
+	}
+}
+
+func TestGateIOProcessMessage(t *testing.T) {
+	m := NewGateIOManager()
+
+	var updates int
+	m.OnOrderBookUpdate(func(exchange, symbol string, _ *models.OrderBook) {
+		updates++
+	})
+
+	var ticker *TickerData
+	m.OnTicker(func(exchange, symbol string, data *TickerData) {
+		ticker = data
+	})
+
+	messages := []string{
+		// Підтвердження підписки та помилки не змінюють стан
+		`{"time":1700000000,"channel":"spot.order_book","event":"subscribe","result":{"status":"success"}}`,
+		`{"time":1700000000,"channel":"spot.tickers","event":"subscribe","error":{"code":2,"message":"unknown currency pair"}}`,
+		`{"time":1700000000,"channel":"spot.order_book","event":"update","result":{"t":1700000000123,"lastUpdateId":100,"s":"BTC_USDT","bids":[["65000.1","0.5"],["64999","0"]],"asks":[["65001.2","1.25"]]}}`,
+		`{"time":1700000001,"channel":"spot.tickers","event":"update","result":{"currency_pair":"BTC_USDT","last":"65000.5","change_percentage":"-1.5","base_volume":"10","quote_volume":"650000"}}`,
+	}
+	for _, message := range messages {
+		m.processMessage([]byte(message))
+	}
+
+	ob := m.GetOrderBook("BTC/USDT")
+	if ob == nil || updates != 1 {
+		t.Fatalf("Expected one order book update for BTC/USDT, got %d", updates)
+	}
+	if len(ob.Bids) != 1 || ob.Bids[0].Price != 65000.1 || ob.Asks[0].Quantity != 1.25 || ob.LastUpdateID != 100 {
+		t.Errorf("Unexpected order book: bids %v asks %v id %d", ob.Bids, ob.Asks, ob.LastUpdateID)
+	}
+
+	if ticker == nil || ticker.Symbol != "BTC/USDT" || ticker.LastPrice != 65000.5 ||
+		ticker.Volume24h != 650000 || ticker.PriceChange24h != -1.5 {
+		t.Errorf("Unexpected ticker: %+v", ticker)
+	}
+}
+
+func TestGateIOReconnectKeepsStreaming(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	m := NewGateIOManager()
+	m.wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	if err := m.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer m.Disconnect()
+
+	if err := m.reconnect(); err != nil {
+		t.Fatalf("reconnect failed: %v", err)
+	}
+
+	// Новий контекст створюється з контексту власника, а не зі скасованого
+	if err := m.ctx.Err(); err != nil || !m.IsConnected() {
+		t.Errorf("Expected live connection after reconnect, ctx err %v", err)
+	}
+}
@@ -0,0 +1,516 @@
+package websocket
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// krakenBookDepth глибина ордербуку (10, 25, 100, 500, 1000)
+const krakenBookDepth = 25
+
+// KrakenManager управляє WebSocket з'єднанням з Kraken (API v2)
+// Ордербук: snapshot + update повідомлення; рівні що виходять за межі глибини
+// не видаляються біржею, тому книга обрізається після кожного оновлення
+type KrakenManager struct {
+	wsURL             string
+	conn              *websocket.Conn
+	writeMu           sync.Mutex
+	symbols           []string
+	orderbooks        map[string]*models.OrderBook
+	mu                sync.RWMutex
+	reconnectInterval time.Duration
+	pingInterval      time.Duration
+
+	// Callbacks
+	onOrderBookUpdate OrderBookCallback
+	onTicker          TickerCallback
+
+	telemetry *Telemetry
+
+	parent context.Context // Контекст власника: з нього створюється ctx при перепідключенні
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	connected bool
+	connMu    sync.RWMutex
+}
+
+// NewKrakenManager створює новий Kraken WebSocket Manager
+func NewKrakenManager() *KrakenManager {
+	return &KrakenManager{
+		wsURL:             "wss://ws.kraken.com/v2",
+		orderbooks:        make(map[string]*models.OrderBook),
+		reconnectInterval: 5 * time.Second,
+		pingInterval:      20 * time.Second,
+		telemetry:         NewTelemetry(),
+	}
+}
+
+// GetExchange повертає назву біржі
+func (m *KrakenManager) GetExchange() string {
+	return "kraken"
+}
+
+// Telemetry повертає метрики фіду
+func (m *KrakenManager) Telemetry() *Telemetry {
+	return m.telemetry
+}
+
+// Connect підключається до Kraken WebSocket
+func (m *KrakenManager) Connect(ctx context.Context) error {
+	m.parent = ctx
+	ctx, cancel := context.WithCancel(ctx)
+	m.ctx, m.cancel = ctx, cancel
+
+	conn, _, err := websocket.DefaultDialer.Dial(m.wsURL, nil)
+	if err != nil {
+		m.telemetry.RecordError(err)
+		return fmt.Errorf("failed to connect to Kraken WS: %w", err)
+	}
+
+	m.writeMu.Lock()
+	m.conn = conn
+	m.writeMu.Unlock()
+
+	m.setConnected(true)
+	m.telemetry.RecordConnected()
+	log.Printf("✅ Connected to Kraken WebSocket")
+
+	// Start message handler
+	go m.handleMessages(ctx, conn)
+
+	// Start ping/pong
+	go m.ping(ctx)
+
+	// Start connection watcher
+	go m.watchConnection(ctx)
+
+	return nil
+}
+
+// Disconnect від'єднується від WebSocket
+func (m *KrakenManager) Disconnect() error {
+	if m.cancel != nil {
+		m.cancel()
+	}
+
+	m.setConnected(false)
+	m.telemetry.RecordDisconnected(nil)
+
+	if m.conn != nil {
+		return m.conn.Close()
+	}
+
+	return nil
+}
+
+// Subscribe підписується на символи
+func (m *KrakenManager) Subscribe(symbols []string) error {
+	m.mu.Lock()
+	m.symbols = mergeSymbols(m.symbols, symbols)
+	m.mu.Unlock()
+
+	if !m.IsConnected() {
+		return fmt.Errorf("not connected")
+	}
+
+	// Normalize symbols: BTC/USDT -> BTC/USDT (Kraken v2 використовує формат з "/")
+	normalized := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		normalized = append(normalized, normalizeKrakenSymbol(symbol))
+	}
+
+	if err := m.sendRequest("subscribe", map[string]interface{}{
+		"channel":  "book",
+		"symbol":   normalized,
+		"depth":    krakenBookDepth,
+		"snapshot": true,
+	}); err != nil {
+		return fmt.Errorf("failed to send subscribe message: %w", err)
+	}
+
+	if err := m.sendRequest("subscribe", map[string]interface{}{
+		"channel": "ticker",
+		"symbol":  normalized,
+	}); err != nil {
+		return fmt.Errorf("failed to send subscribe message: %w", err)
+	}
+
+	log.Printf("📡 Subscribed to %d symbols on Kraken", len(symbols))
+	return nil
+}
+
+// Unsubscribe відписується від символів
+func (m *KrakenManager) Unsubscribe(symbols []string) error {
+	if !m.IsConnected() {
+		return fmt.Errorf("not connected")
+	}
+
+	normalized := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		normalized = append(normalized, normalizeKrakenSymbol(symbol))
+	}
+
+	if err := m.sendRequest("unsubscribe", map[string]interface{}{
+		"channel": "book",
+		"symbol":  normalized,
+		"depth":   krakenBookDepth,
+	}); err != nil {
+		return fmt.Errorf("failed to send unsubscribe message: %w", err)
+	}
+
+	if err := m.sendRequest("unsubscribe", map[string]interface{}{
+		"channel": "ticker",
+		"symbol":  normalized,
+	}); err != nil {
+		return fmt.Errorf("failed to send unsubscribe message: %w", err)
+	}
+
+	m.mu.Lock()
+	m.symbols = removeSymbols(m.symbols, symbols)
+	for _, symbol := range symbols {
+		delete(m.orderbooks, symbol)
+	}
+	m.mu.Unlock()
+
+	m.telemetry.ForgetSymbols(symbols)
+
+	return nil
+}
+
+// sendRequest надсилає запит у форматі Kraken v2 ({"method": ..., "params": ...})
+func (m *KrakenManager) sendRequest(method string, params map[string]interface{}) error {
+	req := map[string]interface{}{
+		"method": method,
+	}
+	if params != nil {
+		req["params"] = params
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return m.writeMessage(data)
+}
+
+// writeMessage серіалізує запис у з'єднання (gorilla не підтримує конкурентний запис)
+func (m *KrakenManager) writeMessage(data []byte) error {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	return m.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// GetOrderBook отримує OrderBook для символу
+func (m *KrakenManager) GetOrderBook(symbol string) *models.OrderBook {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.orderbooks[symbol]
+}
+
+// OnOrderBookUpdate встановлює callback для оновлень OrderBook
+func (m *KrakenManager) OnOrderBookUpdate(callback OrderBookCallback) {
+	m.onOrderBookUpdate = callback
+}
+
+// OnTicker встановлює callback для ticker updates
+func (m *KrakenManager) OnTicker(callback TickerCallback) {
+	m.onTicker = callback
+}
+
+// IsConnected перевіряє статус з'єднання
+func (m *KrakenManager) IsConnected() bool {
+	m.connMu.RLock()
+	defer m.connMu.RUnlock()
+	return m.connected
+}
+
+// setConnected встановлює статус з'єднання
+func (m *KrakenManager) setConnected(connected bool) {
+	m.connMu.Lock()
+	m.connected = connected
+	m.connMu.Unlock()
+}
+
+// handleMessages обробляє вхідні повідомлення
+func (m *KrakenManager) handleMessages(ctx context.Context, conn *websocket.Conn) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("❌ Kraken message handler panic: %v", r)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				log.Printf("⚠️ Kraken read error: %v", err)
+				m.setConnected(false)
+				m.telemetry.RecordDisconnected(err)
+				return
+			}
+
+			m.processMessage(message)
+		}
+	}
+}
+
+// processMessage обробляє отримане повідомлення
+func (m *KrakenManager) processMessage(message []byte) {
+	var baseMsg struct {
+		Method  string `json:"method"`
+		Channel string `json:"channel"`
+		Type    string `json:"type"`
+		Success *bool  `json:"success"`
+		Error   string `json:"error"`
+	}
+
+	if err := json.Unmarshal(message, &baseMsg); err != nil {
+		return
+	}
+
+	// Відповідь на subscribe/unsubscribe/ping
+	if baseMsg.Method != "" {
+		if baseMsg.Success != nil && !*baseMsg.Success {
+			log.Printf("⚠️ Kraken %s error: %s", baseMsg.Method, baseMsg.Error)
+		}
+		return
+	}
+
+	switch baseMsg.Channel {
+	case "book":
+		m.handleOrderBookUpdate(baseMsg.Type, message)
+	case "ticker":
+		m.handleTickerUpdate(message)
+	}
+}
+
+// krakenLevel рівень ордербуку Kraken v2
+type krakenLevel struct {
+	Price float64 `json:"price"`
+	Qty   float64 `json:"qty"`
+}
+
+// handleOrderBookUpdate обробляє snapshot/update OrderBook
+func (m *KrakenManager) handleOrderBookUpdate(msgType string, message []byte) {
+	var update struct {
+		Data []struct {
+			Symbol    string        `json:"symbol"`
+			Bids      []krakenLevel `json:"bids"`
+			Asks      []krakenLevel `json:"asks"`
+			Timestamp time.Time     `json:"timestamp"` // RFC3339, тільки в update
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(message, &update); err != nil {
+		return
+	}
+
+	for _, data := range update.Data {
+		// Parse symbol (XBT/USDT -> BTC/USDT)
+		symbol := DenormalizeKrakenSymbol(data.Symbol)
+
+		bids := toKrakenPriceLevels(data.Bids)
+		asks := toKrakenPriceLevels(data.Asks)
+
+		m.mu.Lock()
+		ob, exists := m.orderbooks[symbol]
+		if !exists {
+			ob = models.NewOrderBook("kraken", symbol)
+			m.orderbooks[symbol] = ob
+		}
+		m.mu.Unlock()
+
+		// Kraken не надсилає update ID - використовуємо лічильник
+		updateID := ob.LastUpdateID + 1
+
+		if msgType == "snapshot" {
+			ob.Update(nonZeroLevels(bids), nonZeroLevels(asks), updateID)
+		} else {
+			ob.ApplyDelta(bids, asks, updateID)
+			ob.Truncate(krakenBookDepth)
+		}
+
+		m.telemetry.RecordMessage(symbol, data.Timestamp)
+
+		// Trigger callback
+		if m.onOrderBookUpdate != nil {
+			m.onOrderBookUpdate("kraken", symbol, ob)
+		}
+	}
+}
+
+// handleTickerUpdate обробляє оновлення ticker
+func (m *KrakenManager) handleTickerUpdate(message []byte) {
+	var update struct {
+		Data []struct {
+			Symbol    string  `json:"symbol"`
+			Last      float64 `json:"last"`
+			Volume    float64 `json:"volume"` // Volume in base currency
+			Change    float64 `json:"change"`
+			ChangePct float64 `json:"change_pct"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(message, &update); err != nil {
+		return
+	}
+
+	if m.onTicker == nil {
+		return
+	}
+
+	for _, data := range update.Data {
+		symbol := DenormalizeKrakenSymbol(data.Symbol)
+
+		tickerData := &TickerData{
+			Symbol:         symbol,
+			LastPrice:      data.Last,
+			Volume24h:      data.Volume * data.Last,
+			PriceChange:    data.Change,
+			PriceChange24h: data.ChangePct,
+			Timestamp:      time.Now(),
+		}
+
+		m.onTicker("kraken", symbol, tickerData)
+	}
+}
+
+// ping надсилає ping повідомлення
+func (m *KrakenManager) ping(ctx context.Context) {
+	ticker := time.NewTicker(m.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !m.IsConnected() {
+				continue
+			}
+
+			// Kraken application-level ping ({"method": "ping"} -> {"method": "pong"})
+			if err := m.sendRequest("ping", nil); err != nil {
+				log.Printf("⚠️ Kraken ping error: %v", err)
+				m.telemetry.RecordError(err)
+			}
+		}
+	}
+}
+
+// watchConnection відслідковує стан з'єднання
+func (m *KrakenManager) watchConnection(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !m.IsConnected() {
+				log.Printf("⚠️ Kraken connection lost, reconnecting...")
+				time.Sleep(m.reconnectInterval)
+
+				if err := m.reconnect(); err != nil {
+					log.Printf("❌ Kraken reconnect failed: %v", err)
+				}
+			} else {
+				// Check for stale data
+				m.mu.RLock()
+				staleCount := 0
+				for _, ob := range m.orderbooks {
+					if ob.IsStale(30 * time.Second) {
+						staleCount++
+					}
+				}
+				total := len(m.orderbooks)
+				m.mu.RUnlock()
+
+				// If more than 50% orderbooks are stale, reconnect
+				if total > 0 && staleCount > total/2 {
+					log.Printf("⚠️ Kraken: %d/%d orderbooks stale, reconnecting...", staleCount, total)
+					if err := m.reconnect(); err != nil {
+						log.Print(err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// reconnect перепідключається до WebSocket
+func (m *KrakenManager) reconnect() error {
+	if err := m.Disconnect(); err != nil {
+		log.Print(err)
+	}
+
+	time.Sleep(2 * time.Second)
+
+	if err := m.Connect(m.parent); err != nil {
+		return err
+	}
+
+	// Re-subscribe to symbols
+	m.mu.RLock()
+	symbols := m.symbols
+	m.mu.RUnlock()
+
+	if len(symbols) > 0 {
+		return m.Subscribe(symbols)
+	}
+
+	return nil
+}
+
+// toKrakenPriceLevels конвертує рівні Kraken в PriceLevel (qty = 0 означає видалення)
+func toKrakenPriceLevels(levels []krakenLevel) []models.PriceLevel {
+	result := make([]models.PriceLevel, 0, len(levels))
+	for _, level := range levels {
+		if level.Price <= 0 {
+			continue
+		}
+		result = append(result, models.PriceLevel{
+			Price:    level.Price,
+			Quantity: level.Qty,
+		})
+	}
+	return result
+}
+
+// krakenAliases легасі коди активів Kraken -> загальноприйняті
+var krakenAliases = map[string]string{
+	"XBT": "BTC",
+	"XDG": "DOGE",
+}
+
+// normalizeKrakenSymbol нормалізує символ для Kraken (btc/usdt -> BTC/USDT)
+func normalizeKrakenSymbol(symbol string) string {
+	return strings.ToUpper(symbol)
+}
+
+// DenormalizeKrakenSymbol денормалізує символ з Kraken (XBT/USDT -> BTC/USDT)
+func DenormalizeKrakenSymbol(symbol string) string {
+	parts := strings.Split(symbol, "/")
+	for i, part := range parts {
+		if alias, ok := krakenAliases[part]; ok {
+			parts[i] = alias
+		}
+	}
+	return strings.Join(parts, "/")
+}
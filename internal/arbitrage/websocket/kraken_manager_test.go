@@ -0,0 +1,101 @@
+package websocket
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestKrakenSymbols(t *testing.T) {
+	if got := normalizeKrakenSymbol("btc/usdt"); got != "BTC/USDT" {
+		t.Errorf("normalizeKrakenSymbol = %q, want BTC/USDT", got)
+	}
+
+	tests := map[string]string{
+		"XBT/USDT": "BTC/USDT",
+		"XDG/USD":  "DOGE/USD",
+		"ETH/USDC": "ETH/USDC",
+	}
+	for symbol, want := range tests {
+		if got := DenormalizeKrakenSymbol(symbol); got != want {
+			t.Errorf("DenormalizeKrakenSymbol(%q) = %q, want %q", symbol, got, want)
+		}
+	}
+}
+
+// krakenBookMessage повідомлення book з bids від 100 вниз (count рівнів)
+func krakenBookMessage(msgType string, bids string, count int) string {
+	if bids == "" {
+		levels := make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			levels = append(levels, fmt.Sprintf(`{"price":%d,"qty":1}`, 100-i))
+		}
+		bids = strings.Join(levels, ",")
+	}
+
+	return fmt.Sprintf(`{"channel":"book","type":%q,"data":[{"symbol":"XBT/USDT","bids":[%s],"asks":[{"price":101,"qty":2}],"checksum":1,"timestamp":"2025-03-08T12:00:00.000000Z"}]}`, msgType, bids)
+}
+
+func TestKrakenOrderBookSnapshotAndUpdate(t *testing.T) {
+	m := NewKrakenManager()
+
+	var updates int
+	m.OnOrderBookUpdate(func(exchange, symbol string, _ *models.OrderBook) {
+		if exchange != "kraken" || symbol != "BTC/USDT" {
+			t.Errorf("Unexpected callback %s %s", exchange, symbol)
+		}
+		updates++
+	})
+
+	// Відповідь на subscribe не створює книгу
+	m.processMessage([]byte(`{"method":"subscribe","success":true,"result":{"channel":"book","symbol":"XBT/USDT"}}`))
+	if m.GetOrderBook("BTC/USDT") != nil {
+		t.Fatal("Expected no order book before snapshot")
+	}
+
+	m.processMessage([]byte(krakenBookMessage("snapshot", "", krakenBookDepth)))
+
+	ob := m.GetOrderBook("BTC/USDT")
+	if ob == nil || len(ob.Bids) != krakenBookDepth || ob.Bids[0].Price != 100 || ob.LastUpdateID != 1 {
+		t.Fatalf("Unexpected snapshot book: %+v", ob)
+	}
+
+	// Update: новий найкращий bid, видалення рівня 99; книга обрізається до глибини
+	m.processMessage([]byte(krakenBookMessage("update", `{"price":100.5,"qty":3},{"price":99,"qty":0}`, 0)))
+
+	if len(ob.Bids) != krakenBookDepth {
+		t.Errorf("Expected book truncated to %d bids, got %d", krakenBookDepth, len(ob.Bids))
+	}
+	if ob.Bids[0].Price != 100.5 || ob.Bids[1].Price != 100 || ob.Bids[2].Price != 98 {
+		t.Errorf("Unexpected bids after update: %v", ob.Bids[:3])
+	}
+	if ob.Asks[0].Price != 101 || ob.Asks[0].Quantity != 2 || ob.LastUpdateID != 2 {
+		t.Errorf("Unexpected asks/update id after update: %v %d", ob.Asks, ob.LastUpdateID)
+	}
+
+	// Новий snapshot повністю замінює книгу
+	m.processMessage([]byte(krakenBookMessage("snapshot", `{"price":90,"qty":1}`, 0)))
+	if len(ob.Bids) != 1 || ob.Bids[0].Price != 90 {
+		t.Errorf("Expected snapshot to replace book, got %v", ob.Bids)
+	}
+
+	if updates != 3 {
+		t.Errorf("Expected 3 order book callbacks, got %d", updates)
+	}
+}
+
+func TestKrakenTicker(t *testing.T) {
+	m := NewKrakenManager()
+
+	var ticker *TickerData
+	m.OnTicker(func(exchange, symbol string, data *TickerData) {
+		ticker = data
+	})
+
+	m.processMessage([]byte(`{"channel":"ticker","type":"update","data":[{"symbol":"XBT/USDT","last":65000,"volume":2,"change":-500,"change_pct":-0.76}]}`))
+
+	if ticker == nil || ticker.Symbol != "BTC/USDT" || ticker.Volume24h != 130000 || ticker.PriceChange24h != -0.76 {
+		t.Errorf("Unexpected ticker: %+v", ticker)
+	}
+}
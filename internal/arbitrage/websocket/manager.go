@@ -35,6 +35,9 @@ type Manager interface {
 
 	// GetExchange назва біржі
 	GetExchange() string
+
+	// Telemetry метрики фіду (затримка, частота оновлень, перепідключення)
+	Telemetry() *Telemetry
 }
 
 // OrderBookCallback функція для обробки оновлень OrderBook
@@ -68,3 +71,36 @@ func parseFloat(val interface{}) float64 {
 		return 0
 	}
 }
+
+// mergeSymbols додає нові символи до підписаних (для перепідключення)
+func mergeSymbols(current, added []string) []string {
+	seen := make(map[string]bool, len(current)+len(added))
+	merged := make([]string, 0, len(current)+len(added))
+
+	for _, symbol := range append(append([]string{}, current...), added...) {
+		if seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		merged = append(merged, symbol)
+	}
+
+	return merged
+}
+
+// removeSymbols прибирає символи з підписаних
+func removeSymbols(current, removed []string) []string {
+	drop := make(map[string]bool, len(removed))
+	for _, symbol := range removed {
+		drop[symbol] = true
+	}
+
+	kept := make([]string, 0, len(current))
+	for _, symbol := range current {
+		if !drop[symbol] {
+			kept = append(kept, symbol)
+		}
+	}
+
+	return kept
+}
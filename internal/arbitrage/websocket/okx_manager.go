@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,13 +15,20 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// okxBookChannel публічний канал ордербуку з checksum (books50-l2-tbt потребує VIP login)
+const okxBookChannel = "books"
+
 // OKXManager управляє WebSocket з'єднанням з OKX
+// Ордербук: snapshot + update повідомлення з seqId/prevSeqId та CRC32 checksum
 type OKXManager struct {
 	wsURL             string
 	conn              *websocket.Conn
+	writeMu           sync.Mutex
 	symbols           []string
 	orderbooks        map[string]*models.OrderBook
 	mu                sync.RWMutex
+	localBooks        map[string]*okxLocalBook
+	syncMu            sync.Mutex
 	reconnectInterval time.Duration
 	pingInterval      time.Duration
 
@@ -28,6 +36,9 @@ type OKXManager struct {
 	onOrderBookUpdate OrderBookCallback
 	onTicker          TickerCallback
 
+	telemetry *Telemetry
+
+	parent context.Context // Контекст власника: з нього створюється ctx при перепідключенні
 	ctx    context.Context
 	cancel context.CancelFunc
 
@@ -40,8 +51,10 @@ func NewOKXManager() *OKXManager {
 	return &OKXManager{
 		wsURL:             "wss://ws.okx.com:8443/ws/v5/public",
 		orderbooks:        make(map[string]*models.OrderBook),
+		localBooks:        make(map[string]*okxLocalBook),
 		reconnectInterval: 5 * time.Second,
 		pingInterval:      20 * time.Second,
+		telemetry:         NewTelemetry(),
 	}
 }
 
@@ -50,17 +63,25 @@ func (m *OKXManager) GetExchange() string {
 	return "okx"
 }
 
+// Telemetry повертає метрики фіду
+func (m *OKXManager) Telemetry() *Telemetry {
+	return m.telemetry
+}
+
 // Connect підключається до OKX WebSocket
 func (m *OKXManager) Connect(ctx context.Context) error {
+	m.parent = ctx
 	m.ctx, m.cancel = context.WithCancel(ctx)
 
 	conn, _, err := websocket.DefaultDialer.Dial(m.wsURL, nil)
 	if err != nil {
+		m.telemetry.RecordError(err)
 		return fmt.Errorf("failed to connect to OKX WS: %w", err)
 	}
 
 	m.conn = conn
 	m.setConnected(true)
+	m.telemetry.RecordConnected()
 	log.Printf("✅ Connected to OKX WebSocket")
 
 	// Start message handler
@@ -82,6 +103,7 @@ func (m *OKXManager) Disconnect() error {
 	}
 
 	m.setConnected(false)
+	m.telemetry.RecordDisconnected(nil)
 
 	if m.conn != nil {
 		return m.conn.Close()
@@ -93,7 +115,7 @@ func (m *OKXManager) Disconnect() error {
 // Subscribe підписується на символи
 func (m *OKXManager) Subscribe(symbols []string) error {
 	m.mu.Lock()
-	m.symbols = symbols
+	m.symbols = mergeSymbols(m.symbols, symbols)
 	m.mu.Unlock()
 
 	if !m.IsConnected() {
@@ -106,9 +128,9 @@ func (m *OKXManager) Subscribe(symbols []string) error {
 		// Normalize symbol: BTC/USDT -> BTC-USDT
 		normalized := normalizeOKXSymbol(symbol)
 
-		// Subscribe to orderbook (books50-l2-tbt = top 50 levels, tick-by-tick)
+		// Subscribe to orderbook (books = 400 levels, snapshot + incremental updates with checksum)
 		args = append(args, map[string]string{
-			"channel": "books50-l2-tbt",
+			"channel": okxBookChannel,
 			"instId":  normalized,
 		})
 
@@ -129,7 +151,14 @@ func (m *OKXManager) Subscribe(symbols []string) error {
 		return fmt.Errorf("failed to marshal subscribe message: %w", err)
 	}
 
-	if err := m.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+	// Чекаємо на snapshot для кожного символу
+	m.syncMu.Lock()
+	for _, symbol := range symbols {
+		m.localBooks[symbol] = newOKXLocalBook()
+	}
+	m.syncMu.Unlock()
+
+	if err := m.writeMessage(data); err != nil {
 		return fmt.Errorf("failed to send subscribe message: %w", err)
 	}
 
@@ -149,7 +178,7 @@ func (m *OKXManager) Unsubscribe(symbols []string) error {
 		normalized := normalizeOKXSymbol(symbol)
 
 		args = append(args, map[string]string{
-			"channel": "books50-l2-tbt",
+			"channel": okxBookChannel,
 			"instId":  normalized,
 		})
 
@@ -169,13 +198,36 @@ func (m *OKXManager) Unsubscribe(symbols []string) error {
 		return fmt.Errorf("failed to marshal unsubscribe message: %w", err)
 	}
 
-	if err := m.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+	m.syncMu.Lock()
+	for _, symbol := range symbols {
+		delete(m.localBooks, symbol)
+	}
+	m.syncMu.Unlock()
+
+	if err := m.writeMessage(data); err != nil {
 		return fmt.Errorf("failed to send unsubscribe message: %w", err)
 	}
 
+	m.mu.Lock()
+	m.symbols = removeSymbols(m.symbols, symbols)
+	for _, symbol := range symbols {
+		delete(m.orderbooks, symbol)
+	}
+	m.mu.Unlock()
+
+	m.telemetry.ForgetSymbols(symbols)
+
 	return nil
 }
 
+// writeMessage серіалізує запис у з'єднання (gorilla не підтримує конкурентний запис)
+func (m *OKXManager) writeMessage(data []byte) error {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	return m.conn.WriteMessage(websocket.TextMessage, data)
+}
+
 // GetOrderBook отримує OrderBook для символу
 func (m *OKXManager) GetOrderBook(symbol string) *models.OrderBook {
 	m.mu.RLock()
@@ -225,6 +277,7 @@ func (m *OKXManager) handleMessages() {
 			if err != nil {
 				log.Printf("⚠️ OKX read error: %v", err)
 				m.setConnected(false)
+				m.telemetry.RecordDisconnected(err)
 				return
 			}
 
@@ -260,24 +313,28 @@ func (m *OKXManager) processMessage(message []byte) {
 
 	// Check channel
 	switch baseMsg.Arg.Channel {
-	case "books50-l2-tbt":
+	case okxBookChannel:
 		m.handleOrderBookUpdate(message)
 	case "tickers":
 		m.handleTickerUpdate(message)
 	}
 }
 
-// handleOrderBookUpdate обробляє оновлення OrderBook
+// handleOrderBookUpdate обробляє snapshot/update OrderBook з перевіркою seqId та checksum
 func (m *OKXManager) handleOrderBookUpdate(message []byte) {
 	var update struct {
 		Arg struct {
 			Channel string `json:"channel"`
 			InstID  string `json:"instId"`
 		} `json:"arg"`
-		Data []struct {
+		Action string `json:"action"` // "snapshot" або "update"
+		Data   []struct {
 			Asks      [][]string `json:"asks"`
 			Bids      [][]string `json:"bids"`
 			Timestamp string     `json:"ts"`
+			Checksum  int32      `json:"checksum"`
+			SeqID     int64      `json:"seqId"`
+			PrevSeqID int64      `json:"prevSeqId"`
 		} `json:"data"`
 	}
 
@@ -294,59 +351,109 @@ func (m *OKXManager) handleOrderBookUpdate(message []byte) {
 	// Parse symbol (BTC-USDT -> BTC/USDT)
 	symbol := denormalizeOKXSymbol(update.Arg.InstID)
 
-	// Parse timestamp
-	ts := parseFloat(data.Timestamp)
-	timestamp := time.UnixMilli(int64(ts))
-
-	// Create OrderBook
-	ob := &models.OrderBook{
-		Exchange:   "okx",
-		Symbol:     symbol,
-		LastUpdate: timestamp,
-		Bids:       make([]models.PriceLevel, 0),
-		Asks:       make([]models.PriceLevel, 0),
+	m.syncMu.Lock()
+	book, ok := m.localBooks[symbol]
+	if !ok {
+		m.syncMu.Unlock()
+		return // Не підписані на символ
 	}
 
-	// Parse bids
-	for _, bid := range data.Bids {
-		if len(bid) < 2 {
-			continue
+	if update.Action == "snapshot" {
+		book.reset()
+	} else {
+		if !book.synced {
+			m.syncMu.Unlock()
+			return // Чекаємо на snapshot
 		}
 
-		price := parseFloat(bid[0])
-		quantity := parseFloat(bid[1])
+		if data.PrevSeqID != book.seqID {
+			log.Printf("⚠️ OKX %s: sequence gap (last=%d, prevSeqId=%d), resyncing...", symbol, book.seqID, data.PrevSeqID)
+			m.telemetry.RecordError(fmt.Errorf("%s: %w", symbol, errSequenceGap))
+			m.markForResync(symbol, book)
+			return
+		}
+	}
 
-		ob.Bids = append(ob.Bids, models.PriceLevel{
-			Price:    price,
-			Quantity: quantity,
-		})
+	book.apply(data.Bids, data.Asks)
+	book.seqID = data.SeqID
+
+	bids, asks := book.sorted()
+	if checksum := okxChecksum(bids, asks); checksum != data.Checksum {
+		log.Printf("⚠️ OKX %s: checksum mismatch (local=%d, remote=%d), resyncing...", symbol, checksum, data.Checksum)
+		m.telemetry.RecordError(fmt.Errorf("%s: orderbook checksum mismatch", symbol))
+		m.markForResync(symbol, book)
+		return
 	}
 
-	// Parse asks
-	for _, ask := range data.Asks {
-		if len(ask) < 2 {
-			continue
-		}
+	book.synced = true
+	book.resyncing = false
+
+	ob := m.getOrCreateOrderBook(symbol)
+	ob.Update(toPriceLevels(bids), toPriceLevels(asks), data.SeqID)
+	m.syncMu.Unlock()
 
-		price := parseFloat(ask[0])
+	ts, _ := strconv.ParseInt(data.Timestamp, 10, 64)
+	m.telemetry.RecordMessage(symbol, msToTime(ts))
 
-		quantity := parseFloat(ask[1])
+	// Trigger callback
+	if m.onOrderBookUpdate != nil {
+		m.onOrderBookUpdate("okx", symbol, ob)
+	}
+}
+
+// markForResync позначає книгу як несинхронізовану і запускає resync (викликається під syncMu)
+func (m *OKXManager) markForResync(symbol string, book *okxLocalBook) {
+	book.synced = false
+	resyncing := book.resyncing
+	book.resyncing = true
+	m.syncMu.Unlock()
+
+	if !resyncing {
+		go m.resync(symbol)
+	}
+}
+
+// resync перепідписується на orderbook символу, щоб отримати новий snapshot
+func (m *OKXManager) resync(symbol string) {
+	arg := map[string]string{
+		"channel": okxBookChannel,
+		"instId":  normalizeOKXSymbol(symbol),
+	}
 
-		ob.Asks = append(ob.Asks, models.PriceLevel{
-			Price:    price,
-			Quantity: quantity,
+	for _, op := range []string{"unsubscribe", "subscribe"} {
+		data, err := json.Marshal(map[string]interface{}{
+			"op":   op,
+			"args": []map[string]string{arg},
 		})
+		if err != nil {
+			return
+		}
+
+		if err := m.writeMessage(data); err != nil {
+			log.Printf("❌ OKX %s: resync %s failed: %v", symbol, op, err)
+
+			m.syncMu.Lock()
+			if book, ok := m.localBooks[symbol]; ok {
+				book.resyncing = false
+			}
+			m.syncMu.Unlock()
+			return
+		}
 	}
+}
 
-	// Store OrderBook
+// getOrCreateOrderBook повертає OrderBook символу, створюючи за потреби
+func (m *OKXManager) getOrCreateOrderBook(symbol string) *models.OrderBook {
 	m.mu.Lock()
-	m.orderbooks[symbol] = ob
-	m.mu.Unlock()
+	defer m.mu.Unlock()
 
-	// Trigger callback
-	if m.onOrderBookUpdate != nil {
-		m.onOrderBookUpdate("okx", symbol, ob)
+	ob, exists := m.orderbooks[symbol]
+	if !exists {
+		ob = models.NewOrderBook("okx", symbol)
+		m.orderbooks[symbol] = ob
 	}
+
+	return ob
 }
 
 // handleTickerUpdate обробляє оновлення ticker
@@ -414,8 +521,9 @@ func (m *OKXManager) ping() {
 			}
 
 			// OKX uses plain text "ping" message
-			if err := m.conn.WriteMessage(websocket.TextMessage, []byte("ping")); err != nil {
+			if err := m.writeMessage([]byte("ping")); err != nil {
 				log.Printf("⚠️ OKX ping error: %v", err)
+				m.telemetry.RecordError(err)
 			}
 		}
 	}
@@ -465,7 +573,7 @@ func (m *OKXManager) reconnect() error {
 	m.Disconnect()
 	time.Sleep(2 * time.Second)
 
-	if err := m.Connect(m.ctx); err != nil {
+	if err := m.Connect(m.parent); err != nil {
 		return err
 	}
 
@@ -0,0 +1,109 @@
+package websocket
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// OKXPerpManager mark price та funding rate USDT-margined SWAP OKX.
+// Дані приходять окремими каналами (funding-rate, mark-price, index-tickers),
+// тому оновлення часткові
+type OKXPerpManager struct {
+	*perpStream
+}
+
+// NewOKXPerpManager створює новий OKX perpetual Manager
+func NewOKXPerpManager() *OKXPerpManager {
+	return &OKXPerpManager{perpStream: newPerpStream(okxPerpAdapter{})}
+}
+
+type okxPerpAdapter struct{}
+
+func (okxPerpAdapter) exchange() string {
+	return "okx"
+}
+
+func (okxPerpAdapter) url() string {
+	return "wss://ws.okx.com:8443/ws/v5/public"
+}
+
+// nativeSymbol "BTC/USDT" -> "BTC-USDT-SWAP"
+func (okxPerpAdapter) nativeSymbol(symbol string) string {
+	return strings.ToUpper(strings.ReplaceAll(symbol, "/", "-")) + "-SWAP"
+}
+
+func (okxPerpAdapter) subscribeMessages(natives []string) []interface{} {
+	args := make([]map[string]string, 0, len(natives)*3)
+
+	for _, native := range natives {
+		args = append(args,
+			map[string]string{"channel": "funding-rate", "instId": native},
+			map[string]string{"channel": "mark-price", "instId": native},
+			// Індекс публікується для spot instId ("BTC-USDT")
+			map[string]string{"channel": "index-tickers", "instId": strings.TrimSuffix(native, "-SWAP")},
+		)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"op":   "subscribe",
+			"args": args,
+		},
+	}
+}
+
+// parse обробляє {"arg":{"channel":...,"instId":...},"data":[{...}]}
+func (okxPerpAdapter) parse(message []byte) []fundingUpdate {
+	var msg struct {
+		Arg struct {
+			Channel string `json:"channel"`
+			InstID  string `json:"instId"`
+		} `json:"arg"`
+		Data []map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil || len(msg.Data) == 0 {
+		return nil // "pong", підтвердження підписки
+	}
+
+	updates := make([]fundingUpdate, 0, len(msg.Data))
+
+	for _, data := range msg.Data {
+		switch msg.Arg.Channel {
+		case "funding-rate":
+			update := fundingUpdate{
+				native:          msg.Arg.InstID,
+				fundingRate:     optionalRate(data["fundingRate"]),
+				nextFundingTime: millisToTime(data["fundingTime"]), // fundingTime = найближче нарахування
+			}
+
+			// Інтервал = різниця між наступними двома нарахуваннями
+			current, next := millisToTime(data["fundingTime"]), millisToTime(data["nextFundingTime"])
+			if !current.IsZero() && next.After(current) {
+				update.intervalHours = next.Sub(current).Hours()
+			}
+
+			updates = append(updates, update)
+
+		case "mark-price":
+			updates = append(updates, fundingUpdate{
+				native:    msg.Arg.InstID,
+				markPrice: parseFloat(data["markPx"]),
+			})
+
+		case "index-tickers":
+			updates = append(updates, fundingUpdate{
+				native:     msg.Arg.InstID + "-SWAP",
+				indexPrice: parseFloat(data["idxPx"]),
+			})
+		}
+	}
+
+	return updates
+}
+
+// ping OKX очікує текстове "ping" (з'єднання закривається після 30с тиші)
+func (okxPerpAdapter) ping(conn *websocket.Conn) error {
+	return conn.WriteMessage(websocket.TextMessage, []byte("ping"))
+}
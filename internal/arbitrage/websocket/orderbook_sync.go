@@ -0,0 +1,216 @@
+package websocket
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"errors"
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errSequenceGap пропущене оновлення ордербуку - потрібен resync
+var errSequenceGap = errors.New("orderbook sequence gap")
+
+const (
+	// maxBufferedDeltas скільки delta оновлень тримати поки чекаємо snapshot
+	maxBufferedDeltas = 1000
+
+	// resyncRetryInterval пауза між спробами resync
+	resyncRetryInterval = 1 * time.Second
+
+	// maxResyncAttempts кількість спроб отримати валідний snapshot
+	maxResyncAttempts = 5
+
+	// resyncBackoff пауза перед новою серією спроб після невдалого resync
+	resyncBackoff = 30 * time.Second
+)
+
+// depthDelta incremental оновлення ордербуку з діапазоном update ID
+type depthDelta struct {
+	FirstUpdateID int64
+	FinalUpdateID int64
+	Bids          []models.PriceLevel
+	Asks          []models.PriceLevel
+}
+
+// binanceSyncState стан синхронізації ордербуку за протоколом Binance diff-depth:
+// REST snapshot (lastUpdateId) + буфер delta подій з U (first) / u (final)
+type binanceSyncState struct {
+	synced       bool         // snapshot застосовано
+	bridged      bool         // перша подія після snapshot застосована
+	resyncing    bool         // snapshot завантажується
+	lastUpdateID int64        // останній застосований update ID
+	buffer       []depthDelta // події отримані до snapshot
+}
+
+// accept перевіряє послідовність події відносно застосованого стану.
+// Повертає false для застарілих подій і errSequenceGap при пропуску.
+func (s *binanceSyncState) accept(ev depthDelta) (bool, error) {
+	// Подія вже врахована в snapshot
+	if ev.FinalUpdateID <= s.lastUpdateID {
+		return false, nil
+	}
+
+	if !s.bridged {
+		// Перша подія повинна перекривати lastUpdateId+1: U <= lastUpdateId+1 <= u
+		if ev.FirstUpdateID > s.lastUpdateID+1 {
+			return false, errSequenceGap
+		}
+		s.bridged = true
+	} else if ev.FirstUpdateID != s.lastUpdateID+1 {
+		return false, errSequenceGap
+	}
+
+	s.lastUpdateID = ev.FinalUpdateID
+	return true, nil
+}
+
+// bufferDelta додає подію до буфера (найстаріші відкидаються при переповненні)
+func (s *binanceSyncState) bufferDelta(ev depthDelta) {
+	s.buffer = append(s.buffer, ev)
+	if len(s.buffer) > maxBufferedDeltas {
+		s.buffer = s.buffer[len(s.buffer)-maxBufferedDeltas:]
+	}
+}
+
+// parseDeltaLevels парсить [price, quantity] включно з quantity = 0 (видалення рівня)
+func parseDeltaLevels(levels []interface{}) []models.PriceLevel {
+	result := make([]models.PriceLevel, 0, len(levels))
+
+	for _, level := range levels {
+		levelArr, ok := level.([]interface{})
+		if !ok || len(levelArr) < 2 {
+			continue
+		}
+
+		price := parseFloat(levelArr[0])
+		if price <= 0 {
+			continue
+		}
+
+		result = append(result, models.PriceLevel{
+			Price:    price,
+			Quantity: parseFloat(levelArr[1]),
+		})
+	}
+
+	return result
+}
+
+// parseLevelArrays парсить масиви [price, size, ...]; size = 0 означає видалення рівня
+func parseLevelArrays(levels [][]interface{}) []models.PriceLevel {
+	raw := make([]interface{}, len(levels))
+	for i, level := range levels {
+		raw[i] = level
+	}
+	return parseDeltaLevels(raw)
+}
+
+// nonZeroLevels відкидає рівні з нульовим обсягом
+func nonZeroLevels(levels []models.PriceLevel) []models.PriceLevel {
+	result := make([]models.PriceLevel, 0, len(levels))
+	for _, level := range levels {
+		if level.Quantity > 0 {
+			result = append(result, level)
+		}
+	}
+	return result
+}
+
+// okxLocalBook локальна копія ордербуку OKX з оригінальними рядками цін
+// (потрібні для CRC32 checksum)
+type okxLocalBook struct {
+	bids      map[string]string // price -> size
+	asks      map[string]string // price -> size
+	seqID     int64
+	synced    bool
+	resyncing bool
+}
+
+func newOKXLocalBook() *okxLocalBook {
+	return &okxLocalBook{
+		bids: make(map[string]string),
+		asks: make(map[string]string),
+	}
+}
+
+// reset очищає книгу перед snapshot
+func (b *okxLocalBook) reset() {
+	b.bids = make(map[string]string)
+	b.asks = make(map[string]string)
+	b.synced = false
+}
+
+// apply застосовує рівні [price, size, ...]; size "0" видаляє рівень
+func (b *okxLocalBook) apply(bids, asks [][]string) {
+	applyOKXLevels(b.bids, bids)
+	applyOKXLevels(b.asks, asks)
+}
+
+func applyOKXLevels(side map[string]string, levels [][]string) {
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+
+		if parseFloat(level[1]) == 0 {
+			delete(side, level[0])
+		} else {
+			side[level[0]] = level[1]
+		}
+	}
+}
+
+// sorted повертає відсортовані рівні: bids за спаданням, asks за зростанням
+func (b *okxLocalBook) sorted() (bids, asks [][2]string) {
+	return sortOKXLevels(b.bids, true), sortOKXLevels(b.asks, false)
+}
+
+func sortOKXLevels(side map[string]string, descending bool) [][2]string {
+	levels := make([][2]string, 0, len(side))
+	for price, size := range side {
+		levels = append(levels, [2]string{price, size})
+	}
+
+	sort.Slice(levels, func(i, j int) bool {
+		pi, _ := strconv.ParseFloat(levels[i][0], 64)
+		pj, _ := strconv.ParseFloat(levels[j][0], 64)
+		if descending {
+			return pi > pj
+		}
+		return pi < pj
+	})
+
+	return levels
+}
+
+// okxChecksum розраховує checksum топ-25 рівнів у форматі OKX:
+// "bid1px:bid1sz:ask1px:ask1sz:bid2px:..." -> CRC32 як signed int32
+func okxChecksum(bids, asks [][2]string) int32 {
+	parts := make([]string, 0, 100)
+
+	for i := 0; i < 25; i++ {
+		if i < len(bids) {
+			parts = append(parts, bids[i][0], bids[i][1])
+		}
+		if i < len(asks) {
+			parts = append(parts, asks[i][0], asks[i][1])
+		}
+	}
+
+	return int32(crc32.ChecksumIEEE([]byte(strings.Join(parts, ":"))))
+}
+
+// toPriceLevels конвертує рядкові рівні в PriceLevel
+func toPriceLevels(levels [][2]string) []models.PriceLevel {
+	result := make([]models.PriceLevel, 0, len(levels))
+	for _, level := range levels {
+		result = append(result, models.PriceLevel{
+			Price:    parseFloat(level[0]),
+			Quantity: parseFloat(level[1]),
+		})
+	}
+	return result
+}
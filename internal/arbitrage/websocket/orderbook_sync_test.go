@@ -0,0 +1,142 @@
+package websocket
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBinanceSyncStateAccept(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   binanceSyncState
+		first   int64
+		final   int64
+		apply   bool
+		gap     bool
+		lastID  int64
+		bridged bool
+	}{
+		{"already in snapshot", binanceSyncState{lastUpdateID: 100}, 90, 100, false, false, 100, false},
+		{"first event bridges snapshot", binanceSyncState{lastUpdateID: 100}, 95, 105, true, false, 105, true},
+		{"first event starts right after snapshot", binanceSyncState{lastUpdateID: 100}, 101, 103, true, false, 103, true},
+		{"first event after gap", binanceSyncState{lastUpdateID: 100}, 102, 110, false, true, 100, false},
+		{"next event continues", binanceSyncState{lastUpdateID: 105, bridged: true}, 106, 108, true, false, 108, true},
+		{"next event overlaps", binanceSyncState{lastUpdateID: 105, bridged: true}, 104, 108, false, true, 105, true},
+		{"next event skips update", binanceSyncState{lastUpdateID: 105, bridged: true}, 107, 108, false, true, 105, true},
+	}
+
+	for _, tt := range tests {
+		state := tt.state
+		apply, err := state.accept(depthDelta{FirstUpdateID: tt.first, FinalUpdateID: tt.final})
+
+		if apply != tt.apply || errors.Is(err, errSequenceGap) != tt.gap {
+			t.Errorf("%s: accept = %v, %v; want %v, gap %v", tt.name, apply, err, tt.apply, tt.gap)
+		}
+		if state.lastUpdateID != tt.lastID || state.bridged != tt.bridged {
+			t.Errorf("%s: state last=%d bridged=%v, want last=%d bridged=%v",
+				tt.name, state.lastUpdateID, state.bridged, tt.lastID, tt.bridged)
+		}
+	}
+}
+
+func TestBinanceSyncStateBufferReplay(t *testing.T) {
+	state := &binanceSyncState{}
+	for id := int64(1); id <= maxBufferedDeltas+10; id++ {
+		state.bufferDelta(depthDelta{FirstUpdateID: id, FinalUpdateID: id})
+	}
+
+	// При переповненні відкидаються найстаріші події
+	if len(state.buffer) != maxBufferedDeltas || state.buffer[0].FirstUpdateID != 11 {
+		t.Fatalf("Expected %d newest buffered deltas, got %d starting at %d",
+			maxBufferedDeltas, len(state.buffer), state.buffer[0].FirstUpdateID)
+	}
+
+	// Snapshot посередині буфера: старіші події пропускаються, решта застосовуються
+	state.lastUpdateID = 500
+	applied := 0
+	for _, ev := range state.buffer {
+		apply, err := state.accept(ev)
+		if err != nil {
+			t.Fatalf("Unexpected gap at %d: %v", ev.FirstUpdateID, err)
+		}
+		if apply {
+			applied++
+		}
+	}
+	if applied != maxBufferedDeltas+10-500 || state.lastUpdateID != maxBufferedDeltas+10 {
+		t.Errorf("Expected %d applied up to %d, got %d up to %d",
+			maxBufferedDeltas+10-500, maxBufferedDeltas+10, applied, state.lastUpdateID)
+	}
+
+	// Snapshot новіший за весь буфер з розривом - потрібен новий snapshot
+	stale := &binanceSyncState{lastUpdateID: 10}
+	stale.bufferDelta(depthDelta{FirstUpdateID: 20, FinalUpdateID: 25})
+	if _, err := stale.accept(stale.buffer[0]); !errors.Is(err, errSequenceGap) {
+		t.Errorf("Expected gap for buffer starting after snapshot, got %v", err)
+	}
+}
+
+func TestOKXLocalBook(t *testing.T) {
+	book := newOKXLocalBook()
+	book.apply(
+		[][]string{{"3366.1", "7", "0", "3"}, {"3366", "6", "3", "4"}, {"3365", "1", "0", "1"}},
+		[][]string{{"3366.8", "9", "10", "3"}, {"3368", "8", "3", "4"}},
+	)
+
+	// Update: видалення рівня (size "0") та зміна обсягу
+	book.apply([][]string{{"3365", "0", "0", "0"}}, [][]string{{"3368", "8", "0", "4"}})
+
+	bids, asks := book.sorted()
+	if len(bids) != 2 || bids[0][0] != "3366.1" || bids[1][0] != "3366" {
+		t.Errorf("Expected bids sorted descending without removed level, got %v", bids)
+	}
+	if len(asks) != 2 || asks[0][0] != "3366.8" || asks[1][0] != "3368" {
+		t.Errorf("Expected asks sorted ascending, got %v", asks)
+	}
+
+	levels := toPriceLevels(bids)
+	if levels[0].Price != 3366.1 || levels[0].Quantity != 7 {
+		t.Errorf("Unexpected price level %+v", levels[0])
+	}
+
+	book.reset()
+	if len(book.bids) != 0 || len(book.asks) != 0 || book.synced {
+		t.Error("Expected reset to clear the book")
+	}
+}
+
+func TestOKXChecksum(t *testing.T) {
+	bids := [][2]string{{"3366.1", "7"}, {"3366", "6"}}
+	asks := [][2]string{{"3366.8", "9"}, {"3368", "8"}}
+
+	// CRC32 рядка "3366.1:7:3366.8:9:3366:6:3368:8" (книга з прикладу документації OKX)
+	const expected int32 = -1881014294
+
+	tests := []struct {
+		name  string
+		bids  [][2]string
+		asks  [][2]string
+		match bool
+	}{
+		{"matching book", bids, asks, true},
+		{"changed size", [][2]string{{"3366.1", "7.5"}, {"3366", "6"}}, asks, false},
+		{"missing level", bids[:1], asks, false},
+		// Рядки цін використовуються як є: "3366.10" != "3366.1"
+		{"reformatted price", [][2]string{{"3366.10", "7"}, {"3366", "6"}}, asks, false},
+	}
+
+	for _, tt := range tests {
+		if got := okxChecksum(tt.bids, tt.asks); (got == expected) != tt.match {
+			t.Errorf("%s: checksum %d, expected match %v", tt.name, got, tt.match)
+		}
+	}
+
+	// Враховуються тільки топ-25 рівнів
+	deep := make([][2]string, 0, 30)
+	for i := 0; i < 30; i++ {
+		deep = append(deep, [2]string{string(rune('a' + i%26)), "1"})
+	}
+	if okxChecksum(deep[:25], asks) != okxChecksum(deep, asks) {
+		t.Error("Expected levels beyond 25 to be ignored")
+	}
+}
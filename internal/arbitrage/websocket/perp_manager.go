@@ -0,0 +1,414 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// PerpManager інтерфейс для WebSocket з'єднань з perpetual ф'ючерсами бірж
+// (mark price та funding rate)
+type PerpManager interface {
+	// Connect підключається до WebSocket
+	Connect(ctx context.Context) error
+
+	// Disconnect від'єднується від WebSocket
+	Disconnect() error
+
+	// Subscribe підписується на символи ("BTC/USDT" = USDT-margined perpetual)
+	Subscribe(symbols []string) error
+
+	// IsConnected перевіряє чи активне з'єднання
+	IsConnected() bool
+
+	// GetFunding отримує останні funding дані символу
+	GetFunding(symbol string) *FundingData
+
+	// OnFunding callback при оновленні funding/mark price
+	OnFunding(callback FundingCallback)
+
+	// GetExchange назва біржі
+	GetExchange() string
+}
+
+// FundingCallback функція для обробки оновлень funding
+type FundingCallback func(exchange, symbol string, funding *FundingData)
+
+// FundingData стан perpetual контракту
+type FundingData struct {
+	Symbol               string
+	MarkPrice            float64
+	IndexPrice           float64
+	FundingRate          float64 // частка за період (0.0001 = 0.01%)
+	FundingIntervalHours float64
+	NextFundingTime      time.Time
+	Timestamp            time.Time
+}
+
+// IsStale перевіряє чи дані застаріли
+func (f *FundingData) IsStale(maxAge time.Duration) bool {
+	return time.Since(f.Timestamp) > maxAge
+}
+
+// HourlyRate funding rate за годину (біржі мають різні інтервали)
+func (f *FundingData) HourlyRate() float64 {
+	if f.FundingIntervalHours <= 0 {
+		return 0
+	}
+	return f.FundingRate / f.FundingIntervalHours
+}
+
+// defaultFundingInterval стандартний інтервал нарахування funding
+const defaultFundingInterval = 8.0
+
+// fundingUpdate часткове оновлення (Bybit шле дельти, OKX - окремі канали)
+type fundingUpdate struct {
+	native          string // символ біржі
+	markPrice       float64
+	indexPrice      float64
+	fundingRate     *float64
+	intervalHours   float64
+	nextFundingTime time.Time
+}
+
+// perpAdapter протокол конкретної біржі
+type perpAdapter interface {
+	exchange() string
+	url() string
+	nativeSymbol(symbol string) string
+	subscribeMessages(natives []string) []interface{}
+	parse(message []byte) []fundingUpdate
+	ping(conn *websocket.Conn) error
+}
+
+// perpStream спільна логіка з'єднання, підписки та перепідключення
+type perpStream struct {
+	adapter perpAdapter
+
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+
+	symbols []string
+	natives map[string]string // native -> "BTC/USDT"
+	funding map[string]*FundingData
+	mu      sync.RWMutex
+
+	reconnectInterval time.Duration
+	pingInterval      time.Duration
+
+	onFunding FundingCallback
+
+	root   context.Context // живе до Disconnect
+	stop   context.CancelFunc
+	ctx    context.Context // живе до розриву поточного з'єднання
+	cancel context.CancelFunc
+
+	connected    bool
+	reconnecting bool
+	connMu       sync.RWMutex
+}
+
+func newPerpStream(adapter perpAdapter) *perpStream {
+	return &perpStream{
+		adapter:           adapter,
+		natives:           make(map[string]string),
+		funding:           make(map[string]*FundingData),
+		reconnectInterval: 5 * time.Second,
+		pingInterval:      20 * time.Second,
+	}
+}
+
+// GetExchange повертає назву біржі
+func (m *perpStream) GetExchange() string {
+	return m.adapter.exchange()
+}
+
+// Connect підключається до WebSocket
+func (m *perpStream) Connect(ctx context.Context) error {
+	m.root, m.stop = context.WithCancel(ctx)
+
+	return m.dial()
+}
+
+// dial відкриває нове з'єднання (також при перепідключенні)
+func (m *perpStream) dial() error {
+	m.ctx, m.cancel = context.WithCancel(m.root)
+
+	conn, _, err := websocket.DefaultDialer.Dial(m.adapter.url(), nil)
+	if err != nil {
+		m.cancel()
+		return fmt.Errorf("failed to connect to %s perp WS: %w", m.adapter.exchange(), err)
+	}
+
+	m.conn = conn
+	m.setConnected(true)
+	log.Printf("✅ Connected to %s perpetual WebSocket", m.adapter.exchange())
+
+	// Горутини прив'язані до свого з'єднання, перепідключення створює нові
+	go m.handleMessages(m.ctx, conn)
+	go m.ping(m.ctx, conn)
+
+	return nil
+}
+
+// Disconnect від'єднується від WebSocket (без перепідключення)
+func (m *perpStream) Disconnect() error {
+	if m.stop != nil {
+		m.stop()
+	}
+
+	m.setConnected(false)
+
+	if m.conn != nil {
+		return m.conn.Close()
+	}
+
+	return nil
+}
+
+// Subscribe підписується на символи
+func (m *perpStream) Subscribe(symbols []string) error {
+	if len(symbols) == 0 {
+		return fmt.Errorf("no symbols to subscribe")
+	}
+
+	natives := make([]string, 0, len(symbols))
+
+	m.mu.Lock()
+	m.symbols = symbols
+	for _, symbol := range symbols {
+		native := m.adapter.nativeSymbol(symbol)
+		m.natives[native] = symbol
+		natives = append(natives, native)
+	}
+	m.mu.Unlock()
+
+	if !m.IsConnected() {
+		return fmt.Errorf("not connected")
+	}
+
+	log.Printf("🔔 Subscribing to %d perpetuals on %s", len(symbols), m.adapter.exchange())
+
+	for _, msg := range m.adapter.subscribeMessages(natives) {
+		if err := m.writeJSON(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetFunding отримує funding дані символу (копію)
+func (m *perpStream) GetFunding(symbol string) *FundingData {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	funding, ok := m.funding[symbol]
+	if !ok {
+		return nil
+	}
+
+	copied := *funding
+	return &copied
+}
+
+// OnFunding встановлює callback для оновлень funding
+func (m *perpStream) OnFunding(callback FundingCallback) {
+	m.onFunding = callback
+}
+
+// IsConnected перевіряє чи з'єднання активне
+func (m *perpStream) IsConnected() bool {
+	m.connMu.RLock()
+	defer m.connMu.RUnlock()
+	return m.connected
+}
+
+func (m *perpStream) setConnected(status bool) {
+	m.connMu.Lock()
+	defer m.connMu.Unlock()
+	m.connected = status
+}
+
+func (m *perpStream) writeJSON(v interface{}) error {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	return m.conn.WriteJSON(v)
+}
+
+// handleMessages обробляє вхідні WebSocket повідомлення
+func (m *perpStream) handleMessages(ctx context.Context, conn *websocket.Conn) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("⚠️ Recovered in %s perp handleMessages: %v", m.adapter.exchange(), r)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				if ctx.Err() != nil {
+					return // З'єднання закрите навмисно
+				}
+				log.Printf("❌ WebSocket read error (%s perp): %v", m.adapter.exchange(), err)
+				m.setConnected(false)
+				go m.reconnect()
+				return
+			}
+
+			for _, update := range m.adapter.parse(message) {
+				m.apply(update)
+			}
+		}
+	}
+}
+
+// apply зливає часткове оновлення з попереднім станом
+func (m *perpStream) apply(update fundingUpdate) {
+	m.mu.Lock()
+	symbol, ok := m.natives[update.native]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+
+	funding, ok := m.funding[symbol]
+	if !ok {
+		funding = &FundingData{Symbol: symbol, FundingIntervalHours: defaultFundingInterval}
+		m.funding[symbol] = funding
+	}
+
+	if update.markPrice > 0 {
+		funding.MarkPrice = update.markPrice
+	}
+	if update.indexPrice > 0 {
+		funding.IndexPrice = update.indexPrice
+	}
+	if update.fundingRate != nil {
+		funding.FundingRate = *update.fundingRate
+	}
+	if update.intervalHours > 0 {
+		funding.FundingIntervalHours = update.intervalHours
+	}
+	if !update.nextFundingTime.IsZero() {
+		funding.NextFundingTime = update.nextFundingTime
+	}
+	funding.Timestamp = time.Now()
+
+	copied := *funding
+	m.mu.Unlock()
+
+	if m.onFunding != nil {
+		go m.onFunding(m.adapter.exchange(), symbol, &copied)
+	}
+}
+
+// ping підтримує з'єднання
+func (m *perpStream) ping(ctx context.Context, conn *websocket.Conn) {
+	ticker := time.NewTicker(m.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !m.IsConnected() {
+				continue
+			}
+
+			m.writeMu.Lock()
+			err := m.adapter.ping(conn)
+			m.writeMu.Unlock()
+
+			if err != nil {
+				log.Printf("⚠️ Ping error (%s perp): %v", m.adapter.exchange(), err)
+				m.setConnected(false)
+				go m.reconnect()
+				return
+			}
+		}
+	}
+}
+
+// reconnect перепідключається до WebSocket. Читання та ping можуть
+// одночасно виявити розрив, тому перепідключення виконується лише одне
+func (m *perpStream) reconnect() {
+	m.connMu.Lock()
+	if m.connected || m.reconnecting || m.root.Err() != nil {
+		m.connMu.Unlock()
+		return // Вже підключені, вже перепідключаємось або Disconnect
+	}
+	m.reconnecting = true
+	m.connMu.Unlock()
+
+	defer func() {
+		m.connMu.Lock()
+		m.reconnecting = false
+		m.connMu.Unlock()
+	}()
+
+	log.Printf("🔄 Reconnecting to %s perpetual WebSocket...", m.adapter.exchange())
+
+	m.cancel()
+	if m.conn != nil {
+		m.conn.Close()
+	}
+
+	for {
+		time.Sleep(m.reconnectInterval)
+
+		if m.root.Err() != nil {
+			return
+		}
+
+		if err := m.dial(); err != nil {
+			log.Printf("❌ Reconnection failed (%s perp): %v", m.adapter.exchange(), err)
+			continue
+		}
+		break
+	}
+
+	m.mu.RLock()
+	symbols := m.symbols
+	m.mu.RUnlock()
+
+	if len(symbols) > 0 {
+		if err := m.Subscribe(symbols); err != nil {
+			log.Printf("❌ Resubscription failed (%s perp): %v", m.adapter.exchange(), err)
+		}
+	}
+
+	log.Printf("✅ Reconnected to %s perpetual WebSocket", m.adapter.exchange())
+}
+
+// millisToTime конвертує unix ms (число або рядок) в час
+func millisToTime(val interface{}) time.Time {
+	ms := int64(parseFloat(val))
+	if ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// optionalRate парсить funding rate, якщо поле присутнє
+func optionalRate(val interface{}) *float64 {
+	if val == nil {
+		return nil
+	}
+	if s, ok := val.(string); ok && s == "" {
+		return nil
+	}
+
+	rate := parseFloat(val)
+	return &rate
+}
@@ -0,0 +1,50 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPerpAdaptersParse(t *testing.T) {
+	stream := newPerpStream(okxPerpAdapter{})
+	stream.natives["BTC-USDT-SWAP"] = "BTC/USDT"
+
+	messages := []string{
+		`{"arg":{"channel":"funding-rate","instId":"BTC-USDT-SWAP"},"data":[{"instId":"BTC-USDT-SWAP","fundingRate":"0.0002","fundingTime":"1760000000000","nextFundingTime":"1760014400000"}]}`,
+		`{"arg":{"channel":"mark-price","instId":"BTC-USDT-SWAP"},"data":[{"instId":"BTC-USDT-SWAP","markPx":"65000.5"}]}`,
+		`{"arg":{"channel":"index-tickers","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","idxPx":"64990"}]}`,
+	}
+	for _, message := range messages {
+		for _, update := range stream.adapter.parse([]byte(message)) {
+			stream.apply(update)
+		}
+	}
+
+	funding := stream.GetFunding("BTC/USDT")
+	if funding == nil {
+		t.Fatal("Expected funding data for BTC/USDT")
+	}
+	if funding.FundingRate != 0.0002 || funding.MarkPrice != 65000.5 || funding.IndexPrice != 64990 {
+		t.Errorf("Unexpected merged funding: %+v", funding)
+	}
+	if funding.FundingIntervalHours != 4 || !funding.NextFundingTime.Equal(time.UnixMilli(1760000000000)) {
+		t.Errorf("Expected 4h interval and next funding from fundingTime, got %.1fh %s",
+			funding.FundingIntervalHours, funding.NextFundingTime)
+	}
+
+	// Bybit дельта без fundingRate не скидає ставку
+	bybit := newPerpStream(bybitPerpAdapter{})
+	bybit.natives["BTCUSDT"] = "BTC/USDT"
+	for _, message := range []string{
+		`{"topic":"tickers.BTCUSDT","type":"snapshot","data":{"symbol":"BTCUSDT","markPrice":"65000","fundingRate":"0.0001","nextFundingTime":"1760000000000"}}`,
+		`{"topic":"tickers.BTCUSDT","type":"delta","data":{"symbol":"BTCUSDT","markPrice":"65010"}}`,
+	} {
+		for _, update := range bybit.adapter.parse([]byte(message)) {
+			bybit.apply(update)
+		}
+	}
+
+	if funding := bybit.GetFunding("BTC/USDT"); funding == nil || funding.FundingRate != 0.0001 || funding.MarkPrice != 65010 {
+		t.Errorf("Unexpected bybit funding after delta: %+v", funding)
+	}
+}
@@ -0,0 +1,357 @@
+package websocket
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto-opportunities-bot/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// recordFileExt розширення файлів запису (gzip JSON lines)
+const recordFileExt = ".jsonl.gz"
+
+// BookRecord один нормалізований стан ордербуку в записі
+type BookRecord struct {
+	Time     int64        `json:"t"` // unix milliseconds
+	Exchange string       `json:"e"`
+	Symbol   string       `json:"s"`
+	UpdateID int64        `json:"u,omitempty"`
+	Bids     [][2]float64 `json:"b"` // [price, quantity]
+	Asks     [][2]float64 `json:"a"`
+}
+
+// Timestamp час запису
+func (r *BookRecord) Timestamp() time.Time {
+	return time.UnixMilli(r.Time)
+}
+
+// Levels повертає рівні ордербуку
+func (r *BookRecord) Levels() (bids, asks []models.PriceLevel) {
+	return toLevels(r.Bids), toLevels(r.Asks)
+}
+
+func toLevels(raw [][2]float64) []models.PriceLevel {
+	levels := make([]models.PriceLevel, len(raw))
+	for i, level := range raw {
+		levels[i] = models.PriceLevel{Price: level[0], Quantity: level[1]}
+	}
+	return levels
+}
+
+func fromLevels(levels []models.PriceLevel) [][2]float64 {
+	raw := make([][2]float64, len(levels))
+	for i, level := range levels {
+		raw[i] = [2]float64{level.Price, level.Quantity}
+	}
+	return raw
+}
+
+// Recorder дописує кожне оновлення ордербуку у файли
+// <dir>/<YYYY-MM-DD>/<exchange>.jsonl.gz (новий файл кожного дня, UTC)
+type Recorder struct {
+	dir   string
+	depth int // скільки рівнів зберігати (0 = всі)
+
+	files map[string]*recordFile // exchange -> файл поточного дня
+	mu    sync.Mutex
+
+	flushInterval time.Duration
+	stopChan      chan struct{}
+	closeOnce     sync.Once
+}
+
+type recordFile struct {
+	day    string
+	file   *os.File
+	gz     *gzip.Writer
+	buf    *bufio.Writer
+	encode *json.Encoder
+}
+
+// NewRecorder створює новий Recorder
+func NewRecorder(dir string, depth int) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create record dir: %w", err)
+	}
+
+	r := &Recorder{
+		dir:           dir,
+		depth:         depth,
+		files:         make(map[string]*recordFile),
+		flushInterval: 5 * time.Second,
+		stopChan:      make(chan struct{}),
+	}
+
+	// Періодичний flush, щоб записи не губились при падінні процесу
+	go r.flushLoop()
+
+	return r, nil
+}
+
+// Record записує поточний стан ордербуку
+func (r *Recorder) Record(exchange, symbol string, ob *models.OrderBook) {
+	bids, asks, updateID := ob.Snapshot(r.depth)
+	now := time.Now().UTC()
+
+	record := &BookRecord{
+		Time:     now.UnixMilli(),
+		Exchange: exchange,
+		Symbol:   symbol,
+		UpdateID: updateID,
+		Bids:     fromLevels(bids),
+		Asks:     fromLevels(asks),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	file, err := r.fileFor(exchange, now.Format("2006-01-02"))
+	if err != nil {
+		log.Printf("❌ Recorder: %v", err)
+		return
+	}
+
+	if err := file.encode.Encode(record); err != nil {
+		log.Printf("❌ Recorder: failed to write %s %s: %v", exchange, symbol, err)
+	}
+}
+
+// fileFor повертає файл біржі для дня (ротація опівночі UTC)
+func (r *Recorder) fileFor(exchange, day string) (*recordFile, error) {
+	if file, ok := r.files[exchange]; ok {
+		if file.day == day {
+			return file, nil
+		}
+
+		if err := file.close(); err != nil {
+			log.Printf("⚠️ Recorder: failed to close %s file: %v", exchange, err)
+		}
+		delete(r.files, exchange)
+	}
+
+	dayDir := filepath.Join(r.dir, day)
+	if err := os.MkdirAll(dayDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dayDir, err)
+	}
+
+	// Append: після рестарту в той же день дописується новий gzip member
+	path := filepath.Join(dayDir, exchange+recordFileExt)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	gz := gzip.NewWriter(f)
+	buf := bufio.NewWriterSize(gz, 64*1024)
+
+	file := &recordFile{
+		day:    day,
+		file:   f,
+		gz:     gz,
+		buf:    buf,
+		encode: json.NewEncoder(buf),
+	}
+	r.files[exchange] = file
+
+	return file, nil
+}
+
+func (f *recordFile) flush() error {
+	if err := f.buf.Flush(); err != nil {
+		return err
+	}
+	return f.gz.Flush()
+}
+
+func (f *recordFile) close() error {
+	if err := f.buf.Flush(); err != nil {
+		f.file.Close()
+		return err
+	}
+	if err := f.gz.Close(); err != nil {
+		f.file.Close()
+		return err
+	}
+	return f.file.Close()
+}
+
+func (r *Recorder) flushLoop() {
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopChan:
+			return
+		case <-ticker.C:
+			r.Flush()
+		}
+	}
+}
+
+// Flush скидає буфери всіх файлів на диск
+func (r *Recorder) Flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for exchange, file := range r.files {
+		if err := file.flush(); err != nil {
+			log.Printf("⚠️ Recorder: failed to flush %s: %v", exchange, err)
+		}
+	}
+}
+
+// Close закриває всі файли
+func (r *Recorder) Close() error {
+	r.closeOnce.Do(func() {
+		close(r.stopChan)
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for exchange, file := range r.files {
+		if err := file.close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", exchange, err))
+		}
+		delete(r.files, exchange)
+	}
+
+	return errors.Join(errs...)
+}
+
+// RecordingManager обгортає Manager і записує кожне оновлення ордербуку
+type RecordingManager struct {
+	Manager
+	recorder *Recorder
+}
+
+// NewRecordingManager створює Manager, що записує оновлення inner у recorder
+func NewRecordingManager(inner Manager, recorder *Recorder) *RecordingManager {
+	return &RecordingManager{
+		Manager:  inner,
+		recorder: recorder,
+	}
+}
+
+// OnOrderBookUpdate записує оновлення і передає його далі в callback
+func (m *RecordingManager) OnOrderBookUpdate(callback OrderBookCallback) {
+	m.Manager.OnOrderBookUpdate(func(exchange, symbol string, ob *models.OrderBook) {
+		m.recorder.Record(exchange, symbol, ob)
+
+		if callback != nil {
+			callback(exchange, symbol, ob)
+		}
+	})
+}
+
+// Disconnect від'єднується та скидає записане на диск
+func (m *RecordingManager) Disconnect() error {
+	err := m.Manager.Disconnect()
+	m.recorder.Flush()
+	return err
+}
+
+// RecordReader послідовно читає записи з одного файлу
+type RecordReader struct {
+	path    string
+	file    *os.File
+	gz      *gzip.Reader
+	decoder *json.Decoder
+}
+
+// OpenRecording відкриває файл запису для читання
+func OpenRecording(path string) (*RecordReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read gzip %s: %w", path, err)
+	}
+
+	return &RecordReader{
+		path:    path,
+		file:    f,
+		gz:      gz,
+		decoder: json.NewDecoder(gz),
+	}, nil
+}
+
+// Next повертає наступний запис або io.EOF. Обрізаний кінець файлу
+// (процес впав або файл ще пишеться) вважається кінцем запису
+func (r *RecordReader) Next() (*BookRecord, error) {
+	var record BookRecord
+
+	err := r.decoder.Decode(&record)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		if err != io.EOF {
+			err = fmt.Errorf("failed to decode %s: %w", r.path, err)
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// Close закриває файл
+func (r *RecordReader) Close() error {
+	r.gz.Close()
+	return r.file.Close()
+}
+
+// RecordingFiles знаходить файли запису в dir за днями [from, to]
+// (нульовий час = без обмеження) для вказаних бірж (порожньо = всі)
+func RecordingFiles(dir string, from, to time.Time, exchanges []string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*", "*"+recordFileExt))
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(exchanges))
+	for _, exchange := range exchanges {
+		wanted[strings.ToLower(exchange)] = true
+	}
+
+	var result []string
+	for _, path := range paths {
+		day, err := time.Parse("2006-01-02", filepath.Base(filepath.Dir(path)))
+		if err != nil {
+			continue
+		}
+
+		if !from.IsZero() && day.Before(from.UTC().Truncate(24*time.Hour)) {
+			continue
+		}
+		if !to.IsZero() && day.After(to.UTC()) {
+			continue
+		}
+
+		exchange := strings.TrimSuffix(filepath.Base(path), recordFileExt)
+		if len(wanted) > 0 && !wanted[exchange] {
+			continue
+		}
+
+		result = append(result, path)
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package websocket
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/models"
+	"testing"
+	"time"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	recorder, err := NewRecorder(dir, 2)
+	if err != nil {
+		t.Fatalf("Failed to create recorder: %v", err)
+	}
+
+	book := func(exchange, symbol string, bid, ask float64) *models.OrderBook {
+		ob := models.NewOrderBook(exchange, symbol)
+		ob.Update(
+			[]models.PriceLevel{{Price: bid, Quantity: 1}, {Price: bid - 1, Quantity: 2}, {Price: bid - 2, Quantity: 3}},
+			[]models.PriceLevel{{Price: ask, Quantity: 1}, {Price: ask + 1, Quantity: 2}},
+			7,
+		)
+		return ob
+	}
+
+	recorder.Record("binance", "BTC/USDT", book("binance", "BTC/USDT", 100, 101))
+	time.Sleep(2 * time.Millisecond)
+	recorder.Record("bybit", "BTC/USDT", book("bybit", "BTC/USDT", 102, 103))
+	time.Sleep(2 * time.Millisecond)
+	recorder.Record("binance", "ETH/USDT", book("binance", "ETH/USDT", 10, 11))
+
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Failed to close recorder: %v", err)
+	}
+
+	paths, err := RecordingFiles(dir, time.Time{}, time.Time{}, nil)
+	if err != nil || len(paths) != 2 {
+		t.Fatalf("Expected 2 recording files, got %d (%v)", len(paths), err)
+	}
+
+	replay := NewReplay(paths, 0)
+
+	var order []string
+	for _, exchange := range []string{"binance", "bybit"} {
+		manager := replay.Manager(exchange)
+		manager.OnOrderBookUpdate(func(exchange, symbol string, ob *models.OrderBook) {
+			order = append(order, exchange+" "+symbol)
+		})
+		manager.Connect(context.Background())
+		manager.Subscribe([]string{"BTC/USDT"})
+	}
+
+	if err := replay.Run(context.Background()); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	// ETH/USDT не в підписці, записи бірж перемішані за часом
+	expected := []string{"binance BTC/USDT", "bybit BTC/USDT"}
+	if len(order) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, order)
+		}
+	}
+
+	ob := replay.Manager("bybit").GetOrderBook("BTC/USDT")
+	if ob == nil {
+		t.Fatal("Expected replayed bybit order book")
+	}
+	if bid := ob.GetBestBid(); bid == nil || bid.Price != 102 {
+		t.Errorf("Expected best bid 102, got %v", bid)
+	}
+	if bidDepth, _ := ob.GetDepth(); bidDepth != 2 {
+		t.Errorf("Expected recorded depth 2, got %d", bidDepth)
+	}
+	if replay.Played() != 3 {
+		t.Errorf("Expected 3 played records, got %d", replay.Played())
+	}
+}
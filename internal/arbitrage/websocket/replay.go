@@ -0,0 +1,302 @@
+package websocket
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// Replay програє записані Recorder файли всіх бірж в хронологічному порядку.
+// Для кожної біржі створюється ReplayManager, який реєструється в
+// OrderBookManager як звичайний Manager. LastUpdate ордербуку - час програвання,
+// тому перевірки IsStale працюють у "стиснутому" часі при speed > 1
+type Replay struct {
+	paths []string
+	speed float64 // 1 = реальний час, 60 = хвилина за секунду, <= 0 = без пауз
+
+	managers map[string]*ReplayManager
+	mu       sync.Mutex
+
+	played int
+}
+
+// NewReplay створює новий Replay
+func NewReplay(paths []string, speed float64) *Replay {
+	return &Replay{
+		paths:    paths,
+		speed:    speed,
+		managers: make(map[string]*ReplayManager),
+	}
+}
+
+// Manager повертає ReplayManager біржі (записи бірж без менеджера пропускаються)
+func (r *Replay) Manager(exchange string) *ReplayManager {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if manager, ok := r.managers[exchange]; ok {
+		return manager
+	}
+
+	manager := &ReplayManager{
+		exchange:   exchange,
+		symbols:    make(map[string]bool),
+		orderbooks: make(map[string]*models.OrderBook),
+		telemetry:  NewTelemetry(),
+	}
+	r.managers[exchange] = manager
+
+	return manager
+}
+
+// Played кількість програних записів
+func (r *Replay) Played() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.played
+}
+
+// Run програє всі файли і повертається, коли записи закінчились або ctx скасовано
+func (r *Replay) Run(ctx context.Context) error {
+	log.Printf("▶️ Replaying %d recordings (speed %.0fx)", len(r.paths), r.speed)
+	started := time.Now()
+
+	var prevTime int64
+
+	err := MergeRecordings(ctx, r.paths, func(record *BookRecord) error {
+		if err := r.wait(ctx, prevTime, record.Time); err != nil {
+			return err
+		}
+		prevTime = record.Time
+
+		r.dispatch(record)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("⏹ Replay finished: %d records in %s", r.Played(), time.Since(started).Round(time.Millisecond))
+
+	return nil
+}
+
+// MergeRecordings читає файли запису і викликає fn для кожного запису
+// в хронологічному порядку (k-way merge за часом)
+func MergeRecordings(ctx context.Context, paths []string, fn func(*BookRecord) error) error {
+	readers := make([]*RecordReader, 0, len(paths))
+	defer func() {
+		for _, reader := range readers {
+			reader.Close()
+		}
+	}()
+
+	// Поточний запис кожного файлу
+	heads := make([]*BookRecord, 0, len(paths))
+
+	for _, path := range paths {
+		reader, err := OpenRecording(path)
+		if err != nil {
+			return err
+		}
+		readers = append(readers, reader)
+
+		record, err := reader.Next()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		heads = append(heads, record)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		next := -1
+		for i, head := range heads {
+			if head != nil && (next < 0 || head.Time < heads[next].Time) {
+				next = i
+			}
+		}
+		if next < 0 {
+			return nil
+		}
+
+		if err := fn(heads[next]); err != nil {
+			return err
+		}
+
+		record, err := readers[next].Next()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		heads[next] = record
+	}
+}
+
+// wait витримує паузу між записами з урахуванням швидкості
+func (r *Replay) wait(ctx context.Context, prevTime, nextTime int64) error {
+	if r.speed <= 0 || prevTime == 0 || nextTime <= prevTime {
+		return nil
+	}
+
+	delay := time.Duration(float64(time.Duration(nextTime-prevTime)*time.Millisecond) / r.speed)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (r *Replay) dispatch(record *BookRecord) {
+	r.mu.Lock()
+	manager := r.managers[record.Exchange]
+	r.played++
+	r.mu.Unlock()
+
+	if manager != nil {
+		manager.apply(record)
+	}
+}
+
+// ReplayManager Manager біржі, що отримує ордербуки з Replay замість WebSocket
+type ReplayManager struct {
+	exchange   string
+	symbols    map[string]bool // порожньо = всі символи
+	orderbooks map[string]*models.OrderBook
+	mu         sync.RWMutex
+
+	onOrderBookUpdate OrderBookCallback
+	onTicker          TickerCallback
+
+	telemetry *Telemetry
+	connected bool
+}
+
+// GetExchange повертає назву біржі
+func (m *ReplayManager) GetExchange() string {
+	return m.exchange
+}
+
+// Connect вмикає прийом записів (програвання запускає Replay.Run)
+func (m *ReplayManager) Connect(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.connected = true
+	m.telemetry.RecordConnected()
+	return nil
+}
+
+// Disconnect вимикає прийом записів
+func (m *ReplayManager) Disconnect() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.connected = false
+	m.telemetry.RecordDisconnected(nil)
+	return nil
+}
+
+// Subscribe обмежує програвання символами
+func (m *ReplayManager) Subscribe(symbols []string) error {
+	if len(symbols) == 0 {
+		return fmt.Errorf("no symbols to subscribe")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, symbol := range symbols {
+		m.symbols[symbol] = true
+	}
+
+	return nil
+}
+
+// Unsubscribe відписується від символів
+func (m *ReplayManager) Unsubscribe(symbols []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, symbol := range symbols {
+		delete(m.symbols, symbol)
+		delete(m.orderbooks, symbol)
+	}
+	m.telemetry.ForgetSymbols(symbols)
+
+	return nil
+}
+
+// Telemetry повертає метрики програвання (затримки немає - час записаний)
+func (m *ReplayManager) Telemetry() *Telemetry {
+	return m.telemetry
+}
+
+// IsConnected перевіряє чи менеджер приймає записи
+func (m *ReplayManager) IsConnected() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.connected
+}
+
+// GetOrderBook отримує OrderBook для символу
+func (m *ReplayManager) GetOrderBook(symbol string) *models.OrderBook {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.orderbooks[symbol]
+}
+
+// OnOrderBookUpdate встановлює callback для оновлень OrderBook
+func (m *ReplayManager) OnOrderBookUpdate(callback OrderBookCallback) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.onOrderBookUpdate = callback
+}
+
+// OnTicker встановлює callback для ticker (тікери не записуються)
+func (m *ReplayManager) OnTicker(callback TickerCallback) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.onTicker = callback
+}
+
+// apply застосовує запис до ордербуку і викликає callback
+func (m *ReplayManager) apply(record *BookRecord) {
+	m.mu.Lock()
+	if !m.connected || (len(m.symbols) > 0 && !m.symbols[record.Symbol]) {
+		m.mu.Unlock()
+		return
+	}
+
+	ob, ok := m.orderbooks[record.Symbol]
+	if !ok {
+		ob = models.NewOrderBook(m.exchange, record.Symbol)
+		m.orderbooks[record.Symbol] = ob
+	}
+	callback := m.onOrderBookUpdate
+	m.mu.Unlock()
+
+	bids, asks := record.Levels()
+	ob.Update(bids, asks, record.UpdateID)
+	m.telemetry.RecordMessage(record.Symbol, time.Time{})
+
+	if callback != nil {
+		callback(m.exchange, record.Symbol, ob)
+	}
+}
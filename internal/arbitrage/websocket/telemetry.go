@@ -0,0 +1,278 @@
+package websocket
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"sync"
+	"time"
+)
+
+// latencyBucketsMs межі гістограми затримок (мс), все більше - останній кошик
+var latencyBucketsMs = []float64{10, 25, 50, 100, 250, 500, 1000, 2500, 5000}
+
+// telemetryRateWindow вікно, за яке рахується частота оновлень
+const telemetryRateWindow = 10 * time.Second
+
+// Telemetry збирає метрики WebSocket фіду біржі. Manager повідомляє про
+// кожне оновлення ордербуку (з часом біржі, якщо він є в повідомленні),
+// підключення, розриви та помилки
+type Telemetry struct {
+	mu sync.Mutex
+
+	messages      int64
+	lastMessageAt time.Time
+	rate          rateWindow
+	symbolRates   map[string]*rateWindow
+
+	latencyCounts  []int64 // len(latencyBucketsMs)+1
+	latencySamples int64
+	latencySumMs   float64
+	latencyMaxMs   float64
+
+	connected         bool
+	everConnected     bool
+	reconnects        int
+	disconnectedAt    time.Time
+	disconnectedTotal time.Duration
+	lastError         string
+	lastErrorAt       time.Time
+
+	now func() time.Time
+}
+
+// NewTelemetry створює Telemetry (до першого підключення фід вважається відключеним)
+func NewTelemetry() *Telemetry {
+	return newTelemetryAt(time.Now)
+}
+
+func newTelemetryAt(now func() time.Time) *Telemetry {
+	return &Telemetry{
+		symbolRates:    make(map[string]*rateWindow),
+		latencyCounts:  make([]int64, len(latencyBucketsMs)+1),
+		disconnectedAt: now(),
+		now:            now,
+	}
+}
+
+// RecordMessage оновлення ордербуку символу. exchangeTime - час події на
+// біржі (нульовий, якщо біржа його не надсилає)
+func (t *Telemetry) RecordMessage(symbol string, exchangeTime time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+
+	t.messages++
+	t.lastMessageAt = now
+	t.rate.add(now)
+
+	rate, ok := t.symbolRates[symbol]
+	if !ok {
+		rate = &rateWindow{}
+		t.symbolRates[symbol] = rate
+	}
+	rate.add(now)
+
+	if exchangeTime.IsZero() {
+		return
+	}
+
+	// Годинники біржі та сервера не синхронізовані ідеально
+	latencyMs := float64(now.Sub(exchangeTime).Microseconds()) / 1000
+	if latencyMs < 0 {
+		latencyMs = 0
+	}
+
+	bucket := len(latencyBucketsMs)
+	for i, upTo := range latencyBucketsMs {
+		if latencyMs <= upTo {
+			bucket = i
+			break
+		}
+	}
+
+	t.latencyCounts[bucket]++
+	t.latencySamples++
+	t.latencySumMs += latencyMs
+	if latencyMs > t.latencyMaxMs {
+		t.latencyMaxMs = latencyMs
+	}
+}
+
+// ForgetSymbols прибирає частоту оновлень символів, від яких біржа відписалась
+func (t *Telemetry) ForgetSymbols(symbols []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, symbol := range symbols {
+		delete(t.symbolRates, symbol)
+	}
+}
+
+// RecordConnected з'єднання встановлено (повторне - перепідключення)
+func (t *Telemetry) RecordConnected() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.connected {
+		return
+	}
+
+	if t.everConnected {
+		t.reconnects++
+	}
+	t.everConnected = true
+	t.connected = true
+
+	t.disconnectedTotal += t.now().Sub(t.disconnectedAt)
+	t.disconnectedAt = time.Time{}
+}
+
+// RecordDisconnected з'єднання втрачено або закрито (err = nil)
+func (t *Telemetry) RecordDisconnected(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil {
+		t.setError(err)
+	}
+
+	if !t.connected {
+		return
+	}
+
+	t.connected = false
+	t.disconnectedAt = t.now()
+}
+
+// RecordError помилка фіду без розриву з'єднання (resync, ping, підключення)
+func (t *Telemetry) RecordError(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.setError(err)
+}
+
+func (t *Telemetry) setError(err error) {
+	t.lastError = err.Error()
+	t.lastErrorAt = t.now()
+}
+
+// Snapshot поточний стан телеметрії
+func (t *Telemetry) Snapshot() models.ExchangeTelemetry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+
+	snapshot := models.ExchangeTelemetry{
+		Messages:            t.messages,
+		MessagesPerSecond:   t.rate.current(now),
+		SymbolRates:         make(map[string]float64, len(t.symbolRates)),
+		LatencySamples:      t.latencySamples,
+		LatencyMaxMs:        t.latencyMaxMs,
+		LatencyHistogram:    make([]models.LatencyBucket, 0, len(t.latencyCounts)),
+		Reconnects:          t.reconnects,
+		DisconnectedSeconds: t.disconnectedTotal.Seconds(),
+		LastError:           t.lastError,
+	}
+
+	for symbol, rate := range t.symbolRates {
+		snapshot.SymbolRates[symbol] = rate.current(now)
+	}
+
+	if !t.lastMessageAt.IsZero() {
+		lastMessageAt := t.lastMessageAt
+		snapshot.LastMessageAt = &lastMessageAt
+	}
+
+	if !t.connected {
+		disconnectedAt := t.disconnectedAt
+		snapshot.DisconnectedSince = &disconnectedAt
+		snapshot.DisconnectedSeconds += now.Sub(disconnectedAt).Seconds()
+	}
+
+	if !t.lastErrorAt.IsZero() {
+		lastErrorAt := t.lastErrorAt
+		snapshot.LastErrorAt = &lastErrorAt
+	}
+
+	for i, count := range t.latencyCounts {
+		var upTo float64
+		if i < len(latencyBucketsMs) {
+			upTo = latencyBucketsMs[i]
+		}
+		snapshot.LatencyHistogram = append(snapshot.LatencyHistogram, models.LatencyBucket{UpToMs: upTo, Count: count})
+	}
+
+	if t.latencySamples > 0 {
+		snapshot.LatencyAvgMs = t.latencySumMs / float64(t.latencySamples)
+		snapshot.LatencyP50Ms = t.latencyPercentile(0.50)
+		snapshot.LatencyP99Ms = t.latencyPercentile(0.99)
+	}
+
+	return snapshot
+}
+
+// latencyPercentile верхня межа кошика, в який потрапляє перцентиль
+// (для останнього кошика - максимум)
+func (t *Telemetry) latencyPercentile(q float64) float64 {
+	target := q * float64(t.latencySamples)
+
+	var cumulative int64
+	for i, count := range t.latencyCounts {
+		cumulative += count
+		if float64(cumulative) < target {
+			continue
+		}
+
+		if i < len(latencyBucketsMs) {
+			return min(latencyBucketsMs[i], t.latencyMaxMs)
+		}
+		break
+	}
+
+	return t.latencyMaxMs
+}
+
+// rateWindow частота подій за останнє завершене вікно
+type rateWindow struct {
+	start time.Time
+	count int64
+	rate  float64
+}
+
+func (w *rateWindow) add(now time.Time) {
+	if w.start.IsZero() {
+		w.start = now
+	}
+
+	if elapsed := now.Sub(w.start); elapsed >= telemetryRateWindow {
+		w.rate = float64(w.count) / elapsed.Seconds()
+		w.start = now
+		w.count = 0
+	}
+
+	w.count++
+}
+
+func (w *rateWindow) current(now time.Time) float64 {
+	elapsed := now.Sub(w.start)
+	if elapsed <= 0 {
+		return w.rate
+	}
+
+	// Перше вікно ще не завершене або фід замовк - рахуємо по поточному
+	if w.rate == 0 || elapsed >= 2*telemetryRateWindow {
+		return float64(w.count) / elapsed.Seconds()
+	}
+
+	return w.rate
+}
+
+// msToTime час біржі з мілісекунд (0 - немає часу)
+func msToTime(ms int64) time.Time {
+	if ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
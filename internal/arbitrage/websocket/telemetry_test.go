@@ -0,0 +1,77 @@
+package websocket
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTelemetrySnapshot(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	telemetry := newTelemetryAt(func() time.Time { return now })
+
+	// 5 секунд до першого підключення
+	now = now.Add(5 * time.Second)
+	telemetry.RecordConnected()
+
+	// 20 оновлень BTC за 10 секунд: 18 з затримкою 40мс, 2 - 3с
+	for i := 0; i < 20; i++ {
+		latency := 40 * time.Millisecond
+		if i >= 18 {
+			latency = 3 * time.Second
+		}
+		telemetry.RecordMessage("BTC/USDT", now.Add(-latency))
+		now = now.Add(500 * time.Millisecond)
+	}
+	telemetry.RecordMessage("ETH/USDT", time.Time{}) // біржа без часу події
+
+	snapshot := telemetry.Snapshot()
+
+	if snapshot.Messages != 21 || snapshot.LatencySamples != 20 {
+		t.Fatalf("Expected 21 messages with 20 latency samples, got %d/%d", snapshot.Messages, snapshot.LatencySamples)
+	}
+	if snapshot.LatencyP50Ms != 50 || snapshot.LatencyP99Ms != 3000 || snapshot.LatencyMaxMs != 3000 {
+		t.Errorf("Expected p50 50ms, p99/max 3000ms, got %.0f/%.0f/%.0f",
+			snapshot.LatencyP50Ms, snapshot.LatencyP99Ms, snapshot.LatencyMaxMs)
+	}
+	if math.Abs(snapshot.LatencyAvgMs-336) > 1e-9 {
+		t.Errorf("Expected avg 336ms, got %.2f", snapshot.LatencyAvgMs)
+	}
+	if rate := snapshot.SymbolRates["BTC/USDT"]; math.Abs(rate-2) > 1e-9 {
+		t.Errorf("Expected BTC 2 updates/s, got %.2f", rate)
+	}
+	if snapshot.DisconnectedSeconds != 5 || snapshot.DisconnectedSince != nil {
+		t.Errorf("Expected 5s disconnected before first connect, got %.1fs", snapshot.DisconnectedSeconds)
+	}
+
+	// Розрив на 30 секунд і перепідключення
+	telemetry.RecordDisconnected(errors.New("read: connection reset"))
+	now = now.Add(30 * time.Second)
+
+	snapshot = telemetry.Snapshot()
+	if snapshot.DisconnectedSince == nil || snapshot.DisconnectedSeconds != 35 {
+		t.Errorf("Expected open disconnect totalling 35s, got %.1fs", snapshot.DisconnectedSeconds)
+	}
+	if snapshot.MessagesPerSecond > 0.5 {
+		t.Errorf("Expected rate to decay while feed is silent, got %.2f", snapshot.MessagesPerSecond)
+	}
+
+	telemetry.RecordConnected()
+
+	snapshot = telemetry.Snapshot()
+	if snapshot.Reconnects != 1 || snapshot.DisconnectedSeconds != 35 {
+		t.Errorf("Expected 1 reconnect and 35s disconnected, got %d/%.1fs", snapshot.Reconnects, snapshot.DisconnectedSeconds)
+	}
+	if snapshot.LastError != "read: connection reset" || snapshot.LastErrorAt == nil {
+		t.Errorf("Expected last read error, got %q", snapshot.LastError)
+	}
+
+	// Відписка (ротація universe) прибирає частоту символу
+	telemetry.ForgetSymbols([]string{"ETH/USDT"})
+
+	snapshot = telemetry.Snapshot()
+	if _, ok := snapshot.SymbolRates["ETH/USDT"]; ok || len(snapshot.SymbolRates) != 1 {
+		t.Errorf("Expected only BTC rate after unsubscribing ETH, got %v", snapshot.SymbolRates)
+	}
+}
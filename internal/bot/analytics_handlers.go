@@ -375,8 +375,10 @@ func (b *Bot) buildAnalyticsKeyboard() *tgbotapi.InlineKeyboardMarkup {
 
 // isAdmin checks if user has admin privileges
 func (b *Bot) isAdmin(user *models.User) bool {
-	// You can implement admin check logic here
-	// For now, check against config or database
-	// Example: check if user is in admin list
-	return user.TelegramID == 123456789 // Replace with actual admin check
+	for _, id := range b.config.Telegram.AdminIDs {
+		if user.TelegramID == id {
+			return true
+		}
+	}
+	return false
 }
@@ -10,7 +10,7 @@ import (
 
 // handleArbitrage обробляє команду /arbitrage (тільки для Premium)
 func (b *Bot) handleArbitrage(message *tgbotapi.Message) {
-	user, _ := b.getUserAndPrefs(message.From.ID)
+	user, prefs := b.getUserAndPrefs(message.From.ID)
 
 	// Premium only
 	if user == nil || !user.IsPremium() {
@@ -50,7 +50,7 @@ func (b *Bot) handleArbitrage(message *tgbotapi.Message) {
 	// Форматувати повідомлення
 	text := fmt.Sprintf("🔥 <b>Топ %d арбітражних можливостей</b>\n\n", len(opportunities))
 	for i, opp := range opportunities {
-		text += formatArbitrageOpportunity(opp, i+1)
+		text += formatArbitrageOpportunity(forArbitrageMode(opp, prefs), i+1)
 		text += "\n"
 	}
 
@@ -69,7 +69,7 @@ func (b *Bot) handleArbitrageRefresh(callback *tgbotapi.CallbackQuery) {
 	// Answer callback
 	b.sendMessage(tgbotapi.NewCallback(callback.ID, "🔄 Оновлюю..."))
 
-	user, _ := b.getUserAndPrefs(callback.From.ID)
+	user, prefs := b.getUserAndPrefs(callback.From.ID)
 
 	// Premium only
 	if user == nil || !user.IsPremium() {
@@ -96,7 +96,7 @@ func (b *Bot) handleArbitrageRefresh(callback *tgbotapi.CallbackQuery) {
 		text = fmt.Sprintf("🔥 <b>Топ %d арбітражних можливостей</b>\n\n", len(opportunities))
 
 		for i, opp := range opportunities {
-			text += formatArbitrageOpportunity(opp, i+1)
+			text += formatArbitrageOpportunity(forArbitrageMode(opp, prefs), i+1)
 			text += "\n"
 		}
 
@@ -113,6 +113,14 @@ func (b *Bot) handleArbitrageRefresh(callback *tgbotapi.CallbackQuery) {
 	b.sendMessage(edit)
 }
 
+// forArbitrageMode перераховує прибуток під модель торгівлі користувача
+func forArbitrageMode(opp *models.ArbitrageOpportunity, prefs *models.UserPreferences) *models.ArbitrageOpportunity {
+	if prefs != nil && prefs.IsPrefunded() {
+		return opp.WithPrefunded(prefs.RebalanceTrades)
+	}
+	return opp
+}
+
 // formatArbitrageOpportunity форматує одну арбітражну можливість
 func formatArbitrageOpportunity(opp *models.ArbitrageOpportunity, index int) string {
 	emoji := "💰"
@@ -138,7 +146,7 @@ func formatArbitrageOpportunity(opp *models.ArbitrageOpportunity, index int) str
 			"├ 🔴 Продати: <b>%s</b> @ <code>$%.2f</code>\n"+
 			"├ 💵 Валовий profit: <b>%.2f%%</b>\n"+
 			"├ 💸 На $1000: <b>$%.2f</b>\n"+
-			"├ 📊 Рекомендовано: <b>$%.0f-%.0f</b>\n"+
+			"├ 📊 Оптимально: <b>$%.0f</b> (окупається від $%.0f)\n"+
 			"├ ⚠️ Fees: -%.2f%% (trading + withdrawal)\n"+
 			"├ 📉 Slippage: -%.2f%% (buy+sell)\n"+
 			"├ ✅ Чистий profit: <b>%.2f%%</b> (<b>$%.2f</b> на $1000)\n"+
@@ -148,7 +156,7 @@ func formatArbitrageOpportunity(opp *models.ArbitrageOpportunity, index int) str
 		sellExchangeCap, opp.PriceSell,
 		opp.ProfitPercent,
 		opp.ProfitUSD,
-		opp.MinTradeAmount, opp.RecommendedAmount,
+		opp.OptimalAmount, opp.BreakEvenAmount,
 		opp.TotalFeesPercent,
 		opp.SlippageBuy+opp.SlippageSell,
 		opp.NetProfitPercent, opp.NetProfitUSD,
@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleArbMode обробляє команду /arbmode (тільки для Premium)
+//
+//	/arbmode                    - показати поточний режим
+//	/arbmode transfer           - переказ монет між біржами в кожній угоді
+//	/arbmode prefunded [угод]   - баланси на обох біржах, ребаланс раз на N угод
+func (b *Bot) handleArbMode(message *tgbotapi.Message) {
+	user, prefs := b.getUserAndPrefs(message.From.ID)
+
+	// Premium only
+	if user == nil || !user.IsPremium() {
+		b.sendPremiumRequired(message.Chat.ID)
+		return
+	}
+
+	if prefs == nil {
+		b.sendError(message.Chat.ID)
+		return
+	}
+
+	args := strings.Fields(strings.ToLower(message.CommandArguments()))
+
+	switch {
+	case len(args) == 0:
+		b.showArbMode(message.Chat.ID, prefs)
+	case args[0] == models.ArbitrageModeTransfer && len(args) == 1:
+		prefs.ArbitrageMode = models.ArbitrageModeTransfer
+		b.saveArbMode(message.Chat.ID, prefs)
+	case args[0] == models.ArbitrageModePrefunded && len(args) <= 2:
+		if len(args) == 2 {
+			trades, err := strconv.Atoi(args[1])
+			if err != nil || trades < 1 || trades > 1000 {
+				b.sendArbModeUsage(message.Chat.ID)
+				return
+			}
+			prefs.RebalanceTrades = trades
+		}
+		prefs.ArbitrageMode = models.ArbitrageModePrefunded
+		b.saveArbMode(message.Chat.ID, prefs)
+	default:
+		b.sendArbModeUsage(message.Chat.ID)
+	}
+}
+
+// showArbMode показує поточну модель розрахунку прибутку
+func (b *Bot) showArbMode(chatID int64, prefs *models.UserPreferences) {
+	text := "🏦 <b>Режим арбітражу</b>\n\n"
+
+	if prefs.IsPrefunded() {
+		text += fmt.Sprintf("Зараз: <b>pre-funded</b> - баланси на обох біржах, ребаланс раз на <b>%d</b> угод.\n", prefs.RebalanceTrades)
+		text += "Комісія виведення не списується з кожної угоди, замість неї - частка вартості ребалансу.\n\n"
+	} else {
+		text += "Зараз: <b>transfer</b> - монети переводяться між біржами в кожній угоді.\n"
+		text += "Прибуток враховує комісію виведення.\n\n"
+	}
+
+	text += "Змінити:\n"
+	text += "<code>/arbmode transfer</code>\n"
+	text += "<code>/arbmode prefunded 20</code>"
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "HTML"
+	b.sendMessage(msg)
+}
+
+// saveArbMode зберігає режим в налаштуваннях користувача
+func (b *Bot) saveArbMode(chatID int64, prefs *models.UserPreferences) {
+	if err := b.prefsRepo.Update(prefs); err != nil {
+		log.Printf("Failed to save arbitrage mode for user %d: %v", prefs.UserID, err)
+		b.sendError(chatID)
+		return
+	}
+
+	text := "✅ Режим арбітражу: <b>transfer</b>\n\nАлерти враховують переказ монет в кожній угоді."
+	if prefs.IsPrefunded() {
+		text = fmt.Sprintf("✅ Режим арбітражу: <b>pre-funded</b> (ребаланс раз на %d угод)\n\n"+
+			"Алерти розраховуються для торгівлі з балансів на обох біржах.", prefs.RebalanceTrades)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "HTML"
+	b.sendMessage(msg)
+}
+
+func (b *Bot) sendArbModeUsage(chatID int64) {
+	text := "🏦 <b>Використання /arbmode</b>\n\n" +
+		"<code>/arbmode</code> - показати режим\n" +
+		"<code>/arbmode transfer</code> - переказ в кожній угоді\n" +
+		"<code>/arbmode prefunded [угод]</code> - баланси на обох біржах, ребаланс раз на N угод (1-1000)"
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "HTML"
+	b.sendMessage(msg)
+}
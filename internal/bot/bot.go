@@ -23,6 +23,10 @@ type Bot struct {
 	arbRepo           repository.ArbitrageRepository
 	defiRepo          repository.DeFiRepository
 	whaleRepo         repository.WhaleRepository
+	feeRepo           repository.FeeRepository
+	clientStatsRepo   repository.ClientStatisticsRepository
+	priceAlertRepo    repository.PriceAlertRepository
+	fundingRepo       repository.FundingRepository
 	paymentService    *payment.Service
 	analyticsService  *analytics.Service
 	referralService   *referral.Service
@@ -41,6 +45,10 @@ func NewBot(
 	arbRepo repository.ArbitrageRepository,
 	defiRepo repository.DeFiRepository,
 	whaleRepo repository.WhaleRepository,
+	feeRepo repository.FeeRepository,
+	clientStatsRepo repository.ClientStatisticsRepository,
+	priceAlertRepo repository.PriceAlertRepository,
+	fundingRepo repository.FundingRepository,
 	paymentService *payment.Service,
 	referralService *referral.Service,
 	analyticsService *analytics.Service,
@@ -64,6 +72,10 @@ func NewBot(
 		arbRepo:           arbRepo,
 		defiRepo:          defiRepo,
 		whaleRepo:         whaleRepo,
+		feeRepo:           feeRepo,
+		clientStatsRepo:   clientStatsRepo,
+		priceAlertRepo:    priceAlertRepo,
+		fundingRepo:       fundingRepo,
 		paymentService:    paymentService,
 		referralService:   referralService,
 		analyticsService:  analyticsService,
@@ -136,6 +148,16 @@ func (b *Bot) handleMessage(message *tgbotapi.Message) {
 		b.handleInvite(message)
 	case CommandWhales:
 		b.handleWhales(message)
+	case CommandFees:
+		b.handleFees(message)
+	case CommandArbMode:
+		b.handleArbMode(message)
+	case CommandPaper:
+		b.handlePaper(message)
+	case CommandPriceAlerts:
+		b.handlePriceAlerts(message)
+	case CommandFunding:
+		b.handleFunding(message)
 	case "client":
 		b.handleClient(message)
 	case "clientstats":
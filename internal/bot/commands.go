@@ -17,6 +17,11 @@ const (
 	CommandReferral     = "referral"
 	CommandInvite       = "invite"
 	CommandWhales       = "whales"
+	CommandFees         = "fees"
+	CommandArbMode      = "arbmode"
+	CommandPaper        = "paper"
+	CommandPriceAlerts  = "pricealerts"
+	CommandFunding      = "funding"
 )
 
 // Callback data для inline buttons
@@ -0,0 +1,184 @@
+package bot
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// supportedFeeExchanges біржі, для яких можна вказати свій VIP рівень
+var supportedFeeExchanges = []string{
+	models.ExchangeBinance,
+	models.ExchangeBybit,
+	models.ExchangeOKX,
+	models.ExchangeGateIO,
+	models.ExchangeKraken,
+}
+
+// handleFees обробляє команду /fees (тільки для Premium)
+//
+//	/fees                             - показати комісії
+//	/fees <exchange> <maker> <taker>  - встановити свій VIP рівень (%)
+//	/fees <exchange> reset            - повернутись до стандартних комісій
+func (b *Bot) handleFees(message *tgbotapi.Message) {
+	user, _ := b.getUserAndPrefs(message.From.ID)
+
+	// Premium only
+	if user == nil || !user.IsPremium() {
+		b.sendPremiumRequired(message.Chat.ID)
+		return
+	}
+
+	args := strings.Fields(message.CommandArguments())
+
+	switch {
+	case len(args) == 0:
+		b.showFees(message.Chat.ID, user.ID)
+	case len(args) == 2 && strings.ToLower(args[1]) == "reset":
+		b.resetFeeTier(message.Chat.ID, user.ID, strings.ToLower(args[0]))
+	case len(args) >= 3:
+		b.setFeeTier(message.Chat.ID, user.ID, args)
+	default:
+		b.sendFeesUsage(message.Chat.ID)
+	}
+}
+
+// showFees показує синхронізовані комісії бірж та персональні рівні користувача
+func (b *Bot) showFees(chatID int64, userID uint) {
+	tiers, err := b.feeRepo.GetUserFeeTiers(userID)
+	if err != nil {
+		log.Printf("Failed to get fee tiers for user %d: %v", userID, err)
+		b.sendError(chatID)
+		return
+	}
+
+	fees, err := b.feeRepo.ListExchangeFees()
+	if err != nil {
+		log.Printf("Failed to get exchange fees: %v", err)
+		b.sendError(chatID)
+		return
+	}
+
+	userTiers := make(map[string]*models.UserFeeTier, len(tiers))
+	for _, tier := range tiers {
+		userTiers[tier.Exchange] = tier
+	}
+
+	tradingFees := make(map[string]*models.ExchangeFee)
+	for _, fee := range fees {
+		if fee.FeeType == models.FeeTypeTrading {
+			tradingFees[fee.Exchange] = fee
+		}
+	}
+
+	text := "💸 <b>Торгові комісії</b>\n\n"
+
+	for _, exchange := range supportedFeeExchanges {
+		text += fmt.Sprintf("<b>%s</b>\n", strings.ToUpper(exchange))
+
+		if fee, ok := tradingFees[exchange]; ok {
+			text += fmt.Sprintf("   Біржа: maker %.3f%% / taker %.3f%% <i>(оновлено %s)</i>\n",
+				fee.MakerFee, fee.TakerFee, fee.FetchedAt.Format("02.01 15:04"))
+		} else {
+			text += "   Біржа: стандартна таблиця\n"
+		}
+
+		if tier, ok := userTiers[exchange]; ok {
+			label := ""
+			if tier.Tier != "" {
+				label = " (" + tier.Tier + ")"
+			}
+			text += fmt.Sprintf("   ⭐ Ваш рівень%s: maker %.3f%% / taker %.3f%%\n", label, tier.MakerFee, tier.TakerFee)
+		}
+
+		text += "\n"
+	}
+
+	text += "💡 Арбітражні алерти перераховуються з вашою taker комісією.\n"
+	text += "Встановити: <code>/fees binance 0.075 0.075 VIP1</code>\n"
+	text += "Скинути: <code>/fees binance reset</code>"
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "HTML"
+	b.sendMessage(msg)
+}
+
+// setFeeTier зберігає персональні maker/taker комісії користувача
+func (b *Bot) setFeeTier(chatID int64, userID uint, args []string) {
+	exchange := strings.ToLower(args[0])
+	if !isSupportedFeeExchange(exchange) {
+		b.sendFeesUsage(chatID)
+		return
+	}
+
+	maker, errMaker := strconv.ParseFloat(strings.TrimSuffix(args[1], "%"), 64)
+	taker, errTaker := strconv.ParseFloat(strings.TrimSuffix(args[2], "%"), 64)
+	if errMaker != nil || errTaker != nil || maker < 0 || taker < 0 || maker > 1 || taker > 1 {
+		msg := tgbotapi.NewMessage(chatID, "⚠️ Комісії вказуються у відсотках від 0 до 1, наприклад: /fees binance 0.075 0.075")
+		b.sendMessage(msg)
+		return
+	}
+
+	tier := &models.UserFeeTier{
+		UserID:   userID,
+		Exchange: exchange,
+		MakerFee: maker,
+		TakerFee: taker,
+	}
+	if len(args) > 3 {
+		tier.Tier = strings.ToUpper(args[3])
+	}
+
+	if err := b.feeRepo.UpsertUserFeeTier(tier); err != nil {
+		log.Printf("Failed to save fee tier for user %d: %v", userID, err)
+		b.sendError(chatID)
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(
+		"✅ Комісії для %s збережено: maker %.3f%% / taker %.3f%%\n\nПрибуток в арбітражних алертах тепер розраховується для вашого акаунту.",
+		strings.ToUpper(exchange), maker, taker))
+	b.sendMessage(msg)
+}
+
+// resetFeeTier видаляє персональний рівень комісій
+func (b *Bot) resetFeeTier(chatID int64, userID uint, exchange string) {
+	if !isSupportedFeeExchange(exchange) {
+		b.sendFeesUsage(chatID)
+		return
+	}
+
+	if err := b.feeRepo.DeleteUserFeeTier(userID, exchange); err != nil {
+		log.Printf("Failed to delete fee tier for user %d: %v", userID, err)
+		b.sendError(chatID)
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("✅ Для %s використовуються стандартні комісії біржі", strings.ToUpper(exchange)))
+	b.sendMessage(msg)
+}
+
+func (b *Bot) sendFeesUsage(chatID int64) {
+	text := "💸 <b>Використання /fees</b>\n\n" +
+		"<code>/fees</code> - показати комісії\n" +
+		"<code>/fees &lt;біржа&gt; &lt;maker%&gt; &lt;taker%&gt; [VIP]</code> - свій рівень\n" +
+		"<code>/fees &lt;біржа&gt; reset</code> - скинути\n\n" +
+		"Біржі: " + strings.Join(supportedFeeExchanges, ", ")
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "HTML"
+	b.sendMessage(msg)
+}
+
+func isSupportedFeeExchange(exchange string) bool {
+	for _, e := range supportedFeeExchanges {
+		if e == exchange {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleFunding обробляє команду /funding (тільки для Premium)
+//
+//	/funding      - активні funding можливості
+//	/funding on   - отримувати сповіщення
+//	/funding off  - вимкнути сповіщення
+func (b *Bot) handleFunding(message *tgbotapi.Message) {
+	user, prefs := b.getUserAndPrefs(message.From.ID)
+
+	// Premium only
+	if user == nil || !user.IsPremium() {
+		b.sendPremiumRequired(message.Chat.ID)
+		return
+	}
+
+	if prefs == nil {
+		b.sendError(message.Chat.ID)
+		return
+	}
+
+	args := strings.Fields(strings.ToLower(message.CommandArguments()))
+
+	switch {
+	case len(args) == 0:
+		b.showFunding(message.Chat.ID, prefs)
+	case len(args) == 1 && (args[0] == "on" || args[0] == "off"):
+		prefs.NotifyFunding = args[0] == "on"
+
+		if err := b.prefsRepo.Update(prefs); err != nil {
+			log.Printf("Failed to save funding notifications for user %d: %v", prefs.UserID, err)
+			b.sendError(message.Chat.ID)
+			return
+		}
+
+		text := "⏸ Funding сповіщення вимкнено."
+		if prefs.NotifyFunding {
+			text = "✅ Funding сповіщення увімкнено.\n\n" +
+				"Ти отримаєш сповіщення про дельта-нейтральні позиції (spot + perp або perp + perp) " +
+				"на твоїх біржах. Активні можливості: /funding"
+		}
+
+		b.sendMessage(tgbotapi.NewMessage(message.Chat.ID, text))
+	default:
+		text := "💸 <b>Використання /funding</b>\n\n" +
+			"<code>/funding</code> - активні funding можливості\n" +
+			"<code>/funding on</code> - отримувати сповіщення\n" +
+			"<code>/funding off</code> - вимкнути"
+
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = "HTML"
+		b.sendMessage(msg)
+	}
+}
+
+// showFunding показує активні funding можливості
+func (b *Bot) showFunding(chatID int64, prefs *models.UserPreferences) {
+	text := "💸 <b>Funding rate можливості</b>\n\n"
+
+	if prefs.NotifyFunding {
+		text += "Сповіщення: <b>увімкнено</b> (<code>/funding off</code>)\n\n"
+	} else {
+		text += "Сповіщення: <b>вимкнено</b> (<code>/funding on</code>)\n\n"
+	}
+
+	opps, err := b.fundingRepo.GetActive(10)
+	if err != nil {
+		log.Printf("Failed to get active funding opportunities: %v", err)
+		b.sendError(chatID)
+		return
+	}
+
+	if len(opps) == 0 {
+		text += "<i>Зараз немає позицій з дохідністю вище порогу.</i>"
+	}
+
+	for i, opp := range opps {
+		longLeg := opp.LongExchange + " spot"
+		if opp.LongMarket == models.MarketPerp {
+			longLeg = opp.LongExchange + " perp"
+		}
+
+		text += fmt.Sprintf("%d. <b>%s</b>: long %s / short %s perp, net APR %.2f%% (до нарахування %s)\n",
+			i+1, opp.Symbol, longLeg, opp.ShortExchange, opp.NetAPR, time.Until(opp.ExpiresAt).Round(time.Minute))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "HTML"
+	b.sendMessage(msg)
+}
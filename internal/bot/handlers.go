@@ -85,6 +85,11 @@ func (b *Bot) handleHelp(message *tgbotapi.Message) {
 /settings - Налаштування
 /premium - Інформація про Premium
 /arbitrage - Арбітражні можливості
+/fees - Комісії бірж та ваш VIP рівень
+/arbmode - Режим арбітражу: переказ або pre-funded баланси
+/paper - Paper trading: симуляція угод по алертах
+/pricealerts - Розбіжності цін між біржами та depeg стейблкоїнів
+/funding - Funding rate та базис spot-perp
 /support - Зв'язатись з підтримкою
 
 💡 Підказка: Використовуй кнопки меню для швидкого доступу!
@@ -368,7 +373,8 @@ func (b *Bot) shouldShowOpportunity(user *models.User, prefs *models.UserPrefere
 		return false
 	}
 
-	isPremiumOpp := opp.Type == models.OpportunityTypeArbitrage || opp.Type == models.OpportunityTypeDeFi
+	isPremiumOpp := opp.Type == models.OpportunityTypeArbitrage || opp.Type == models.OpportunityTypeDeFi ||
+		opp.Type == models.OpportunityTypeFunding
 	if isPremiumOpp && !user.IsPremium() {
 		return false
 	}
@@ -496,6 +502,10 @@ func (b *Bot) getTypeEmoji(oppType string) string {
 		return "🔥"
 	case models.OpportunityTypeDeFi:
 		return "🌾"
+	case models.OpportunityTypeFunding:
+		return "💸"
+	case models.OpportunityTypePriceAlert:
+		return "🚨"
 	default:
 		return "💰"
 	}
@@ -517,6 +527,10 @@ func (b *Bot) getTypeName(oppType string) string {
 		return "Арбітраж"
 	case models.OpportunityTypeDeFi:
 		return "DeFi"
+	case models.OpportunityTypeFunding:
+		return "Funding"
+	case models.OpportunityTypePriceAlert:
+		return "Аномалії цін"
 	default:
 		return "Інше"
 	}
@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handlePaper обробляє команду /paper (тільки для Premium)
+//
+//	/paper      - статус та статистика симульованих угод
+//	/paper on   - симулювати угоду по кожному арбітражному алерту
+//	/paper off  - вимкнути симуляцію
+func (b *Bot) handlePaper(message *tgbotapi.Message) {
+	user, prefs := b.getUserAndPrefs(message.From.ID)
+
+	// Premium only
+	if user == nil || !user.IsPremium() {
+		b.sendPremiumRequired(message.Chat.ID)
+		return
+	}
+
+	if prefs == nil {
+		b.sendError(message.Chat.ID)
+		return
+	}
+
+	args := strings.Fields(strings.ToLower(message.CommandArguments()))
+
+	switch {
+	case len(args) == 0:
+		b.showPaperStats(message.Chat.ID, user, prefs)
+	case len(args) == 1 && (args[0] == "on" || args[0] == "off"):
+		prefs.PaperTrading = args[0] == "on"
+
+		if err := b.prefsRepo.Update(prefs); err != nil {
+			log.Printf("Failed to save paper trading for user %d: %v", prefs.UserID, err)
+			b.sendError(message.Chat.ID)
+			return
+		}
+
+		text := "⏸ Paper trading вимкнено."
+		if prefs.PaperTrading {
+			text = "✅ Paper trading увімкнено.\n\n" +
+				"Кожен арбітражний алерт буде \"виконано\" по живих ордербуках через кілька секунд після доставки. " +
+				"Результати: /paper"
+		}
+
+		b.sendMessage(tgbotapi.NewMessage(message.Chat.ID, text))
+	default:
+		text := "📝 <b>Використання /paper</b>\n\n" +
+			"<code>/paper</code> - статистика симульованих угод\n" +
+			"<code>/paper on</code> - симулювати угоди по алертах\n" +
+			"<code>/paper off</code> - вимкнути"
+
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = "HTML"
+		b.sendMessage(msg)
+	}
+}
+
+// showPaperStats показує статистику paper trading користувача
+func (b *Bot) showPaperStats(chatID int64, user *models.User, prefs *models.UserPreferences) {
+	text := "📝 <b>Paper Trading</b>\n\n"
+
+	if prefs.PaperTrading {
+		text += "Статус: <b>увімкнено</b> (<code>/paper off</code>)\n\n"
+	} else {
+		text += "Статус: <b>вимкнено</b> (<code>/paper on</code>)\n\n"
+	}
+
+	stats, err := b.clientStatsRepo.GetPaperByUserID(user.ID)
+	if err != nil {
+		log.Printf("Failed to get paper statistics for user %d: %v", user.ID, err)
+		b.sendError(chatID)
+		return
+	}
+
+	if stats == nil || stats.TotalTrades == 0 {
+		text += "<i>Симульованих угод ще немає. Вони з'являться після наступних арбітражних алертів.</i>"
+	} else {
+		text += fmt.Sprintf("🔄 Угод: %d (✅ прибуткових %d, ❌ не виконано %d)\n", stats.TotalTrades, stats.SuccessfulTrades, stats.FailedTrades)
+		text += fmt.Sprintf("📈 Win rate: %.0f%%\n", stats.WinRate)
+		text += fmt.Sprintf("💰 Чистий прибуток: $%.2f (ROI %.2f%%)\n", stats.NetProfit, stats.ROI())
+		text += fmt.Sprintf("🏆 Кращий: $%.2f | Гірший: $%.2f\n", stats.BestTrade, stats.WorstTrade)
+		text += "\n<i>Симуляція враховує fees, slippage та затримку реакції, але не гарантує реальне виконання.</i>"
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "HTML"
+	b.sendMessage(msg)
+}
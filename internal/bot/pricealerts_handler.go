@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handlePriceAlerts обробляє команду /pricealerts (тільки для Premium)
+//
+//	/pricealerts      - активні розбіжності цін та depeg
+//	/pricealerts on   - отримувати алерти
+//	/pricealerts off  - вимкнути алерти
+func (b *Bot) handlePriceAlerts(message *tgbotapi.Message) {
+	user, prefs := b.getUserAndPrefs(message.From.ID)
+
+	// Premium only
+	if user == nil || !user.IsPremium() {
+		b.sendPremiumRequired(message.Chat.ID)
+		return
+	}
+
+	if prefs == nil {
+		b.sendError(message.Chat.ID)
+		return
+	}
+
+	args := strings.Fields(strings.ToLower(message.CommandArguments()))
+
+	switch {
+	case len(args) == 0:
+		b.showPriceAlerts(message.Chat.ID, prefs)
+	case len(args) == 1 && (args[0] == "on" || args[0] == "off"):
+		prefs.NotifyPriceAlerts = args[0] == "on"
+
+		if err := b.prefsRepo.Update(prefs); err != nil {
+			log.Printf("Failed to save price alerts for user %d: %v", prefs.UserID, err)
+			b.sendError(message.Chat.ID)
+			return
+		}
+
+		text := "⏸ Алерти цін вимкнено."
+		if prefs.NotifyPriceAlerts {
+			text = "✅ Алерти цін увімкнено.\n\n" +
+				"Ти отримаєш сповіщення, коли ціна на одній з твоїх бірж тривалий час відрізняється від інших " +
+				"або стейблкоїн відходить від $1. Активні алерти: /pricealerts"
+		}
+
+		b.sendMessage(tgbotapi.NewMessage(message.Chat.ID, text))
+	default:
+		text := "🚨 <b>Використання /pricealerts</b>\n\n" +
+			"<code>/pricealerts</code> - активні розбіжності цін та depeg\n" +
+			"<code>/pricealerts on</code> - отримувати алерти\n" +
+			"<code>/pricealerts off</code> - вимкнути"
+
+		msg := tgbotapi.NewMessage(message.Chat.ID, text)
+		msg.ParseMode = "HTML"
+		b.sendMessage(msg)
+	}
+}
+
+// showPriceAlerts показує активні алерти цін
+func (b *Bot) showPriceAlerts(chatID int64, prefs *models.UserPreferences) {
+	text := "🚨 <b>Аномалії цін</b>\n\n"
+
+	if prefs.NotifyPriceAlerts {
+		text += "Сповіщення: <b>увімкнено</b> (<code>/pricealerts off</code>)\n\n"
+	} else {
+		text += "Сповіщення: <b>вимкнено</b> (<code>/pricealerts on</code>)\n\n"
+	}
+
+	alerts, err := b.priceAlertRepo.GetActive(10)
+	if err != nil {
+		log.Printf("Failed to get active price alerts: %v", err)
+		b.sendError(chatID)
+		return
+	}
+
+	if len(alerts) == 0 {
+		text += "<i>Зараз ціни на всіх біржах узгоджені, стейблкоїни тримають прив'язку.</i>"
+	}
+
+	for i, alert := range alerts {
+		since := time.Since(alert.StartedAt).Round(time.Minute)
+
+		if alert.IsDepeg() {
+			text += fmt.Sprintf("%d. 🪙 <b>%s</b> depeg: %.4f (%+.2f%%), %s\n",
+				i+1, alert.Symbol, alert.Price, alert.DeviationPercent, since)
+			continue
+		}
+
+		text += fmt.Sprintf("%d. 📐 <b>%s</b> на %s: %.4f vs медіана %.4f (%+.2f%%), %s\n",
+			i+1, alert.Symbol, alert.Exchange, alert.Price, alert.ReferencePrice, alert.DeviationPercent, since)
+	}
+
+	if len(alerts) > 0 {
+		text += "\n<i>Тривала розбіжність часто означає закриті виведення або проблеми біржі - це не арбітражний сигнал.</i>"
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "HTML"
+	b.sendMessage(msg)
+}
@@ -10,12 +10,14 @@ import (
 
 // Scheduler відповідає за автоматичне очищення старих даних
 type Scheduler struct {
-	cron         *cron.Cron
-	oppRepo      repository.OpportunityRepository
-	arbRepo      repository.ArbitrageRepository
-	defiRepo     repository.DeFiRepository
-	notifRepo    repository.NotificationRepository
-	config       *Config
+	cron           *cron.Cron
+	oppRepo        repository.OpportunityRepository
+	arbRepo        repository.ArbitrageRepository
+	fundingRepo    repository.FundingRepository
+	defiRepo       repository.DeFiRepository
+	notifRepo      repository.NotificationRepository
+	scraperRunRepo repository.ScraperRunRepository
+	config         *Config
 }
 
 // Config налаштування для cleanup операцій
@@ -26,6 +28,9 @@ type Config struct {
 	// ArbitrageRetentionDays - скільки днів зберігати arbitrage opportunities
 	ArbitrageRetentionDays int
 
+	// FundingRetentionDays - скільки днів зберігати funding opportunities
+	FundingRetentionDays int
+
 	// DeFiRetentionDays - скільки днів зберігати DeFi opportunities
 	DeFiRetentionDays int
 
@@ -35,6 +40,9 @@ type Config struct {
 	// FailedNotificationsRetentionDays - скільки днів зберігати failed notifications
 	FailedNotificationsRetentionDays int
 
+	// ScraperRunsRetentionDays - скільки днів зберігати історію запусків scrapers
+	ScraperRunsRetentionDays int
+
 	// Schedule - cron schedule для cleanup (default: "0 2 * * *" - щодня о 2:00)
 	Schedule string
 }
@@ -44,9 +52,11 @@ func DefaultConfig() *Config {
 	return &Config{
 		OpportunitiesRetentionDays:       30,  // 30 днів для звичайних opportunities
 		ArbitrageRetentionDays:           7,   // 7 днів для arbitrage
+		FundingRetentionDays:             7,   // 7 днів для funding
 		DeFiRetentionDays:                7,   // 7 днів для DeFi
 		SentNotificationsRetentionDays:   90,  // 90 днів для відправлених
 		FailedNotificationsRetentionDays: 30,  // 30 днів для failed
+		ScraperRunsRetentionDays:         30,  // 30 днів історії scrapers
 		Schedule:                         "0 2 * * *", // Щодня о 2:00 AM
 	}
 }
@@ -55,8 +65,10 @@ func DefaultConfig() *Config {
 func NewScheduler(
 	oppRepo repository.OpportunityRepository,
 	arbRepo repository.ArbitrageRepository,
+	fundingRepo repository.FundingRepository,
 	defiRepo repository.DeFiRepository,
 	notifRepo repository.NotificationRepository,
+	scraperRunRepo repository.ScraperRunRepository,
 	config *Config,
 ) *Scheduler {
 	if config == nil {
@@ -64,12 +76,14 @@ func NewScheduler(
 	}
 
 	return &Scheduler{
-		cron:      cron.New(),
-		oppRepo:   oppRepo,
-		arbRepo:   arbRepo,
-		defiRepo:  defiRepo,
-		notifRepo: notifRepo,
-		config:    config,
+		cron:           cron.New(),
+		oppRepo:        oppRepo,
+		arbRepo:        arbRepo,
+		fundingRepo:    fundingRepo,
+		defiRepo:       defiRepo,
+		notifRepo:      notifRepo,
+		scraperRunRepo: scraperRunRepo,
+		config:         config,
 	}
 }
 
@@ -109,12 +123,18 @@ func (s *Scheduler) RunCleanup() {
 	// 2. Cleanup старих arbitrage opportunities
 	s.cleanupArbitrage()
 
-	// 3. Cleanup старих DeFi opportunities
+	// 3. Cleanup старих funding opportunities
+	s.cleanupFunding()
+
+	// 4. Cleanup старих DeFi opportunities
 	s.cleanupDeFi()
 
-	// 4. Cleanup старих notifications
+	// 5. Cleanup старих notifications
 	s.cleanupNotifications()
 
+	// 6. Cleanup історії запусків scrapers
+	s.cleanupScraperRuns()
+
 	elapsed := time.Since(startTime)
 	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	log.Printf("✅ Cleanup completed in %v", elapsed)
@@ -146,6 +166,19 @@ func (s *Scheduler) cleanupArbitrage() {
 	log.Printf("✅ Arbitrage cleanup completed")
 }
 
+// cleanupFunding видаляє старі funding opportunities
+func (s *Scheduler) cleanupFunding() {
+	log.Printf("🗑️  Cleaning up funding opportunities older than %d days...", s.config.FundingRetentionDays)
+
+	duration := time.Duration(s.config.FundingRetentionDays) * 24 * time.Hour
+	if err := s.fundingRepo.DeleteOlderThan(duration); err != nil {
+		log.Printf("❌ Failed to cleanup funding: %v", err)
+		return
+	}
+
+	log.Printf("✅ Funding cleanup completed")
+}
+
 // cleanupDeFi видаляє старі DeFi opportunities
 func (s *Scheduler) cleanupDeFi() {
 	log.Printf("🗑️  Cleaning up DeFi opportunities older than %d days...", s.config.DeFiRetentionDays)
@@ -172,6 +205,18 @@ func (s *Scheduler) cleanupNotifications() {
 	log.Printf("✅ Notifications cleanup completed")
 }
 
+// cleanupScraperRuns видаляє стару історію запусків scrapers
+func (s *Scheduler) cleanupScraperRuns() {
+	log.Printf("🗑️  Cleaning up scraper runs older than %d days...", s.config.ScraperRunsRetentionDays)
+
+	if err := s.scraperRunRepo.DeleteOldRuns(s.config.ScraperRunsRetentionDays); err != nil {
+		log.Printf("❌ Failed to cleanup scraper runs: %v", err)
+		return
+	}
+
+	log.Printf("✅ Scraper runs cleanup completed")
+}
+
 // RunNow запускає cleanup негайно (для тестування)
 func (s *Scheduler) RunNow() {
 	s.RunCleanup()
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/joho/godotenv"
@@ -11,15 +12,19 @@ import (
 )
 
 type Config struct {
-	App       AppConfig       `yaml:"app" mapstructure:"app"`
-	Telegram  TelegramConfig  `yaml:"telegram" mapstructure:"telegram"`
-	Database  DatabaseConfig  `yaml:"database" mapstructure:"database"`
-	Redis     RedisConfig     `yaml:"redis" mapstructure:"redis"`
-	Payment   PaymentConfig   `yaml:"payment" mapstructure:"payment"`
-	Arbitrage ArbitrageConfig `yaml:"arbitrage" mapstructure:"arbitrage"`
-	DeFi      DeFiConfig      `yaml:"defi" mapstructure:"defi"`
-	Whale     WhaleConfig     `yaml:"whale" mapstructure:"whale"`
-	Admin     AdminConfig     `yaml:"admin" mapstructure:"admin"`
+	App          AppConfig          `yaml:"app" mapstructure:"app"`
+	Telegram     TelegramConfig     `yaml:"telegram" mapstructure:"telegram"`
+	Database     DatabaseConfig     `yaml:"database" mapstructure:"database"`
+	Redis        RedisConfig        `yaml:"redis" mapstructure:"redis"`
+	Payment      PaymentConfig      `yaml:"payment" mapstructure:"payment"`
+	Arbitrage    ArbitrageConfig    `yaml:"arbitrage" mapstructure:"arbitrage"`
+	Funding      FundingConfig      `yaml:"funding" mapstructure:"funding"`
+	PaperTrading PaperTradingConfig `yaml:"paper_trading" mapstructure:"paper_trading"`
+	PriceAlerts  PriceAlertConfig   `yaml:"price_alerts" mapstructure:"price_alerts"`
+	Scraper      ScraperConfig      `yaml:"scraper" mapstructure:"scraper"`
+	DeFi         DeFiConfig         `yaml:"defi" mapstructure:"defi"`
+	Whale        WhaleConfig        `yaml:"whale" mapstructure:"whale"`
+	Admin        AdminConfig        `yaml:"admin" mapstructure:"admin"`
 }
 
 type AppConfig struct {
@@ -29,9 +34,10 @@ type AppConfig struct {
 }
 
 type TelegramConfig struct {
-	BotToken   string `yaml:"bot_token" mapstructure:"bot_token"`
-	WebhookURL string `yaml:"webhook_url" mapstructure:"webhook_url"`
-	Debug      bool   `yaml:"debug" mapstructure:"debug"`
+	BotToken   string  `yaml:"bot_token" mapstructure:"bot_token"`
+	WebhookURL string  `yaml:"webhook_url" mapstructure:"webhook_url"`
+	Debug      bool    `yaml:"debug" mapstructure:"debug"`
+	AdminIDs   []int64 `yaml:"admin_ids" mapstructure:"admin_ids"` // Telegram ID адмінів (аналітика, алерти скраперів)
 }
 
 type DatabaseConfig struct {
@@ -75,6 +81,92 @@ type ArbitrageConfig struct {
 	MaxSlippage      float64  `yaml:"max_slippage" mapstructure:"max_slippage"`
 	Amount           float64  `yaml:"amount" mapstructure:"amount"`
 	DeduplicateTTL   int      `yaml:"deduplicate_ttl" mapstructure:"deduplicate_ttl"` // minutes
+
+	// Triangular (intra-exchange) arbitrage
+	TriangularEnabled         bool     `yaml:"triangular_enabled" mapstructure:"triangular_enabled"`
+	TriangularPairs           []string `yaml:"triangular_pairs" mapstructure:"triangular_pairs"`                       // cross pairs (ETH/BTC, ...)
+	TriangularStartCurrencies []string `yaml:"triangular_start_currencies" mapstructure:"triangular_start_currencies"` // USDT, ...
+
+	// Fee sync з публічних endpoints бірж
+	FeeSyncEnabled  bool `yaml:"fee_sync_enabled" mapstructure:"fee_sync_enabled"`
+	FeeSyncInterval int  `yaml:"fee_sync_interval" mapstructure:"fee_sync_interval"` // minutes
+
+	// Перевірка статусу депозитів/виведень (мережа призупинена = арбітраж неможливий)
+	TransferCheckEnabled   bool   `yaml:"transfer_check_enabled" mapstructure:"transfer_check_enabled"`
+	TransferCheckMode      string `yaml:"transfer_check_mode" mapstructure:"transfer_check_mode"`           // "drop" або "flag"
+	TransferStatusInterval int    `yaml:"transfer_status_interval" mapstructure:"transfer_status_interval"` // minutes
+
+	// Pre-funded режим: баланси на обох біржах, переказ раз на RebalanceTrades угод
+	RebalanceTrades int `yaml:"rebalance_trades" mapstructure:"rebalance_trades"`
+
+	// Запис ордербуків на диск для відтворення (replay) та бектестів
+	RecordEnabled bool   `yaml:"record_enabled" mapstructure:"record_enabled"`
+	RecordDir     string `yaml:"record_dir" mapstructure:"record_dir"`
+	RecordDepth   int    `yaml:"record_depth" mapstructure:"record_depth"` // levels per side (0 = all)
+
+	// Захист від застарілих, перехрещених та аномальних ордербуків
+	BookMaxAge       int     `yaml:"book_max_age" mapstructure:"book_max_age"`             // seconds
+	BookMaxDeviation float64 `yaml:"book_max_deviation" mapstructure:"book_max_deviation"` // % від медіани інших бірж
+	BookMinDepth     int     `yaml:"book_min_depth" mapstructure:"book_min_depth"`         // levels per side
+
+	// Порівняння пар з різними валютами котирування (BTC/USD на Kraken з BTC/USDT)
+	ReferenceQuote string              `yaml:"reference_quote" mapstructure:"reference_quote"`   // USDT
+	ExchangeQuotes map[string][]string `yaml:"exchange_quotes" mapstructure:"exchange_quotes"`   // exchange -> додаткові валюти котирування
+	QuoteRatePairs map[string][]string `yaml:"quote_rate_pairs" mapstructure:"quote_rate_pairs"` // exchange -> пари курсів (USDC/USDT, USDT/USD)
+
+	// Динамічний набір пар: спільні ринки бірж, топ N за 24h обсягом (pairs завжди включені)
+	UniverseEnabled         bool    `yaml:"universe_enabled" mapstructure:"universe_enabled"`
+	UniverseMaxSymbols      int     `yaml:"universe_max_symbols" mapstructure:"universe_max_symbols"`             // N (включно з pairs)
+	UniverseMinExchanges    int     `yaml:"universe_min_exchanges" mapstructure:"universe_min_exchanges"`         // бірж, де пара має лістинг
+	UniverseMinVolume       float64 `yaml:"universe_min_volume" mapstructure:"universe_min_volume"`               // USD сумарно по біржах
+	UniverseRotationBuffer  int     `yaml:"universe_rotation_buffer" mapstructure:"universe_rotation_buffer"`     // позицій запасу до виключення
+	UniverseNewListingHours int     `yaml:"universe_new_listing_hours" mapstructure:"universe_new_listing_hours"` // нові лістинги поза рейтингом
+	UniverseInterval        int     `yaml:"universe_interval" mapstructure:"universe_interval"`                   // minutes
+}
+
+// FundingConfig funding rate / spot-perp basis можливості (Premium).
+// Spot ціни беруться з ордербуків арбітражу, тому потрібен arbitrage.enabled
+type FundingConfig struct {
+	Enabled        bool               `yaml:"enabled" mapstructure:"enabled"`
+	Exchanges      []string           `yaml:"exchanges" mapstructure:"exchanges"`             // binance, bybit, okx
+	Pairs          []string           `yaml:"pairs" mapstructure:"pairs"`                     // порожньо = arbitrage.pairs
+	ScanInterval   int                `yaml:"scan_interval" mapstructure:"scan_interval"`     // seconds
+	MinAPR         float64            `yaml:"min_apr" mapstructure:"min_apr"`                 // % річних після fees
+	HoldingDays    int                `yaml:"holding_days" mapstructure:"holding_days"`       // на скільки днів розподіляти fees
+	Amount         float64            `yaml:"amount" mapstructure:"amount"`                   // USD на кожну ногу
+	PerpTakerFees  map[string]float64 `yaml:"perp_taker_fees" mapstructure:"perp_taker_fees"` // exchange -> %
+	PerpSlippage   float64            `yaml:"perp_slippage" mapstructure:"perp_slippage"`     // % на perp ногу (perp ордербуків немає - оцінка)
+	DeduplicateTTL int                `yaml:"deduplicate_ttl" mapstructure:"deduplicate_ttl"` // minutes
+}
+
+// PaperTradingConfig симуляція виконання арбітражних алертів для користувачів,
+// що увімкнули /paper. Ордербуки беруться з арбітражу, тому потрібен arbitrage.enabled
+type PaperTradingConfig struct {
+	Enabled bool    `yaml:"enabled" mapstructure:"enabled"`
+	Latency int     `yaml:"latency" mapstructure:"latency"` // ms від доставки алерту до виконання
+	Amount  float64 `yaml:"amount" mapstructure:"amount"`   // USD на угоду (не більше max_investment користувача)
+}
+
+// PriceAlertConfig алерти розбіжності цін між біржами та depeg стейблкоїнів (Premium).
+// Ціни беруться з ордербуків арбітражу, тому потрібен arbitrage.enabled
+type PriceAlertConfig struct {
+	Enabled           bool     `yaml:"enabled" mapstructure:"enabled"`
+	DivergencePercent float64  `yaml:"divergence_percent" mapstructure:"divergence_percent"` // % від медіани інших бірж
+	DepegPercent      float64  `yaml:"depeg_percent" mapstructure:"depeg_percent"`           // % від $1
+	Stablecoins       []string `yaml:"stablecoins" mapstructure:"stablecoins"`               // пари стейблкоїнів (порожньо = arbitrage.quote_rate_pairs)
+	Duration          int      `yaml:"duration" mapstructure:"duration"`                     // seconds, скільки має тривати відхилення
+	ScanInterval      int      `yaml:"scan_interval" mapstructure:"scan_interval"`           // seconds
+	DeduplicateTTL    int      `yaml:"deduplicate_ttl" mapstructure:"deduplicate_ttl"`       // minutes
+}
+
+type ScraperConfig struct {
+	DefinitionsDir   string `yaml:"definitions_dir" mapstructure:"definitions_dir"`     // YAML описи додаткових бірж ("" - вимкнено)
+	Concurrency      int    `yaml:"concurrency" mapstructure:"concurrency"`             // скраперів одночасно
+	Timeout          int    `yaml:"timeout" mapstructure:"timeout"`                     // seconds, дедлайн одного скрапера
+	Retries          int    `yaml:"retries" mapstructure:"retries"`                     // повтори після тимчасових помилок
+	RetryBackoff     int    `yaml:"retry_backoff" mapstructure:"retry_backoff"`         // seconds, подвоюється з кожним повтором
+	FailureThreshold int    `yaml:"failure_threshold" mapstructure:"failure_threshold"` // невдач поспіль до відкриття circuit breaker
+	ProbeInterval    int    `yaml:"probe_interval" mapstructure:"probe_interval"`       // minutes між пробами відкритого circuit breaker
 }
 
 type DeFiConfig struct {
@@ -161,6 +253,26 @@ func LoadConfig(configPath string) (*Config, error) {
 	return &config, nil
 }
 
+// LoadArbitrageConfig читає тільки секцію arbitrage без валідації решти
+// конфігу (для офлайн інструментів, яким не потрібні Telegram та БД)
+func LoadArbitrageConfig(configPath string) (*ArbitrageConfig, error) {
+	v := viper.New()
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	v.AddConfigPath(configPath)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+
+	var cfg ArbitrageConfig
+	if err := v.UnmarshalKey("arbitrage", &cfg); err != nil {
+		return nil, fmt.Errorf("error parsing arbitrage config: %w", err)
+	}
+
+	return &cfg, nil
+}
+
 func (c *Config) Validate() error {
 	if c.Telegram.BotToken == "" {
 		return fmt.Errorf("telegram.bot_token is required")
@@ -252,6 +364,9 @@ func (c *Config) SafeString() string {
 			Max Spread: %.2f%%
 			Max Slippage: %.2f%%
 			Deduplicate TTL: %d min
+			Triangular: %t (start: %v, extra pairs: %v)
+			Fee Sync: %t (every %d min)
+			Transfer Check: %t (mode: %s, every %d min)
 
 		DeFi:
 			Enabled: %t
@@ -298,6 +413,14 @@ func (c *Config) SafeString() string {
 		c.Arbitrage.MaxSpreadPercent,
 		c.Arbitrage.MaxSlippage,
 		c.Arbitrage.DeduplicateTTL,
+		c.Arbitrage.TriangularEnabled,
+		c.Arbitrage.TriangularStartCurrencies,
+		c.Arbitrage.TriangularPairs,
+		c.Arbitrage.FeeSyncEnabled,
+		c.Arbitrage.FeeSyncInterval,
+		c.Arbitrage.TransferCheckEnabled,
+		c.Arbitrage.TransferCheckMode,
+		c.Arbitrage.TransferStatusInterval,
 		c.DeFi.Enabled,
 		c.DeFi.Chains,
 		c.DeFi.Protocols,
@@ -315,6 +438,112 @@ func (c *Config) SafeString() string {
 	)
 }
 
+// FlagClosedTransfers чи позначати (а не відкидати) можливості з закритим маршрутом переказу
+func (c *ArbitrageConfig) FlagClosedTransfers() bool {
+	return c.TransferCheckMode == "flag"
+}
+
+// GetRebalanceTrades кількість угод між ребалансами для pre-funded розрахунку (за замовчуванням 20)
+func (c *ArbitrageConfig) GetRebalanceTrades() int {
+	if c.RebalanceTrades <= 0 {
+		return 20
+	}
+	return c.RebalanceTrades
+}
+
+// SubscriptionPairs повертає всі пари на які треба підписатись (включно з парами для трикутного арбітражу)
+func (c *ArbitrageConfig) SubscriptionPairs() []string {
+	if !c.TriangularEnabled || len(c.TriangularPairs) == 0 {
+		return c.Pairs
+	}
+
+	seen := make(map[string]bool, len(c.Pairs)+len(c.TriangularPairs))
+	pairs := make([]string, 0, len(c.Pairs)+len(c.TriangularPairs))
+
+	for _, pair := range append(append([]string{}, c.Pairs...), c.TriangularPairs...) {
+		if seen[pair] {
+			continue
+		}
+		seen[pair] = true
+		pairs = append(pairs, pair)
+	}
+
+	return pairs
+}
+
+// ExchangeSubscriptionPairs повертає пари біржі: SubscriptionPairs, ті ж пари
+// в додаткових валютах котирування біржі та пари курсів для конвертації
+func (c *ArbitrageConfig) ExchangeSubscriptionPairs(exchange string) []string {
+	pairs := c.SubscriptionPairs()
+	quotes := c.ExchangeQuotes[exchange]
+	ratePairs := c.QuoteRatePairs[exchange]
+
+	if len(quotes) == 0 && len(ratePairs) == 0 {
+		return pairs
+	}
+
+	reference := c.GetReferenceQuote()
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(pairs)*(len(quotes)+1)+len(ratePairs))
+
+	add := func(pair string) {
+		if !seen[pair] {
+			seen[pair] = true
+			result = append(result, pair)
+		}
+	}
+
+	for _, pair := range pairs {
+		add(pair)
+
+		parts := strings.Split(pair, "/")
+		if len(parts) != 2 || parts[1] != reference {
+			continue
+		}
+		for _, quote := range quotes {
+			add(parts[0] + "/" + strings.ToUpper(quote))
+		}
+	}
+
+	for _, pair := range ratePairs {
+		add(strings.ToUpper(pair))
+	}
+
+	return result
+}
+
+// DepegPairs пари стейблкоїнів для перевірки depeg: price_alerts.stablecoins
+// або всі пари курсів арбітражу (на них вже є підписка)
+func (c *Config) DepegPairs() []string {
+	if len(c.PriceAlerts.Stablecoins) > 0 {
+		return c.PriceAlerts.Stablecoins
+	}
+
+	seen := make(map[string]bool)
+	var pairs []string
+
+	for _, ratePairs := range c.Arbitrage.QuoteRatePairs {
+		for _, pair := range ratePairs {
+			pair = strings.ToUpper(pair)
+			if !seen[pair] {
+				seen[pair] = true
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+	sort.Strings(pairs)
+
+	return pairs
+}
+
+// GetReferenceQuote валюта котирування, до якої приводяться всі інші (за замовчуванням USDT)
+func (c *ArbitrageConfig) GetReferenceQuote() string {
+	if c.ReferenceQuote == "" {
+		return "USDT"
+	}
+	return strings.ToUpper(c.ReferenceQuote)
+}
+
 func getEnv(key string, fallback string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -24,11 +24,16 @@ type Client struct {
 
 // NewClient створює новий DeFiLlama API client
 func NewClient() *Client {
+	return NewClientWithHTTP(BaseURL, &http.Client{
+		Timeout: RequestTimeout,
+	})
+}
+
+// NewClientWithHTTP створює client з іншою адресою yields API та HTTP клієнтом
+func NewClientWithHTTP(baseURL string, httpClient *http.Client) *Client {
 	return &Client{
-		baseURL: BaseURL,
-		httpClient: &http.Client{
-			Timeout: RequestTimeout,
-		},
+		baseURL:    baseURL,
+		httpClient: httpClient,
 	}
 }
 
@@ -4,10 +4,30 @@ import (
 	"time"
 )
 
+const (
+	ArbitrageTypeCrossExchange = "cross_exchange" // Купівля на одній біржі, продаж на іншій
+	ArbitrageTypeTriangular    = "triangular"     // Цикл з трьох угод на одній біржі
+)
+
+const (
+	TransferStatusOpen    = "open"    // Виведення та депозит відкриті в спільній мережі
+	TransferStatusClosed  = "closed"  // Маршрут переказу закритий (мережа призупинена)
+	TransferStatusUnknown = "unknown" // Біржа не публікує статус мереж
+)
+
+const (
+	ArbitrageStatusOpen   = "open"   // Спред ще присутній в orderbook
+	ArbitrageStatusClosed = "closed" // Спред зник, LifetimeSeconds зафіксовано
+)
+
 // ArbitrageOpportunity представляє арбітражну можливість між двома біржами
 type ArbitrageOpportunity struct {
 	BaseModel
 
+	// Kind of arbitrage
+	Type  string `gorm:"index;default:'cross_exchange'" json:"type"` // 'cross_exchange', 'triangular'
+	Route string `json:"route,omitempty"`                            // 'USDT → BTC → ETH → USDT' (triangular only)
+
 	// Trading pair
 	Pair          string `gorm:"index;not null" json:"pair"`           // 'BTC/USDT', 'ETH/USDT'
 	BaseCurrency  string `gorm:"not null" json:"base_currency"`        // 'BTC', 'ETH'
@@ -34,6 +54,14 @@ type ArbitrageOpportunity struct {
 	WithdrawalFeeUSD float64 `gorm:"type:decimal(10,2)" json:"withdrawal_fee_usd"` // $33.72
 	TotalFeesPercent float64 `gorm:"type:decimal(5,4)" json:"total_fees_percent"` // 0.2500 (0.25%)
 
+	// Pre-funded mode (balances on both exchanges, withdrawal replaced by periodic rebalance)
+	RebalanceCostUSD       float64 `gorm:"type:decimal(10,2)" json:"rebalance_cost_usd"`      // Moving base and quote back once
+	PrefundedProfitPercent float64 `gorm:"type:decimal(5,2)" json:"prefunded_profit_percent"` // Net profit % with default rebalance interval
+	Prefunded              bool    `gorm:"-" json:"prefunded,omitempty"`                      // Profit fields recalculated by WithPrefunded
+
+	PrefundedOptimalAmount    float64 `gorm:"type:decimal(12,2)" json:"prefunded_optimal_amount"`     // OptimalAmount without withdrawal fee
+	PrefundedOptimalProfitUSD float64 `gorm:"type:decimal(12,2)" json:"prefunded_optimal_profit_usd"` // Net profit at PrefundedOptimalAmount
+
 	// Net profit (after fees and slippage)
 	NetProfitPercent float64 `gorm:"type:decimal(5,2);not null" json:"net_profit_percent"` // 0.34 (%)
 	NetProfitUSD     float64 `gorm:"type:decimal(12,2)" json:"net_profit_usd"` // $3.40 on $1000
@@ -47,15 +75,38 @@ type ArbitrageOpportunity struct {
 	SlippageSell float64 `gorm:"type:decimal(5,4)" json:"slippage_sell"` // Slippage on sell side
 
 	// Trade amounts
-	MinTradeAmount    float64 `gorm:"type:decimal(12,2)" json:"min_trade_amount"`    // Minimum $50
-	MaxTradeAmount    float64 `gorm:"type:decimal(12,2)" json:"max_trade_amount"`    // Maximum $5000
-	RecommendedAmount float64 `gorm:"type:decimal(12,2)" json:"recommended_amount"`  // $500-2000
+	MinTradeAmount   float64 `gorm:"type:decimal(12,2)" json:"min_trade_amount"`   // Minimum $100
+	MaxTradeAmount   float64 `gorm:"type:decimal(12,2)" json:"max_trade_amount"`   // Available liquidity of both books
+	OptimalAmount    float64 `gorm:"type:decimal(12,2)" json:"optimal_amount"`     // Max absolute net profit by order book depth
+	OptimalProfitUSD float64 `gorm:"type:decimal(12,2)" json:"optimal_profit_usd"` // Net profit at OptimalAmount
+	BreakEvenAmount  float64 `gorm:"type:decimal(12,2)" json:"break_even_amount"`  // Min amount covering withdrawal fee
+
+	// Quote conversion (leg quoted in another stablecoin/fiat, prices converted to QuoteCurrency)
+	SymbolBuy     string  `json:"symbol_buy,omitempty"`                    // 'BTC/USD' if differs from Pair
+	SymbolSell    string  `json:"symbol_sell,omitempty"`                   // 'BTC/FDUSD' if differs from Pair
+	ConversionFee float64 `gorm:"type:decimal(5,4)" json:"conversion_fee"` // % fee + spread of quote conversion
+
+	// Transfer route (ExchangeBuy -> ExchangeSell)
+	TransferNetwork string  `json:"transfer_network,omitempty"`                     // 'TRX', 'ETH', 'SOL'
+	TransferStatus  string  `gorm:"index;default:'unknown'" json:"transfer_status"` // 'open', 'closed', 'unknown'
+	TransferMinutes float64 `gorm:"type:decimal(8,2)" json:"transfer_minutes"`      // Estimated transfer time
 
 	// Timing
 	DetectedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"detected_at"` // When detected
 	ExpiresAt  time.Time `gorm:"index;not null" json:"expires_at"`                      // Valid until (+ 3-5 min)
 	IsNotified bool      `gorm:"default:false" json:"is_notified"`                      // Sent to users?
 
+	// Lifecycle (open -> update -> close)
+	Status            string     `gorm:"index;default:'open'" json:"status"`           // 'open', 'closed'
+	OpenProfitPercent float64    `gorm:"type:decimal(5,2)" json:"open_profit_percent"` // Net profit at open
+	PeakProfitPercent float64    `gorm:"type:decimal(5,2)" json:"peak_profit_percent"` // Max net profit while open
+	LastProfitPercent float64    `gorm:"type:decimal(5,2)" json:"last_profit_percent"` // Net profit at last update
+	LastSeenAt        time.Time  `json:"last_seen_at"`                                 // Last time orderbook confirmed it
+	UpdateCount       int        `gorm:"default:0" json:"update_count"`                // Number of confirmations
+	ClosedAt          *time.Time `gorm:"index" json:"closed_at,omitempty"`             // When spread disappeared
+	CloseReason       string     `json:"close_reason,omitempty"`                       // 'spread_closed', 'stale', 'shutdown'
+	LifetimeSeconds   float64    `gorm:"type:decimal(12,3)" json:"lifetime_seconds"`   // ClosedAt - DetectedAt
+
 	// Deduplication
 	ExternalID string `gorm:"uniqueIndex;not null" json:"external_id"` // MD5 hash
 }
@@ -74,6 +125,29 @@ func (a *ArbitrageOpportunity) TimeLeft() time.Duration {
 	return time.Until(a.ExpiresAt)
 }
 
+// IsOpen перевіряє чи спред ще присутній в orderbook
+func (a *ArbitrageOpportunity) IsOpen() bool {
+	return a.Status == ArbitrageStatusOpen
+}
+
+// Lifetime повертає тривалість життя можливості (для відкритої - до LastSeenAt)
+func (a *ArbitrageOpportunity) Lifetime() time.Duration {
+	if a.ClosedAt != nil {
+		return a.ClosedAt.Sub(a.DetectedAt)
+	}
+	return a.LastSeenAt.Sub(a.DetectedAt)
+}
+
+// IsTriangular перевіряє чи це трикутний арбітраж в межах однієї біржі
+func (a *ArbitrageOpportunity) IsTriangular() bool {
+	return a.Type == ArbitrageTypeTriangular
+}
+
+// IsTransferClosed перевіряє чи маршрут переказу між біржами закритий
+func (a *ArbitrageOpportunity) IsTransferClosed() bool {
+	return a.TransferStatus == TransferStatusClosed
+}
+
 // IsHighProfit перевіряє чи це висока можливість
 func (a *ArbitrageOpportunity) IsHighProfit() bool {
 	return a.NetProfitPercent >= 0.5
@@ -83,3 +157,106 @@ func (a *ArbitrageOpportunity) IsHighProfit() bool {
 func (a *ArbitrageOpportunity) IsVeryHighProfit() bool {
 	return a.NetProfitPercent >= 1.0
 }
+
+// WithTradingFees повертає копію можливості, перераховану під персональні
+// trading fees користувача (buyFee/sellFee в %). Трикутний арбітраж має три
+// угоди на одній біржі, тому різниця buyFee враховується тричі.
+func (a *ArbitrageOpportunity) WithTradingFees(buyFee, sellFee float64) *ArbitrageOpportunity {
+	adjusted := *a
+
+	var delta float64
+	if a.IsTriangular() {
+		delta = 3 * (buyFee - a.TradingFeeBuy)
+		sellFee = buyFee
+	} else {
+		delta = (buyFee - a.TradingFeeBuy) + (sellFee - a.TradingFeeSell)
+	}
+
+	adjusted.TradingFeeBuy = buyFee
+	adjusted.TradingFeeSell = sellFee
+	adjusted.TotalFeesPercent += delta
+	adjusted.NetProfitPercent -= delta
+	adjusted.NetProfitUSD = (adjusted.NetProfitPercent / 100) * 1000
+
+	return &adjusted
+}
+
+// WithUserFeeTiers повертає копію можливості з taker fees VIP рівнів
+// користувача на біржах купівлі/продажу (або саму можливість, якщо рівнів
+// для цих бірж немає)
+func (a *ArbitrageOpportunity) WithUserFeeTiers(tiers []*UserFeeTier) *ArbitrageOpportunity {
+	buyFee, sellFee := a.TradingFeeBuy, a.TradingFeeSell
+	customized := false
+
+	for _, tier := range tiers {
+		if tier.Exchange == a.ExchangeBuy {
+			buyFee = tier.TakerFee
+			customized = true
+		}
+		if tier.Exchange == a.ExchangeSell {
+			sellFee = tier.TakerFee
+			customized = true
+		}
+	}
+
+	if !customized {
+		return a
+	}
+
+	return a.WithTradingFees(buyFee, sellFee)
+}
+
+// WithPrefunded повертає копію можливості, перераховану для торгівлі з
+// балансів на обох біржах: замість виведення в кожній угоді - частка вартості
+// ребалансу, який робиться раз на rebalanceTrades угод (розрахунок на $1000)
+func (a *ArbitrageOpportunity) WithPrefunded(rebalanceTrades int) *ArbitrageOpportunity {
+	if rebalanceTrades < 1 {
+		rebalanceTrades = DefaultRebalanceTrades
+	}
+
+	adjusted := *a
+
+	withdrawal := (a.WithdrawalFeeUSD / 1000) * 100
+	rebalance := (a.RebalanceCostUSD / float64(rebalanceTrades) / 1000) * 100
+	delta := rebalance - withdrawal
+
+	adjusted.TotalFeesPercent += delta
+	adjusted.NetProfitPercent -= delta
+	adjusted.NetProfitUSD = (adjusted.NetProfitPercent / 100) * 1000
+	adjusted.Prefunded = true
+
+	// Розмір угоди без фіксованої вартості виведення (якщо розрахований)
+	if a.PrefundedOptimalAmount > 0 {
+		adjusted.OptimalAmount = a.PrefundedOptimalAmount
+		adjusted.OptimalProfitUSD = a.PrefundedOptimalProfitUSD
+		adjusted.BreakEvenAmount = 0
+	}
+
+	return &adjusted
+}
+
+// BestProfitPercent найвищий net profit серед моделей (переказ або pre-funded)
+func (a *ArbitrageOpportunity) BestProfitPercent() float64 {
+	if a.PrefundedProfitPercent > a.NetProfitPercent {
+		return a.PrefundedProfitPercent
+	}
+	return a.NetProfitPercent
+}
+
+// ArbitrageLifetimeStats агрегована статистика тривалості життя можливостей
+// по парі або по парі бірж
+type ArbitrageLifetimeStats struct {
+	Pair                  string  `json:"pair"`
+	ExchangeBuy           string  `json:"exchange_buy,omitempty"`
+	ExchangeSell          string  `json:"exchange_sell,omitempty"`
+	Count                 int     `json:"count"`
+	AvgLifetimeSeconds    float64 `json:"avg_lifetime_seconds"`
+	MedianLifetimeSeconds float64 `json:"median_lifetime_seconds"`
+	P90LifetimeSeconds    float64 `json:"p90_lifetime_seconds"`
+	MaxLifetimeSeconds    float64 `json:"max_lifetime_seconds"`
+	AvgOpenProfit         float64 `json:"avg_open_profit_percent"`
+	AvgPeakProfit         float64 `json:"avg_peak_profit_percent"`
+	AvgCloseProfit        float64 `json:"avg_close_profit_percent"`
+	AvgDecayPerMinute     float64 `json:"avg_decay_per_minute"` // (peak - last) % за хвилину
+	ActionableRatio       float64 `json:"actionable_ratio"`     // Частка, що прожила >= actionable часу
+}
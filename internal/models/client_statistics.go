@@ -4,12 +4,14 @@ import (
 	"time"
 )
 
-// ClientStatistics представляє статистику торгівлі користувача
+// ClientStatistics представляє статистику торгівлі користувача.
+// Paper trading рахується окремим рядком (IsPaper), щоб не змішуватись з реальними трейдами
 type ClientStatistics struct {
 	BaseModel
 
-	UserID uint  `gorm:"uniqueIndex;not null" json:"user_id"`
-	User   *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	UserID  uint  `gorm:"uniqueIndex:idx_client_statistics_user_paper;not null" json:"user_id"`
+	User    *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	IsPaper bool  `gorm:"uniqueIndex:idx_client_statistics_user_paper;default:false" json:"is_paper"`
 
 	// Trade counters
 	TotalTrades      int `gorm:"default:0" json:"total_trades"`
@@ -71,6 +73,9 @@ func (cs *ClientStatistics) UpdateFromTrade(trade *ClientTrade) {
 		if trade.ActualProfit < 0 {
 			cs.TotalLoss += -trade.ActualProfit // Зберігаємо як позитивне число
 		}
+	} else if trade.IsPaper && trade.ActualProfit < 0 {
+		// Paper: виконана, але збиткова симуляція теж рахується в збитки
+		cs.TotalLoss += -trade.ActualProfit
 	}
 
 	// Update net profit
@@ -0,0 +1,24 @@
+package models
+
+import "testing"
+
+func TestClientStatisticsUpdateFromTradeLoss(t *testing.T) {
+	// Реальний трейд: виконаний збитковий не змінює прибуток/збитки, як і раніше
+	real := &ClientStatistics{}
+	real.UpdateFromTrade(&ClientTrade{Status: TradeStatusCompleted, ActualProfit: -5})
+
+	if real.TotalProfit != 0 || real.TotalLoss != 0 || real.TotalTrades != 1 {
+		t.Errorf("Expected real completed loss outside totals, got profit %.2f loss %.2f trades %d",
+			real.TotalProfit, real.TotalLoss, real.TotalTrades)
+	}
+
+	// Paper: виконаний збитковий рахується в TotalLoss
+	paper := &ClientStatistics{IsPaper: true}
+	paper.UpdateFromTrade(&ClientTrade{Status: TradeStatusCompleted, ActualProfit: 3, IsPaper: true})
+	paper.UpdateFromTrade(&ClientTrade{Status: TradeStatusCompleted, ActualProfit: -5, IsPaper: true})
+
+	if paper.TotalProfit != 3 || paper.TotalLoss != 5 || paper.NetProfit != -2 {
+		t.Errorf("Expected paper loss in total loss, got profit %.2f loss %.2f net %.2f",
+			paper.TotalProfit, paper.TotalLoss, paper.NetProfit)
+	}
+}
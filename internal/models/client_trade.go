@@ -5,6 +5,7 @@ import (
 )
 
 // ClientTrade представляє трейд виконаний Premium клієнтом
+// або симульований paper trading (IsPaper)
 type ClientTrade struct {
 	BaseModel
 
@@ -19,6 +20,9 @@ type ClientTrade struct {
 	BuyExchange  string `gorm:"index;not null" json:"buy_exchange"`  // "binance"
 	SellExchange string `gorm:"index;not null" json:"sell_exchange"` // "bybit"
 
+	// Paper trading: симуляція по живих ордербуках, без реальних ордерів
+	IsPaper bool `gorm:"index;default:false" json:"is_paper"`
+
 	// Trade details
 	Amount    float64 `gorm:"type:decimal(20,8);not null" json:"amount"`     // Кількість базової валюти
 	BuyPrice  float64 `gorm:"type:decimal(20,8);not null" json:"buy_price"`  // Ціна купівлі
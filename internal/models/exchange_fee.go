@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+const (
+	FeeTypeTrading    = "trading"
+	FeeTypeWithdrawal = "withdrawal"
+)
+
+// ExchangeFee комісія біржі, синхронізована з публічних endpoints
+type ExchangeFee struct {
+	BaseModel
+
+	Exchange string `gorm:"uniqueIndex:idx_exchange_fee;not null" json:"exchange"`
+	FeeType  string `gorm:"uniqueIndex:idx_exchange_fee;not null" json:"fee_type"` // trading, withdrawal
+	Currency string `gorm:"uniqueIndex:idx_exchange_fee" json:"currency"`          // для withdrawal (BTC, USDT)
+	Network  string `gorm:"uniqueIndex:idx_exchange_fee" json:"network"`           // для withdrawal (ERC20, TRC20)
+
+	MakerFee      float64 `json:"maker_fee"`      // % (trading)
+	TakerFee      float64 `json:"taker_fee"`      // % (trading)
+	WithdrawalFee float64 `json:"withdrawal_fee"` // в валюті Currency (withdrawal)
+	IsDefault     bool    `json:"is_default"`     // мережа за замовчуванням для Currency
+
+	Source    string    `json:"source"` // endpoint з якого отримано
+	FetchedAt time.Time `gorm:"index" json:"fetched_at"`
+}
+
+func (*ExchangeFee) TableName() string {
+	return "exchange_fees"
+}
+
+// IsStale перевіряє чи комісія давно не оновлювалась
+func (f *ExchangeFee) IsStale(maxAge time.Duration) bool {
+	return time.Since(f.FetchedAt) > maxAge
+}
+
+// UserFeeTier персональний VIP рівень комісій користувача на біржі
+type UserFeeTier struct {
+	BaseModel
+
+	UserID   uint   `gorm:"uniqueIndex:idx_user_fee_tier;not null" json:"user_id"`
+	User     User   `gorm:"foreignKey:UserID" json:"-"`
+	Exchange string `gorm:"uniqueIndex:idx_user_fee_tier;not null" json:"exchange"`
+	Tier     string `json:"tier,omitempty"` // VIP0, VIP1, ...
+
+	MakerFee float64 `json:"maker_fee"` // %
+	TakerFee float64 `json:"taker_fee"` // %
+}
+
+func (*UserFeeTier) TableName() string {
+	return "user_fee_tiers"
+}
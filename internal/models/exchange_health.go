@@ -0,0 +1,96 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	ExchangeHealthHealthy  = "healthy"  // Всі ордербуки придатні для арбітражу
+	ExchangeHealthDegraded = "degraded" // Частина ордербуків виключена з виявлення
+	ExchangeHealthDown     = "down"     // Немає з'єднання або жодного придатного ордербуку
+)
+
+// ExchangeHealth знімок стану ордербуків біржі. Пишеться процесом бота,
+// читається admin API (процеси не мають спільної пам'яті)
+type ExchangeHealth struct {
+	BaseModel
+
+	Exchange  string `gorm:"uniqueIndex;not null" json:"exchange"`
+	Status    string `gorm:"index" json:"status"` // healthy, degraded, down
+	Connected bool   `json:"connected"`
+
+	Books         int `json:"books"`
+	HealthyBooks  int `json:"healthy_books"`
+	StaleBooks    int `json:"stale_books"`    // Немає оновлень довше за max age
+	CrossedBooks  int `json:"crossed_books"`  // bid >= ask
+	DeviatedBooks int `json:"deviated_books"` // Ціна далеко від медіани інших бірж
+	ShallowBooks  int `json:"shallow_books"`  // Менше мінімальної кількості рівнів
+
+	LastBookUpdate time.Time `json:"last_book_update"`
+	ReportedAt     time.Time `gorm:"index" json:"reported_at"`
+
+	Telemetry ExchangeTelemetry `gorm:"type:jsonb" json:"telemetry"` // Стан WebSocket фіду
+}
+
+func (*ExchangeHealth) TableName() string {
+	return "exchange_health"
+}
+
+// IsReportStale перевіряє чи бот давно не оновлював знімок (бот зупинений)
+func (h *ExchangeHealth) IsReportStale(maxAge time.Duration) bool {
+	return time.Since(h.ReportedAt) > maxAge
+}
+
+// ExchangeTelemetry телеметрія WebSocket фіду біржі: затримка від часу біржі
+// до отримання, частота оновлень, перепідключення та останні помилки
+type ExchangeTelemetry struct {
+	Messages          int64              `json:"messages"` // Оновлень ордербуків з моменту старту
+	MessagesPerSecond float64            `json:"messages_per_second"`
+	SymbolRates       map[string]float64 `json:"symbol_rates,omitempty"` // symbol -> оновлень/с
+	LastMessageAt     *time.Time         `json:"last_message_at,omitempty"`
+
+	LatencySamples   int64           `json:"latency_samples"`
+	LatencyAvgMs     float64         `json:"latency_avg_ms"`
+	LatencyP50Ms     float64         `json:"latency_p50_ms"` // Оцінка за гістограмою (верхня межа кошика)
+	LatencyP99Ms     float64         `json:"latency_p99_ms"`
+	LatencyMaxMs     float64         `json:"latency_max_ms"`
+	LatencyHistogram []LatencyBucket `json:"latency_histogram,omitempty"`
+
+	Reconnects          int        `json:"reconnects"`
+	DisconnectedSeconds float64    `json:"disconnected_seconds"` // Сумарно без з'єднання
+	DisconnectedSince   *time.Time `json:"disconnected_since,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastErrorAt         *time.Time `json:"last_error_at,omitempty"`
+}
+
+// LatencyBucket кошик гістограми затримок
+type LatencyBucket struct {
+	UpToMs float64 `json:"up_to_ms"` // 0 = більше за всі межі
+	Count  int64   `json:"count"`
+}
+
+func (t *ExchangeTelemetry) Scan(value interface{}) error {
+	if value == nil {
+		*t = ExchangeTelemetry{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("failed to unmarshal JSONB value: %v", value)
+	}
+
+	return json.Unmarshal(bytes, t)
+}
+
+func (t ExchangeTelemetry) Value() (driver.Value, error) {
+	return json.Marshal(t)
+}
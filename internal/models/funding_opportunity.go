@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+const (
+	FundingTypeCashAndCarry = "cash_and_carry" // Spot long + perp short, отримуємо funding
+	FundingTypeDifferential = "funding_diff"   // Perp long на біржі з нижчим funding, perp short з вищим
+)
+
+const (
+	MarketSpot = "spot"
+	MarketPerp = "perp"
+)
+
+// FundingOpportunity дельта-нейтральна позиція, що заробляє на funding rate
+// perpetual ф'ючерсів та базисі spot-perp
+type FundingOpportunity struct {
+	BaseModel
+
+	Type   string `gorm:"index;not null" json:"type"`   // 'cash_and_carry', 'funding_diff'
+	Symbol string `gorm:"index;not null" json:"symbol"` // 'BTC/USDT'
+
+	// Long leg
+	LongExchange string  `gorm:"index;not null" json:"long_exchange"`
+	LongMarket   string  `gorm:"not null" json:"long_market"` // 'spot', 'perp'
+	LongPrice    float64 `gorm:"type:decimal(20,8)" json:"long_price"`
+	LongFunding  float64 `gorm:"type:decimal(10,6)" json:"long_funding"` // % за період (perp only)
+
+	// Short leg (завжди perp)
+	ShortExchange string  `gorm:"index;not null" json:"short_exchange"`
+	ShortPrice    float64 `gorm:"type:decimal(20,8)" json:"short_price"`
+	ShortFunding  float64 `gorm:"type:decimal(10,6)" json:"short_funding"` // % за період
+
+	FundingIntervalHours float64   `gorm:"type:decimal(5,2)" json:"funding_interval_hours"` // Інтервал short leg
+	NextFundingTime      time.Time `json:"next_funding_time"`
+
+	// Yield
+	BasisPercent     float64 `gorm:"type:decimal(8,4)" json:"basis_percent"`           // (short - long) / long, фіксується при сходженні цін
+	FundingAPR       float64 `gorm:"type:decimal(10,2)" json:"funding_apr"`            // Річний funding без fees
+	TotalFeesPercent float64 `gorm:"type:decimal(8,4)" json:"total_fees_percent"`      // Вхід + вихід обох ніг
+	SlippagePercent  float64 `gorm:"type:decimal(8,4)" json:"slippage_percent"`        // Spot leg + оцінка perp ніг
+	HoldingDays      int     `json:"holding_days"`                                     // Період, на який розподілені fees
+	NetAPR           float64 `gorm:"index;type:decimal(10,2);not null" json:"net_apr"` // Річна дохідність після fees
+
+	Amount float64 `gorm:"type:decimal(12,2)" json:"amount"` // USD на кожну ногу
+
+	// Timing
+	DetectedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"detected_at"`
+	ExpiresAt  time.Time `gorm:"index;not null" json:"expires_at"` // Наступне нарахування funding
+	IsNotified bool      `gorm:"default:false" json:"is_notified"`
+}
+
+func (*FundingOpportunity) TableName() string {
+	return "funding_opportunities"
+}
+
+// IsActive перевіряє чи можливість ще актуальна
+func (f *FundingOpportunity) IsActive() bool {
+	return time.Now().Before(f.ExpiresAt)
+}
+
+// IsCashAndCarry перевіряє чи це spot long + perp short
+func (f *FundingOpportunity) IsCashAndCarry() bool {
+	return f.Type == FundingTypeCashAndCarry
+}
+
+// NetProfitForHolding чистий прибуток % за HoldingDays
+func (f *FundingOpportunity) NetProfitForHolding() float64 {
+	return f.NetAPR * float64(f.HoldingDays) / 365
+}
+
+// DailyReturnUSD очікуваний прибуток на день для суми
+func (f *FundingOpportunity) DailyReturnUSD(amount float64) float64 {
+	return amount * f.NetAPR / 100 / 365
+}
@@ -80,3 +80,18 @@ func (n *Notification) MarkAsFailed(errorMsg string) {
 	n.ErrorMessage = errorMsg
 	n.RetryCount++
 }
+
+// ArbitrageID повертає ID арбітражної можливості з MessageData (0 якщо немає).
+// Після читання з БД числа в JSON приходять як float64
+func (n *Notification) ArbitrageID() uint {
+	switch id := n.MessageData["arbitrage_id"].(type) {
+	case float64:
+		return uint(id)
+	case uint:
+		return id
+	case int:
+		return uint(id)
+	default:
+		return 0
+	}
+}
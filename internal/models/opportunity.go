@@ -8,8 +8,10 @@ const (
 	OpportunityTypeAirdrop    = "airdrop"
 	OpportunityTypeLearnEarn  = "learn_earn"
 	OpportunityTypeStaking    = "staking"
-	OpportunityTypeArbitrage  = "arbitrage" // Premium
-	OpportunityTypeDeFi       = "defi"      // Premium
+	OpportunityTypeArbitrage  = "arbitrage"   // Premium
+	OpportunityTypeDeFi       = "defi"        // Premium
+	OpportunityTypeFunding    = "funding"     // Premium
+	OpportunityTypePriceAlert = "price_alert" // Premium
 )
 
 const (
@@ -41,6 +43,9 @@ type Opportunity struct {
 	IsActive      bool       `gorm:"index;default:true" json:"is_active"`
 	IsFeatured    bool       `gorm:"default:false" json:"is_featured"` // Виділені можливості
 	Metadata      JSONMap    `gorm:"type:jsonb;serializer:json" json:"metadata,omitempty"`
+
+	ProjectID *uint    `gorm:"index" json:"project_id,omitempty"` // Той самий токен на інших біржах
+	Project   *Project `gorm:"foreignKey:ProjectID" json:"project,omitempty"`
 }
 
 func (*Opportunity) TableName() string {
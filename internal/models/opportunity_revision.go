@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Поля Opportunity, зміни яких відстежуються скрапером
+const (
+	RevisionFieldTitle         = "title"
+	RevisionFieldDescription   = "description"
+	RevisionFieldReward        = "reward"
+	RevisionFieldEstimatedROI  = "estimated_roi"
+	RevisionFieldPoolSize      = "pool_size"
+	RevisionFieldMinInvestment = "min_investment"
+	RevisionFieldDuration      = "duration"
+	RevisionFieldRequirements  = "requirements"
+	RevisionFieldStartDate     = "start_date"
+	RevisionFieldEndDate       = "end_date"
+	RevisionFieldURL           = "url"
+	RevisionFieldIsActive      = "is_active"
+)
+
+// FieldChange зміна одного поля між збереженою та новою версією
+type FieldChange struct {
+	Field    string `json:"field"`
+	Old      string `json:"old"`
+	New      string `json:"new"`
+	Material bool   `json:"material"` // Зміна важлива для користувачів
+}
+
+// OpportunityRevision зміни можливості, виявлені повторним скрапінгом
+type OpportunityRevision struct {
+	BaseModel
+
+	OpportunityID uint          `gorm:"index;not null" json:"opportunity_id"`
+	Exchange      string        `gorm:"index" json:"exchange"`
+	Changes       []FieldChange `gorm:"type:jsonb;serializer:json" json:"changes"`
+	Material      bool          `gorm:"index" json:"material"`
+	DetectedAt    time.Time     `gorm:"index" json:"detected_at"`
+}
+
+func (*OpportunityRevision) TableName() string {
+	return "opportunity_revisions"
+}
+
+// MaterialChanges зміни, про які повідомляються користувачі
+func (r *OpportunityRevision) MaterialChanges() []FieldChange {
+	changes := make([]FieldChange, 0, len(r.Changes))
+	for _, change := range r.Changes {
+		if change.Material {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
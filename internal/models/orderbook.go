@@ -15,6 +15,11 @@ type OrderBook struct {
 	LastUpdateID int64
 	LastUpdate   time.Time
 	mu           sync.RWMutex
+
+	// Заповнюються для книги, приведеної до іншої валюти котирування (Converted)
+	NativeSymbol string  // символ на біржі (BTC/USD)
+	QuoteRate    float64 // ціна 1 одиниці валюти котирування біржі у валюті Symbol
+	QuoteSpread  float64 // % спреду курсу (вартість конвертації без комісії)
 }
 
 // PriceLevel - рівень ціни в ордербуці
@@ -223,6 +228,36 @@ func (ob *OrderBook) ApplyDelta(bids, asks []PriceLevel, updateID int64) {
 	ob.LastUpdate = time.Now()
 }
 
+// Truncate залишає тільки depth найкращих рівнів з кожної сторони
+// (для бірж, які не надсилають видалення рівнів що виходять за межі глибини)
+func (ob *OrderBook) Truncate(depth int) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if len(ob.Bids) > depth {
+		ob.Bids = ob.Bids[:depth]
+	}
+	if len(ob.Asks) > depth {
+		ob.Asks = ob.Asks[:depth]
+	}
+}
+
+// Snapshot повертає копію depth найкращих рівнів з кожної сторони (0 = всі)
+func (ob *OrderBook) Snapshot(depth int) (bids, asks []PriceLevel, updateID int64) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	bids, asks = ob.Bids, ob.Asks
+	if depth > 0 && len(bids) > depth {
+		bids = bids[:depth]
+	}
+	if depth > 0 && len(asks) > depth {
+		asks = asks[:depth]
+	}
+
+	return append([]PriceLevel(nil), bids...), append([]PriceLevel(nil), asks...), ob.LastUpdateID
+}
+
 // updateLevel оновлює окремий рівень ціни
 func (ob *OrderBook) updateLevel(levels *[]PriceLevel, update PriceLevel, isBid bool) {
 	// Знайти рівень з такою ціною
@@ -258,6 +293,39 @@ func (ob *OrderBook) sortLevels() {
 	})
 }
 
+// Converted повертає копію з цінами, помноженими на rate, під символом symbol
+// (BTC/USD -> BTC/USDT). Час оновлення зберігається, тому перевірки свіжості
+// працюють як для оригіналу
+func (ob *OrderBook) Converted(symbol string, rate, spreadPercent float64) *OrderBook {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	convert := func(levels []PriceLevel) []PriceLevel {
+		converted := make([]PriceLevel, len(levels))
+		for i, level := range levels {
+			converted[i] = PriceLevel{Price: level.Price * rate, Quantity: level.Quantity}
+		}
+		return converted
+	}
+
+	return &OrderBook{
+		Exchange:     ob.Exchange,
+		Symbol:       symbol,
+		Bids:         convert(ob.Bids),
+		Asks:         convert(ob.Asks),
+		LastUpdateID: ob.LastUpdateID,
+		LastUpdate:   ob.LastUpdate,
+		NativeSymbol: ob.Symbol,
+		QuoteRate:    rate,
+		QuoteSpread:  spreadPercent,
+	}
+}
+
+// IsConverted перевіряє чи ціни приведені з іншої валюти котирування
+func (ob *OrderBook) IsConverted() bool {
+	return ob.NativeSymbol != ""
+}
+
 // IsStale перевіряє чи ордербук застарілий
 func (ob *OrderBook) IsStale(maxAge time.Duration) bool {
 	ob.mu.RLock()
@@ -266,6 +334,14 @@ func (ob *OrderBook) IsStale(maxAge time.Duration) bool {
 	return time.Since(ob.LastUpdate) > maxAge
 }
 
+// UpdatedAt час останнього оновлення
+func (ob *OrderBook) UpdatedAt() time.Time {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.LastUpdate
+}
+
 // GetDepth повертає кількість рівнів
 func (ob *OrderBook) GetDepth() (bidDepth, askDepth int) {
 	ob.mu.RLock()
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	PriceAlertTypeDivergence = "divergence" // Ціна біржі відхилилась від медіани інших бірж
+	PriceAlertTypeDepeg      = "depeg"      // Стейблкоїн торгується далеко від $1
+)
+
+// PriceAlert тривала аномалія ціни: біржа торгує символ далеко від інших бірж
+// або стейблкоїн втратив прив'язку до долара. Часто - ранній сигнал проблем
+// з виведенням коштів чи платоспроможністю біржі (а не арбітражна можливість)
+type PriceAlert struct {
+	BaseModel
+
+	Type     string `gorm:"index;not null" json:"type"`   // 'divergence', 'depeg'
+	Symbol   string `gorm:"index;not null" json:"symbol"` // 'BTC/USDT', 'USDC/USDT'
+	Exchange string `gorm:"index" json:"exchange"`        // Біржа з відхиленням (порожньо для depeg по медіані бірж)
+
+	Price            float64 `gorm:"type:decimal(20,8);not null" json:"price"`            // Mid price біржі (медіана бірж для depeg)
+	ReferencePrice   float64 `gorm:"type:decimal(20,8);not null" json:"reference_price"`  // Медіана інших бірж або $1
+	DeviationPercent float64 `gorm:"type:decimal(8,4);not null" json:"deviation_percent"` // (price - reference) / reference
+	PeakDeviation    float64 `gorm:"type:decimal(8,4)" json:"peak_deviation"`             // Найбільше |відхилення| до завершення
+	Exchanges        int     `json:"exchanges"`                                           // Бірж у розрахунку
+
+	// Timing
+	StartedAt  time.Time  `gorm:"not null" json:"started_at"`  // Початок відхилення
+	DetectedAt time.Time  `gorm:"not null" json:"detected_at"` // Відхилення тривало довше порогу
+	ResolvedAt *time.Time `gorm:"index" json:"resolved_at,omitempty"`
+	IsNotified bool       `gorm:"default:false" json:"is_notified"`
+}
+
+func (*PriceAlert) TableName() string {
+	return "price_alerts"
+}
+
+// IsActive перевіряє чи відхилення ще триває
+func (a *PriceAlert) IsActive() bool {
+	return a.ResolvedAt == nil
+}
+
+// IsDepeg перевіряє чи це depeg стейблкоїна
+func (a *PriceAlert) IsDepeg() bool {
+	return a.Type == PriceAlertTypeDepeg
+}
+
+// Duration скільки триває (тривало) відхилення
+func (a *PriceAlert) Duration() time.Duration {
+	if a.ResolvedAt != nil {
+		return a.ResolvedAt.Sub(a.StartedAt)
+	}
+	return time.Since(a.StartedAt)
+}
+
+// AbsDeviation модуль відхилення у %
+func (a *PriceAlert) AbsDeviation() float64 {
+	return math.Abs(a.DeviationPercent)
+}
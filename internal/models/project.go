@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Project канонічний проєкт (токен), до якого прив'язуються можливості
+// різних бірж: один airdrop на Binance, Bybit та OKX - один Project
+type Project struct {
+	BaseModel
+
+	Symbol      string    `gorm:"index;not null" json:"symbol"` // XYZ
+	Name        string    `json:"name"`                         // Pixels або symbol, якщо назва невідома
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `gorm:"index" json:"last_seen_at"`
+}
+
+func (*Project) TableName() string {
+	return "projects"
+}
+
+// DisplayName назва для повідомлень
+func (p *Project) DisplayName() string {
+	if p.Name == "" || p.Name == p.Symbol {
+		return p.Symbol
+	}
+	return p.Name + " (" + p.Symbol + ")"
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+const (
+	ScraperAnomalyZeroResults = "zero_results" // Скрапер раптом нічого не повернув
+	ScraperAnomalySpike       = "spike"        // Результатів у рази більше за звичне
+	ScraperAnomalyFieldEmpty  = "field_empty"  // Звично заповнене поле стало порожнім
+)
+
+// ScraperBaseline звичний результат скрапера: ковзні середні кількості
+// можливостей та частки заповнених полів за успішними запусками
+type ScraperBaseline struct {
+	BaseModel
+
+	Exchange  string             `gorm:"uniqueIndex;not null" json:"exchange"`
+	Samples   int                `json:"samples"` // Успішних запусків у середньому
+	AvgCount  float64            `json:"avg_count"`
+	FillRates map[string]float64 `gorm:"type:jsonb;serializer:json" json:"fill_rates"` // поле -> частка 0-1
+
+	LastCount     int                `json:"last_count"`
+	LastFillRates map[string]float64 `gorm:"type:jsonb;serializer:json" json:"last_fill_rates"`
+	LastRunAt     time.Time          `json:"last_run_at"`
+
+	AnomalousSince *time.Time `json:"anomalous_since,omitempty"` // Перший з поспіль аномальних запусків
+}
+
+func (*ScraperBaseline) TableName() string {
+	return "scraper_baselines"
+}
+
+// ScraperAnomaly відхилення результату скрапера від baseline - ознака зміни
+// формату API біржі. Пишеться процесом бота, admin API транслює нові записи
+// через WebSocket
+type ScraperAnomaly struct {
+	BaseModel
+
+	Exchange string  `gorm:"index;not null" json:"exchange"`
+	Kind     string  `gorm:"index" json:"kind"` // zero_results, spike, field_empty
+	Field    string  `json:"field,omitempty"`   // Для field_empty (RevisionField*)
+	Expected float64 `json:"expected"`          // Середня кількість або частка заповнення
+	Actual   float64 `json:"actual"`
+	Message  string  `json:"message"`
+
+	DetectedAt time.Time `gorm:"index" json:"detected_at"`
+}
+
+func (*ScraperAnomaly) TableName() string {
+	return "scraper_anomalies"
+}
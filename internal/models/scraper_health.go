@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+const (
+	ScraperCircuitClosed   = "closed"    // Скрапер запускається штатно
+	ScraperCircuitOpen     = "open"      // Забагато помилок поспіль - запуски пропускаються
+	ScraperCircuitHalfOpen = "half_open" // Пробний запуск після паузи
+)
+
+// ScraperHealth стан скрапера біржі (circuit breaker та останній запуск).
+// Пишеться процесом бота після кожного scraper.Service.RunAll, читається admin API
+type ScraperHealth struct {
+	BaseModel
+
+	Exchange            string `gorm:"uniqueIndex;not null" json:"exchange"`
+	State               string `gorm:"index" json:"state"` // closed, open, half_open
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+
+	TotalRuns     int64 `json:"total_runs"`
+	TotalFailures int64 `json:"total_failures"`
+	TotalSkipped  int64 `json:"total_skipped"` // Пропущено відкритим circuit breaker
+	TotalRetries  int64 `json:"total_retries"` // Повторні спроби після тимчасових помилок
+
+	LastOpportunities int        `json:"last_opportunities"`
+	LastDurationMs    int64      `json:"last_duration_ms"`
+	LastRunAt         *time.Time `json:"last_run_at,omitempty"`
+	LastSuccessAt     *time.Time `json:"last_success_at,omitempty"`
+	LastError         string     `gorm:"type:text" json:"last_error,omitempty"`
+	LastErrorAt       *time.Time `json:"last_error_at,omitempty"`
+
+	OpenedAt    *time.Time `json:"opened_at,omitempty"`     // Коли circuit breaker відкрився
+	NextProbeAt *time.Time `json:"next_probe_at,omitempty"` // Наступний пробний запуск
+
+	ReportedAt time.Time `gorm:"index" json:"reported_at"`
+}
+
+func (*ScraperHealth) TableName() string {
+	return "scraper_health"
+}
+
+// SuccessRate частка успішних запусків (%)
+func (h *ScraperHealth) SuccessRate() float64 {
+	if h.TotalRuns == 0 {
+		return 0
+	}
+	return float64(h.TotalRuns-h.TotalFailures) / float64(h.TotalRuns) * 100
+}
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+const (
+	ScraperTriggerSchedule = "schedule" // Запуск за розкладом
+	ScraperTriggerManual   = "manual"   // Запуск з admin API
+
+	ScraperRunRunning = "running"
+	ScraperRunSuccess = "success"
+	ScraperRunFailed  = "failed"
+	ScraperRunSkipped = "skipped" // Circuit breaker відкритий
+
+	ScraperJobPending = "pending"
+	ScraperJobRunning = "running"
+	ScraperJobDone    = "done"
+	ScraperJobFailed  = "failed"
+)
+
+// ScraperRun один запуск скрапера біржі в scraper.Service
+type ScraperRun struct {
+	BaseModel
+
+	Exchange string `gorm:"index;not null" json:"exchange"`
+	Trigger  string `json:"trigger"` // schedule, manual
+	JobID    *uint  `gorm:"index" json:"job_id,omitempty"`
+	Status   string `gorm:"index" json:"status"` // running, success, failed, skipped
+
+	StartedAt  time.Time  `gorm:"index" json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs int64      `json:"duration_ms"`
+
+	Found   int `json:"found"`
+	New     int `json:"new"`
+	Updated int `json:"updated"`
+	Retries int `json:"retries"`
+
+	Error string `gorm:"type:text" json:"error,omitempty"`
+}
+
+// ScraperJob запит admin API на запуск скраперів. Процес бота опитує таблицю
+// і виконує нові завдання (процеси не мають спільної пам'яті)
+type ScraperJob struct {
+	BaseModel
+
+	Exchange    string `gorm:"index" json:"exchange"` // "" - всі зареєстровані скрапери
+	Status      string `gorm:"index;not null" json:"status"`
+	RequestedBy string `json:"requested_by"`
+
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	Scrapers int `json:"scrapers"` // Скільки скраперів запущено
+	Failed   int `json:"failed"`
+	Skipped  int `json:"skipped"`
+	New      int `json:"new"`
+	Updated  int `json:"updated"`
+
+	Error string `gorm:"type:text" json:"error,omitempty"`
+}
+
+// IsFinished чи завершене завдання
+func (j *ScraperJob) IsFinished() bool {
+	return j.Status == ScraperJobDone || j.Status == ScraperJobFailed
+}
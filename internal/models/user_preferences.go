@@ -1,5 +1,12 @@
 package models
 
+const (
+	ArbitrageModeTransfer  = "transfer"  // Монети переводяться між біржами в кожній угоді
+	ArbitrageModePrefunded = "prefunded" // Баланси на обох біржах, періодичний ребаланс
+
+	DefaultRebalanceTrades = 20
+)
+
 type UserPreferences struct {
 	BaseModel
 
@@ -13,12 +20,22 @@ type UserPreferences struct {
 	NotifyLaunchpool   bool        `gorm:"default:true" json:"notify_launchpool"`
 	NotifyAirdrop      bool        `gorm:"default:true" json:"notify_airdrop"`
 	NotifyLearnEarn    bool        `gorm:"default:true" json:"notify_learn_earn"`
-	NotifyDeFi         bool        `gorm:"default:false" json:"notify_defi"`   // Premium
-	NotifyWhales       bool        `gorm:"default:false" json:"notify_whales"` // Premium
+	NotifyDeFi         bool        `gorm:"default:false" json:"notify_defi"`         // Premium
+	NotifyWhales       bool        `gorm:"default:false" json:"notify_whales"`       // Premium
+	NotifyFunding      bool        `gorm:"default:false" json:"notify_funding"`      // Premium
+	NotifyPriceAlerts  bool        `gorm:"default:false" json:"notify_price_alerts"` // Premium
 	DailyDigestEnabled bool        `gorm:"default:true" json:"daily_digest_enabled"`
 	DailyDigestTime    string      `gorm:"default:'09:00'" json:"daily_digest_time"` // HH:MM format
+	ArbitrageMode      string      `gorm:"default:'transfer'" json:"arbitrage_mode"` // transfer, prefunded
+	RebalanceTrades    int         `gorm:"default:20" json:"rebalance_trades"`       // Угод між ребалансами (prefunded)
+	PaperTrading       bool        `gorm:"default:false" json:"paper_trading"`       // Симулювати угоди по арбітражних алертах
 }
 
 func (*UserPreferences) TableName() string {
 	return "user_preferences"
 }
+
+// IsPrefunded перевіряє чи користувач торгує з балансів на обох біржах
+func (p *UserPreferences) IsPrefunded() bool {
+	return p.ArbitrageMode == ArbitrageModePrefunded
+}
@@ -5,7 +5,12 @@ import (
 	"time"
 )
 
-type Filter struct{}
+type Filter struct {
+	// Поріг прибутку детектора арбітражу: детектор пропускає можливість, якщо
+	// поріг проходить хоча б одна модель торгівлі, а користувач отримує лише ті,
+	// де поріг проходить його модель
+	minArbitrageProfit float64
+}
 
 func NewFilter() *Filter {
 	return &Filter{}
@@ -20,6 +25,23 @@ func (f *Filter) ShouldNotify(user *models.User, prefs *models.UserPreferences,
 		return false
 	}
 
+	return f.matchesPreferences(user, prefs, opp)
+}
+
+// ShouldNotifyUpdate перевіряє чи надсилати зміну умов можливості за тими ж
+// налаштуваннями, що й нову. Неактивна можливість не відсіюється - завершення
+// кампанії теж є зміною, про яку варто знати
+func (f *Filter) ShouldNotifyUpdate(user *models.User, prefs *models.UserPreferences, opp *models.Opportunity) bool {
+	if !user.IsActive || user.IsBlocked {
+		return false
+	}
+
+	return f.matchesPreferences(user, prefs, opp)
+}
+
+// matchesPreferences чи відповідає можливість типам, біржам, ROI, капіталу та
+// ризик-профілю користувача
+func (f *Filter) matchesPreferences(user *models.User, prefs *models.UserPreferences, opp *models.Opportunity) bool {
 	if f.isPremiumOpportunity(opp.Type) && !user.IsPremium() {
 		return false
 	}
@@ -99,6 +121,8 @@ func (f *Filter) isPremiumOpportunity(oppType string) bool {
 	premiumTypes := []string{
 		models.OpportunityTypeArbitrage,
 		models.OpportunityTypeDeFi,
+		models.OpportunityTypeFunding,
+		models.OpportunityTypePriceAlert,
 	}
 
 	for _, pt := range premiumTypes {
@@ -242,12 +266,12 @@ func (f *Filter) ShouldNotifyArbitrage(user *models.User, prefs *models.UserPref
 	}
 
 	// Check min ROI
-	if arb.NetProfitPercent < prefs.MinROI {
+	if arb.NetProfitPercent < prefs.MinROI || arb.NetProfitPercent < f.minArbitrageProfit {
 		return false
 	}
 
-	// Check max investment (recommended amount should be within user's budget)
-	if prefs.MaxInvestment > 0 && arb.RecommendedAmount > float64(prefs.MaxInvestment) {
+	// Check max investment (break-even amount should be within user's budget)
+	if prefs.MaxInvestment > 0 && arb.BreakEvenAmount > float64(prefs.MaxInvestment) {
 		return false
 	}
 
@@ -276,6 +300,60 @@ func (f *Filter) areBothExchangesEnabled(exchangeBuy, exchangeSell string, prefs
 	return buyEnabled && sellEnabled
 }
 
+// ShouldNotifyFunding перевіряє чи потрібно відправити funding сповіщення
+func (f *Filter) ShouldNotifyFunding(user *models.User, prefs *models.UserPreferences, opp *models.FundingOpportunity) bool {
+	// User must be active and not blocked
+	if !user.IsActive || user.IsBlocked {
+		return false
+	}
+
+	// Must be Premium
+	if !user.IsPremium() {
+		return false
+	}
+
+	// Check if funding notifications are enabled
+	if !prefs.NotifyFunding {
+		return false
+	}
+
+	// Check if both exchanges are enabled
+	if !f.areBothExchangesEnabled(opp.LongExchange, opp.ShortExchange, prefs) {
+		return false
+	}
+
+	// Check min ROI (прибуток за період утримання)
+	if opp.NetProfitForHolding() < prefs.MinROI {
+		return false
+	}
+
+	return true
+}
+
+// ShouldNotifyPriceAlert перевіряє чи потрібно відправити алерт розбіжності цін / depeg
+func (f *Filter) ShouldNotifyPriceAlert(user *models.User, prefs *models.UserPreferences, alert *models.PriceAlert) bool {
+	// User must be active and not blocked
+	if !user.IsActive || user.IsBlocked {
+		return false
+	}
+
+	// Must be Premium
+	if !user.IsPremium() {
+		return false
+	}
+
+	if !prefs.NotifyPriceAlerts {
+		return false
+	}
+
+	// Divergence - тільки по біржах користувача (depeg стосується всіх)
+	if alert.Exchange != "" && !f.isExchangeEnabled(alert.Exchange, prefs) {
+		return false
+	}
+
+	return true
+}
+
 // ShouldNotifyDeFi перевіряє чи потрібно відправити DeFi сповіщення
 func (f *Filter) ShouldNotifyDeFi(user *models.User, prefs *models.UserPreferences, defi *models.DeFiOpportunity) bool {
 	// User must be active and not blocked
@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"testing"
+	"time"
+)
+
+func TestShouldNotifyArbitrageUsesUserTradingModel(t *testing.T) {
+	expires := time.Now().Add(24 * time.Hour)
+	user := &models.User{IsActive: true, SubscriptionTier: "premium", SubscriptionExpiresAt: &expires}
+
+	// Детектор пропустив можливість за pre-funded моделлю (0.6%), переказ збитковий
+	arb := &models.ArbitrageOpportunity{
+		ExchangeBuy:            "binance",
+		ExchangeSell:           "kraken",
+		NetProfitPercent:       -0.4,
+		PrefundedProfitPercent: 0.6,
+		WithdrawalFeeUSD:       10,
+	}
+
+	filter := NewFilter()
+	filter.minArbitrageProfit = 0.3
+
+	tests := []struct {
+		name  string
+		prefs *models.UserPreferences
+		arb   *models.ArbitrageOpportunity
+		want  bool
+	}{
+		{"transfer user without MinROI", &models.UserPreferences{NotifyArbitrage: true}, arb, false},
+		{"transfer user with negative MinROI", &models.UserPreferences{NotifyArbitrage: true, MinROI: -1}, arb, false},
+		{"prefunded user", &models.UserPreferences{NotifyArbitrage: true}, arb.WithPrefunded(1), true},
+		{"prefunded user above MinROI", &models.UserPreferences{NotifyArbitrage: true, MinROI: 1}, arb.WithPrefunded(1), false},
+	}
+
+	for _, tt := range tests {
+		if got := filter.ShouldNotifyArbitrage(user, tt.prefs, tt.arb); got != tt.want {
+			t.Errorf("%s: ShouldNotifyArbitrage = %v (net %.2f%%), want %v", tt.name, got, tt.arb.NetProfitPercent, tt.want)
+		}
+	}
+}
+
+func TestShouldNotifyUpdateUsesPreferences(t *testing.T) {
+	user := &models.User{IsActive: true}
+	opp := &models.Opportunity{Exchange: "okx", Type: models.OpportunityTypeAirdrop, EstimatedROI: 2}
+	ended := &models.Opportunity{Exchange: "okx", Type: models.OpportunityTypeAirdrop, EstimatedROI: 2, IsActive: false}
+
+	tests := []struct {
+		name  string
+		user  *models.User
+		prefs *models.UserPreferences
+		opp   *models.Opportunity
+		want  bool
+	}{
+		{"matching preferences", user, &models.UserPreferences{}, opp, true},
+		{"exchange filtered out", user, &models.UserPreferences{Exchanges: models.StringArray{"binance"}}, opp, false},
+		{"type filtered out", user, &models.UserPreferences{OpportunityTypes: models.StringArray{models.OpportunityTypeLaunchpool}}, opp, false},
+		{"below min ROI", user, &models.UserPreferences{MinROI: 5}, opp, false},
+		{"campaign ended", user, &models.UserPreferences{}, ended, true},
+		{"blocked user", &models.User{IsActive: true, IsBlocked: true}, &models.UserPreferences{}, opp, false},
+	}
+
+	filter := NewFilter()
+	for _, tt := range tests {
+		if got := filter.ShouldNotifyUpdate(tt.user, tt.prefs, tt.opp); got != tt.want {
+			t.Errorf("%s: ShouldNotifyUpdate = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -72,6 +72,41 @@ func (f *Formatter) FormatOpportunity(opp *models.Opportunity) string {
 	return builder.String()
 }
 
+// FormatProjectLinks перелік інших бірж з тим самим проєктом (порожній, якщо немає)
+func (f *Formatter) FormatProjectLinks(opp *models.Opportunity, related []*models.Opportunity) string {
+	exchanges := f.projectExchanges(related, opp.Exchange)
+	if len(exchanges) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("\n🔗 Також на: <b>%s</b>\n", strings.Join(exchanges, ", "))
+}
+
+// FormatOpportunityUpdate форматує важливі зміни вже надісланої можливості
+func (f *Formatter) FormatOpportunityUpdate(opp *models.Opportunity, changes []models.FieldChange) string {
+	var builder strings.Builder
+
+	emoji := f.getOpportunityEmoji(opp.Type)
+
+	builder.WriteString(fmt.Sprintf("🔄 <b>Оновлення</b>: %s <b>%s</b>\n\n", emoji, opp.Title))
+	builder.WriteString(fmt.Sprintf("🏦 Біржа: <b>%s</b>\n\n", f.titleCase(opp.Exchange)))
+
+	for _, change := range changes {
+		builder.WriteString(fmt.Sprintf("• %s: %s → <b>%s</b>\n",
+			f.getFieldName(change.Field),
+			f.formatFieldValue(change.Field, change.Old),
+			f.formatFieldValue(change.Field, change.New)))
+	}
+
+	if opp.IsActive && opp.EndDate != nil {
+		if daysLeft := opp.DaysLeft(); daysLeft >= 0 {
+			builder.WriteString(fmt.Sprintf("\n⏰ Залишилось: <b>%d днів</b>\n", daysLeft))
+		}
+	}
+
+	return builder.String()
+}
+
 // FormatDailyDigest форматує щоденний дайджест
 func (f *Formatter) FormatDailyDigest(opportunities []*models.Opportunity, user *models.User) string {
 	var builder strings.Builder
@@ -98,12 +133,29 @@ func (f *Formatter) FormatDailyDigest(opportunities []*models.Opportunity, user
 
 		builder.WriteString(fmt.Sprintf("%s <b>%s (%d)</b>\n", emoji, typeName, len(opps)))
 
-		for i, opp := range opps {
+		// Один проєкт на кількох біржах - один рядок
+		groups := f.groupByProject(opps)
+
+		for i, group := range groups {
 			if i >= 3 {
-				builder.WriteString(fmt.Sprintf("   ... і ще %d\n", len(opps)-3))
+				builder.WriteString(fmt.Sprintf("   ... і ще %d\n", len(groups)-3))
 				break
 			}
 
+			opp := group[0]
+			if len(group) > 1 {
+				name := f.truncateTitle(opp.Title, 40)
+				if opp.Project != nil {
+					name = opp.Project.DisplayName()
+				}
+
+				builder.WriteString(fmt.Sprintf("   • %s - %s\n",
+					name,
+					strings.Join(f.projectExchanges(group, ""), ", "),
+				))
+				continue
+			}
+
 			roi := ""
 			if opp.EstimatedROI > 0 {
 				roi = fmt.Sprintf(" • %.1f%% ROI", opp.EstimatedROI)
@@ -153,6 +205,10 @@ func (f *Formatter) FormatPremiumTeaser(missedOpportunities int) string {
 
 // FormatArbitrage форматує арбітражну можливість з моделі
 func (f *Formatter) FormatArbitrage(arb *models.ArbitrageOpportunity) string {
+	if arb.IsTriangular() {
+		return f.formatTriangularArbitrage(arb)
+	}
+
 	var builder strings.Builder
 
 	emoji := "💰"
@@ -164,18 +220,45 @@ func (f *Formatter) FormatArbitrage(arb *models.ArbitrageOpportunity) string {
 
 	builder.WriteString(fmt.Sprintf("%s <b>АРБІТРАЖ!</b>\n\n", emoji))
 	builder.WriteString(fmt.Sprintf("Пара: <b>%s</b>\n", arb.Pair))
-	builder.WriteString(fmt.Sprintf("🟢 Купити: <b>%s</b> @ $%.4f\n", f.titleCase(arb.ExchangeBuy), arb.PriceBuy))
-	builder.WriteString(fmt.Sprintf("🔴 Продати: <b>%s</b> @ $%.4f\n\n", f.titleCase(arb.ExchangeSell), arb.PriceSell))
+	builder.WriteString(fmt.Sprintf("🟢 Купити: <b>%s</b>%s @ $%.4f\n", f.titleCase(arb.ExchangeBuy), f.nativeSymbol(arb.SymbolBuy), arb.PriceBuy))
+	builder.WriteString(fmt.Sprintf("🔴 Продати: <b>%s</b>%s @ $%.4f\n\n", f.titleCase(arb.ExchangeSell), f.nativeSymbol(arb.SymbolSell), arb.PriceSell))
 
 	builder.WriteString(fmt.Sprintf("💵 Валовий profit: <b>%.2f%%</b>\n", arb.ProfitPercent))
 	builder.WriteString(fmt.Sprintf("📊 На $1000: <b>$%.2f</b>\n", arb.ProfitUSD))
-	builder.WriteString(fmt.Sprintf("💼 Рекомендовано: <b>$%.0f-%.0f</b>\n\n", arb.MinTradeAmount, arb.RecommendedAmount))
+	builder.WriteString(fmt.Sprintf("💼 Оптимально: <b>$%.0f</b> (<b>$%.2f</b> net)\n", arb.OptimalAmount, arb.OptimalProfitUSD))
+	if arb.BreakEvenAmount > 0 {
+		builder.WriteString(fmt.Sprintf("⚖️ Окупається від: <b>$%.0f</b>\n", arb.BreakEvenAmount))
+	}
+	builder.WriteString("\n")
 
 	builder.WriteString(fmt.Sprintf("⚠️ Trading fees: <b>-%.2f%%</b>\n", arb.TotalFeesPercent))
 	builder.WriteString(fmt.Sprintf("📉 Slippage: <b>-%.2f%%</b>\n", arb.SlippageBuy+arb.SlippageSell))
+	if arb.ConversionFee > 0 {
+		builder.WriteString(fmt.Sprintf("💱 Конвертація в %s: <b>-%.2f%%</b>\n", arb.QuoteCurrency, arb.ConversionFee))
+	}
 	builder.WriteString(fmt.Sprintf("✅ Чистий profit: <b>%.2f%%</b> (<b>$%.2f</b> на $1000)\n\n",
 		arb.NetProfitPercent, arb.NetProfitUSD))
 
+	// Transfer route (pre-funded - тільки для періодичного ребалансу)
+	switch {
+	case arb.Prefunded:
+		builder.WriteString(fmt.Sprintf("🏦 З балансів на обох біржах: без переказу, ребаланс $%.2f розподілено між угодами\n",
+			arb.RebalanceCostUSD))
+		if arb.TransferStatus == models.TransferStatusClosed {
+			builder.WriteString(fmt.Sprintf("⚠️ Переказ %s зараз закритий - ребаланс тимчасово недоступний\n", arb.BaseCurrency))
+		}
+	case arb.TransferStatus == models.TransferStatusOpen:
+		builder.WriteString(fmt.Sprintf("🔀 Переказ %s: мережа <b>%s</b> (~%.0f хв)\n",
+			arb.BaseCurrency, arb.TransferNetwork, arb.TransferMinutes))
+	case arb.TransferStatus == models.TransferStatusClosed:
+		builder.WriteString(fmt.Sprintf("🚫 <b>Переказ %s закритий</b> (виведення/депозит призупинено) - спред може бути недосяжним\n",
+			arb.BaseCurrency))
+	default:
+		builder.WriteString(fmt.Sprintf("❔ Статус мереж для %s невідомий - перевірте депозит/виведення перед угодою\n",
+			arb.BaseCurrency))
+	}
+	builder.WriteString("\n")
+
 	// Time left
 	timeLeft := arb.TimeLeft()
 	minutesLeft := int(timeLeft.Minutes())
@@ -189,6 +272,39 @@ func (f *Formatter) FormatArbitrage(arb *models.ArbitrageOpportunity) string {
 	return builder.String()
 }
 
+// formatTriangularArbitrage форматує трикутний арбітраж в межах однієї біржі
+func (f *Formatter) formatTriangularArbitrage(arb *models.ArbitrageOpportunity) string {
+	var builder strings.Builder
+
+	emoji := "🔺"
+	if arb.NetProfitPercent >= 1.0 {
+		emoji = "🔺🔥"
+	}
+
+	builder.WriteString(fmt.Sprintf("%s <b>ТРИКУТНИЙ АРБІТРАЖ!</b>\n\n", emoji))
+	builder.WriteString(fmt.Sprintf("🏦 Біржа: <b>%s</b>\n", f.titleCase(arb.ExchangeBuy)))
+	builder.WriteString(fmt.Sprintf("🔄 Маршрут: <b>%s</b>\n", arb.Route))
+	builder.WriteString(fmt.Sprintf("📋 Пари: %s\n\n", strings.ReplaceAll(arb.Pair, ",", ", ")))
+
+	builder.WriteString(fmt.Sprintf("💵 Валовий profit: <b>%.2f%%</b>\n", arb.ProfitPercent))
+	builder.WriteString(fmt.Sprintf("⚠️ Fees + slippage (3 угоди): <b>-%.2f%%</b>\n", arb.TotalFeesPercent))
+	builder.WriteString(fmt.Sprintf("✅ Чистий profit: <b>%.2f%%</b> (<b>$%.2f</b> на $1000)\n", arb.NetProfitPercent, arb.NetProfitUSD))
+	builder.WriteString(fmt.Sprintf("💼 Розраховано на: <b>$%.0f</b>\n\n", arb.OptimalAmount))
+
+	builder.WriteString("💡 Без переказів між біржами - всі угоди на одному акаунті\n")
+	builder.WriteString("\n⚠️ <i>Це інформація, не гарантія прибутку. Ціни змінюються швидко.</i>")
+
+	return builder.String()
+}
+
+// nativeSymbol пара на біржі, якщо вона котирується в іншій валюті (ціни в алерті вже сконвертовані)
+func (f *Formatter) nativeSymbol(symbol string) string {
+	if symbol == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", symbol)
+}
+
 // FormatArbitrageAlert форматує арбітражний алерт (Premium) - legacy
 func (f *Formatter) FormatArbitrageAlert(exchangeBuy, exchangeSell, pair string,
 	priceBuy, priceSell, profitPercent, netProfitPercent float64) string {
@@ -212,6 +328,77 @@ func (f *Formatter) FormatArbitrageAlert(exchangeBuy, exchangeSell, pair string,
 	return builder.String()
 }
 
+// FormatFunding форматує funding rate / basis можливість
+func (f *Formatter) FormatFunding(opp *models.FundingOpportunity) string {
+	var builder strings.Builder
+
+	emoji := "💸"
+	if opp.NetAPR >= 50 {
+		emoji = "🔥💸"
+	}
+
+	if opp.IsCashAndCarry() {
+		builder.WriteString(fmt.Sprintf("%s <b>CASH & CARRY</b>\n\n", emoji))
+	} else {
+		builder.WriteString(fmt.Sprintf("%s <b>FUNDING АРБІТРАЖ</b>\n\n", emoji))
+	}
+
+	builder.WriteString(fmt.Sprintf("Пара: <b>%s</b>\n", opp.Symbol))
+	if opp.IsCashAndCarry() {
+		builder.WriteString(fmt.Sprintf("🟢 Купити spot: <b>%s</b> @ $%.4f\n", f.titleCase(opp.LongExchange), opp.LongPrice))
+	} else {
+		builder.WriteString(fmt.Sprintf("🟢 Long perp: <b>%s</b> @ $%.4f (funding %.4f%%)\n",
+			f.titleCase(opp.LongExchange), opp.LongPrice, opp.LongFunding))
+	}
+	builder.WriteString(fmt.Sprintf("🔴 Short perp: <b>%s</b> @ $%.4f (funding %.4f%%)\n\n",
+		f.titleCase(opp.ShortExchange), opp.ShortPrice, opp.ShortFunding))
+
+	builder.WriteString(fmt.Sprintf("📈 Funding APR: <b>%.2f%%</b>\n", opp.FundingAPR))
+	builder.WriteString(fmt.Sprintf("📐 Базис: <b>%+.3f%%</b>\n", opp.BasisPercent))
+	builder.WriteString(fmt.Sprintf("⚠️ Fees (вхід + вихід): <b>-%.2f%%</b>\n", opp.TotalFeesPercent+opp.SlippagePercent))
+	builder.WriteString(fmt.Sprintf("✅ Чиста дохідність: <b>%.2f%% річних</b> (%.2f%% за %d дн.)\n",
+		opp.NetAPR, opp.NetProfitForHolding(), opp.HoldingDays))
+	builder.WriteString(fmt.Sprintf("💵 На $1000: <b>$%.2f/день</b>\n\n", opp.DailyReturnUSD(1000)))
+
+	if !opp.NextFundingTime.IsZero() {
+		builder.WriteString(fmt.Sprintf("⏰ Наступне нарахування: %s UTC (кожні %.0f год)\n",
+			opp.NextFundingTime.UTC().Format("15:04"), opp.FundingIntervalHours))
+	}
+
+	builder.WriteString("\n⚠️ <i>Funding rate змінюється кожен період. Потрібна маржа на short позицію.</i>")
+
+	return builder.String()
+}
+
+// FormatPriceAlert форматує алерт розбіжності цін між біржами або depeg стейблкоїна
+func (f *Formatter) FormatPriceAlert(alert *models.PriceAlert) string {
+	var builder strings.Builder
+
+	if alert.IsDepeg() {
+		builder.WriteString("🚨 <b>DEPEG СТЕЙБЛКОЇНА</b>\n\n")
+		builder.WriteString(fmt.Sprintf("Пара: <b>%s</b>\n", alert.Symbol))
+		builder.WriteString(fmt.Sprintf("💲 Ціна: <b>%.4f</b> (медіана %d бірж)\n", alert.Price, alert.Exchanges))
+		builder.WriteString(fmt.Sprintf("📐 Відхилення від $1: <b>%+.2f%%</b>\n", alert.DeviationPercent))
+	} else {
+		builder.WriteString("🚨 <b>РОЗБІЖНІСТЬ ЦІН</b>\n\n")
+		builder.WriteString(fmt.Sprintf("Пара: <b>%s</b>\n", alert.Symbol))
+		builder.WriteString(fmt.Sprintf("🏦 Біржа: <b>%s</b> @ %.4f\n", f.titleCase(alert.Exchange), alert.Price))
+		builder.WriteString(fmt.Sprintf("📊 Медіана %d бірж: %.4f\n", alert.Exchanges, alert.ReferencePrice))
+		builder.WriteString(fmt.Sprintf("📐 Відхилення: <b>%+.2f%%</b>\n", alert.DeviationPercent))
+	}
+
+	builder.WriteString(fmt.Sprintf("⏱ Триває: %s\n", alert.DetectedAt.Sub(alert.StartedAt).Round(time.Second)))
+
+	if alert.IsDepeg() {
+		builder.WriteString("\n⚠️ <i>Перевір новини емітента та резерви. Угоди через цей стейблкоїн зараз ризиковані.</i>")
+	} else {
+		builder.WriteString("\n⚠️ <i>Тривала розбіжність часто означає закриті виведення або проблеми з платоспроможністю біржі. " +
+			"Це не арбітражний сигнал - перевір статус депозитів/виведень.</i>")
+	}
+
+	return builder.String()
+}
+
 // FormatDeFi форматує DeFi opportunity
 func (f *Formatter) FormatDeFi(defi *models.DeFiOpportunity) string {
 	var builder strings.Builder
@@ -359,6 +546,31 @@ func (f *Formatter) FormatWhale(whale *models.WhaleTransaction) string {
 
 // Helper методи
 
+// FormatScraperAnomalies форматує алерт адмінам про аномалії результату скрапера
+func (f *Formatter) FormatScraperAnomalies(exchange string, anomalies []*models.ScraperAnomaly) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("🚨 <b>Аномалія скрапера</b>: %s\n\n", f.titleCase(exchange)))
+
+	for _, anomaly := range anomalies {
+		switch anomaly.Kind {
+		case models.ScraperAnomalyZeroResults:
+			builder.WriteString(fmt.Sprintf("• Жодної можливості (звично ~%.0f)\n", anomaly.Expected))
+		case models.ScraperAnomalySpike:
+			builder.WriteString(fmt.Sprintf("• Сплеск: <b>%.0f</b> можливостей (звично ~%.0f)\n", anomaly.Actual, anomaly.Expected))
+		case models.ScraperAnomalyFieldEmpty:
+			builder.WriteString(fmt.Sprintf("• Поле <b>%s</b> заповнене у %.0f%% (звично %.0f%%)\n",
+				f.getFieldName(anomaly.Field), anomaly.Actual*100, anomaly.Expected*100))
+		default:
+			builder.WriteString(fmt.Sprintf("• %s\n", anomaly.Message))
+		}
+	}
+
+	builder.WriteString("\n⚠️ Можлива зміна формату API біржі - перевірте скрапер")
+
+	return builder.String()
+}
+
 func (f *Formatter) getOpportunityEmoji(oppType string) string {
 	switch oppType {
 	case models.OpportunityTypeLaunchpool:
@@ -375,6 +587,10 @@ func (f *Formatter) getOpportunityEmoji(oppType string) string {
 		return "🔥"
 	case models.OpportunityTypeDeFi:
 		return "🌾"
+	case models.OpportunityTypeFunding:
+		return "💸"
+	case models.OpportunityTypePriceAlert:
+		return "🚨"
 	default:
 		return "💰"
 	}
@@ -396,11 +612,61 @@ func (f *Formatter) getTypeName(oppType string) string {
 		return "Арбітраж"
 	case models.OpportunityTypeDeFi:
 		return "DeFi"
+	case models.OpportunityTypeFunding:
+		return "Funding"
+	case models.OpportunityTypePriceAlert:
+		return "Аномалії цін"
 	default:
 		return "Інше"
 	}
 }
 
+func (f *Formatter) getFieldName(field string) string {
+	switch field {
+	case models.RevisionFieldReward:
+		return "Винагорода"
+	case models.RevisionFieldEstimatedROI:
+		return "Очікуваний ROI"
+	case models.RevisionFieldPoolSize:
+		return "Розмір пулу"
+	case models.RevisionFieldMinInvestment:
+		return "Мін. інвестиція"
+	case models.RevisionFieldStartDate:
+		return "Початок"
+	case models.RevisionFieldEndDate:
+		return "Завершення"
+	case models.RevisionFieldIsActive:
+		return "Статус"
+	default:
+		return f.titleCase(strings.ReplaceAll(field, "_", " "))
+	}
+}
+
+// formatFieldValue значення FieldChange для користувача
+func (f *Formatter) formatFieldValue(field, value string) string {
+	if value == "" {
+		return "—"
+	}
+
+	switch field {
+	case models.RevisionFieldEstimatedROI:
+		return value + "%"
+	case models.RevisionFieldMinInvestment:
+		return "$" + value
+	case models.RevisionFieldStartDate, models.RevisionFieldEndDate:
+		if t, err := time.Parse("2006-01-02", value); err == nil {
+			return t.Format("02.01.2006")
+		}
+	case models.RevisionFieldIsActive:
+		if value == "true" {
+			return "активна"
+		}
+		return "завершена"
+	}
+
+	return value
+}
+
 func (f *Formatter) getGreeting(user *models.User) string {
 	hour := time.Now().Hour()
 
@@ -433,6 +699,44 @@ func (f *Formatter) groupByType(opportunities []*models.Opportunity) map[string]
 	return result
 }
 
+// groupByProject групує можливості одного проєкту, зберігаючи порядок
+func (f *Formatter) groupByProject(opportunities []*models.Opportunity) [][]*models.Opportunity {
+	var groups [][]*models.Opportunity
+	index := make(map[uint]int)
+
+	for _, opp := range opportunities {
+		if opp.ProjectID == nil {
+			groups = append(groups, []*models.Opportunity{opp})
+			continue
+		}
+
+		if i, ok := index[*opp.ProjectID]; ok {
+			groups[i] = append(groups[i], opp)
+			continue
+		}
+
+		index[*opp.ProjectID] = len(groups)
+		groups = append(groups, []*models.Opportunity{opp})
+	}
+
+	return groups
+}
+
+// projectExchanges унікальні назви бірж, крім exclude
+func (f *Formatter) projectExchanges(opportunities []*models.Opportunity, exclude string) []string {
+	var exchanges []string
+	seen := map[string]bool{exclude: true}
+
+	for _, opp := range opportunities {
+		if !seen[opp.Exchange] {
+			seen[opp.Exchange] = true
+			exchanges = append(exchanges, f.titleCase(opp.Exchange))
+		}
+	}
+
+	return exchanges
+}
+
 func (f *Formatter) truncateTitle(title string, maxLen int) string {
 	if len(title) <= maxLen {
 		return title
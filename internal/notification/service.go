@@ -10,17 +10,25 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// DeliveryCallback викликається після успішної доставки повідомлення
+type DeliveryCallback func(notification *models.Notification)
+
 type Service struct {
-	bot       *tgbotapi.BotAPI
-	notifRepo repository.NotificationRepository
-	userRepo  repository.UserRepository
-	prefsRepo repository.UserPreferencesRepository
-	oppRepo   repository.OpportunityRepository
-	arbRepo   repository.ArbitrageRepository
-	defiRepo  repository.DeFiRepository
-	whaleRepo repository.WhaleRepository
-	formatter *Formatter
-	filter    *Filter
+	bot        *tgbotapi.BotAPI
+	notifRepo  repository.NotificationRepository
+	userRepo   repository.UserRepository
+	prefsRepo  repository.UserPreferencesRepository
+	oppRepo    repository.OpportunityRepository
+	arbRepo    repository.ArbitrageRepository
+	defiRepo   repository.DeFiRepository
+	whaleRepo  repository.WhaleRepository
+	feeRepo    repository.FeeRepository
+	actionRepo repository.UserActionRepository
+	formatter  *Formatter
+	filter     *Filter
+
+	onDelivered DeliveryCallback
+	adminIDs    []int64
 }
 
 func NewService(
@@ -32,21 +40,41 @@ func NewService(
 	arbRepo repository.ArbitrageRepository,
 	defiRepo repository.DeFiRepository,
 	whaleRepo repository.WhaleRepository,
+	feeRepo repository.FeeRepository,
+	actionRepo repository.UserActionRepository,
 ) *Service {
 	return &Service{
-		bot:       bot,
-		notifRepo: notifRepo,
-		userRepo:  userRepo,
-		prefsRepo: prefsRepo,
-		oppRepo:   oppRepo,
-		arbRepo:   arbRepo,
-		defiRepo:  defiRepo,
-		whaleRepo: whaleRepo,
-		formatter: NewFormatter(),
-		filter:    NewFilter(),
+		bot:        bot,
+		notifRepo:  notifRepo,
+		userRepo:   userRepo,
+		prefsRepo:  prefsRepo,
+		oppRepo:    oppRepo,
+		arbRepo:    arbRepo,
+		defiRepo:   defiRepo,
+		whaleRepo:  whaleRepo,
+		feeRepo:    feeRepo,
+		actionRepo: actionRepo,
+		formatter:  NewFormatter(),
+		filter:     NewFilter(),
 	}
 }
 
+// SetAdminIDs встановлює Telegram ID адмінів для службових алертів
+func (s *Service) SetAdminIDs(ids []int64) {
+	s.adminIDs = ids
+}
+
+// SetArbitrageMinProfit встановлює мінімальний net profit (%) арбітражу для
+// моделі торгівлі користувача (переказ або pre-funded)
+func (s *Service) SetArbitrageMinProfit(percent float64) {
+	s.filter.minArbitrageProfit = percent
+}
+
+// OnDelivered встановлює callback для доставлених повідомлень (paper trading)
+func (s *Service) OnDelivered(callback DeliveryCallback) {
+	s.onDelivered = callback
+}
+
 func (s *Service) CreateOpportunityNotifications(opp *models.Opportunity) error {
 	log.Printf("Creating notifications for opportunity: %s", opp.Title)
 
@@ -56,6 +84,7 @@ func (s *Service) CreateOpportunityNotifications(opp *models.Opportunity) error
 	}
 
 	created := 0
+	projectLinks := s.projectLinks(opp)
 
 	for _, user := range users {
 		prefs, err := s.prefsRepo.GetByUserID(user.ID)
@@ -87,7 +116,7 @@ func (s *Service) CreateOpportunityNotifications(opp *models.Opportunity) error
 			}
 		}
 
-		message := s.formatter.FormatOpportunity(opp)
+		message := s.formatter.FormatOpportunity(opp) + projectLinks
 
 		priority := s.filter.GetNotificationPriority(user, opp)
 
@@ -124,6 +153,120 @@ func (s *Service) CreateOpportunityNotifications(opp *models.Opportunity) error
 	return nil
 }
 
+// projectLinks рядок з іншими біржами, де доступний той самий проєкт
+func (s *Service) projectLinks(opp *models.Opportunity) string {
+	if opp.ProjectID == nil {
+		return ""
+	}
+
+	related, err := s.oppRepo.ListByProject(*opp.ProjectID)
+	if err != nil {
+		log.Printf("Failed to get project opportunities for %s: %v", opp.Title, err)
+		return ""
+	}
+
+	return s.formatter.FormatProjectLinks(opp, related)
+}
+
+// CreateOpportunityUpdateNotifications повідомляє про важливі зміни можливості
+// користувачів, які отримали повідомлення про неї або переходили за посиланням.
+// Денний ліміт не застосовується - це уточнення вже надісланого
+func (s *Service) CreateOpportunityUpdateNotifications(opp *models.Opportunity, revision *models.OpportunityRevision) error {
+	changes := revision.MaterialChanges()
+	if len(changes) == 0 {
+		return nil
+	}
+
+	userIDs, err := s.opportunityAudience(opp.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get opportunity audience: %w", err)
+	}
+
+	message := s.formatter.FormatOpportunityUpdate(opp, changes)
+
+	fields := make([]string, 0, len(changes))
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+
+	created := 0
+
+	for _, userID := range userIDs {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil || user == nil {
+			log.Printf("Failed to get user %d: %v", userID, err)
+			continue
+		}
+
+		prefs, err := s.prefsRepo.GetByUserID(user.ID)
+		if err != nil || prefs == nil {
+			log.Printf("Failed to get preferences for user %d: %v", user.ID, err)
+			continue
+		}
+
+		if !s.filter.ShouldNotifyUpdate(user, prefs, opp) {
+			continue
+		}
+
+		var scheduledFor *time.Time
+		if delay := s.filter.CalculateDelay(user); delay > 0 {
+			scheduled := time.Now().Add(delay)
+			scheduledFor = &scheduled
+		}
+
+		notification := &models.Notification{
+			UserID:        user.ID,
+			OpportunityID: &opp.ID,
+			Type:          opp.Type,
+			Priority:      models.NotificationPriorityHigh,
+			Status:        models.NotificationStatusPending,
+			Message:       message,
+			ScheduledFor:  scheduledFor,
+			MessageData: models.JSONMap{
+				"opportunity_id": opp.ID,
+				"revision_id":    revision.ID,
+				"changed_fields": fields,
+				"exchange":       opp.Exchange,
+				"url":            opp.URL,
+			},
+		}
+
+		if err := s.notifRepo.Create(notification); err != nil {
+			log.Printf("Failed to create update notification for user %d: %v", user.ID, err)
+			continue
+		}
+
+		created++
+	}
+
+	log.Printf("Created %d update notifications for opportunity: %s", created, opp.Title)
+	return nil
+}
+
+// opportunityAudience користувачі, яким надіслано можливість або які її відкривали
+func (s *Service) opportunityAudience(opportunityID uint) ([]uint, error) {
+	notified, err := s.notifRepo.GetSentUserIDsByOpportunity(opportunityID)
+	if err != nil {
+		return nil, err
+	}
+
+	clicked, err := s.actionRepo.GetUserIDsByOpportunity(opportunityID, models.ActionTypeClicked)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint]bool, len(notified)+len(clicked))
+	userIDs := make([]uint, 0, len(notified)+len(clicked))
+	for _, userID := range append(notified, clicked...) {
+		if !seen[userID] {
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	return userIDs, nil
+}
+
 // CreateArbitrageNotifications створює notification для арбітражної можливості (Premium only)
 func (s *Service) CreateArbitrageNotifications(arb *models.ArbitrageOpportunity) error {
 	log.Printf("Creating arbitrage notifications for: %s (%.2f%% profit)", arb.Pair, arb.NetProfitPercent)
@@ -153,13 +296,19 @@ func (s *Service) CreateArbitrageNotifications(arb *models.ArbitrageOpportunity)
 			continue
 		}
 
+		// Перерахувати прибуток під персональні VIP fees та модель торгівлі користувача
+		userArb := s.applyUserFeeTiers(user.ID, arb)
+		if prefs.IsPrefunded() {
+			userArb = userArb.WithPrefunded(prefs.RebalanceTrades)
+		}
+
 		// Filter by user preferences
-		if !s.filter.ShouldNotifyArbitrage(user, prefs, arb) {
+		if !s.filter.ShouldNotifyArbitrage(user, prefs, userArb) {
 			continue
 		}
 
 		// Format arbitrage message
-		message := s.formatter.FormatArbitrage(arb)
+		message := s.formatter.FormatArbitrage(userArb)
 
 		// Premium users get instant notifications (no delay)
 		notification := &models.Notification{
@@ -174,8 +323,9 @@ func (s *Service) CreateArbitrageNotifications(arb *models.ArbitrageOpportunity)
 				"pair":          arb.Pair,
 				"exchange_buy":  arb.ExchangeBuy,
 				"exchange_sell": arb.ExchangeSell,
-				"net_profit":    arb.NetProfitPercent,
-				"profit_usd":    arb.NetProfitUSD,
+				"net_profit":    userArb.NetProfitPercent,
+				"profit_usd":    userArb.NetProfitUSD,
+				"prefunded":     userArb.Prefunded,
 			},
 		}
 
@@ -191,6 +341,155 @@ func (s *Service) CreateArbitrageNotifications(arb *models.ArbitrageOpportunity)
 	return nil
 }
 
+// applyUserFeeTiers перераховує NetProfitPercent з taker fees користувача
+// (якщо він вказав свій VIP рівень для бірж угоди)
+func (s *Service) applyUserFeeTiers(userID uint, arb *models.ArbitrageOpportunity) *models.ArbitrageOpportunity {
+	if s.feeRepo == nil {
+		return arb
+	}
+
+	tiers, err := s.feeRepo.GetUserFeeTiers(userID)
+	if err != nil {
+		log.Printf("Failed to get fee tiers for user %d: %v", userID, err)
+		return arb
+	}
+
+	return arb.WithUserFeeTiers(tiers)
+}
+
+// CreateFundingNotifications створює notification для funding можливості (Premium only)
+func (s *Service) CreateFundingNotifications(opp *models.FundingOpportunity) error {
+	log.Printf("Creating funding notifications for: %s %s (%.2f%% APR)", opp.Type, opp.Symbol, opp.NetAPR)
+
+	users, err := s.userRepo.List(0, 10000)
+	if err != nil {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+
+	created := 0
+
+	for _, user := range users {
+		// Only Premium users get funding notifications
+		if !user.IsPremium() {
+			continue
+		}
+
+		prefs, err := s.prefsRepo.GetByUserID(user.ID)
+		if err != nil {
+			log.Printf("Failed to get preferences for user %d: %v", user.ID, err)
+			continue
+		}
+
+		if prefs == nil {
+			log.Printf("No preferences for user %d, skipping", user.ID)
+			continue
+		}
+
+		if !s.filter.ShouldNotifyFunding(user, prefs, opp) {
+			continue
+		}
+
+		message := s.formatter.FormatFunding(opp)
+
+		priority := models.NotificationPriorityMedium
+		if opp.NetAPR >= 50 {
+			priority = models.NotificationPriorityHigh
+		}
+
+		// Premium users get instant notifications (no delay)
+		notification := &models.Notification{
+			UserID:       user.ID,
+			Type:         models.OpportunityTypeFunding,
+			Priority:     priority,
+			Status:       models.NotificationStatusPending,
+			Message:      message,
+			ScheduledFor: nil, // Instant
+			MessageData: models.JSONMap{
+				"funding_id":     opp.ID,
+				"funding_type":   opp.Type,
+				"symbol":         opp.Symbol,
+				"long_exchange":  opp.LongExchange,
+				"short_exchange": opp.ShortExchange,
+				"net_apr":        opp.NetAPR,
+				"funding_apr":    opp.FundingAPR,
+				"basis_percent":  opp.BasisPercent,
+			},
+		}
+
+		if err := s.notifRepo.Create(notification); err != nil {
+			log.Printf("Failed to create funding notification for user %d: %v", user.ID, err)
+			continue
+		}
+
+		created++
+	}
+
+	log.Printf("Created %d funding notifications for: %s", created, opp.Symbol)
+	return nil
+}
+
+// CreatePriceAlertNotifications створює notification для розбіжності цін / depeg (Premium only)
+func (s *Service) CreatePriceAlertNotifications(alert *models.PriceAlert) error {
+	log.Printf("Creating price alert notifications for: %s %s %s (%+.2f%%)",
+		alert.Type, alert.Symbol, alert.Exchange, alert.DeviationPercent)
+
+	users, err := s.userRepo.List(0, 10000)
+	if err != nil {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+
+	message := s.formatter.FormatPriceAlert(alert)
+	created := 0
+
+	for _, user := range users {
+		// Only Premium users get price alerts
+		if !user.IsPremium() {
+			continue
+		}
+
+		prefs, err := s.prefsRepo.GetByUserID(user.ID)
+		if err != nil {
+			log.Printf("Failed to get preferences for user %d: %v", user.ID, err)
+			continue
+		}
+
+		if prefs == nil {
+			continue
+		}
+
+		if !s.filter.ShouldNotifyPriceAlert(user, prefs, alert) {
+			continue
+		}
+
+		// Ризик-сигнал - завжди миттєво та з високим пріоритетом
+		notification := &models.Notification{
+			UserID:       user.ID,
+			Type:         models.OpportunityTypePriceAlert,
+			Priority:     models.NotificationPriorityHigh,
+			Status:       models.NotificationStatusPending,
+			Message:      message,
+			ScheduledFor: nil, // Instant
+			MessageData: models.JSONMap{
+				"price_alert_id":    alert.ID,
+				"alert_type":        alert.Type,
+				"symbol":            alert.Symbol,
+				"exchange":          alert.Exchange,
+				"deviation_percent": alert.DeviationPercent,
+			},
+		}
+
+		if err := s.notifRepo.Create(notification); err != nil {
+			log.Printf("Failed to create price alert notification for user %d: %v", user.ID, err)
+			continue
+		}
+
+		created++
+	}
+
+	log.Printf("Created %d price alert notifications for: %s", created, alert.Symbol)
+	return nil
+}
+
 // CreateDeFiNotifications створює notification для DeFi opportunity (Premium only)
 func (s *Service) CreateDeFiNotifications(defi *models.DeFiOpportunity) error {
 	log.Printf("📢 Creating DeFi notifications for: %s on %s (APY: %.2f%%)",
@@ -392,6 +691,8 @@ func (s *Service) SendPendingNotifications(batchSize int) error {
 			log.Printf("Failed to update notification %d: %v", notification.ID, err)
 		}
 
+		s.notifyDelivered(notification)
+
 		time.Sleep(50 * time.Millisecond)
 	}
 
@@ -520,11 +821,50 @@ func (s *Service) RetryFailedNotifications(batchSize int) error {
 		if err != nil {
 			log.Printf("Failed to update notification %d: %v", notification.ID, err)
 		}
+
+		s.notifyDelivered(notification)
 	}
 
 	return nil
 }
 
+// SendScraperAnomalyAlert надсилає адмінам алерт про аномалії скрапера
+// (службове повідомлення, без черги Notification)
+func (s *Service) SendScraperAnomalyAlert(exchange string, anomalies []*models.ScraperAnomaly) error {
+	if len(s.adminIDs) == 0 {
+		log.Printf("⚠️ Scraper anomaly on %s, but no admin IDs configured", exchange)
+		return nil
+	}
+
+	text := s.formatter.FormatScraperAnomalies(exchange, anomalies)
+
+	var failed int
+	for _, adminID := range s.adminIDs {
+		msg := tgbotapi.NewMessage(adminID, text)
+		msg.ParseMode = "HTML"
+
+		if _, err := s.bot.Send(msg); err != nil {
+			log.Printf("❌ Failed to send scraper anomaly alert to admin %d: %v", adminID, err)
+			failed++
+		}
+	}
+
+	if failed == len(s.adminIDs) {
+		return fmt.Errorf("failed to send scraper anomaly alert to any admin")
+	}
+
+	return nil
+}
+
+// notifyDelivered передає щойно доставлене повідомлення в callback
+func (s *Service) notifyDelivered(notification *models.Notification) {
+	if s.onDelivered == nil || !notification.IsSent() {
+		return
+	}
+
+	go s.onDelivered(notification)
+}
+
 func (s *Service) sendNotification(notification *models.Notification) error {
 	if notification.User.TelegramID == 0 {
 		return fmt.Errorf("invalid telegram_id for user %d", notification.UserID)
@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"math"
+	"testing"
+)
+
+type fakeFeeRepo struct {
+	repository.FeeRepository
+	tiers map[uint][]*models.UserFeeTier
+}
+
+func (r *fakeFeeRepo) GetUserFeeTiers(userID uint) ([]*models.UserFeeTier, error) {
+	return r.tiers[userID], nil
+}
+
+func TestApplyUserFeeTiers(t *testing.T) {
+	s := &Service{feeRepo: &fakeFeeRepo{tiers: map[uint][]*models.UserFeeTier{
+		1: {{Exchange: "binance", Tier: "VIP1", TakerFee: 0.09}},
+		2: {{Exchange: "binance", Tier: "VIP2", TakerFee: 0.08}, {Exchange: "kraken", Tier: "VIP1", TakerFee: 0.2}},
+		3: {{Exchange: "okx", Tier: "VIP1", TakerFee: 0.06}},
+	}}}
+
+	spatial := &models.ArbitrageOpportunity{
+		Type:             models.ArbitrageTypeCrossExchange,
+		ExchangeBuy:      "binance",
+		ExchangeSell:     "kraken",
+		TradingFeeBuy:    0.1,
+		TradingFeeSell:   0.26,
+		TotalFeesPercent: 0.5,
+		NetProfitPercent: 0.4,
+		NetProfitUSD:     4,
+	}
+
+	triangular := &models.ArbitrageOpportunity{
+		Type:             models.ArbitrageTypeTriangular,
+		ExchangeBuy:      "binance",
+		ExchangeSell:     "binance",
+		TradingFeeBuy:    0.1,
+		TradingFeeSell:   0.1,
+		TotalFeesPercent: 0.3,
+		NetProfitPercent: 0.2,
+	}
+
+	tests := []struct {
+		name      string
+		userID    uint
+		arb       *models.ArbitrageOpportunity
+		buyFee    float64
+		sellFee   float64
+		netProfit float64
+	}{
+		{"buy exchange tier", 1, spatial, 0.09, 0.26, 0.41},
+		{"both exchange tiers", 2, spatial, 0.08, 0.2, 0.48},
+		{"tier on unrelated exchange", 3, spatial, 0.1, 0.26, 0.4},
+		{"no tiers", 4, spatial, 0.1, 0.26, 0.4},
+		// Три угоди на одній біржі - різниця комісії тричі
+		{"triangular", 2, triangular, 0.08, 0.08, 0.26},
+	}
+
+	for _, tt := range tests {
+		got := s.applyUserFeeTiers(tt.userID, tt.arb)
+
+		if got.TradingFeeBuy != tt.buyFee || got.TradingFeeSell != tt.sellFee {
+			t.Errorf("%s: fees %.2f/%.2f, want %.2f/%.2f", tt.name, got.TradingFeeBuy, got.TradingFeeSell, tt.buyFee, tt.sellFee)
+		}
+		if math.Abs(got.NetProfitPercent-tt.netProfit) > 1e-9 {
+			t.Errorf("%s: net profit %.4f%%, want %.4f%%", tt.name, got.NetProfitPercent, tt.netProfit)
+		}
+		if math.Abs(got.NetProfitUSD-got.NetProfitPercent*10) > 1e-9 {
+			t.Errorf("%s: net profit USD %.4f not recalculated for $1000", tt.name, got.NetProfitUSD)
+		}
+		if math.Abs((got.TotalFeesPercent+got.NetProfitPercent)-(tt.arb.TotalFeesPercent+tt.arb.NetProfitPercent)) > 1e-9 {
+			t.Errorf("%s: fee change not mirrored in net profit", tt.name)
+		}
+	}
+
+	// Оригінальна можливість спільна для всіх користувачів і не змінюється
+	if spatial.TradingFeeBuy != 0.1 || spatial.NetProfitPercent != 0.4 || triangular.NetProfitPercent != 0.2 {
+		t.Error("Expected per-user recalculation to work on a copy")
+	}
+}
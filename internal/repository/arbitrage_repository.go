@@ -3,6 +3,7 @@ package repository
 import (
 	"crypto-opportunities-bot/internal/models"
 	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -21,6 +22,13 @@ type ArbitrageRepository interface {
 	DeleteOlderThan(duration time.Duration) error
 	CountActive() (int64, error)
 	GetTopByProfit(limit int) ([]*models.ArbitrageOpportunity, error)
+	CountByPairBetween(from, to time.Time) (map[string]int64, error)
+
+	// Lifecycle
+	UpdateLifecycle(arb *models.ArbitrageOpportunity) error
+	CloseOrphaned(reason string) (int64, error)
+	GetLifetimeStatsByPair(since time.Time, actionable time.Duration) ([]*models.ArbitrageLifetimeStats, error)
+	GetLifetimeStatsByExchangePair(since time.Time, actionable time.Duration) ([]*models.ArbitrageLifetimeStats, error)
 }
 
 type arbitrageRepository struct {
@@ -151,3 +159,112 @@ func (r *arbitrageRepository) GetTopByProfit(limit int) ([]*models.ArbitrageOppo
 
 	return arbitrages, err
 }
+
+// CountByPairBetween підраховує виявлені можливості по парі за період
+func (r *arbitrageRepository) CountByPairBetween(from, to time.Time) (map[string]int64, error) {
+	var rows []struct {
+		Pair  string
+		Count int64
+	}
+
+	err := r.db.Model(&models.ArbitrageOpportunity{}).
+		Select("pair, COUNT(*) as count").
+		Where("type = ? AND detected_at BETWEEN ? AND ?", models.ArbitrageTypeCrossExchange, from, to).
+		Group("pair").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Pair] = row.Count
+	}
+
+	return counts, nil
+}
+
+// lifecycleColumns колонки, які змінюються протягом життя можливості
+// (is_notified не чіпаємо - його виставляє сервіс нотифікацій)
+var lifecycleColumns = []string{
+	"price_buy", "volume_buy", "price_sell", "volume_sell",
+	"profit_percent", "profit_usd",
+	"trading_fee_buy", "trading_fee_sell", "withdrawal_fee", "withdrawal_fee_usd", "total_fees_percent",
+	"net_profit_percent", "net_profit_usd",
+	"volume24h", "spread_percent", "slippage_buy", "slippage_sell",
+	"max_trade_amount", "optimal_amount", "optimal_profit_usd", "break_even_amount",
+	"prefunded_optimal_amount", "prefunded_optimal_profit_usd",
+	"rebalance_cost_usd", "prefunded_profit_percent",
+	"symbol_buy", "symbol_sell", "conversion_fee",
+	"transfer_network", "transfer_status", "transfer_minutes",
+	"expires_at", "status", "peak_profit_percent", "last_profit_percent",
+	"last_seen_at", "update_count", "closed_at", "close_reason", "lifetime_seconds",
+}
+
+// UpdateLifecycle зберігає поточний стан відкритої/закритої можливості
+func (r *arbitrageRepository) UpdateLifecycle(arb *models.ArbitrageOpportunity) error {
+	if arb == nil || arb.ID == 0 {
+		return fmt.Errorf("arbitrage opportunity is not persisted")
+	}
+
+	return r.db.Model(arb).Select(lifecycleColumns).Updates(arb).Error
+}
+
+// CloseOrphaned закриває можливості, що лишились відкритими після рестарту,
+// моментом останнього підтвердження
+func (r *arbitrageRepository) CloseOrphaned(reason string) (int64, error) {
+	result := r.db.Model(&models.ArbitrageOpportunity{}).
+		Where("status = ?", models.ArbitrageStatusOpen).
+		Updates(map[string]interface{}{
+			"status":           models.ArbitrageStatusClosed,
+			"close_reason":     reason,
+			"closed_at":        gorm.Expr("COALESCE(last_seen_at, detected_at)"),
+			"expires_at":       gorm.Expr("COALESCE(last_seen_at, detected_at)"),
+			"lifetime_seconds": gorm.Expr("EXTRACT(EPOCH FROM (COALESCE(last_seen_at, detected_at) - detected_at))"),
+		})
+
+	return result.RowsAffected, result.Error
+}
+
+// GetLifetimeStatsByPair статистика тривалості життя закритих можливостей по парі
+func (r *arbitrageRepository) GetLifetimeStatsByPair(since time.Time, actionable time.Duration) ([]*models.ArbitrageLifetimeStats, error) {
+	return r.lifetimeStats([]string{"pair"}, since, actionable)
+}
+
+// GetLifetimeStatsByExchangePair статистика тривалості життя по парі та напрямку бірж
+func (r *arbitrageRepository) GetLifetimeStatsByExchangePair(since time.Time, actionable time.Duration) ([]*models.ArbitrageLifetimeStats, error) {
+	return r.lifetimeStats([]string{"pair", "exchange_buy", "exchange_sell"}, since, actionable)
+}
+
+// lifetimeStats агрегує закриті можливості. actionable - час, за який людина
+// встигає відкрити угоди; частка можливостей, що жили довше, = ActionableRatio
+func (r *arbitrageRepository) lifetimeStats(groupBy []string, since time.Time, actionable time.Duration) ([]*models.ArbitrageLifetimeStats, error) {
+	var stats []*models.ArbitrageLifetimeStats
+
+	group := strings.Join(groupBy, ", ")
+
+	err := r.db.Model(&models.ArbitrageOpportunity{}).
+		Select(group+", "+
+			"COUNT(*) as count, "+
+			"COALESCE(AVG(lifetime_seconds), 0) as avg_lifetime_seconds, "+
+			"COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY lifetime_seconds), 0) as median_lifetime_seconds, "+
+			"COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY lifetime_seconds), 0) as p90_lifetime_seconds, "+
+			"COALESCE(MAX(lifetime_seconds), 0) as max_lifetime_seconds, "+
+			"COALESCE(AVG(open_profit_percent), 0) as avg_open_profit, "+
+			"COALESCE(AVG(peak_profit_percent), 0) as avg_peak_profit, "+
+			"COALESCE(AVG(last_profit_percent), 0) as avg_close_profit, "+
+			"COALESCE(AVG(CASE WHEN lifetime_seconds > 0 "+
+			"THEN (peak_profit_percent - last_profit_percent) * 60 / lifetime_seconds ELSE 0 END), 0) as avg_decay_per_minute, "+
+			"COALESCE(AVG(CASE WHEN lifetime_seconds >= ? THEN 1.0 ELSE 0.0 END), 0) as actionable_ratio",
+			actionable.Seconds()).
+		Where("status = ? AND closed_at >= ?", models.ArbitrageStatusClosed, since).
+		Group(group).
+		Order("count DESC").
+		Scan(&stats).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate lifetime stats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -11,9 +11,11 @@ type ClientStatisticsRepository interface {
 	Create(stats *models.ClientStatistics) error
 	GetByID(id uint) (*models.ClientStatistics, error)
 	GetByUserID(userID uint) (*models.ClientStatistics, error)
+	GetPaperByUserID(userID uint) (*models.ClientStatistics, error)
 	Update(stats *models.ClientStatistics) error
 	UpdateFromTrade(trade *models.ClientTrade) error
 	GetOrCreate(userID uint) (*models.ClientStatistics, error)
+	GetOrCreatePaper(userID uint) (*models.ClientStatistics, error)
 	GetLeaderboard(limit int) ([]*models.ClientStatistics, error)
 	GetLeaderboardByProfit(limit int) ([]*models.ClientStatistics, error)
 	GetLeaderboardByWinRate(limit int) ([]*models.ClientStatistics, error)
@@ -43,8 +45,17 @@ func (r *ClientStatisticsRepositoryImpl) GetByID(id uint) (*models.ClientStatist
 }
 
 func (r *ClientStatisticsRepositoryImpl) GetByUserID(userID uint) (*models.ClientStatistics, error) {
+	return r.getByUserID(userID, false)
+}
+
+// GetPaperByUserID статистика симульованих (paper) трейдів
+func (r *ClientStatisticsRepositoryImpl) GetPaperByUserID(userID uint) (*models.ClientStatistics, error) {
+	return r.getByUserID(userID, true)
+}
+
+func (r *ClientStatisticsRepositoryImpl) getByUserID(userID uint, paper bool) (*models.ClientStatistics, error) {
 	var stats models.ClientStatistics
-	err := r.db.Preload("User").Where("user_id = ?", userID).First(&stats).Error
+	err := r.db.Preload("User").Where("user_id = ? AND is_paper = ?", userID, paper).First(&stats).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -61,7 +72,7 @@ func (r *ClientStatisticsRepositoryImpl) Update(stats *models.ClientStatistics)
 
 func (r *ClientStatisticsRepositoryImpl) UpdateFromTrade(trade *models.ClientTrade) error {
 	// Get or create statistics
-	stats, err := r.GetOrCreate(trade.UserID)
+	stats, err := r.getOrCreate(trade.UserID, trade.IsPaper)
 	if err != nil {
 		return err
 	}
@@ -74,7 +85,16 @@ func (r *ClientStatisticsRepositoryImpl) UpdateFromTrade(trade *models.ClientTra
 }
 
 func (r *ClientStatisticsRepositoryImpl) GetOrCreate(userID uint) (*models.ClientStatistics, error) {
-	stats, err := r.GetByUserID(userID)
+	return r.getOrCreate(userID, false)
+}
+
+// GetOrCreatePaper статистика paper trading (створюється при першому зверненні)
+func (r *ClientStatisticsRepositoryImpl) GetOrCreatePaper(userID uint) (*models.ClientStatistics, error) {
+	return r.getOrCreate(userID, true)
+}
+
+func (r *ClientStatisticsRepositoryImpl) getOrCreate(userID uint, paper bool) (*models.ClientStatistics, error) {
+	stats, err := r.getByUserID(userID, paper)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +103,7 @@ func (r *ClientStatisticsRepositoryImpl) GetOrCreate(userID uint) (*models.Clien
 	if stats == nil {
 		stats = &models.ClientStatistics{
 			UserID:           userID,
+			IsPaper:          paper,
 			TotalTrades:      0,
 			SuccessfulTrades: 0,
 			FailedTrades:     0,
@@ -112,7 +133,7 @@ func (r *ClientStatisticsRepositoryImpl) GetLeaderboard(limit int) ([]*models.Cl
 func (r *ClientStatisticsRepositoryImpl) GetLeaderboardByProfit(limit int) ([]*models.ClientStatistics, error) {
 	var stats []*models.ClientStatistics
 	err := r.db.Preload("User").
-		Where("total_trades > ?", 0).
+		Where("total_trades > ? AND is_paper = ?", 0, false).
 		Order("net_profit DESC").
 		Limit(limit).
 		Find(&stats).Error
@@ -126,7 +147,7 @@ func (r *ClientStatisticsRepositoryImpl) GetLeaderboardByProfit(limit int) ([]*m
 func (r *ClientStatisticsRepositoryImpl) GetLeaderboardByWinRate(limit int) ([]*models.ClientStatistics, error) {
 	var stats []*models.ClientStatistics
 	err := r.db.Preload("User").
-		Where("total_trades >= ?", 10). // Мінімум 10 трейдів для fair comparison
+		Where("total_trades >= ? AND is_paper = ?", 10, false). // Мінімум 10 трейдів для fair comparison
 		Order("win_rate DESC, net_profit DESC").
 		Limit(limit).
 		Find(&stats).Error
@@ -140,7 +161,7 @@ func (r *ClientStatisticsRepositoryImpl) GetLeaderboardByWinRate(limit int) ([]*
 func (r *ClientStatisticsRepositoryImpl) RecalculateStats(userID uint) error {
 	// Get all completed trades
 	var trades []*models.ClientTrade
-	err := r.db.Where("user_id = ? AND status IN (?)", userID, []string{models.TradeStatusCompleted, models.TradeStatusFailed}).
+	err := r.db.Where("user_id = ? AND is_paper = ? AND status IN (?)", userID, false, []string{models.TradeStatusCompleted, models.TradeStatusFailed}).
 		Find(&trades).Error
 	if err != nil {
 		return err
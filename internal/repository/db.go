@@ -50,16 +50,20 @@ func InitDatabase(cfg config.DatabaseConfig, app config.AppConfig) (*gorm.DB, er
 }
 
 func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	err := db.AutoMigrate(
 		&models.User{},
 		&models.UserPreferences{},
+		&models.Project{},
 		&models.Opportunity{},
+		&models.OpportunityRevision{},
 		&models.Notification{},
 		&models.UserAction{},
 		&models.Subscription{},
 		&models.Payment{},
 		&models.ArbitrageOpportunity{},
 		&models.DeFiOpportunity{},
+		&models.FundingOpportunity{},
+		&models.PriceAlert{},
 		// Referral models
 		&models.Referral{},
 		&models.ReferralCode{},
@@ -75,7 +79,30 @@ func AutoMigrate(db *gorm.DB) error {
 		&models.UserEngagement{},
 		// Whale watching
 		&models.WhaleTransaction{},
+		// Fees
+		&models.ExchangeFee{},
+		&models.UserFeeTier{},
+		// Order book health
+		&models.ExchangeHealth{},
+		// Scraper health
+		&models.ScraperHealth{},
+		&models.ScraperRun{},
+		&models.ScraperJob{},
+		&models.ScraperBaseline{},
+		&models.ScraperAnomaly{},
 	)
+	if err != nil {
+		return err
+	}
+
+	// Статистика paper trading - окремий рядок, унікальність тепер по (user_id, is_paper)
+	if db.Migrator().HasIndex(&models.ClientStatistics{}, "idx_client_statistics_user_id") {
+		if err := db.Migrator().DropIndex(&models.ClientStatistics{}, "idx_client_statistics_user_id"); err != nil {
+			return fmt.Errorf("failed to drop legacy client statistics index: %w", err)
+		}
+	}
+
+	return nil
 }
 
 func CloseDatabase(db *gorm.DB) error {
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"crypto-opportunities-bot/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ExchangeHealthRepository interface {
+	Upsert(health *models.ExchangeHealth) error
+	List() ([]*models.ExchangeHealth, error)
+}
+
+type exchangeHealthRepository struct {
+	db *gorm.DB
+}
+
+func NewExchangeHealthRepository(db *gorm.DB) ExchangeHealthRepository {
+	return &exchangeHealthRepository{db: db}
+}
+
+// Upsert створює або оновлює знімок стану біржі
+func (r *exchangeHealthRepository) Upsert(health *models.ExchangeHealth) error {
+	var existing models.ExchangeHealth
+
+	// Assign через map, щоб нульові значення теж оновлювались
+	return r.db.
+		Where("exchange = ?", health.Exchange).
+		Assign(map[string]interface{}{
+			"status":           health.Status,
+			"connected":        health.Connected,
+			"books":            health.Books,
+			"healthy_books":    health.HealthyBooks,
+			"stale_books":      health.StaleBooks,
+			"crossed_books":    health.CrossedBooks,
+			"deviated_books":   health.DeviatedBooks,
+			"shallow_books":    health.ShallowBooks,
+			"last_book_update": health.LastBookUpdate,
+			"reported_at":      health.ReportedAt,
+			"telemetry":        health.Telemetry,
+		}).
+		FirstOrCreate(&existing, models.ExchangeHealth{Exchange: health.Exchange}).Error
+}
+
+// List повертає знімки всіх бірж
+func (r *exchangeHealthRepository) List() ([]*models.ExchangeHealth, error) {
+	var health []*models.ExchangeHealth
+	err := r.db.Order("exchange").Find(&health).Error
+	return health, err
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"crypto-opportunities-bot/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type FeeRepository interface {
+	// Exchange fees
+	UpsertExchangeFee(fee *models.ExchangeFee) error
+	ListExchangeFees() ([]*models.ExchangeFee, error)
+	ListExchangeFeesByExchange(exchange string) ([]*models.ExchangeFee, error)
+
+	// User fee tiers
+	UpsertUserFeeTier(tier *models.UserFeeTier) error
+	GetUserFeeTiers(userID uint) ([]*models.UserFeeTier, error)
+	DeleteUserFeeTier(userID uint, exchange string) error
+}
+
+type feeRepository struct {
+	db *gorm.DB
+}
+
+func NewFeeRepository(db *gorm.DB) FeeRepository {
+	return &feeRepository{db: db}
+}
+
+// UpsertExchangeFee створює або оновлює комісію (exchange + type + currency + network)
+func (r *feeRepository) UpsertExchangeFee(fee *models.ExchangeFee) error {
+	var existing models.ExchangeFee
+
+	// Assign через map, щоб нульові значення теж оновлювались
+	return r.db.
+		Where("exchange = ? AND fee_type = ? AND currency = ? AND network = ?", fee.Exchange, fee.FeeType, fee.Currency, fee.Network).
+		Assign(map[string]interface{}{
+			"maker_fee":      fee.MakerFee,
+			"taker_fee":      fee.TakerFee,
+			"withdrawal_fee": fee.WithdrawalFee,
+			"is_default":     fee.IsDefault,
+			"source":         fee.Source,
+			"fetched_at":     fee.FetchedAt,
+		}).
+		FirstOrCreate(&existing, models.ExchangeFee{
+			Exchange: fee.Exchange,
+			FeeType:  fee.FeeType,
+			Currency: fee.Currency,
+			Network:  fee.Network,
+		}).Error
+}
+
+func (r *feeRepository) ListExchangeFees() ([]*models.ExchangeFee, error) {
+	var fees []*models.ExchangeFee
+	err := r.db.Order("exchange, fee_type, currency").Find(&fees).Error
+	return fees, err
+}
+
+func (r *feeRepository) ListExchangeFeesByExchange(exchange string) ([]*models.ExchangeFee, error) {
+	var fees []*models.ExchangeFee
+	err := r.db.Where("exchange = ?", exchange).
+		Order("fee_type, currency").
+		Find(&fees).Error
+	return fees, err
+}
+
+// UpsertUserFeeTier створює або оновлює VIP рівень користувача на біржі
+func (r *feeRepository) UpsertUserFeeTier(tier *models.UserFeeTier) error {
+	var existing models.UserFeeTier
+	return r.db.
+		Where("user_id = ? AND exchange = ?", tier.UserID, tier.Exchange).
+		Assign(map[string]interface{}{
+			"tier":      tier.Tier,
+			"maker_fee": tier.MakerFee,
+			"taker_fee": tier.TakerFee,
+		}).
+		FirstOrCreate(&existing, models.UserFeeTier{
+			UserID:   tier.UserID,
+			Exchange: tier.Exchange,
+		}).Error
+}
+
+func (r *feeRepository) GetUserFeeTiers(userID uint) ([]*models.UserFeeTier, error) {
+	var tiers []*models.UserFeeTier
+	err := r.db.Where("user_id = ?", userID).
+		Order("exchange").
+		Find(&tiers).Error
+	return tiers, err
+}
+
+func (r *feeRepository) DeleteUserFeeTier(userID uint, exchange string) error {
+	return r.db.Unscoped().
+		Where("user_id = ? AND exchange = ?", userID, exchange).
+		Delete(&models.UserFeeTier{}).Error
+}
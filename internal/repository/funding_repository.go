@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type FundingRepository interface {
+	Create(opp *models.FundingOpportunity) error
+	GetByID(id uint) (*models.FundingOpportunity, error)
+	GetActive(limit int) ([]*models.FundingOpportunity, error)
+	GetActiveBySymbol(symbol string, limit int) ([]*models.FundingOpportunity, error)
+	MarkAsNotified(id uint) error
+	DeleteOlderThan(duration time.Duration) error
+	CountActive() (int64, error)
+}
+
+type fundingRepository struct {
+	db *gorm.DB
+}
+
+func NewFundingRepository(db *gorm.DB) FundingRepository {
+	return &fundingRepository{db: db}
+}
+
+// Create створює нову funding можливість
+func (r *fundingRepository) Create(opp *models.FundingOpportunity) error {
+	if opp == nil {
+		return fmt.Errorf("funding opportunity is nil")
+	}
+
+	return r.db.Create(opp).Error
+}
+
+// GetByID отримує funding можливість по ID
+func (r *fundingRepository) GetByID(id uint) (*models.FundingOpportunity, error) {
+	var opp models.FundingOpportunity
+	err := r.db.First(&opp, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &opp, nil
+}
+
+// GetActive отримує активні funding можливості (до наступного нарахування)
+func (r *fundingRepository) GetActive(limit int) ([]*models.FundingOpportunity, error) {
+	var opps []*models.FundingOpportunity
+
+	query := r.db.Where("expires_at > ?", time.Now()).
+		Order("net_apr DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&opps).Error
+	return opps, err
+}
+
+// GetActiveBySymbol отримує активні funding можливості для символу
+func (r *fundingRepository) GetActiveBySymbol(symbol string, limit int) ([]*models.FundingOpportunity, error) {
+	var opps []*models.FundingOpportunity
+
+	query := r.db.Where("symbol = ? AND expires_at > ?", symbol, time.Now()).
+		Order("net_apr DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&opps).Error
+	return opps, err
+}
+
+// MarkAsNotified позначає можливість як відправлену користувачам
+func (r *fundingRepository) MarkAsNotified(id uint) error {
+	return r.db.Model(&models.FundingOpportunity{}).
+		Where("id = ?", id).
+		Update("is_notified", true).Error
+}
+
+// DeleteOlderThan видаляє можливості, старші за duration
+func (r *fundingRepository) DeleteOlderThan(duration time.Duration) error {
+	return r.db.Where("detected_at < ?", time.Now().Add(-duration)).
+		Delete(&models.FundingOpportunity{}).Error
+}
+
+// CountActive рахує активні funding можливості
+func (r *fundingRepository) CountActive() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.FundingOpportunity{}).
+		Where("expires_at > ?", time.Now()).
+		Count(&count).Error
+	return count, err
+}
@@ -20,6 +20,7 @@ type NotificationRepository interface {
 	CountByStatus(status string) (int64, error)
 	CountByUserAndStatus(userID uint, status string) (int64, error)
 	CountTodayByUser(userID uint) (int64, error)
+	GetSentUserIDsByOpportunity(opportunityID uint) ([]uint, error)
 	DeleteOld(days int) error
 	DeleteByUserID(userID uint) error
 }
@@ -150,6 +151,17 @@ func (r *notificationRepository) CountTodayByUser(userID uint) (int64, error) {
 	return count, err
 }
 
+// GetSentUserIDsByOpportunity користувачі, яким доставлено повідомлення про можливість
+func (r *notificationRepository) GetSentUserIDsByOpportunity(opportunityID uint) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.Model(&models.Notification{}).
+		Where("opportunity_id = ? AND status = ?", opportunityID, models.NotificationStatusSent).
+		Distinct().
+		Pluck("user_id", &userIDs).Error
+
+	return userIDs, err
+}
+
 func (r *notificationRepository) DeleteOld(days int) error {
 	cutoff := time.Now().AddDate(0, 0, -days)
 
@@ -18,6 +18,8 @@ type OpportunityRepository interface {
 	ListCreatedToday(limit, offset int) ([]*models.Opportunity, error)
 	ListByType(oppType string, limit, offset int) ([]*models.Opportunity, error)
 	ListByExchange(exchange string, limit, offset int) ([]*models.Opportunity, error)
+	ListRecentByExchangeAndType(exchange, oppType string, since time.Time) ([]*models.Opportunity, error)
+	ListByProject(projectID uint) ([]*models.Opportunity, error)
 	ListByFilters(filters OpportunityFilters) ([]*models.Opportunity, error)
 	CountActive() (int64, error)
 	CountByType(oppType string) (int64, error)
@@ -84,6 +86,7 @@ func (r *opportunityRepository) Delete(id uint) error {
 func (r *opportunityRepository) ListActive(limit, offset int) ([]*models.Opportunity, error) {
 	var opps []*models.Opportunity
 	err := r.db.
+		Preload("Project").
 		Where("is_active = ?", true).
 		Order("created_at DESC").
 		Limit(limit).
@@ -134,6 +137,29 @@ func (r *opportunityRepository) ListByExchange(exchange string, limit, offset in
 	return opps, err
 }
 
+// ListRecentByExchangeAndType можливості біржі одного типу, створені після since
+// (кандидати для пошуку дублікатів, включно з неактивними)
+func (r *opportunityRepository) ListRecentByExchangeAndType(exchange, oppType string, since time.Time) ([]*models.Opportunity, error) {
+	var opps []*models.Opportunity
+	err := r.db.
+		Where("exchange = ? AND type = ? AND created_at >= ?", exchange, oppType, since).
+		Order("created_at DESC").
+		Find(&opps).Error
+
+	return opps, err
+}
+
+// ListByProject активні можливості проєкту на всіх біржах
+func (r *opportunityRepository) ListByProject(projectID uint) ([]*models.Opportunity, error) {
+	var opps []*models.Opportunity
+	err := r.db.
+		Where("project_id = ? AND is_active = ?", projectID, true).
+		Order("created_at").
+		Find(&opps).Error
+
+	return opps, err
+}
+
 func (r *opportunityRepository) ListByFilters(filters OpportunityFilters) ([]*models.Opportunity, error) {
 	query := r.db.Model(&models.Opportunity{})
 
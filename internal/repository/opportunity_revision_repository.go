@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OpportunityRevisionRepository interface {
+	Create(revision *models.OpportunityRevision) error
+	ListByOpportunity(opportunityID uint, limit int) ([]*models.OpportunityRevision, error)
+	ListRecent(materialOnly bool, limit int) ([]*models.OpportunityRevision, error)
+}
+
+type opportunityRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewOpportunityRevisionRepository(db *gorm.DB) OpportunityRevisionRepository {
+	return &opportunityRevisionRepository{db: db}
+}
+
+// Create зберігає виявлені зміни можливості
+func (r *opportunityRevisionRepository) Create(revision *models.OpportunityRevision) error {
+	if revision == nil {
+		return fmt.Errorf("opportunity revision is nil")
+	}
+
+	if revision.DetectedAt.IsZero() {
+		revision.DetectedAt = time.Now()
+	}
+
+	return r.db.Create(revision).Error
+}
+
+// ListByOpportunity історія змін можливості, від найновіших
+func (r *opportunityRevisionRepository) ListByOpportunity(opportunityID uint, limit int) ([]*models.OpportunityRevision, error) {
+	var revisions []*models.OpportunityRevision
+
+	query := r.db.Where("opportunity_id = ?", opportunityID).Order("detected_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&revisions).Error
+	return revisions, err
+}
+
+// ListRecent останні зміни по всіх можливостях
+func (r *opportunityRevisionRepository) ListRecent(materialOnly bool, limit int) ([]*models.OpportunityRevision, error) {
+	var revisions []*models.OpportunityRevision
+
+	query := r.db.Order("detected_at DESC")
+	if materialOnly {
+		query = query.Where("material = ?", true)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&revisions).Error
+	return revisions, err
+}
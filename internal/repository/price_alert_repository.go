@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PriceAlertRepository interface {
+	Create(alert *models.PriceAlert) error
+	GetByID(id uint) (*models.PriceAlert, error)
+	GetActive(limit int) ([]*models.PriceAlert, error)
+	Resolve(alert *models.PriceAlert) error
+	ResolveOrphaned() (int64, error)
+}
+
+type priceAlertRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceAlertRepository(db *gorm.DB) PriceAlertRepository {
+	return &priceAlertRepository{db: db}
+}
+
+// Create створює новий алерт
+func (r *priceAlertRepository) Create(alert *models.PriceAlert) error {
+	if alert == nil {
+		return fmt.Errorf("price alert is nil")
+	}
+
+	return r.db.Create(alert).Error
+}
+
+// GetByID отримує алерт по ID
+func (r *priceAlertRepository) GetByID(id uint) (*models.PriceAlert, error) {
+	var alert models.PriceAlert
+	err := r.db.First(&alert, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// GetActive отримує алерти, відхилення яких ще триває (найбільші першими)
+func (r *priceAlertRepository) GetActive(limit int) ([]*models.PriceAlert, error) {
+	var alerts []*models.PriceAlert
+
+	query := r.db.Where("resolved_at IS NULL").
+		Order("ABS(deviation_percent) DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&alerts).Error
+	return alerts, err
+}
+
+// Resolve зберігає завершення відхилення (resolved_at, peak_deviation)
+func (r *priceAlertRepository) Resolve(alert *models.PriceAlert) error {
+	if alert == nil || alert.ID == 0 {
+		return fmt.Errorf("price alert is not persisted")
+	}
+
+	return r.db.Model(alert).
+		Select("resolved_at", "peak_deviation").
+		Updates(alert).Error
+}
+
+// ResolveOrphaned завершує алерти, що залишились відкритими після
+// перезапуску (стан відхилення тримається тільки в пам'яті)
+func (r *priceAlertRepository) ResolveOrphaned() (int64, error) {
+	result := r.db.Model(&models.PriceAlert{}).
+		Where("resolved_at IS NULL").
+		Update("resolved_at", time.Now())
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ProjectRepository interface {
+	Create(project *models.Project) error
+	GetByID(id uint) (*models.Project, error)
+	GetRecentBySymbol(symbol string, since time.Time) (*models.Project, error)
+	Update(project *models.Project) error
+}
+
+type projectRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &projectRepository{db: db}
+}
+
+// Create створює проєкт
+func (r *projectRepository) Create(project *models.Project) error {
+	if project == nil {
+		return fmt.Errorf("project is nil")
+	}
+
+	return r.db.Create(project).Error
+}
+
+// GetByID отримує проєкт по ID
+func (r *projectRepository) GetByID(id uint) (*models.Project, error) {
+	var project models.Project
+	err := r.db.First(&project, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// GetRecentBySymbol проєкт з символом, який зустрічався після since
+// (nil - немає; старі проєкти з тим самим символом не підтягуються)
+func (r *projectRepository) GetRecentBySymbol(symbol string, since time.Time) (*models.Project, error) {
+	var project models.Project
+	err := r.db.
+		Where("symbol = ? AND last_seen_at >= ?", symbol, since).
+		Order("last_seen_at DESC").
+		First(&project).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// Update зберігає проєкт
+func (r *projectRepository) Update(project *models.Project) error {
+	return r.db.Save(project).Error
+}
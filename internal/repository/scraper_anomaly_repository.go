@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type ScraperAnomalyRepository interface {
+	GetBaseline(exchange string) (*models.ScraperBaseline, error)
+	SaveBaseline(baseline *models.ScraperBaseline) error
+	ListBaselines() ([]*models.ScraperBaseline, error)
+
+	CreateAnomaly(anomaly *models.ScraperAnomaly) error
+	LastAnomaly(exchange, kind, field string) (*models.ScraperAnomaly, error)
+	ListAnomalies(exchange string, limit int) ([]*models.ScraperAnomaly, error)
+	ListAnomaliesAfter(id uint, limit int) ([]*models.ScraperAnomaly, error)
+	LatestAnomalyID() (uint, error)
+}
+
+type scraperAnomalyRepository struct {
+	db *gorm.DB
+}
+
+func NewScraperAnomalyRepository(db *gorm.DB) ScraperAnomalyRepository {
+	return &scraperAnomalyRepository{db: db}
+}
+
+// GetBaseline baseline скрапера (nil - ще не було успішних запусків)
+func (r *scraperAnomalyRepository) GetBaseline(exchange string) (*models.ScraperBaseline, error) {
+	var baseline models.ScraperBaseline
+	err := r.db.Where("exchange = ?", exchange).First(&baseline).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &baseline, nil
+}
+
+// SaveBaseline створює або оновлює baseline
+func (r *scraperAnomalyRepository) SaveBaseline(baseline *models.ScraperBaseline) error {
+	if baseline == nil {
+		return fmt.Errorf("scraper baseline is nil")
+	}
+
+	return r.db.Save(baseline).Error
+}
+
+// ListBaselines baseline всіх скраперів
+func (r *scraperAnomalyRepository) ListBaselines() ([]*models.ScraperBaseline, error) {
+	var baselines []*models.ScraperBaseline
+	err := r.db.Order("exchange").Find(&baselines).Error
+	return baselines, err
+}
+
+// CreateAnomaly зберігає виявлену аномалію
+func (r *scraperAnomalyRepository) CreateAnomaly(anomaly *models.ScraperAnomaly) error {
+	if anomaly == nil {
+		return fmt.Errorf("scraper anomaly is nil")
+	}
+
+	return r.db.Create(anomaly).Error
+}
+
+// LastAnomaly остання аномалія того ж виду (nil - не було)
+func (r *scraperAnomalyRepository) LastAnomaly(exchange, kind, field string) (*models.ScraperAnomaly, error) {
+	var anomaly models.ScraperAnomaly
+	err := r.db.
+		Where("exchange = ? AND kind = ? AND field = ?", exchange, kind, field).
+		Order("detected_at DESC").
+		First(&anomaly).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &anomaly, nil
+}
+
+// ListAnomalies останні аномалії (exchange "" - всіх скраперів)
+func (r *scraperAnomalyRepository) ListAnomalies(exchange string, limit int) ([]*models.ScraperAnomaly, error) {
+	var anomalies []*models.ScraperAnomaly
+
+	query := r.db.Order("detected_at DESC")
+	if exchange != "" {
+		query = query.Where("exchange = ?", exchange)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&anomalies).Error
+	return anomalies, err
+}
+
+// ListAnomaliesAfter аномалії, створені після запису id (по зростанню id)
+func (r *scraperAnomalyRepository) ListAnomaliesAfter(id uint, limit int) ([]*models.ScraperAnomaly, error) {
+	var anomalies []*models.ScraperAnomaly
+	err := r.db.
+		Where("id > ?", id).
+		Order("id").
+		Limit(limit).
+		Find(&anomalies).Error
+	return anomalies, err
+}
+
+// LatestAnomalyID id останньої аномалії (0 - немає)
+func (r *scraperAnomalyRepository) LatestAnomalyID() (uint, error) {
+	var id uint
+	err := r.db.Model(&models.ScraperAnomaly{}).Select("COALESCE(MAX(id), 0)").Scan(&id).Error
+	return id, err
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"crypto-opportunities-bot/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ScraperHealthRepository interface {
+	Upsert(health *models.ScraperHealth) error
+	List() ([]*models.ScraperHealth, error)
+}
+
+type scraperHealthRepository struct {
+	db *gorm.DB
+}
+
+func NewScraperHealthRepository(db *gorm.DB) ScraperHealthRepository {
+	return &scraperHealthRepository{db: db}
+}
+
+// Upsert створює або оновлює стан скрапера
+func (r *scraperHealthRepository) Upsert(health *models.ScraperHealth) error {
+	var existing models.ScraperHealth
+
+	// Assign через map, щоб нульові значення теж оновлювались
+	return r.db.
+		Where("exchange = ?", health.Exchange).
+		Assign(map[string]interface{}{
+			"state":                health.State,
+			"consecutive_failures": health.ConsecutiveFailures,
+			"total_runs":           health.TotalRuns,
+			"total_failures":       health.TotalFailures,
+			"total_skipped":        health.TotalSkipped,
+			"total_retries":        health.TotalRetries,
+			"last_opportunities":   health.LastOpportunities,
+			"last_duration_ms":     health.LastDurationMs,
+			"last_run_at":          health.LastRunAt,
+			"last_success_at":      health.LastSuccessAt,
+			"last_error":           health.LastError,
+			"last_error_at":        health.LastErrorAt,
+			"opened_at":            health.OpenedAt,
+			"next_probe_at":        health.NextProbeAt,
+			"reported_at":          health.ReportedAt,
+		}).
+		FirstOrCreate(&existing, models.ScraperHealth{Exchange: health.Exchange}).Error
+}
+
+// List повертає стан всіх скраперів
+func (r *scraperHealthRepository) List() ([]*models.ScraperHealth, error) {
+	var health []*models.ScraperHealth
+	err := r.db.Order("exchange").Find(&health).Error
+	return health, err
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ScraperRunRepository interface {
+	CreateRun(run *models.ScraperRun) error
+	UpdateRun(run *models.ScraperRun) error
+	ListRuns(exchange string, limit int) ([]*models.ScraperRun, error)
+	ListJobRuns(jobID uint) ([]*models.ScraperRun, error)
+	LatestRuns() ([]*models.ScraperRun, error)
+	DeleteOldRuns(days int) error
+
+	CreateJob(job *models.ScraperJob) error
+	GetJob(id uint) (*models.ScraperJob, error)
+	ClaimJob() (*models.ScraperJob, error)
+	UpdateJob(job *models.ScraperJob) error
+	ListJobs(limit int) ([]*models.ScraperJob, error)
+
+	FailInterrupted() (int64, error)
+}
+
+type scraperRunRepository struct {
+	db *gorm.DB
+}
+
+func NewScraperRunRepository(db *gorm.DB) ScraperRunRepository {
+	return &scraperRunRepository{db: db}
+}
+
+// CreateRun створює запис про запуск скрапера
+func (r *scraperRunRepository) CreateRun(run *models.ScraperRun) error {
+	if run == nil {
+		return fmt.Errorf("scraper run is nil")
+	}
+
+	return r.db.Create(run).Error
+}
+
+// UpdateRun зберігає результат запуску
+func (r *scraperRunRepository) UpdateRun(run *models.ScraperRun) error {
+	return r.db.Save(run).Error
+}
+
+// ListRuns останні запуски (exchange "" - всіх скраперів)
+func (r *scraperRunRepository) ListRuns(exchange string, limit int) ([]*models.ScraperRun, error) {
+	var runs []*models.ScraperRun
+
+	query := r.db.Order("started_at DESC")
+	if exchange != "" {
+		query = query.Where("exchange = ?", exchange)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&runs).Error
+	return runs, err
+}
+
+// ListJobRuns запуски, виконані в межах завдання
+func (r *scraperRunRepository) ListJobRuns(jobID uint) ([]*models.ScraperRun, error) {
+	var runs []*models.ScraperRun
+	err := r.db.Where("job_id = ?", jobID).Order("exchange").Find(&runs).Error
+	return runs, err
+}
+
+// LatestRuns останній запуск кожного скрапера
+func (r *scraperRunRepository) LatestRuns() ([]*models.ScraperRun, error) {
+	var runs []*models.ScraperRun
+
+	err := r.db.
+		Select("DISTINCT ON (exchange) *").
+		Order("exchange, started_at DESC").
+		Find(&runs).Error
+
+	return runs, err
+}
+
+// DeleteOldRuns видаляє історію запусків, старшу за days
+func (r *scraperRunRepository) DeleteOldRuns(days int) error {
+	cutoff := time.Now().AddDate(0, 0, -days)
+	return r.db.Unscoped().
+		Where("started_at < ?", cutoff).
+		Delete(&models.ScraperRun{}).Error
+}
+
+// CreateJob ставить завдання в чергу
+func (r *scraperRunRepository) CreateJob(job *models.ScraperJob) error {
+	if job == nil {
+		return fmt.Errorf("scraper job is nil")
+	}
+
+	if job.Status == "" {
+		job.Status = models.ScraperJobPending
+	}
+
+	return r.db.Create(job).Error
+}
+
+// GetJob отримує завдання по ID
+func (r *scraperRunRepository) GetJob(id uint) (*models.ScraperJob, error) {
+	var job models.ScraperJob
+	err := r.db.First(&job, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ClaimJob бере найстаріше pending завдання в роботу (nil - черга порожня).
+// Умовний UPDATE гарантує, що завдання не виконається двічі
+func (r *scraperRunRepository) ClaimJob() (*models.ScraperJob, error) {
+	for {
+		var job models.ScraperJob
+		err := r.db.
+			Where("status = ?", models.ScraperJobPending).
+			Order("created_at").
+			First(&job).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		result := r.db.Model(&models.ScraperJob{}).
+			Where("id = ? AND status = ?", job.ID, models.ScraperJobPending).
+			Updates(map[string]interface{}{
+				"status":     models.ScraperJobRunning,
+				"started_at": now,
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		// Інший процес встиг першим - беремо наступне
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		job.Status = models.ScraperJobRunning
+		job.StartedAt = &now
+		return &job, nil
+	}
+}
+
+// UpdateJob зберігає результат завдання
+func (r *scraperRunRepository) UpdateJob(job *models.ScraperJob) error {
+	return r.db.Save(job).Error
+}
+
+// ListJobs останні завдання
+func (r *scraperRunRepository) ListJobs(limit int) ([]*models.ScraperJob, error) {
+	var jobs []*models.ScraperJob
+
+	query := r.db.Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&jobs).Error
+	return jobs, err
+}
+
+// FailInterrupted завершує запуски та завдання, що залишились у running
+// після перезапуску бота
+func (r *scraperRunRepository) FailInterrupted() (int64, error) {
+	now := time.Now()
+	const reason = "interrupted by bot restart"
+
+	runs := r.db.Model(&models.ScraperRun{}).
+		Where("status = ?", models.ScraperRunRunning).
+		Updates(map[string]interface{}{
+			"status":      models.ScraperRunFailed,
+			"finished_at": now,
+			"error":       reason,
+		})
+	if runs.Error != nil {
+		return 0, runs.Error
+	}
+
+	jobs := r.db.Model(&models.ScraperJob{}).
+		Where("status = ?", models.ScraperJobRunning).
+		Updates(map[string]interface{}{
+			"status":      models.ScraperJobFailed,
+			"finished_at": now,
+			"error":       reason,
+		})
+
+	return runs.RowsAffected + jobs.RowsAffected, jobs.Error
+}
@@ -9,6 +9,7 @@ import (
 type UserActionRepository interface {
 	Create(action *models.UserAction) error
 	CountByUserAndType(userID uint, actionType string, opportunityID uint) (int64, error)
+	GetUserIDsByOpportunity(opportunityID uint, actionType string) ([]uint, error)
 }
 
 type userActionRepository struct {
@@ -35,3 +36,14 @@ func (r *userActionRepository) CountByUserAndType(userID uint, actionType string
 	err := query.Count(&count).Error
 	return count, err
 }
+
+// GetUserIDsByOpportunity користувачі з дією actionType для можливості
+func (r *userActionRepository) GetUserIDsByOpportunity(opportunityID uint, actionType string) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.Model(&models.UserAction{}).
+		Where("opportunity_id = ? AND action_type = ?", opportunityID, actionType).
+		Distinct().
+		Pluck("user_id", &userIDs).Error
+
+	return userIDs, err
+}
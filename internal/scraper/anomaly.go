@@ -0,0 +1,226 @@
+package scraper
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+// Пороги аномалій результату скрапера відносно baseline
+const (
+	baselineMinSamples = 5   // Успішних запусків до першої перевірки
+	baselineSmoothing  = 0.2 // Вага нового запуску в ковзних середніх
+
+	zeroResultsMinAvg = 2.0 // Нуль результатів - аномалія, якщо звично хоча б 2
+	spikeFactor       = 3.0 // Сплеск: у 3 рази більше за звичне
+	spikeMinIncrease  = 10  // ... і щонайменше на 10 можливостей більше
+
+	fieldFilledRate    = 0.8 // Поле звично заповнене щонайменше у 80% результатів
+	fieldEmptyRate     = 0.1 // ... а тепер менш як у 10%
+	fieldCheckMinCount = 3   // На меншій кількості результатів частки не перевіряються
+
+	anomalyRepeatInterval = 6 * time.Hour  // Та сама аномалія повідомляється не частіше
+	baselineRelearnAfter  = 24 * time.Hour // Відхилення довше - новий рівень, baseline вивчається заново
+)
+
+// baselineFields поля, частка заповнення яких відстежується
+var baselineFields = map[string]func(*models.Opportunity) bool{
+	models.RevisionFieldTitle:         func(o *models.Opportunity) bool { return o.Title != "" },
+	models.RevisionFieldDescription:   func(o *models.Opportunity) bool { return o.Description != "" },
+	models.RevisionFieldReward:        func(o *models.Opportunity) bool { return o.Reward != "" },
+	models.RevisionFieldEstimatedROI:  func(o *models.Opportunity) bool { return o.EstimatedROI > 0 },
+	models.RevisionFieldPoolSize:      func(o *models.Opportunity) bool { return o.PoolSize > 0 },
+	models.RevisionFieldMinInvestment: func(o *models.Opportunity) bool { return o.MinInvestment > 0 },
+	models.RevisionFieldDuration:      func(o *models.Opportunity) bool { return o.Duration != "" },
+	models.RevisionFieldStartDate:     func(o *models.Opportunity) bool { return o.StartDate != nil },
+	models.RevisionFieldEndDate:       func(o *models.Opportunity) bool { return o.EndDate != nil },
+	models.RevisionFieldURL:           func(o *models.Opportunity) bool { return o.URL != "" },
+}
+
+// AnomalyCallback викликається з аномаліями одного запуску скрапера
+type AnomalyCallback func(exchange string, anomalies []*models.ScraperAnomaly)
+
+// OnScraperAnomaly реєструє callback для аномалій результату скрапера
+// (нуль результатів, сплеск, звично заповнене поле стало порожнім)
+func (s *Service) OnScraperAnomaly(callback AnomalyCallback) {
+	s.anomalyCallbacks = append(s.anomalyCallbacks, callback)
+}
+
+// checkBaseline порівнює результат успішного запуску з baseline скрапера,
+// зберігає нові аномалії та оновлює baseline. Аномальний запуск не входить у
+// ковзні середні, інакше тривалий збій (нуль результатів) став би новою нормою.
+// Якщо аномалії тримаються baselineRelearnAfter, зміна вважається сталою
+// (біржа змінила формат чи обсяг) і baseline вивчається з поточного запуску
+func (s *Service) checkBaseline(exchange string, opportunities []*models.Opportunity) {
+	if s.anomalyRepo == nil {
+		return
+	}
+
+	baseline, err := s.anomalyRepo.GetBaseline(exchange)
+	if err != nil {
+		log.Printf("⚠️ Failed to load scraper baseline for %s: %v", exchange, err)
+		return
+	}
+	if baseline == nil {
+		baseline = &models.ScraperBaseline{Exchange: exchange}
+	}
+
+	now := s.now()
+	count := len(opportunities)
+	rates := fillRates(opportunities)
+
+	var detected []*models.ScraperAnomaly
+	if baseline.Samples >= baselineMinSamples {
+		detected = detectAnomalies(baseline, count, rates)
+	}
+
+	if len(detected) > 0 && baseline.AnomalousSince != nil && now.Sub(*baseline.AnomalousSince) >= baselineRelearnAfter {
+		log.Printf("🔄 Re-learning scraper baseline for %s: anomalies persist since %s",
+			exchange, baseline.AnomalousSince.Format(time.RFC3339))
+
+		detected = nil
+		baseline.Samples = 0
+		baseline.FillRates = nil
+	}
+
+	var reported []*models.ScraperAnomaly
+	for _, anomaly := range detected {
+		if s.recentlyReported(anomaly, now) {
+			continue
+		}
+
+		anomaly.DetectedAt = now
+		if err := s.anomalyRepo.CreateAnomaly(anomaly); err != nil {
+			log.Printf("⚠️ Failed to save scraper anomaly for %s: %v", exchange, err)
+			continue
+		}
+
+		log.Printf("🚨 Scraper anomaly on %s: %s", exchange, anomaly.Message)
+		reported = append(reported, anomaly)
+	}
+
+	if len(detected) == 0 {
+		baseline.AnomalousSince = nil
+		updateBaseline(baseline, count, rates)
+	} else if baseline.AnomalousSince == nil {
+		baseline.AnomalousSince = &now
+	}
+	baseline.LastCount = count
+	baseline.LastFillRates = rates
+	baseline.LastRunAt = now
+
+	if err := s.anomalyRepo.SaveBaseline(baseline); err != nil {
+		log.Printf("⚠️ Failed to save scraper baseline for %s: %v", exchange, err)
+	}
+
+	if len(reported) == 0 {
+		return
+	}
+
+	for _, callback := range s.anomalyCallbacks {
+		go callback(exchange, reported)
+	}
+}
+
+// recentlyReported чи повідомлялась та сама аномалія за anomalyRepeatInterval
+func (s *Service) recentlyReported(anomaly *models.ScraperAnomaly, now time.Time) bool {
+	last, err := s.anomalyRepo.LastAnomaly(anomaly.Exchange, anomaly.Kind, anomaly.Field)
+	if err != nil {
+		log.Printf("⚠️ Failed to load last scraper anomaly for %s: %v", anomaly.Exchange, err)
+		return false
+	}
+
+	return last != nil && now.Sub(last.DetectedAt) < anomalyRepeatInterval
+}
+
+// detectAnomalies відхилення кількості результатів та заповнення полів від baseline
+func detectAnomalies(baseline *models.ScraperBaseline, count int, rates map[string]float64) []*models.ScraperAnomaly {
+	var anomalies []*models.ScraperAnomaly
+
+	add := func(kind, field string, expected, actual float64, message string) {
+		anomalies = append(anomalies, &models.ScraperAnomaly{
+			Exchange: baseline.Exchange,
+			Kind:     kind,
+			Field:    field,
+			Expected: expected,
+			Actual:   actual,
+			Message:  message,
+		})
+	}
+
+	switch {
+	case count == 0 && baseline.AvgCount >= zeroResultsMinAvg:
+		add(models.ScraperAnomalyZeroResults, "", baseline.AvgCount, 0,
+			fmt.Sprintf("no opportunities returned (usually %.1f)", baseline.AvgCount))
+	case float64(count) >= baseline.AvgCount*spikeFactor && float64(count)-baseline.AvgCount >= spikeMinIncrease:
+		add(models.ScraperAnomalySpike, "", baseline.AvgCount, float64(count),
+			fmt.Sprintf("%d opportunities returned (usually %.1f)", count, baseline.AvgCount))
+	}
+
+	if count < fieldCheckMinCount {
+		return anomalies
+	}
+
+	fields := make([]string, 0, len(baseline.FillRates))
+	for field := range baseline.FillRates {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		expected, actual := baseline.FillRates[field], rates[field]
+		if expected >= fieldFilledRate && actual < fieldEmptyRate {
+			add(models.ScraperAnomalyFieldEmpty, field, expected, actual,
+				fmt.Sprintf("%s filled in %.0f%% of %d opportunities (usually %.0f%%)", field, actual*100, count, expected*100))
+		}
+	}
+
+	return anomalies
+}
+
+// updateBaseline додає запуск до ковзних середніх (перший запуск - як є)
+func updateBaseline(baseline *models.ScraperBaseline, count int, rates map[string]float64) {
+	if baseline.Samples == 0 {
+		baseline.AvgCount = float64(count)
+	} else {
+		baseline.AvgCount += baselineSmoothing * (float64(count) - baseline.AvgCount)
+	}
+
+	// Без результатів заповнення полів невідоме
+	if count > 0 {
+		if baseline.FillRates == nil {
+			baseline.FillRates = make(map[string]float64, len(rates))
+		}
+		for field, rate := range rates {
+			if previous, ok := baseline.FillRates[field]; ok {
+				baseline.FillRates[field] = previous + baselineSmoothing*(rate-previous)
+			} else {
+				baseline.FillRates[field] = rate
+			}
+		}
+	}
+
+	baseline.Samples++
+}
+
+// fillRates частка можливостей із заповненим полем (порожньо без результатів)
+func fillRates(opportunities []*models.Opportunity) map[string]float64 {
+	rates := make(map[string]float64, len(baselineFields))
+	if len(opportunities) == 0 {
+		return rates
+	}
+
+	for field, filled := range baselineFields {
+		n := 0
+		for _, opp := range opportunities {
+			if filled(opp) {
+				n++
+			}
+		}
+		rates[field] = float64(n) / float64(len(opportunities))
+	}
+
+	return rates
+}
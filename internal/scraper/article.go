@@ -0,0 +1,193 @@
+package scraper
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	articleCacheTTL      = 6 * time.Hour    // Текст анонсу змінюється рідко
+	articleErrorCacheTTL = 30 * time.Minute // Не повторювати невдалі запити кожен запуск
+	articleMaxBytes      = 2 << 20
+	articleWorkers       = 4
+	articleMaxFetches    = 20 // Нових статей за один EnrichAll, решта - наступного запуску
+)
+
+var (
+	hiddenBlockPattern = regexp.MustCompile(`(?is)<(script|style|noscript|svg|head)\b.*?</(script|style|noscript|svg|head)>`)
+	blockTagPattern    = regexp.MustCompile(`(?i)<(br|/p|/div|/li|/h[1-6]|/tr|/table|/section|/article)\b[^>]*>`)
+	listItemPattern    = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	tagPattern         = regexp.MustCompile(`(?s)<[^>]*>`)
+	spacePattern       = regexp.MustCompile(`[ \t\r\f\v\x{00a0}]+`)
+)
+
+type articleEntry struct {
+	text      string
+	err       error
+	fetchedAt time.Time
+}
+
+// ArticleFetcher завантажує текст статей анонсів для ExtractArticle.
+// Результати кешуються, щоб не завантажувати ту саму статтю кожен запуск
+type ArticleFetcher struct {
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string]articleEntry
+	now   func() time.Time
+}
+
+// NewArticleFetcher створює новий ArticleFetcher
+func NewArticleFetcher(httpClient *http.Client) *ArticleFetcher {
+	return &ArticleFetcher{
+		httpClient: httpClient,
+		cache:      make(map[string]articleEntry),
+		now:        time.Now,
+	}
+}
+
+// Text текст статті (HTML перетворюється в рядки за блоками)
+func (f *ArticleFetcher) Text(url string) (string, error) {
+	if entry, ok := f.cached(url); ok {
+		return entry.text, entry.err
+	}
+
+	text, err := f.fetch(url)
+
+	f.mu.Lock()
+	f.cache[url] = articleEntry{text: text, err: err, fetchedAt: f.now()}
+	f.mu.Unlock()
+
+	return text, err
+}
+
+// cached незастарілий результат попереднього завантаження
+func (f *ArticleFetcher) cached(url string) (articleEntry, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry, ok := f.cache[url]
+	if !ok {
+		return entry, false
+	}
+
+	ttl := articleCacheTTL
+	if entry.err != nil {
+		ttl = articleErrorCacheTTL
+	}
+
+	return entry, f.now().Sub(entry.fetchedAt) < ttl
+}
+
+func (f *ArticleFetcher) fetch(url string) (string, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; crypto-opportunities-bot)")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch article: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, articleMaxBytes))
+	if err != nil {
+		return "", err
+	}
+
+	return htmlToText(string(body)), nil
+}
+
+// EnrichAll доповнює можливості полями зі статей (паралельно, articleWorkers
+// одночасно, не більше articleMaxFetches нових статей, щоб вкластися в
+// таймаут скрапера). Помилка завантаження не критична - залишаються поля зі списку
+func (f *ArticleFetcher) EnrichAll(opportunities []*models.Opportunity) {
+	// Одна стаття може бути в кількох можливостях (різні типи одного анонсу)
+	var urls []string
+	byURL := make(map[string][]*models.Opportunity)
+	for _, opp := range opportunities {
+		if opp.URL == "" {
+			continue
+		}
+		if _, ok := byURL[opp.URL]; !ok {
+			urls = append(urls, opp.URL)
+		}
+		byURL[opp.URL] = append(byURL[opp.URL], opp)
+	}
+
+	jobs := make(chan string)
+
+	var budget atomic.Int64
+	budget.Store(articleMaxFetches)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(articleWorkers, len(urls)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range jobs {
+				f.enrich(url, byURL[url], &budget)
+			}
+		}()
+	}
+
+	for _, url := range urls {
+		jobs <- url
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func (f *ArticleFetcher) enrich(url string, opportunities []*models.Opportunity, budget *atomic.Int64) {
+	if _, ok := f.cached(url); !ok && budget.Add(-1) < 0 {
+		return
+	}
+
+	text, err := f.Text(url)
+	if err != nil {
+		log.Printf("⚠️ Failed to fetch article %s: %v", url, err)
+		return
+	}
+
+	extraction := ExtractArticle(text)
+	for _, opp := range opportunities {
+		extraction.Apply(opp)
+	}
+}
+
+// htmlToText прибирає теги, залишаючи блоки (абзаци, пункти списків) окремими рядками
+func htmlToText(source string) string {
+	source = hiddenBlockPattern.ReplaceAllString(source, " ")
+	source = blockTagPattern.ReplaceAllString(source, "\n")
+	source = listItemPattern.ReplaceAllString(source, "\n")
+	source = tagPattern.ReplaceAllString(source, " ")
+	source = html.UnescapeString(source)
+
+	lines := strings.Split(source, "\n")
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(spacePattern.ReplaceAllString(line, " "))
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
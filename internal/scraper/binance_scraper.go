@@ -14,15 +14,28 @@ import (
 	"time"
 )
 
+// BinanceBaseURL адреса API Binance
+const BinanceBaseURL = "https://www.binance.com"
+
 type BinanceScraper struct {
+	baseURL    string
 	httpClient *http.Client
+	articles   *ArticleFetcher // Тексти анонсів для airdrop та learn & earn
 }
 
 func NewBinanceScraper() *BinanceScraper {
+	return NewBinanceScraperWithClient(BinanceBaseURL, &http.Client{
+		Timeout: 10 * time.Second,
+	})
+}
+
+// NewBinanceScraperWithClient створює scraper з іншою адресою API та HTTP
+// клієнтом (проксі, тести з записаними відповідями)
+func NewBinanceScraperWithClient(baseURL string, httpClient *http.Client) *BinanceScraper {
 	return &BinanceScraper{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		articles:   NewArticleFetcher(httpClient),
 	}
 }
 
@@ -69,7 +82,7 @@ func (s *BinanceScraper) ScrapeAll() ([]*models.Opportunity, error) {
 }
 
 func (s *BinanceScraper) ScrapeLaunchpool() ([]*models.Opportunity, error) {
-	url := "https://www.binance.com/bapi/earn/v1/public/launchpool/project/list"
+	url := s.baseURL + "/bapi/earn/v1/public/launchpool/project/list"
 
 	resp, err := s.httpClient.Get(url)
 	if err != nil {
@@ -133,7 +146,7 @@ func (s *BinanceScraper) ScrapeLaunchpool() ([]*models.Opportunity, error) {
 }
 
 func (s *BinanceScraper) ScrapeAirdrops() ([]*models.Opportunity, error) {
-	url := "https://www.binance.com/bapi/composite/v1/public/cms/article/list/query"
+	url := s.baseURL + "/bapi/composite/v1/public/cms/article/list/query"
 
 	params := urlpkg.Values{}
 	params.Add("type", "1")
@@ -207,11 +220,13 @@ func (s *BinanceScraper) ScrapeAirdrops() ([]*models.Opportunity, error) {
 		opportunities = append(opportunities, opp)
 	}
 
+	s.articles.EnrichAll(opportunities)
+
 	return opportunities, nil
 }
 
 func (s *BinanceScraper) ScrapeLearnEarn() ([]*models.Opportunity, error) {
-	url := "https://www.binance.com/bapi/composite/v1/public/cms/article/list/query"
+	url := s.baseURL + "/bapi/composite/v1/public/cms/article/list/query"
 
 	payload := map[string]interface{}{
 		"type":      1,
@@ -285,6 +300,8 @@ func (s *BinanceScraper) ScrapeLearnEarn() ([]*models.Opportunity, error) {
 		opportunities = append(opportunities, opp)
 	}
 
+	s.articles.EnrichAll(opportunities)
+
 	return opportunities, nil
 }
 
@@ -13,15 +13,30 @@ import (
 	"time"
 )
 
+// BybitBaseURL адреса API Bybit
+const BybitBaseURL = "https://api.bybit.com"
+
+const bybitAnnouncementsPath = "/v5/announcements/index?locale=en-US&type=latest_activities&page=1&limit=20"
+
 type BybitScraper struct {
+	baseURL    string
 	httpClient *http.Client
+	articles   *ArticleFetcher // Тексти анонсів для airdrop та learn & earn
 }
 
 func NewBybitScraper() *BybitScraper {
+	return NewBybitScraperWithClient(BybitBaseURL, &http.Client{
+		Timeout: 10 * time.Second,
+	})
+}
+
+// NewBybitScraperWithClient створює scraper з іншою адресою API та HTTP
+// клієнтом (проксі, тести з записаними відповідями)
+func NewBybitScraperWithClient(baseURL string, httpClient *http.Client) *BybitScraper {
 	return &BybitScraper{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		articles:   NewArticleFetcher(httpClient),
 	}
 }
 
@@ -57,7 +72,7 @@ func (s *BybitScraper) ScrapeAll() ([]*models.Opportunity, error) {
 }
 
 func (s *BybitScraper) ScrapeLaunchpool() ([]*models.Opportunity, error) {
-	url := "https://api.bybit.com/v5/announcements/index?locale=en-US&type=latest_activities&page=1&limit=20"
+	url := s.baseURL + bybitAnnouncementsPath
 
 	resp, err := s.httpClient.Get(url)
 	if err != nil {
@@ -118,7 +133,7 @@ func (s *BybitScraper) ScrapeLaunchpool() ([]*models.Opportunity, error) {
 }
 
 func (s *BybitScraper) ScrapeAirdrops() ([]*models.Opportunity, error) {
-	url := "https://api.bybit.com/v5/announcements/index?locale=en-US&type=latest_activities&page=1&limit=20"
+	url := s.baseURL + bybitAnnouncementsPath
 
 	resp, err := s.httpClient.Get(url)
 	if err != nil {
@@ -174,13 +189,15 @@ func (s *BybitScraper) ScrapeAirdrops() ([]*models.Opportunity, error) {
 		opportunities = append(opportunities, opp)
 	}
 
+	s.articles.EnrichAll(opportunities)
+
 	return opportunities, nil
 }
 
 func (s *BybitScraper) ScrapeLearnEarn() ([]*models.Opportunity, error) {
 	// Bybit Learn & Earn campaigns are less common
 	// Scraping from announcements
-	url := "https://api.bybit.com/v5/announcements/index?locale=en-US&type=latest_activities&page=1&limit=20"
+	url := s.baseURL + bybitAnnouncementsPath
 
 	resp, err := s.httpClient.Get(url)
 	if err != nil {
@@ -235,6 +252,8 @@ func (s *BybitScraper) ScrapeLearnEarn() ([]*models.Opportunity, error) {
 		opportunities = append(opportunities, opp)
 	}
 
+	s.articles.EnrichAll(opportunities)
+
 	return opportunities, nil
 }
 
@@ -0,0 +1,236 @@
+package scraper
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	dedupWindow   = 14 * 24 * time.Hour // Скільки днів шукати дублікати на тій самій біржі
+	projectWindow = 30 * 24 * time.Hour // Після паузи той самий символ - новий проєкт
+
+	symbolOverlapThreshold = 0.6 // Схожість назв з однаковим символом токена
+	titleJaccardThreshold  = 0.8 // Схожість назв без символу
+)
+
+var (
+	// "Pixels (PIXEL)" - назва та символ у дужках
+	namedSymbolPattern = regexp.MustCompile(`((?:[A-Z][\w.]*\s+){0,2}[A-Z][\w.]*)\s*\(([A-Z0-9]{2,10})\)`)
+	// "(PIXEL)" без назви
+	bracketSymbolPattern = regexp.MustCompile(`\(([A-Z][A-Z0-9]{1,9})\)`)
+	// "50,000 ABC" у винагороді
+	amountSymbolPattern = regexp.MustCompile(`\d[\d,.]*\s*([A-Z][A-Z0-9]{1,9})\b`)
+	// Окремі слова з великих літер
+	capsTokenPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]{1,9}\b`)
+
+	wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+)
+
+// symbolStopList великі літери, що не є символом проєкту: біржі, базові
+// активи для стейкінгу, терміни
+var symbolStopList = map[string]bool{
+	"BINANCE": true, "BYBIT": true, "OKX": true, "GATE": true, "KRAKEN": true,
+	"MEXC": true, "BITGET": true, "KUCOIN": true, "HTX": true,
+	"USDT": true, "USDC": true, "FDUSD": true, "USD": true, "EUR": true,
+	"BNB": true, "BTC": true, "ETH": true, "SOL": true,
+	"APR": true, "APY": true, "NEW": true, "KYC": true, "VIP": true, "NFT": true,
+	"TGE": true, "AMA": true, "UTC": true, "API": true, "FAQ": true, "IEO": true,
+	"IDO": true, "ICO": true, "DEX": true, "CEX": true, "P2P": true, "AI": true,
+	"DEFI": true, "WEB3": true, "RWA": true, "MEME": true, "HODLER": true,
+}
+
+// titleStopWords слова, що не впливають на схожість назв
+var titleStopWords = map[string]bool{
+	"the": true, "a": true, "an": true, "and": true, "or": true, "to": true,
+	"for": true, "of": true, "on": true, "in": true, "with": true, "by": true,
+	"will": true, "is": true, "are": true, "new": true, "now": true, "live": true,
+	"binance": true, "bybit": true, "okx": true, "gate": true, "io": true,
+	"kraken": true, "mexc": true, "bitget": true, "kucoin": true,
+}
+
+// extractSymbol символ токена проєкту та його назва (якщо є в заголовку)
+func extractSymbol(opp *models.Opportunity) (string, string) {
+	if match := namedSymbolPattern.FindStringSubmatch(opp.Title); match != nil && !symbolStopList[match[2]] {
+		return match[2], projectName(match[1])
+	}
+
+	if match := bracketSymbolPattern.FindStringSubmatch(opp.Title); match != nil && !symbolStopList[match[1]] {
+		return match[1], ""
+	}
+
+	for _, text := range []string{opp.Reward, opp.Title} {
+		for _, match := range amountSymbolPattern.FindAllStringSubmatch(text, -1) {
+			if !symbolStopList[match[1]] {
+				return match[1], ""
+			}
+		}
+	}
+
+	for _, token := range capsTokenPattern.FindAllString(opp.Title, -1) {
+		if !symbolStopList[token] {
+			return token, ""
+		}
+	}
+
+	return "", ""
+}
+
+// projectName прибирає з назви слова-біржі ("Binance Will List Pixels" -> "Pixels")
+func projectName(raw string) string {
+	words := strings.Fields(raw)
+	for len(words) > 0 {
+		word := strings.ToLower(words[0])
+		if !titleStopWords[word] && word != "list" && word != "introducing" {
+			break
+		}
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// normalizeTitle множина значущих слів назви
+func normalizeTitle(title string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range wordPattern.FindAllString(strings.ToLower(title), -1) {
+		if !titleStopWords[word] {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// titleSimilarity Jaccard та коефіцієнт перекриття множин слів. Перекриття
+// ловить доповнені назви ("XYZ Launchpool" -> "XYZ Launchpool: stake BNB")
+func titleSimilarity(a, b string) (float64, float64) {
+	wordsA, wordsB := normalizeTitle(a), normalizeTitle(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0, 0
+	}
+
+	common := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			common++
+		}
+	}
+
+	union := len(wordsA) + len(wordsB) - common
+	return float64(common) / float64(union), float64(common) / float64(min(len(wordsA), len(wordsB)))
+}
+
+// duplicateScore схожість двох можливостей однієї біржі та типу (0 - різні)
+func duplicateScore(a, b *models.Opportunity) float64 {
+	symbolA, _ := extractSymbol(a)
+	symbolB, _ := extractSymbol(b)
+
+	jaccard, overlap := titleSimilarity(a.Title, b.Title)
+
+	switch {
+	case symbolA != "" && symbolB != "" && symbolA != symbolB:
+		return 0
+	case symbolA != "" && symbolA == symbolB && overlap >= symbolOverlapThreshold:
+		return overlap
+	case jaccard >= titleJaccardThreshold:
+		return jaccard
+	}
+
+	return 0
+}
+
+// dedupBatch стан дедуплікації в межах одного збереження результатів скрапера
+type dedupBatch struct {
+	service    *Service
+	since      time.Time
+	candidates map[string][]*models.Opportunity // exchange:type -> збережені можливості
+	claimed    map[uint]bool                    // Вже зіставлені з можливістю з цього запуску
+}
+
+func (s *Service) newDedupBatch() *dedupBatch {
+	return &dedupBatch{
+		service:    s,
+		since:      s.now().Add(-dedupWindow),
+		candidates: make(map[string][]*models.Opportunity),
+		claimed:    make(map[uint]bool),
+	}
+}
+
+// findDuplicate найсхожіша збережена можливість тієї ж біржі та типу за dedupWindow
+func (b *dedupBatch) findDuplicate(opp *models.Opportunity) *models.Opportunity {
+	var best *models.Opportunity
+	bestScore := 0.0
+
+	for _, candidate := range b.candidatesFor(opp) {
+		if score := duplicateScore(opp, candidate); score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+
+	return best
+}
+
+func (b *dedupBatch) candidatesFor(opp *models.Opportunity) []*models.Opportunity {
+	key := opp.Exchange + ":" + opp.Type
+
+	candidates, ok := b.candidates[key]
+	if !ok {
+		var err error
+		candidates, err = b.service.oppRepo.ListRecentByExchangeAndType(opp.Exchange, opp.Type, b.since)
+		if err != nil {
+			log.Printf("⚠️ Failed to load dedup candidates for %s: %v", key, err)
+		}
+		b.candidates[key] = candidates
+	}
+
+	return candidates
+}
+
+// add додає щойно створену можливість до кандидатів
+func (b *dedupBatch) add(opp *models.Opportunity) {
+	key := opp.Exchange + ":" + opp.Type
+	b.candidates[key] = append(b.candidatesFor(opp), opp)
+	b.claimed[opp.ID] = true
+}
+
+// assignProject прив'язує можливість до проєкту за символом токена
+func (s *Service) assignProject(opp *models.Opportunity) {
+	if s.projectRepo == nil || opp.ProjectID != nil {
+		return
+	}
+
+	symbol, name := extractSymbol(opp)
+	if symbol == "" {
+		return
+	}
+
+	now := s.now()
+
+	project, err := s.projectRepo.GetRecentBySymbol(symbol, now.Add(-projectWindow))
+	if err != nil {
+		log.Printf("⚠️ Failed to find project %s: %v", symbol, err)
+		return
+	}
+
+	if project == nil {
+		if name == "" {
+			name = symbol
+		}
+		project = &models.Project{Symbol: symbol, Name: name, FirstSeenAt: now, LastSeenAt: now}
+		if err := s.projectRepo.Create(project); err != nil {
+			log.Printf("⚠️ Failed to create project %s: %v", symbol, err)
+			return
+		}
+	} else {
+		project.LastSeenAt = now
+		if project.Name == project.Symbol && name != "" {
+			project.Name = name
+		}
+		if err := s.projectRepo.Update(project); err != nil {
+			log.Printf("⚠️ Failed to update project %s: %v", symbol, err)
+		}
+	}
+
+	opp.ProjectID = &project.ID
+}
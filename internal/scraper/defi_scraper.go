@@ -34,8 +34,14 @@ type DeFiCallback func(opp *models.DeFiOpportunity)
 
 // NewDeFiScraper створює новий DeFi scraper
 func NewDeFiScraper(repo repository.DeFiRepository, config DeFiScraperConfig) *DeFiScraper {
+	return NewDeFiScraperWithClient(repo, config, defillama.NewClient())
+}
+
+// NewDeFiScraperWithClient створює DeFi scraper з іншим DeFiLlama client
+// (defillama.NewClientWithHTTP для тестів із записаними відповідями)
+func NewDeFiScraperWithClient(repo repository.DeFiRepository, config DeFiScraperConfig, client *defillama.Client) *DeFiScraper {
 	return &DeFiScraper{
-		client:    defillama.NewClient(),
+		client:    client,
 		repo:      repo,
 		config:    config,
 		callbacks: make([]DeFiCallback, 0),
@@ -0,0 +1,281 @@
+package scraper
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Поля models.Opportunity, які можна заповнити з опису
+const (
+	FieldExternalID    = "external_id"
+	FieldTitle         = "title"
+	FieldDescription   = "description"
+	FieldReward        = "reward"
+	FieldURL           = "url"
+	FieldStartDate     = "start_date"
+	FieldEndDate       = "end_date"
+	FieldEstimatedROI  = "estimated_roi"
+	FieldPoolSize      = "pool_size"
+	FieldMinInvestment = "min_investment"
+	FieldDuration      = "duration"
+)
+
+var definitionFields = map[string]bool{
+	FieldExternalID:    true,
+	FieldTitle:         true,
+	FieldDescription:   true,
+	FieldReward:        true,
+	FieldURL:           true,
+	FieldStartDate:     true,
+	FieldEndDate:       true,
+	FieldEstimatedROI:  true,
+	FieldPoolSize:      true,
+	FieldMinInvestment: true,
+	FieldDuration:      true,
+}
+
+var definitionTypes = map[string]bool{
+	models.OpportunityTypeLaunchpool: true,
+	models.OpportunityTypeLaunchpad:  true,
+	models.OpportunityTypeAirdrop:    true,
+	models.OpportunityTypeLearnEarn:  true,
+	models.OpportunityTypeStaking:    true,
+}
+
+// ScraperDefinition декларативний опис скрапера біржі (configs/scrapers/*.yaml).
+// Нова біржа додається YAML файлом без Go коду
+type ScraperDefinition struct {
+	Exchange string            `yaml:"exchange"`
+	Timeout  int               `yaml:"timeout"` // секунди
+	Headers  map[string]string `yaml:"headers"`
+	Feeds    []*FeedDefinition `yaml:"feeds"`
+}
+
+// FeedDefinition endpoint, що повертає список оголошень або проєктів
+type FeedDefinition struct {
+	Name       string                   `yaml:"name"`
+	Type       string                   `yaml:"type"` // Тип, якщо жодне правило classify не спрацювало ("" - пропустити елемент)
+	Request    RequestDefinition        `yaml:"request"`
+	Pagination PaginationDefinition     `yaml:"pagination"`
+	Items      string                   `yaml:"items"` // Шлях до масиву елементів у відповіді
+	Filters    []*FilterRule            `yaml:"filters"`
+	Classify   []*ClassifyRule          `yaml:"classify"`
+	Fields     map[string]*FieldMapping `yaml:"fields"`
+	Defaults   map[string]*TypeDefaults `yaml:"defaults"`     // тип -> значення за замовчуванням
+	MaxAgeDays int                      `yaml:"max_age_days"` // Пропускати оголошення, старші за N днів
+
+	// FetchArticle завантажувати статтю за url і доповнювати поля через ExtractArticle
+	FetchArticle bool `yaml:"fetch_article"`
+}
+
+// RequestDefinition HTTP запит. {page} в url, query та body замінюється номером сторінки
+type RequestDefinition struct {
+	Method  string            `yaml:"method"`
+	URL     string            `yaml:"url"`
+	Query   map[string]string `yaml:"query"`
+	Body    string            `yaml:"body"` // JSON тіло для POST
+	Headers map[string]string `yaml:"headers"`
+}
+
+// PaginationDefinition скільки сторінок запитувати
+type PaginationDefinition struct {
+	Start int `yaml:"start"` // Номер першої сторінки (за замовчуванням 1)
+	Pages int `yaml:"pages"` // Кількість сторінок (за замовчуванням 1)
+}
+
+// FilterRule пропускає елементи, значення яких не підходить
+type FilterRule struct {
+	Path  string   `yaml:"path"`
+	In    []string `yaml:"in"`     // Дозволені значення (без регістру)
+	NotIn []string `yaml:"not_in"` // Заборонені значення (без регістру)
+}
+
+// ClassifyRule визначає тип можливості за ключовими словами
+type ClassifyRule struct {
+	Type     string   `yaml:"type"`
+	Path     string   `yaml:"path"` // Поле елемента; за замовчуванням title
+	Keywords []string `yaml:"keywords"`
+	Exclude  []string `yaml:"exclude"`
+}
+
+// FieldMapping звідки взяти значення поля Opportunity. В YAML можна
+// вказати просто шлях рядком: `title: annTitle`
+type FieldMapping struct {
+	Path     string `yaml:"path"`     // Шлях в елементі (a.b.0.c)
+	Template string `yaml:"template"` // Рядок з підстановками {шлях}
+	Pattern  string `yaml:"pattern"`  // Regex: береться перша група (або весь збіг)
+	Format   string `yaml:"format"`   // Дати: unix, unix_ms або Go layout
+	Default  string `yaml:"default"`  // Якщо значення порожнє або pattern не знайдено
+
+	pattern *regexp.Regexp
+}
+
+// UnmarshalYAML дозволяє коротку форму `field: path`
+func (f *FieldMapping) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		f.Path = node.Value
+		return nil
+	}
+
+	type plain FieldMapping
+	return node.Decode((*plain)(f))
+}
+
+// TypeDefaults значення для типу можливості, якщо їх немає в оголошенні
+type TypeDefaults struct {
+	Description  string  `yaml:"description"`
+	Reward       string  `yaml:"reward"`
+	EstimatedROI float64 `yaml:"estimated_roi"`
+	PoolSize     float64 `yaml:"pool_size"`
+	Duration     string  `yaml:"duration"`
+	EndAfterDays int     `yaml:"end_after_days"` // Кінець = початок + N днів, якщо end_date невідома
+}
+
+// LoadDefinitions завантажує всі *.yaml описи скраперів з директорії
+func LoadDefinitions(dir string) ([]*ScraperDefinition, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scraper definitions: %w", err)
+	}
+	sort.Strings(paths)
+
+	definitions := make([]*ScraperDefinition, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		def, err := ParseDefinition(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scraper definition %s: %w", filepath.Base(path), err)
+		}
+
+		definitions = append(definitions, def)
+	}
+
+	return definitions, nil
+}
+
+// ParseDefinition розбирає та перевіряє опис скрапера
+func ParseDefinition(data []byte) (*ScraperDefinition, error) {
+	var def ScraperDefinition
+	if err := yaml.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	if err := def.validate(); err != nil {
+		return nil, err
+	}
+
+	return &def, nil
+}
+
+// validate перевіряє опис та компілює regex
+func (d *ScraperDefinition) validate() error {
+	d.Exchange = strings.ToLower(strings.TrimSpace(d.Exchange))
+	if d.Exchange == "" {
+		return fmt.Errorf("exchange is required")
+	}
+
+	if len(d.Feeds) == 0 {
+		return fmt.Errorf("at least one feed is required")
+	}
+
+	for i, feed := range d.Feeds {
+		if feed.Name == "" {
+			feed.Name = fmt.Sprintf("feed%d", i+1)
+		}
+
+		if err := feed.validate(); err != nil {
+			return fmt.Errorf("feed %s: %w", feed.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (f *FeedDefinition) validate() error {
+	if f.Request.URL == "" {
+		return fmt.Errorf("request.url is required")
+	}
+
+	f.Request.Method = strings.ToUpper(f.Request.Method)
+	if f.Request.Method == "" {
+		f.Request.Method = "GET"
+	}
+
+	if f.Pagination.Start == 0 {
+		f.Pagination.Start = 1
+	}
+	if f.Pagination.Pages < 1 {
+		f.Pagination.Pages = 1
+	}
+
+	if f.Type != "" && !definitionTypes[f.Type] {
+		return fmt.Errorf("unknown type %q", f.Type)
+	}
+
+	if f.Type == "" && len(f.Classify) == 0 {
+		return fmt.Errorf("type or classify rules are required")
+	}
+
+	for _, rule := range f.Classify {
+		if !definitionTypes[rule.Type] {
+			return fmt.Errorf("classify: unknown type %q", rule.Type)
+		}
+		if len(rule.Keywords) == 0 {
+			return fmt.Errorf("classify %s: keywords are required", rule.Type)
+		}
+	}
+
+	for oppType := range f.Defaults {
+		if !definitionTypes[oppType] {
+			return fmt.Errorf("defaults: unknown type %q", oppType)
+		}
+	}
+
+	for _, required := range []string{FieldExternalID, FieldTitle} {
+		if f.Fields[required] == nil {
+			return fmt.Errorf("fields.%s is required", required)
+		}
+	}
+
+	for name, mapping := range f.Fields {
+		if !definitionFields[name] {
+			return fmt.Errorf("unknown field %q", name)
+		}
+
+		if mapping.Pattern != "" {
+			re, err := regexp.Compile(mapping.Pattern)
+			if err != nil {
+				return fmt.Errorf("fields.%s: invalid pattern: %w", name, err)
+			}
+			mapping.pattern = re
+		}
+	}
+
+	return nil
+}
+
+// produces чи може feed повертати можливості типу
+func (f *FeedDefinition) produces(oppType string) bool {
+	if f.Type == oppType {
+		return true
+	}
+
+	for _, rule := range f.Classify {
+		if rule.Type == oppType {
+			return true
+		}
+	}
+
+	return false
+}
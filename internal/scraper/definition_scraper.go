@@ -0,0 +1,482 @@
+package scraper
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	urlpkg "net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// templateVar підстановка {шлях} в шаблонах
+var templateVar = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// DefinitionScraper Scraper, що працює за декларативним описом ScraperDefinition
+type DefinitionScraper struct {
+	def        *ScraperDefinition
+	httpClient *http.Client
+	articles   *ArticleFetcher
+}
+
+// NewDefinitionScraper створює скрапер з опису
+func NewDefinitionScraper(def *ScraperDefinition) *DefinitionScraper {
+	timeout := time.Duration(def.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	httpClient := &http.Client{
+		Timeout: timeout,
+	}
+
+	return &DefinitionScraper{
+		def:        def,
+		httpClient: httpClient,
+		articles:   NewArticleFetcher(httpClient),
+	}
+}
+
+func (s *DefinitionScraper) GetExchange() string {
+	return s.def.Exchange
+}
+
+func (s *DefinitionScraper) ScrapeAll() ([]*models.Opportunity, error) {
+	return s.scrape("")
+}
+
+func (s *DefinitionScraper) ScrapeLaunchpool() ([]*models.Opportunity, error) {
+	return s.scrape(models.OpportunityTypeLaunchpool)
+}
+
+func (s *DefinitionScraper) ScrapeAirdrops() ([]*models.Opportunity, error) {
+	return s.scrape(models.OpportunityTypeAirdrop)
+}
+
+func (s *DefinitionScraper) ScrapeLearnEarn() ([]*models.Opportunity, error) {
+	return s.scrape(models.OpportunityTypeLearnEarn)
+}
+
+// scrape обходить feeds, що можуть повернути тип ("" - всі типи)
+func (s *DefinitionScraper) scrape(oppType string) ([]*models.Opportunity, error) {
+	var allOpps []*models.Opportunity
+	var errors []error
+	attempted := 0
+	seen := make(map[string]bool)
+
+	for _, feed := range s.def.Feeds {
+		if oppType != "" && !feed.produces(oppType) {
+			continue
+		}
+		attempted++
+
+		opps, err := s.scrapeFeed(feed)
+		if err != nil {
+			log.Printf("Error scraping %s %s: %v", s.def.Exchange, feed.Name, err)
+			errors = append(errors, fmt.Errorf("%s: %w", feed.Name, err))
+			continue
+		}
+
+		count := 0
+		for _, opp := range opps {
+			if (oppType != "" && opp.Type != oppType) || seen[opp.ExternalID] {
+				continue
+			}
+			seen[opp.ExternalID] = true
+
+			allOpps = append(allOpps, opp)
+			count++
+		}
+
+		log.Printf("✅ Scraped %d %s opportunities from %s", count, s.def.Exchange, feed.Name)
+	}
+
+	if attempted > 0 && len(errors) == attempted {
+		return allOpps, fmt.Errorf("all feeds failed: %v", errors)
+	}
+
+	return allOpps, nil
+}
+
+// scrapeFeed завантажує сторінки feed і перетворює елементи на Opportunity
+func (s *DefinitionScraper) scrapeFeed(feed *FeedDefinition) ([]*models.Opportunity, error) {
+	var opportunities []*models.Opportunity
+
+	for page := feed.Pagination.Start; page < feed.Pagination.Start+feed.Pagination.Pages; page++ {
+		items, err := s.fetchItems(feed, page)
+		if err != nil {
+			// Перша сторінка обов'язкова, наступні - як вийде
+			if page == feed.Pagination.Start {
+				return nil, err
+			}
+			log.Printf("⚠️ %s %s page %d: %v", s.def.Exchange, feed.Name, page, err)
+			break
+		}
+
+		if len(items) == 0 {
+			break
+		}
+
+		for _, item := range items {
+			if opp := s.buildOpportunity(feed, item); opp != nil {
+				opportunities = append(opportunities, opp)
+			}
+		}
+	}
+
+	if feed.FetchArticle {
+		s.articles.EnrichAll(opportunities)
+	}
+
+	return opportunities, nil
+}
+
+// fetchItems виконує запит сторінки та повертає масив елементів
+func (s *DefinitionScraper) fetchItems(feed *FeedDefinition, page int) ([]interface{}, error) {
+	vars := map[string]string{"page": strconv.Itoa(page)}
+
+	fullURL := expandVars(feed.Request.URL, vars)
+	if len(feed.Request.Query) > 0 {
+		params := urlpkg.Values{}
+		for key, value := range feed.Request.Query {
+			params.Set(key, expandVars(value, vars))
+		}
+
+		separator := "?"
+		if strings.Contains(fullURL, "?") {
+			separator = "&"
+		}
+		fullURL += separator + params.Encode()
+	}
+
+	var body io.Reader
+	if feed.Request.Body != "" {
+		body = strings.NewReader(expandVars(feed.Request.Body, vars))
+	}
+
+	req, err := http.NewRequest(feed.Request.Method, fullURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range s.def.Headers {
+		req.Header.Set(key, value)
+	}
+	for key, value := range feed.Request.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", feed.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	items, ok := lookupPath(data, feed.Items).([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("items path %q is not an array", feed.Items)
+	}
+
+	return items, nil
+}
+
+// buildOpportunity перетворює елемент на Opportunity (nil - пропустити)
+func (s *DefinitionScraper) buildOpportunity(feed *FeedDefinition, item interface{}) *models.Opportunity {
+	for _, filter := range feed.Filters {
+		if !filter.matches(item) {
+			return nil
+		}
+	}
+
+	id := s.fieldValue(feed, item, FieldExternalID)
+	title := cleanText(s.fieldValue(feed, item, FieldTitle), 150)
+	if id == "" || title == "" {
+		return nil
+	}
+
+	oppType := feed.classify(item, title)
+	if oppType == "" {
+		return nil
+	}
+
+	defaults := feed.Defaults[oppType]
+	if defaults == nil {
+		defaults = &TypeDefaults{}
+	}
+
+	startDate := s.dateValue(feed, item, FieldStartDate)
+	endDate := s.dateValue(feed, item, FieldEndDate)
+
+	if feed.MaxAgeDays > 0 && startDate != nil && time.Since(*startDate) > time.Duration(feed.MaxAgeDays)*24*time.Hour {
+		return nil
+	}
+
+	if endDate == nil && startDate != nil && defaults.EndAfterDays > 0 {
+		end := startDate.AddDate(0, 0, defaults.EndAfterDays)
+		endDate = &end
+	}
+
+	opp := &models.Opportunity{
+		ExternalID:    GenerateExternalID(s.def.Exchange, oppType, id),
+		Exchange:      s.def.Exchange,
+		Type:          oppType,
+		Title:         title,
+		Description:   firstNonEmpty(cleanText(s.fieldValue(feed, item, FieldDescription), 500), defaults.Description),
+		Reward:        firstNonEmpty(s.fieldValue(feed, item, FieldReward), defaults.Reward),
+		EstimatedROI:  s.numberValue(feed, item, FieldEstimatedROI, defaults.EstimatedROI),
+		PoolSize:      s.numberValue(feed, item, FieldPoolSize, defaults.PoolSize),
+		MinInvestment: s.numberValue(feed, item, FieldMinInvestment, 0),
+		Duration:      firstNonEmpty(s.fieldValue(feed, item, FieldDuration), defaults.Duration),
+		StartDate:     startDate,
+		EndDate:       endDate,
+		URL:           s.fieldValue(feed, item, FieldURL),
+		IsActive:      true,
+		Metadata: map[string]interface{}{
+			"source": "definition",
+			"feed":   feed.Name,
+		},
+	}
+
+	return opp
+}
+
+// fieldValue значення поля за FieldMapping ("" якщо поле не описане)
+func (s *DefinitionScraper) fieldValue(feed *FeedDefinition, item interface{}, field string) string {
+	mapping := feed.Fields[field]
+	if mapping == nil {
+		return ""
+	}
+
+	var value string
+	if mapping.Template != "" {
+		value = templateVar.ReplaceAllStringFunc(mapping.Template, func(match string) string {
+			return stringValue(lookupPath(item, match[1:len(match)-1]))
+		})
+	} else {
+		value = stringValue(lookupPath(item, mapping.Path))
+	}
+
+	if mapping.pattern != nil && value != "" {
+		matches := mapping.pattern.FindStringSubmatch(value)
+		switch {
+		case len(matches) > 1:
+			value = strings.Join(matches[1:], " ")
+		case len(matches) == 1:
+			value = matches[0]
+		default:
+			value = ""
+		}
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return mapping.Default
+	}
+
+	return value
+}
+
+// numberValue число з поля (коми-розділювачі тисяч прибираються)
+func (s *DefinitionScraper) numberValue(feed *FeedDefinition, item interface{}, field string, fallback float64) float64 {
+	value := s.fieldValue(feed, item, field)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+	if err != nil {
+		return fallback
+	}
+
+	return number
+}
+
+// dateValue дата з поля за Format (unix, unix_ms, Go layout або автовизначення)
+func (s *DefinitionScraper) dateValue(feed *FeedDefinition, item interface{}, field string) *time.Time {
+	value := s.fieldValue(feed, item, field)
+	if value == "" {
+		return nil
+	}
+
+	switch format := feed.Fields[field].Format; format {
+	case "unix", "unix_ms":
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || number <= 0 {
+			return nil
+		}
+
+		t := time.Unix(number, 0)
+		if format == "unix_ms" {
+			t = time.UnixMilli(number)
+		}
+		return &t
+	case "":
+		t, err := ParseDate(value)
+		if err != nil {
+			return nil
+		}
+		return t
+	default:
+		t, err := time.Parse(format, value)
+		if err != nil {
+			return nil
+		}
+		return &t
+	}
+}
+
+// matches чи проходить елемент фільтр
+func (r *FilterRule) matches(item interface{}) bool {
+	value := strings.ToLower(stringValue(lookupPath(item, r.Path)))
+
+	for _, excluded := range r.NotIn {
+		if value == strings.ToLower(excluded) {
+			return false
+		}
+	}
+
+	if len(r.In) == 0 {
+		return true
+	}
+
+	for _, allowed := range r.In {
+		if value == strings.ToLower(allowed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// classify тип можливості: перше правило, що спрацювало, інакше Type feed
+func (f *FeedDefinition) classify(item interface{}, title string) string {
+	for _, rule := range f.Classify {
+		text := title
+		if rule.Path != "" {
+			text = stringValue(lookupPath(item, rule.Path))
+		}
+
+		if rule.matches(strings.ToLower(text)) {
+			return rule.Type
+		}
+	}
+
+	return f.Type
+}
+
+func (r *ClassifyRule) matches(text string) bool {
+	for _, excluded := range r.Exclude {
+		if strings.Contains(text, strings.ToLower(excluded)) {
+			return false
+		}
+	}
+
+	for _, keyword := range r.Keywords {
+		if strings.Contains(text, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookupPath значення за шляхом a.b.0.c ("" - сам елемент)
+func lookupPath(data interface{}, path string) interface{} {
+	if path == "" {
+		return data
+	}
+
+	current := data
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			current = node[key]
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil
+			}
+			current = node[index]
+		default:
+			return nil
+		}
+	}
+
+	return current
+}
+
+// stringValue рядкове представлення JSON значення
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, element := range v {
+			parts = append(parts, stringValue(element))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// expandVars підставляє {name} змінні запиту
+func expandVars(template string, vars map[string]string) string {
+	return templateVar.ReplaceAllStringFunc(template, func(match string) string {
+		if value, ok := vars[match[1:len(match)-1]]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// cleanText прибирає зайві пробіли та обрізає до maxLen символів
+func cleanText(text string, maxLen int) string {
+	text = strings.Join(strings.Fields(text), " ")
+
+	if runes := []rune(text); len(runes) > maxLen {
+		text = string(runes[:maxLen-3]) + "..."
+	}
+
+	return text
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
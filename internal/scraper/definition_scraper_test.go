@@ -0,0 +1,192 @@
+package scraper
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testDefinition = `
+exchange: TestEx
+feeds:
+  - name: announcements
+    request:
+      url: %s/announcements
+      query:
+        page: "{page}"
+    pagination:
+      pages: 3
+    items: data.list
+    max_age_days: 30
+    filters:
+      - path: status
+        not_in: ["ended"]
+    classify:
+      - type: launchpool
+        keywords: ["launchpool"]
+      - type: airdrop
+        keywords: ["airdrop"]
+        exclude: ["winners"]
+    fields:
+      external_id: id
+      title: title
+      url:
+        template: "https://testex.com/news/{id}"
+      start_date:
+        path: publishedAt
+        format: unix_ms
+      reward:
+        path: title
+        pattern: '(\d+(?:,\d+)*)\s*([A-Z]{2,10})'
+      pool_size:
+        path: title
+        pattern: '(\d+(?:,\d+)*)\s*[A-Z]{2,10}'
+    defaults:
+      airdrop:
+        description: "TestEx Airdrop"
+        estimated_roi: 2
+        end_after_days: 14
+`
+
+func TestDefinitionScraper(t *testing.T) {
+	published := time.Now().Add(-24 * time.Hour).UnixMilli()
+	stale := time.Now().Add(-60 * 24 * time.Hour).UnixMilli()
+
+	pages := map[string]string{
+		"1": fmt.Sprintf(`{"data":{"list":[
+			{"id":1,"title":"XYZ Launchpool: stake BNB","status":"live","publishedAt":%d},
+			{"id":2,"title":"ABC Airdrop - share 50,000 ABC","status":"live","publishedAt":%d},
+			{"id":3,"title":"ABC Airdrop winners","status":"live","publishedAt":%d}
+		]}}`, published, published, published),
+		"2": fmt.Sprintf(`{"data":{"list":[
+			{"id":4,"title":"OLD Airdrop","status":"live","publishedAt":%d},
+			{"id":5,"title":"DEF Airdrop","status":"ended","publishedAt":%d},
+			{"id":2,"title":"ABC Airdrop - share 50,000 ABC","status":"live","publishedAt":%d}
+		]}}`, stale, published, published),
+		"3": `{"data":{"list":[]}}`,
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	}))
+	defer server.Close()
+
+	def, err := ParseDefinition([]byte(fmt.Sprintf(testDefinition, server.URL)))
+	if err != nil {
+		t.Fatalf("Failed to parse definition: %v", err)
+	}
+
+	s := NewDefinitionScraper(def)
+	if s.GetExchange() != "testex" {
+		t.Errorf("Expected lowercase exchange, got %s", s.GetExchange())
+	}
+
+	opps, err := s.ScrapeAll()
+	if err != nil {
+		t.Fatalf("ScrapeAll failed: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 page requests, got %d", requests)
+	}
+
+	// Пропущені: winners (exclude), старе (max_age_days), ended (filter), дублікат
+	if len(opps) != 2 {
+		t.Fatalf("Expected 2 opportunities, got %d", len(opps))
+	}
+
+	launchpool, airdrop := opps[0], opps[1]
+	if launchpool.Type != models.OpportunityTypeLaunchpool || launchpool.ExternalID != GenerateExternalID("testex", models.OpportunityTypeLaunchpool, "1") {
+		t.Errorf("Unexpected launchpool %s %s", launchpool.Type, launchpool.ExternalID)
+	}
+	if launchpool.URL != "https://testex.com/news/1" {
+		t.Errorf("Expected templated URL, got %s", launchpool.URL)
+	}
+
+	if airdrop.Type != models.OpportunityTypeAirdrop || airdrop.Reward != "50,000 ABC" || airdrop.PoolSize != 50000 {
+		t.Errorf("Expected airdrop with 50,000 ABC reward, got %s %q %.0f", airdrop.Type, airdrop.Reward, airdrop.PoolSize)
+	}
+	if airdrop.Description != "TestEx Airdrop" || airdrop.EstimatedROI != 2 {
+		t.Errorf("Expected airdrop defaults, got %q %.1f", airdrop.Description, airdrop.EstimatedROI)
+	}
+	if airdrop.StartDate == nil || airdrop.StartDate.UnixMilli() != published {
+		t.Fatalf("Expected start date from unix_ms, got %v", airdrop.StartDate)
+	}
+	if airdrop.EndDate == nil || !airdrop.EndDate.Equal(airdrop.StartDate.AddDate(0, 0, 14)) {
+		t.Errorf("Expected end date 14 days after start, got %v", airdrop.EndDate)
+	}
+
+	requests = 0
+	launchpools, err := s.ScrapeLaunchpool()
+	if err != nil || len(launchpools) != 1 || launchpools[0].Type != models.OpportunityTypeLaunchpool {
+		t.Errorf("Expected 1 launchpool, got %d (%v)", len(launchpools), err)
+	}
+
+	learnEarn, err := s.ScrapeLearnEarn()
+	if err != nil || len(learnEarn) != 0 || requests != 3 {
+		t.Errorf("Expected learn & earn to skip feed without requests, got %d opps, %d requests", len(learnEarn), requests)
+	}
+}
+
+func TestDefinitionScraperFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	def, err := ParseDefinition([]byte(fmt.Sprintf(testDefinition, server.URL)))
+	if err != nil {
+		t.Fatalf("Failed to parse definition: %v", err)
+	}
+
+	if _, err := NewDefinitionScraper(def).ScrapeAll(); err == nil {
+		t.Error("Expected error when all feeds fail")
+	}
+}
+
+func TestParseDefinitionValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"no exchange", "feeds: [{request: {url: x}, type: airdrop, fields: {external_id: id, title: t}}]", "exchange is required"},
+		{"no feeds", "exchange: x", "at least one feed"},
+		{"no url", "exchange: x\nfeeds: [{type: airdrop, fields: {external_id: id, title: t}}]", "request.url is required"},
+		{"no type", "exchange: x\nfeeds: [{request: {url: x}, fields: {external_id: id, title: t}}]", "type or classify"},
+		{"bad type", "exchange: x\nfeeds: [{request: {url: x}, type: lottery, fields: {external_id: id, title: t}}]", "unknown type"},
+		{"no title", "exchange: x\nfeeds: [{request: {url: x}, type: airdrop, fields: {external_id: id}}]", "fields.title is required"},
+		{"bad field", "exchange: x\nfeeds: [{request: {url: x}, type: airdrop, fields: {external_id: id, title: t, apy: a}}]", "unknown field"},
+		{"bad pattern", "exchange: x\nfeeds: [{request: {url: x}, type: airdrop, fields: {external_id: id, title: {path: t, pattern: '('}}}]", "invalid pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDefinition([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadShippedDefinitions(t *testing.T) {
+	definitions, err := LoadDefinitions("../../configs/scrapers")
+	if err != nil {
+		t.Fatalf("Shipped definitions are invalid: %v", err)
+	}
+
+	exchanges := make([]string, 0, len(definitions))
+	for _, def := range definitions {
+		exchanges = append(exchanges, def.Exchange)
+	}
+
+	if got := strings.Join(exchanges, ","); got != "bitget,kucoin,mexc" {
+		t.Errorf("Expected bitget, kucoin and mexc definitions, got %s", got)
+	}
+}
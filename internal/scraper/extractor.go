@@ -0,0 +1,431 @@
+package scraper
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minFieldConfidence нижче цього значення поле лише записується в Metadata
+const minFieldConfidence = 0.6
+
+// Поля, для яких ExtractArticle повідомляє впевненість
+// (ключі Metadata["extraction"]["confidence"])
+const (
+	ExtractFieldStartDate  = "start_date"
+	ExtractFieldEndDate    = "end_date"
+	ExtractFieldRewardPool = "reward_pool"
+	ExtractFieldRegions    = "regions"
+	ExtractFieldKYC        = "kyc"
+	ExtractFieldMinHolding = "min_holding"
+)
+
+var (
+	monthNames = `(Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Sept|Oct|Nov|Dec)[a-z]*\.?`
+	timeSuffix = `(?:,?\s*(?:at\s+)?(\d{1,2}):(\d{2})(?::\d{2})?)?`
+
+	isoDatePattern       = regexp.MustCompile(`(\d{4})[-/.](\d{1,2})[-/.](\d{1,2})(?:[ T]+(\d{1,2}):(\d{2})(?::\d{2})?)?`)
+	monthFirstPattern    = regexp.MustCompile(`(?i)` + monthNames + `\s+(\d{1,2})(?:st|nd|rd|th)?,?\s+(\d{4})` + timeSuffix)
+	dayFirstPattern      = regexp.MustCompile(`(?i)(\d{1,2})(?:st|nd|rd|th)?\s+` + monthNames + `,?\s+(\d{4})` + timeSuffix)
+	periodKeywordPattern = regexp.MustCompile(`(?i)\b(period|duration|between|activity time|event time|campaign|promotion|event)\b`)
+	rangeSepPattern      = regexp.MustCompile(`(?i)(\bto\b|\buntil\b|\btill\b|[-–—~])`)
+	endKeywordPattern    = regexp.MustCompile(`(?i)\b(end|ends|ending|until|deadline|close|closes|expire|expires|no later than)\b`)
+	startKeywordPattern  = regexp.MustCompile(`(?i)\b(start|starts|begin|begins|launch|launches|open|opens|from)\b`)
+
+	poolPattern = regexp.MustCompile(`(?:(?i:prize pool|reward pool|rewards pool|total rewards?|total prize|share(?: a| the)?(?: total of)?|airdrop(?:ping)?|up to|worth))\s*(?:of\s*)?(\$)?([\d,]+(?:\.\d+)?)\s*(?:in\s+)?([A-Z][A-Z0-9]{1,9})?\b`)
+
+	excludedRegionsPattern = regexp.MustCompile(`(?i)(?:not (?:be )?(?:available|eligible|open) (?:to|for)[^:\n.]*?(?:in|from|of)|excluded (?:jurisdictions|countries|regions)|restricted (?:jurisdictions|countries|regions))(?:[^:\n]*:)?\s+([^\n.]+)`)
+	eligibleRegionsPattern = regexp.MustCompile(`(?i)only (?:available|open|eligible) (?:to|for) (?:users|residents|participants) (?:in|from|of)\s+([^\n.]+)`)
+	regionSplitPattern     = regexp.MustCompile(`\s*(?:,|;|\band\b|\bor\b)\s*`)
+
+	kycPattern         = regexp.MustCompile(`(?i)\b(kyc|identity verification|verified users?)\b`)
+	kycNegativePattern = regexp.MustCompile(`(?i)\b(no kyc|not required|without (?:kyc|identity verification))\b`)
+	kycRequiredPattern = regexp.MustCompile(`(?i)\b(must|required|need|needs|complete|completed|only)\b`)
+
+	minHoldingPattern = regexp.MustCompile(`(?i:(?:hold|holding|maintain|balance of|deposit|stake|subscribe|commit)[^.\n]{0,40}?(?:at least|a minimum of|minimum of|minimum|min\.?|≥|>=|more than))\s*(\$)?([\d,]+(?:\.\d+)?)\s*([A-Z][A-Z0-9]{1,9})?`)
+)
+
+// stableAssets активи, сума в яких - це сума в доларах
+var stableAssets = map[string]bool{"USDT": true, "USDC": true, "USD": true, "FDUSD": true, "$": true}
+
+// Extraction поля, розпізнані в тексті статті, з впевненістю 0-1 для кожного
+type Extraction struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+
+	PoolAmount float64
+	PoolAsset  string
+
+	EligibleRegions []string
+	ExcludedRegions []string
+
+	KYCRequired bool
+
+	MinHolding      float64
+	MinHoldingAsset string
+
+	Confidence map[string]float64
+}
+
+// ExtractArticle розбирає текст статті: період акції, пул винагород, регіони,
+// KYC та мінімальний баланс
+func ExtractArticle(text string) *Extraction {
+	e := &Extraction{Confidence: make(map[string]float64)}
+
+	lines := strings.Split(text, "\n")
+
+	e.extractDates(lines)
+	e.extractPool(text)
+	e.extractRegions(text)
+	e.extractKYC(lines)
+	e.extractMinHolding(text)
+
+	return e
+}
+
+// datedMatch дата, знайдена в рядку, та її позиція
+type datedMatch struct {
+	t          time.Time
+	start, end int
+	hasTime    bool
+}
+
+func (e *Extraction) extractDates(lines []string) {
+	for _, line := range lines {
+		dates := findDates(line)
+		if len(dates) == 0 {
+			continue
+		}
+
+		if len(dates) >= 2 && rangeSepPattern.MatchString(line[dates[0].end:dates[1].start]) {
+			confidence := 0.75
+			if periodKeywordPattern.MatchString(line) {
+				confidence = 0.9
+			}
+
+			start, end := dates[0].t, dayEnd(dates[1])
+			if end.After(start) {
+				e.setDate(ExtractFieldStartDate, start, confidence)
+				e.setDate(ExtractFieldEndDate, end, confidence)
+			}
+			continue
+		}
+
+		before := line[:dates[0].start]
+		switch {
+		case endKeywordPattern.MatchString(before):
+			e.setDate(ExtractFieldEndDate, dayEnd(dates[0]), 0.7)
+		case startKeywordPattern.MatchString(before):
+			e.setDate(ExtractFieldStartDate, dates[0].t, 0.7)
+		}
+	}
+
+	// Суперечливі дати з різних рядків - знижуємо впевненість
+	if e.StartDate != nil && e.EndDate != nil && !e.EndDate.After(*e.StartDate) {
+		e.Confidence[ExtractFieldStartDate] = 0.3
+		e.Confidence[ExtractFieldEndDate] = 0.3
+	}
+}
+
+// setDate записує дату, якщо впевненість вища за вже знайдену
+func (e *Extraction) setDate(field string, t time.Time, confidence float64) {
+	if confidence <= e.Confidence[field] {
+		return
+	}
+
+	e.Confidence[field] = confidence
+	if field == ExtractFieldStartDate {
+		e.StartDate = &t
+	} else {
+		e.EndDate = &t
+	}
+}
+
+func (e *Extraction) extractPool(text string) {
+	for _, match := range poolPattern.FindAllStringSubmatch(text, -1) {
+		amount := parseAmount(match[2])
+		asset := match[3]
+		if match[1] != "" {
+			asset = "USD"
+		}
+		if amount <= 0 || asset == "" {
+			continue
+		}
+
+		// Найбільша сума - зазвичай загальний пул, а не винагорода одного учасника
+		if amount > e.PoolAmount && (e.PoolAsset == "" || e.PoolAsset == asset) {
+			e.PoolAmount = amount
+			e.PoolAsset = asset
+			e.Confidence[ExtractFieldRewardPool] = 0.85
+		}
+	}
+}
+
+func (e *Extraction) extractRegions(text string) {
+	if match := excludedRegionsPattern.FindStringSubmatch(text); match != nil {
+		e.ExcludedRegions = splitRegions(match[1])
+	}
+	if match := eligibleRegionsPattern.FindStringSubmatch(text); match != nil {
+		e.EligibleRegions = splitRegions(match[1])
+	}
+
+	if len(e.ExcludedRegions) > 0 || len(e.EligibleRegions) > 0 {
+		e.Confidence[ExtractFieldRegions] = 0.8
+	}
+}
+
+func (e *Extraction) extractKYC(lines []string) {
+	for _, line := range lines {
+		if !kycPattern.MatchString(line) {
+			continue
+		}
+
+		switch {
+		case kycNegativePattern.MatchString(line):
+			e.KYCRequired = false
+			e.Confidence[ExtractFieldKYC] = 0.7
+		case kycRequiredPattern.MatchString(line):
+			e.KYCRequired = true
+			e.Confidence[ExtractFieldKYC] = 0.9
+			return
+		default:
+			e.KYCRequired = true
+			e.Confidence[ExtractFieldKYC] = max(e.Confidence[ExtractFieldKYC], 0.5)
+		}
+	}
+}
+
+func (e *Extraction) extractMinHolding(text string) {
+	match := minHoldingPattern.FindStringSubmatch(text)
+	if match == nil {
+		return
+	}
+
+	asset := match[3]
+	if match[1] != "" {
+		asset = "USD"
+	}
+
+	amount := parseAmount(match[2])
+	if amount <= 0 || asset == "" {
+		return
+	}
+
+	e.MinHolding = amount
+	e.MinHoldingAsset = asset
+	e.Confidence[ExtractFieldMinHolding] = 0.85
+}
+
+// confident чи достатньо впевненості, щоб заповнити поле Opportunity
+func (e *Extraction) confident(field string) bool {
+	return e.Confidence[field] >= minFieldConfidence
+}
+
+// Apply заповнює поля Opportunity, розпізнані з достатньою впевненістю, а
+// все знайдене (з впевненістю по кожному полю) зберігає в Metadata["extraction"]
+func (e *Extraction) Apply(opp *models.Opportunity) {
+	if len(e.Confidence) == 0 {
+		return
+	}
+
+	if e.confident(ExtractFieldStartDate) {
+		opp.StartDate = e.StartDate
+	}
+	if e.confident(ExtractFieldEndDate) {
+		opp.EndDate = e.EndDate
+	}
+	if e.confident(ExtractFieldStartDate) && e.confident(ExtractFieldEndDate) {
+		days := int(math.Ceil(e.EndDate.Sub(*e.StartDate).Hours() / 24))
+		opp.Duration = fmt.Sprintf("%d days", days)
+	}
+
+	if e.confident(ExtractFieldRewardPool) {
+		opp.Reward = formatAmount(e.PoolAmount) + " " + e.PoolAsset
+		if stableAssets[e.PoolAsset] {
+			opp.PoolSize = e.PoolAmount
+		}
+	}
+
+	if e.confident(ExtractFieldMinHolding) && stableAssets[e.MinHoldingAsset] {
+		opp.MinInvestment = e.MinHolding
+	}
+
+	if requirements := e.requirements(); requirements != "" {
+		opp.Requirements = requirements
+	}
+
+	if opp.Metadata == nil {
+		opp.Metadata = models.JSONMap{}
+	}
+	opp.Metadata["extraction"] = e.metadata()
+}
+
+// requirements умови участі для Opportunity.Requirements
+func (e *Extraction) requirements() string {
+	var parts []string
+
+	if e.confident(ExtractFieldKYC) && e.KYCRequired {
+		parts = append(parts, "KYC verification required")
+	}
+	if e.confident(ExtractFieldMinHolding) {
+		parts = append(parts, fmt.Sprintf("Minimum holding: %s %s", formatAmount(e.MinHolding), e.MinHoldingAsset))
+	}
+	if e.confident(ExtractFieldRegions) {
+		if len(e.EligibleRegions) > 0 {
+			parts = append(parts, "Only for: "+strings.Join(e.EligibleRegions, ", "))
+		}
+		if len(e.ExcludedRegions) > 0 {
+			parts = append(parts, "Not available in: "+strings.Join(e.ExcludedRegions, ", "))
+		}
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+func (e *Extraction) metadata() models.JSONMap {
+	metadata := models.JSONMap{"confidence": e.Confidence}
+
+	if e.PoolAmount > 0 {
+		metadata["reward_pool"] = formatAmount(e.PoolAmount) + " " + e.PoolAsset
+	}
+	if _, ok := e.Confidence[ExtractFieldKYC]; ok {
+		metadata["kyc_required"] = e.KYCRequired
+	}
+	if e.MinHolding > 0 {
+		metadata["min_holding"] = formatAmount(e.MinHolding) + " " + e.MinHoldingAsset
+	}
+	if len(e.EligibleRegions) > 0 {
+		metadata["eligible_regions"] = e.EligibleRegions
+	}
+	if len(e.ExcludedRegions) > 0 {
+		metadata["excluded_regions"] = e.ExcludedRegions
+	}
+
+	return metadata
+}
+
+// findDates дати в рядку в порядку появи (час UTC; без часу - початок дня)
+func findDates(line string) []datedMatch {
+	var dates []datedMatch
+
+	for _, loc := range isoDatePattern.FindAllStringSubmatchIndex(line, -1) {
+		parts := submatches(line, loc)
+		if t, ok := buildDate(parts[1], parts[2], parts[3], parts[4], parts[5]); ok {
+			dates = append(dates, datedMatch{t: t, start: loc[0], end: loc[1], hasTime: parts[4] != ""})
+		}
+	}
+
+	for _, loc := range monthFirstPattern.FindAllStringSubmatchIndex(line, -1) {
+		parts := submatches(line, loc)
+		if t, ok := buildDate(parts[3], monthNumber(parts[1]), parts[2], parts[4], parts[5]); ok {
+			dates = append(dates, datedMatch{t: t, start: loc[0], end: loc[1], hasTime: parts[4] != ""})
+		}
+	}
+
+	for _, loc := range dayFirstPattern.FindAllStringSubmatchIndex(line, -1) {
+		parts := submatches(line, loc)
+		if t, ok := buildDate(parts[3], monthNumber(parts[2]), parts[1], parts[4], parts[5]); ok {
+			dates = append(dates, datedMatch{t: t, start: loc[0], end: loc[1], hasTime: parts[4] != ""})
+		}
+	}
+
+	// Впорядковуємо за позицією (шаблони шукаються окремо)
+	for i := 1; i < len(dates); i++ {
+		for j := i; j > 0 && dates[j].start < dates[j-1].start; j-- {
+			dates[j], dates[j-1] = dates[j-1], dates[j]
+		}
+	}
+
+	return dates
+}
+
+func submatches(line string, loc []int) []string {
+	parts := make([]string, len(loc)/2)
+	for i := range parts {
+		if loc[2*i] >= 0 {
+			parts[i] = line[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return parts
+}
+
+func buildDate(year, month, day, hour, minute string) (time.Time, bool) {
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	if y < 2000 || m < 1 || m > 12 || d < 1 || d > 31 {
+		return time.Time{}, false
+	}
+
+	h, _ := strconv.Atoi(hour)
+	mi, _ := strconv.Atoi(minute)
+	if h > 23 || mi > 59 {
+		return time.Time{}, false
+	}
+
+	return time.Date(y, time.Month(m), d, h, mi, 0, 0, time.UTC), true
+}
+
+func monthNumber(name string) string {
+	months := []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	lower := strings.ToLower(name)
+	for i, month := range months {
+		if strings.HasPrefix(lower, month) {
+			return strconv.Itoa(i + 1)
+		}
+	}
+	return ""
+}
+
+// dayEnd кінець періоду без часу - кінець дня
+func dayEnd(match datedMatch) time.Time {
+	if match.hasTime {
+		return match.t
+	}
+	return match.t.Add(24*time.Hour - time.Second)
+}
+
+// splitRegions список країн з фрагмента речення
+func splitRegions(fragment string) []string {
+	var regions []string
+	for _, region := range regionSplitPattern.Split(fragment, -1) {
+		region = strings.Trim(region, " ()\"'")
+		region = strings.TrimPrefix(region, "the ")
+		if region == "" || len(region) > 40 || strings.ToUpper(region[:1]) != region[:1] {
+			continue
+		}
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+func parseAmount(value string) float64 {
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	return amount
+}
+
+// formatAmount 100000.5 -> "100,000.5"
+func formatAmount(amount float64) string {
+	whole, fraction, _ := strings.Cut(strconv.FormatFloat(amount, 'f', -1, 64), ".")
+
+	var builder strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteRune(digit)
+	}
+
+	if fraction != "" {
+		builder.WriteString("." + fraction)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,152 @@
+package scraper
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testArticle = `<html><head><title>XYZ</title><script>var x = "2020-01-01";</script></head><body>
+<h1>Trade XYZ and Share a 100,000 USDT Prize Pool</h1>
+<p>Activity Period: 2025-03-10 08:00 to 2025-03-24 08:00 (UTC)</p>
+<p>Eligible users who hold at least 500 USDT in their spot account will share up to 2,000 USDT each.</p>
+<p>Participants must complete KYC identity verification to be eligible for rewards.</p>
+<p>This campaign is not available to users from the following regions: United States, Canada and the United Kingdom.</p>
+<ul><li>Rewards will be distributed within 14 days.</li></ul>
+</body></html>`
+
+func TestHTMLToText(t *testing.T) {
+	text := htmlToText(`<head><style>p{}</style></head><p>First&nbsp;line</p><div>Second <b>line</b></div><ul><li>Item</li></ul>`)
+
+	expected := "First line\nSecond line\nItem"
+	if text != expected {
+		t.Errorf("Expected %q, got %q", expected, text)
+	}
+}
+
+func TestExtractArticle(t *testing.T) {
+	e := ExtractArticle(htmlToText(testArticle))
+
+	start := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 24, 8, 0, 0, 0, time.UTC)
+	if e.StartDate == nil || !e.StartDate.Equal(start) || e.EndDate == nil || !e.EndDate.Equal(end) {
+		t.Errorf("Expected period %v - %v, got %v - %v", start, end, e.StartDate, e.EndDate)
+	}
+	if e.Confidence[ExtractFieldStartDate] != 0.9 {
+		t.Errorf("Expected keyword range confidence 0.9, got %.2f", e.Confidence[ExtractFieldStartDate])
+	}
+
+	if e.PoolAmount != 100000 || e.PoolAsset != "USDT" {
+		t.Errorf("Expected 100,000 USDT pool, got %.0f %s", e.PoolAmount, e.PoolAsset)
+	}
+	if !e.KYCRequired || e.Confidence[ExtractFieldKYC] != 0.9 {
+		t.Errorf("Expected required KYC, got %v (%.2f)", e.KYCRequired, e.Confidence[ExtractFieldKYC])
+	}
+	if e.MinHolding != 500 || e.MinHoldingAsset != "USDT" {
+		t.Errorf("Expected 500 USDT min holding, got %.0f %s", e.MinHolding, e.MinHoldingAsset)
+	}
+	if strings.Join(e.ExcludedRegions, "|") != "United States|Canada|United Kingdom" {
+		t.Errorf("Unexpected excluded regions %v", e.ExcludedRegions)
+	}
+
+	opp := &models.Opportunity{Reward: "See announcement"}
+	e.Apply(opp)
+
+	if opp.Reward != "100,000 USDT" || opp.PoolSize != 100000 || opp.MinInvestment != 500 {
+		t.Errorf("Unexpected applied values %q %.0f %.0f", opp.Reward, opp.PoolSize, opp.MinInvestment)
+	}
+	if opp.Duration != "14 days" {
+		t.Errorf("Expected 14 days duration, got %q", opp.Duration)
+	}
+
+	expected := "KYC verification required; Minimum holding: 500 USDT; Not available in: United States, Canada, United Kingdom"
+	if opp.Requirements != expected {
+		t.Errorf("Expected requirements %q, got %q", expected, opp.Requirements)
+	}
+
+	extraction, ok := opp.Metadata["extraction"].(models.JSONMap)
+	if !ok || extraction["kyc_required"] != true || extraction["reward_pool"] != "100,000 USDT" {
+		t.Errorf("Unexpected extraction metadata %v", opp.Metadata["extraction"])
+	}
+}
+
+func TestExtractArticleLowConfidence(t *testing.T) {
+	// Одна дата без ключового слова та згадка KYC без вимоги
+	e := ExtractArticle("Published on March 3, 2025\nKYC news for everyone")
+
+	if e.StartDate != nil || e.EndDate != nil {
+		t.Errorf("Expected no dates without keywords, got %v - %v", e.StartDate, e.EndDate)
+	}
+	if e.Confidence[ExtractFieldKYC] != 0.5 {
+		t.Errorf("Expected weak KYC confidence, got %.2f", e.Confidence[ExtractFieldKYC])
+	}
+
+	opp := &models.Opportunity{}
+	e.Apply(opp)
+
+	if opp.Requirements != "" {
+		t.Errorf("Expected low-confidence KYC to stay out of requirements, got %q", opp.Requirements)
+	}
+	if opp.Metadata["extraction"] == nil {
+		t.Error("Expected low-confidence fields in metadata")
+	}
+}
+
+func TestArticleFetcherEnrichAll(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(testArticle))
+	}))
+	defer server.Close()
+
+	fetcher := NewArticleFetcher(server.Client())
+
+	opps := []*models.Opportunity{
+		{URL: server.URL + "/xyz"},
+		{URL: server.URL + "/xyz"},
+		{URL: server.URL + "/missing", Reward: "TBA"},
+		{},
+	}
+
+	fetcher.EnrichAll(opps)
+	fetcher.EnrichAll(opps)
+
+	// Повторні статті та помилки беруться з кешу
+	if requests.Load() != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests.Load())
+	}
+	for _, opp := range opps[:2] {
+		if opp.Reward != "100,000 USDT" {
+			t.Errorf("Expected enriched reward, got %q", opp.Reward)
+		}
+	}
+	if opps[2].Reward != "TBA" || opps[2].Metadata != nil {
+		t.Errorf("Expected failed article to leave opportunity untouched, got %q", opps[2].Reward)
+	}
+}
+
+func TestKeepExtraction(t *testing.T) {
+	end := time.Date(2025, 3, 24, 0, 0, 0, 0, time.UTC)
+	stored := &models.Opportunity{
+		Reward:   "100,000 USDT",
+		PoolSize: 100000,
+		EndDate:  &end,
+		Metadata: models.JSONMap{"extraction": map[string]interface{}{"reward_pool": "100,000 USDT"}},
+	}
+	scraped := &models.Opportunity{Reward: "See announcement", Metadata: models.JSONMap{"id": 1}}
+
+	keepExtraction(stored, scraped)
+
+	if changes := diffOpportunity(stored, scraped); len(changes) != 0 {
+		t.Errorf("Expected no changes when article was not fetched, got %v", changes)
+	}
+}
@@ -0,0 +1,246 @@
+package scraper
+
+import (
+	"bytes"
+	"crypto-opportunities-bot/internal/defi/defillama"
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// go test ./internal/scraper -run Fixtures -update
+var updateGolden = flag.Bool("update", false, "перезаписати testdata/scrapers/*/golden.json")
+
+// fixtureTransport направляє запити на будь-який хост (посилання на статті,
+// зашиті в скрапери) на сервер фікстур, щоб тести не виходили в мережу
+type fixtureTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return t.next.RoundTrip(req)
+}
+
+// newFixtureServer віддає записані відповіді з testdata/scrapers/<dir>/.
+// routes: "METHOD /path?query" -> файл; інші запити отримують 404
+func newFixtureServer(t *testing.T, dir string, routes map[string]string) (string, *http.Client) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := routes[r.Method+" "+r.URL.RequestURI()]
+		if !ok {
+			t.Logf("No fixture for %s %s", r.Method, r.URL.RequestURI())
+			http.NotFound(w, r)
+			return
+		}
+
+		body, err := os.ReadFile(filepath.Join("testdata", "scrapers", dir, file))
+		if err != nil {
+			t.Errorf("Failed to read fixture %s: %v", file, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	client := server.Client()
+	client.Transport = &fixtureTransport{target: target, next: client.Transport}
+
+	return server.URL, client
+}
+
+// assertGolden порівнює результат з testdata/scrapers/<dir>/golden.json
+func assertGolden(t *testing.T, dir string, value interface{}) {
+	t.Helper()
+
+	actual, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal result: %v", err)
+	}
+	actual = append(actual, '\n')
+
+	path := filepath.Join("testdata", "scrapers", dir, "golden.json")
+	if *updateGolden {
+		if err := os.WriteFile(path, actual, 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file (run with -update to create): %v", err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("Result differs from %s (run with -update if the change is expected):\n%s", path, actual)
+	}
+}
+
+// normalizeOpportunities стабільний порядок і UTC дати (скрапери створюють
+// дати в локальній зоні)
+func normalizeOpportunities(opps []*models.Opportunity) []*models.Opportunity {
+	sort.SliceStable(opps, func(i, j int) bool {
+		return opps[i].ExternalID < opps[j].ExternalID
+	})
+
+	for _, opp := range opps {
+		if opp.StartDate != nil {
+			start := opp.StartDate.UTC()
+			opp.StartDate = &start
+		}
+		if opp.EndDate != nil {
+			end := opp.EndDate.UTC()
+			opp.EndDate = &end
+		}
+	}
+
+	return opps
+}
+
+func TestScraperFixtures(t *testing.T) {
+	fixedNow := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		exchange string
+		routes   map[string]string
+		scraper  func(baseURL string, client *http.Client) Scraper
+	}{
+		{
+			exchange: models.ExchangeBinance,
+			routes: map[string]string{
+				"GET /bapi/earn/v1/public/launchpool/project/list":                                               "launchpool.json",
+				"GET /bapi/composite/v1/public/cms/article/list/query?catalogId=128&pageNo=1&pageSize=20&type=1": "airdrops.json",
+				"POST /bapi/composite/v1/public/cms/article/list/query":                                          "learn_earn.json",
+				"GET /en/support/announcement/a1b2c3":                                                            "article_a1b2c3.html",
+			},
+			scraper: func(baseURL string, client *http.Client) Scraper {
+				return NewBinanceScraperWithClient(baseURL, client)
+			},
+		},
+		{
+			exchange: models.ExchangeBybit,
+			routes: map[string]string{
+				"GET " + bybitAnnouncementsPath:           "announcements.json",
+				"GET /en/article/pixel-airdrop-blt17002/": "article_17002.html",
+			},
+			scraper: func(baseURL string, client *http.Client) Scraper {
+				return NewBybitScraperWithClient(baseURL, client)
+			},
+		},
+		{
+			exchange: models.ExchangeOKX,
+			routes: map[string]string{
+				"GET /priapi/v1/activity/jumpstart/list":                      "jumpstart.json",
+				"GET /priapi/v1/invest/activity/list?t=announcement&limit=20": "announcements.json",
+				"GET /priapi/v1/invest/activity/list?t=learn&limit=20":        "learn.json",
+				"GET /support/hc/en-us/articles/okx-a-101":                    "article_okx-a-101.html",
+			},
+			scraper: func(baseURL string, client *http.Client) Scraper {
+				return NewOKXScraperWithClient(baseURL, client)
+			},
+		},
+		{
+			exchange: models.ExchangeGateIO,
+			routes: map[string]string{
+				"GET /apiw/v1/startup/list":                               "startup.json",
+				"GET /apiw/v1/announcements?category=activities&limit=20": "activities.json",
+				"GET /apiw/v1/announcements?category=learn&limit=20":      "learn.json",
+				"GET /article/g-501":                                      "article_g-501.html",
+			},
+			scraper: func(baseURL string, client *http.Client) Scraper {
+				return NewGateIOScraperWithClient(baseURL, client)
+			},
+		},
+		{
+			exchange: models.ExchangeKraken,
+			routes: map[string]string{
+				"GET /0/public/Assets": "assets.json",
+			},
+			scraper: func(baseURL string, client *http.Client) Scraper {
+				s := NewKrakenScraperWithClient(baseURL, client)
+				s.now = func() time.Time { return fixedNow }
+				return s
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.exchange, func(t *testing.T) {
+			baseURL, client := newFixtureServer(t, tt.exchange, tt.routes)
+
+			opps, err := tt.scraper(baseURL, client).ScrapeAll()
+			if err != nil {
+				t.Fatalf("ScrapeAll failed: %v", err)
+			}
+			if len(opps) == 0 {
+				t.Fatal("Expected opportunities from fixtures")
+			}
+
+			assertGolden(t, tt.exchange, normalizeOpportunities(opps))
+		})
+	}
+}
+
+func TestScraperFixturesUnavailable(t *testing.T) {
+	baseURL, client := newFixtureServer(t, models.ExchangeBinance, nil)
+
+	if _, err := NewBinanceScraperWithClient(baseURL, client).ScrapeAll(); err == nil {
+		t.Error("Expected error when every endpoint fails")
+	}
+}
+
+type fakeDeFiRepo struct {
+	repository.DeFiRepository
+	created []*models.DeFiOpportunity
+}
+
+func (r *fakeDeFiRepo) GetByExternalID(externalID string) (*models.DeFiOpportunity, error) {
+	return nil, nil
+}
+
+func (r *fakeDeFiRepo) Create(opp *models.DeFiOpportunity) error {
+	r.created = append(r.created, opp)
+	return nil
+}
+
+func TestDeFiScraperFixtures(t *testing.T) {
+	baseURL, client := newFixtureServer(t, "defi", map[string]string{
+		"GET /pools": "pools.json",
+	})
+
+	repo := &fakeDeFiRepo{}
+	s := NewDeFiScraperWithClient(repo, DeFiScraperConfig{MinTVL: 100000}, defillama.NewClientWithHTTP(baseURL, client))
+
+	var notified int
+	s.OnNewDeFi(func(*models.DeFiOpportunity) { notified++ })
+
+	if _, err := s.ScrapeAll(); err != nil {
+		t.Fatalf("ScrapeAll failed: %v", err)
+	}
+
+	// Пул з TVL нижче MinTVL відфільтровано
+	if len(repo.created) != 2 || notified != 2 {
+		t.Fatalf("Expected 2 created and notified pools, got %d/%d", len(repo.created), notified)
+	}
+
+	for _, opp := range repo.created {
+		opp.LastChecked = time.Time{}
+	}
+
+	assertGolden(t, "defi", repo.created)
+}
@@ -13,15 +13,28 @@ import (
 	"time"
 )
 
+// GateIOBaseURL адреса API Gate.io
+const GateIOBaseURL = "https://www.gate.io"
+
 type GateIOScraper struct {
+	baseURL    string
 	httpClient *http.Client
+	articles   *ArticleFetcher // Тексти анонсів для airdrop та learn & earn
 }
 
 func NewGateIOScraper() *GateIOScraper {
+	return NewGateIOScraperWithClient(GateIOBaseURL, &http.Client{
+		Timeout: 10 * time.Second,
+	})
+}
+
+// NewGateIOScraperWithClient створює scraper з іншою адресою API та HTTP
+// клієнтом (проксі, тести з записаними відповідями)
+func NewGateIOScraperWithClient(baseURL string, httpClient *http.Client) *GateIOScraper {
 	return &GateIOScraper{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		articles:   NewArticleFetcher(httpClient),
 	}
 }
 
@@ -69,7 +82,7 @@ func (s *GateIOScraper) ScrapeAll() ([]*models.Opportunity, error) {
 
 func (s *GateIOScraper) ScrapeLaunchpool() ([]*models.Opportunity, error) {
 	// Gate.io Startup API
-	url := "https://www.gate.io/apiw/v1/startup/list"
+	url := s.baseURL + "/apiw/v1/startup/list"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -142,7 +155,7 @@ func (s *GateIOScraper) ScrapeLaunchpool() ([]*models.Opportunity, error) {
 
 func (s *GateIOScraper) ScrapeAirdrops() ([]*models.Opportunity, error) {
 	// Gate.io Announcements API
-	url := "https://www.gate.io/apiw/v1/announcements?category=activities&limit=20"
+	url := s.baseURL + "/apiw/v1/announcements?category=activities&limit=20"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -205,12 +218,14 @@ func (s *GateIOScraper) ScrapeAirdrops() ([]*models.Opportunity, error) {
 		opportunities = append(opportunities, opp)
 	}
 
+	s.articles.EnrichAll(opportunities)
+
 	return opportunities, nil
 }
 
 func (s *GateIOScraper) ScrapeLearnEarn() ([]*models.Opportunity, error) {
 	// Gate.io Learn & Earn campaigns (через announcements з фільтром)
-	url := "https://www.gate.io/apiw/v1/announcements?category=learn&limit=20"
+	url := s.baseURL + "/apiw/v1/announcements?category=learn&limit=20"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -272,6 +287,8 @@ func (s *GateIOScraper) ScrapeLearnEarn() ([]*models.Opportunity, error) {
 		opportunities = append(opportunities, opp)
 	}
 
+	s.articles.EnrichAll(opportunities)
+
 	return opportunities, nil
 }
 
@@ -0,0 +1,142 @@
+package scraper
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"log"
+	"time"
+)
+
+// StandaloneScraper скрапер, що сам зберігає свої результати поза Service
+// (DeFi) і запускається лише завданням зі своєю назвою
+type StandaloneScraper interface {
+	GetExchange() string
+	ScrapeAll() ([]*models.Opportunity, error)
+}
+
+// JobRunner опитує таблицю ScraperJob і виконує запуски, замовлені через
+// admin API (API та бот - окремі процеси)
+type JobRunner struct {
+	service    *Service
+	runRepo    repository.ScraperRunRepository
+	standalone map[string]StandaloneScraper
+	stopChan   chan struct{}
+}
+
+// NewJobRunner створює новий JobRunner
+func NewJobRunner(service *Service, runRepo repository.ScraperRunRepository) *JobRunner {
+	return &JobRunner{
+		service:    service,
+		runRepo:    runRepo,
+		standalone: make(map[string]StandaloneScraper),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// RegisterStandalone додає скрапер, який можна запустити завданням з його назвою
+func (r *JobRunner) RegisterStandalone(scraper StandaloneScraper) {
+	r.standalone[scraper.GetExchange()] = scraper
+}
+
+// Start завершує перервані попереднім процесом запуски, публікує список
+// зареєстрованих скраперів і починає опитування черги
+func (r *JobRunner) Start(interval time.Duration) {
+	if count, err := r.runRepo.FailInterrupted(); err != nil {
+		log.Printf("⚠️ Failed to close interrupted scraper runs: %v", err)
+	} else if count > 0 {
+		log.Printf("🔄 Closed %d scraper runs/jobs interrupted by restart", count)
+	}
+
+	r.service.reportHealth()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stopChan:
+				return
+			case <-ticker.C:
+				r.Poll()
+			}
+		}
+	}()
+
+	log.Printf("✅ Scraper job runner started (every %s)", interval)
+}
+
+// Stop зупиняє опитування
+func (r *JobRunner) Stop() {
+	close(r.stopChan)
+}
+
+// Poll виконує всі завдання з черги
+func (r *JobRunner) Poll() {
+	for {
+		job, err := r.runRepo.ClaimJob()
+		if err != nil {
+			log.Printf("❌ Failed to claim scraper job: %v", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+
+		r.execute(job)
+	}
+}
+
+// execute запускає скрапери завдання та зберігає підсумок
+func (r *JobRunner) execute(job *models.ScraperJob) {
+	var exchanges []string
+	if job.Exchange != "" {
+		exchanges = []string{job.Exchange}
+	}
+
+	log.Printf("🛠️ Running scraper job #%d (%s) requested by %s", job.ID, jobTarget(job), job.RequestedBy)
+
+	var summary RunSummary
+	var err error
+	if scraper, ok := r.standalone[job.Exchange]; ok {
+		summary, err = runStandalone(scraper)
+	} else {
+		summary, err = r.service.RunScrapers(exchanges, models.ScraperTriggerManual, &job.ID)
+	}
+
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Scrapers = summary.Scrapers
+	job.Failed = summary.Failed
+	job.Skipped = summary.Skipped
+	job.New = summary.New
+	job.Updated = summary.Updated
+
+	job.Status = models.ScraperJobDone
+	if err != nil {
+		job.Status = models.ScraperJobFailed
+		job.Error = err.Error()
+		log.Printf("❌ Scraper job #%d failed: %v", job.ID, err)
+	}
+
+	if err := r.runRepo.UpdateJob(job); err != nil {
+		log.Printf("❌ Failed to save scraper job #%d: %v", job.ID, err)
+	}
+}
+
+// runStandalone запускає скрапер поза Service: без історії запусків та baseline
+func runStandalone(scraper StandaloneScraper) (RunSummary, error) {
+	summary := RunSummary{Scrapers: 1}
+	if _, err := scraper.ScrapeAll(); err != nil {
+		summary.Failed = 1
+		return summary, err
+	}
+	return summary, nil
+}
+
+func jobTarget(job *models.ScraperJob) string {
+	if job.Exchange == "" {
+		return "all scrapers"
+	}
+	return job.Exchange
+}
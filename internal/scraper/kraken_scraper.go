@@ -13,15 +13,28 @@ import (
 	"time"
 )
 
+// KrakenBaseURL адреса API Kraken
+const KrakenBaseURL = "https://api.kraken.com"
+
 type KrakenScraper struct {
+	baseURL    string
 	httpClient *http.Client
+	now        func() time.Time // Дати стейкінгу та Earn рахуються від поточного часу
 }
 
 func NewKrakenScraper() *KrakenScraper {
+	return NewKrakenScraperWithClient(KrakenBaseURL, &http.Client{
+		Timeout: 10 * time.Second,
+	})
+}
+
+// NewKrakenScraperWithClient створює scraper з іншою адресою API та HTTP
+// клієнтом (проксі, тести з записаними відповідями)
+func NewKrakenScraperWithClient(baseURL string, httpClient *http.Client) *KrakenScraper {
 	return &KrakenScraper{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		now:        time.Now,
 	}
 }
 
@@ -72,7 +85,7 @@ func (s *KrakenScraper) ScrapeAll() ([]*models.Opportunity, error) {
 
 func (s *KrakenScraper) ScrapeLaunchpool() ([]*models.Opportunity, error) {
 	// Kraken Staking API (public endpoint)
-	url := "https://api.kraken.com/0/public/Assets"
+	url := s.baseURL + "/0/public/Assets"
 
 	resp, err := s.httpClient.Get(url)
 	if err != nil {
@@ -116,7 +129,7 @@ func (s *KrakenScraper) ScrapeLaunchpool() ([]*models.Opportunity, error) {
 			continue
 		}
 
-		now := time.Now()
+		now := s.now()
 		endDate := now.AddDate(1, 0, 0) // Staking is ongoing, set 1 year ahead
 
 		opp := &models.Opportunity{
@@ -166,7 +179,7 @@ func (s *KrakenScraper) ScrapeAirdrops() ([]*models.Opportunity, error) {
 		{"BTC", 2.5, "Earn on your BTC holdings", 0.0001},
 	}
 
-	now := time.Now()
+	now := s.now()
 	endDate := now.AddDate(0, 6, 0) // 6 months ahead
 
 	for _, program := range earnPrograms {
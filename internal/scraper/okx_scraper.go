@@ -13,15 +13,28 @@ import (
 	"time"
 )
 
+// OKXBaseURL адреса API OKX
+const OKXBaseURL = "https://www.okx.com"
+
 type OKXScraper struct {
+	baseURL    string
 	httpClient *http.Client
+	articles   *ArticleFetcher // Тексти анонсів для airdrop та learn & earn
 }
 
 func NewOKXScraper() *OKXScraper {
+	return NewOKXScraperWithClient(OKXBaseURL, &http.Client{
+		Timeout: 10 * time.Second,
+	})
+}
+
+// NewOKXScraperWithClient створює scraper з іншою адресою API та HTTP
+// клієнтом (проксі, тести з записаними відповідями)
+func NewOKXScraperWithClient(baseURL string, httpClient *http.Client) *OKXScraper {
 	return &OKXScraper{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		articles:   NewArticleFetcher(httpClient),
 	}
 }
 
@@ -69,7 +82,7 @@ func (s *OKXScraper) ScrapeAll() ([]*models.Opportunity, error) {
 
 func (s *OKXScraper) ScrapeLaunchpool() ([]*models.Opportunity, error) {
 	// OKX Jumpstart API
-	url := "https://www.okx.com/priapi/v1/activity/jumpstart/list"
+	url := s.baseURL + "/priapi/v1/activity/jumpstart/list"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -142,7 +155,7 @@ func (s *OKXScraper) ScrapeLaunchpool() ([]*models.Opportunity, error) {
 
 func (s *OKXScraper) ScrapeAirdrops() ([]*models.Opportunity, error) {
 	// OKX Announcements API
-	url := "https://www.okx.com/priapi/v1/invest/activity/list?t=announcement&limit=20"
+	url := s.baseURL + "/priapi/v1/invest/activity/list?t=announcement&limit=20"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -205,12 +218,14 @@ func (s *OKXScraper) ScrapeAirdrops() ([]*models.Opportunity, error) {
 		opportunities = append(opportunities, opp)
 	}
 
+	s.articles.EnrichAll(opportunities)
+
 	return opportunities, nil
 }
 
 func (s *OKXScraper) ScrapeLearnEarn() ([]*models.Opportunity, error) {
 	// OKX Learn API
-	url := "https://www.okx.com/priapi/v1/invest/activity/list?t=learn&limit=20"
+	url := s.baseURL + "/priapi/v1/invest/activity/list?t=learn&limit=20"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -268,6 +283,8 @@ func (s *OKXScraper) ScrapeLearnEarn() ([]*models.Opportunity, error) {
 		opportunities = append(opportunities, opp)
 	}
 
+	s.articles.EnrichAll(opportunities)
+
 	return opportunities, nil
 }
 
@@ -0,0 +1,134 @@
+package scraper
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Пороги важливих (material) змін, про які повідомляються користувачі
+const (
+	materialRelativeChange = 0.10               // ROI, розмір пулу, мін. інвестиція
+	materialEndDateEarlier = 24 * time.Hour     // Кінець перенесли раніше
+	materialEndDateLater   = 3 * 24 * time.Hour // Кінець продовжили
+)
+
+// diffOpportunity порівнює збережену можливість зі свіжою версією скрапера.
+// Дати порівнюються з точністю до дня (UTC), бо частина скраперів
+// рахує їх від time.Now()
+func diffOpportunity(stored, scraped *models.Opportunity) []models.FieldChange {
+	var changes []models.FieldChange
+
+	add := func(field, before, after string, material bool) {
+		if before != after {
+			changes = append(changes, models.FieldChange{Field: field, Old: before, New: after, Material: material})
+		}
+	}
+
+	add(models.RevisionFieldTitle, stored.Title, scraped.Title, false)
+	add(models.RevisionFieldDescription, stored.Description, scraped.Description, false)
+	add(models.RevisionFieldReward, stored.Reward, scraped.Reward, true)
+	add(models.RevisionFieldEstimatedROI, formatNumber(stored.EstimatedROI), formatNumber(scraped.EstimatedROI),
+		isMaterialNumberChange(stored.EstimatedROI, scraped.EstimatedROI))
+	add(models.RevisionFieldPoolSize, formatNumber(stored.PoolSize), formatNumber(scraped.PoolSize),
+		isMaterialNumberChange(stored.PoolSize, scraped.PoolSize))
+	add(models.RevisionFieldMinInvestment, formatNumber(stored.MinInvestment), formatNumber(scraped.MinInvestment),
+		isMaterialNumberChange(stored.MinInvestment, scraped.MinInvestment))
+	add(models.RevisionFieldDuration, stored.Duration, scraped.Duration, false)
+	add(models.RevisionFieldRequirements, stored.Requirements, scraped.Requirements, false)
+	add(models.RevisionFieldStartDate, formatDay(stored.StartDate), formatDay(scraped.StartDate), false)
+	add(models.RevisionFieldEndDate, formatDay(stored.EndDate), formatDay(scraped.EndDate),
+		isMaterialEndDateChange(stored.EndDate, scraped.EndDate))
+	add(models.RevisionFieldURL, stored.URL, scraped.URL, false)
+	add(models.RevisionFieldIsActive, strconv.FormatBool(stored.IsActive), strconv.FormatBool(scraped.IsActive), true)
+
+	// Перше заповнення полів зі статті - уточнення, а не зміна умов
+	if stored.Metadata["extraction"] == nil && scraped.Metadata["extraction"] != nil {
+		for i := range changes {
+			changes[i].Material = false
+		}
+	}
+
+	return changes
+}
+
+// applyScraped переносить відстежувані поля свіжої версії у збережену
+// (Metadata - лише якщо скрапер її заповнив)
+func applyScraped(stored, scraped *models.Opportunity) {
+	stored.Title = scraped.Title
+	stored.Description = scraped.Description
+	stored.Reward = scraped.Reward
+	stored.EstimatedROI = scraped.EstimatedROI
+	stored.PoolSize = scraped.PoolSize
+	stored.MinInvestment = scraped.MinInvestment
+	stored.Duration = scraped.Duration
+	stored.Requirements = scraped.Requirements
+	stored.StartDate = scraped.StartDate
+	stored.EndDate = scraped.EndDate
+	stored.URL = scraped.URL
+	stored.IsActive = scraped.IsActive
+
+	if scraped.Metadata != nil {
+		stored.Metadata = scraped.Metadata
+	}
+}
+
+// keepExtraction переносить у свіжу версію поля, раніше заповнені зі статті,
+// якщо цього запуску статтю не завантажено (помилка або ліміт ArticleFetcher).
+// Інакше значення зі списку та зі статті чергувалися б як зміни
+func keepExtraction(stored, scraped *models.Opportunity) {
+	if stored.Metadata["extraction"] == nil || scraped.Metadata["extraction"] != nil {
+		return
+	}
+
+	scraped.StartDate = stored.StartDate
+	scraped.EndDate = stored.EndDate
+	scraped.Duration = stored.Duration
+	scraped.Reward = stored.Reward
+	scraped.PoolSize = stored.PoolSize
+	scraped.MinInvestment = stored.MinInvestment
+	scraped.Requirements = stored.Requirements
+
+	if scraped.Metadata == nil {
+		scraped.Metadata = models.JSONMap{}
+	}
+	scraped.Metadata["extraction"] = stored.Metadata["extraction"]
+}
+
+// isMaterialNumberChange поява/зникнення значення або зміна на materialRelativeChange
+func isMaterialNumberChange(before, after float64) bool {
+	if before == after {
+		return false
+	}
+	if before == 0 || after == 0 {
+		return true
+	}
+	return math.Abs(after-before)/math.Abs(before) >= materialRelativeChange
+}
+
+// isMaterialEndDateChange кінець з'явився, зник, перенесений раніше хоча б
+// на день або продовжений щонайменше на 3 дні
+func isMaterialEndDateChange(before, after *time.Time) bool {
+	if before == nil || after == nil {
+		return before != after
+	}
+
+	shift := truncateDay(*after).Sub(truncateDay(*before))
+	return shift <= -materialEndDateEarlier || shift >= materialEndDateLater
+}
+
+func truncateDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+func formatDay(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,357 @@
+package scraper
+
+import (
+	"context"
+	"crypto-opportunities-bot/internal/models"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RunConfig параметри запуску скраперів у RunAll
+type RunConfig struct {
+	Concurrency      int           // Скільки скраперів працює одночасно
+	Timeout          time.Duration // Дедлайн одного ScrapeAll
+	Retries          int           // Повторні спроби після тимчасових помилок
+	RetryBackoff     time.Duration // Пауза перед першою повторною спробою (далі x2)
+	FailureThreshold int           // Невдалих запусків поспіль до відкриття circuit breaker
+	ProbeInterval    time.Duration // Пауза відкритого circuit breaker до пробного запуску
+}
+
+// DefaultRunConfig параметри за замовчуванням
+func DefaultRunConfig() RunConfig {
+	return RunConfig{
+		Concurrency:      4,
+		Timeout:          60 * time.Second,
+		Retries:          2,
+		RetryBackoff:     2 * time.Second,
+		FailureThreshold: 3,
+		ProbeInterval:    15 * time.Minute,
+	}
+}
+
+// withDefaults замінює нульові значення на значення за замовчуванням
+func (c RunConfig) withDefaults() RunConfig {
+	defaults := DefaultRunConfig()
+
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaults.Concurrency
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaults.Timeout
+	}
+	if c.Retries < 0 {
+		c.Retries = 0
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = defaults.RetryBackoff
+	}
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = defaults.FailureThreshold
+	}
+	if c.ProbeInterval <= 0 {
+		c.ProbeInterval = defaults.ProbeInterval
+	}
+
+	return c
+}
+
+// scrapeResult результат запуску одного скрапера
+type scrapeResult struct {
+	exchange      string
+	opportunities []*models.Opportunity
+	err           error
+	skipped       bool // Circuit breaker відкритий
+	run           *models.ScraperRun
+}
+
+// runScraper запускає скрапер з урахуванням circuit breaker, дедлайну та повторів
+func (s *Service) runScraper(scraper Scraper, trigger string, jobID *uint) scrapeResult {
+	exchange := scraper.GetExchange()
+	start := s.now()
+
+	result := scrapeResult{exchange: exchange}
+	result.run = s.startRun(exchange, trigger, jobID, start)
+
+	if allowed, reason := s.allowRun(exchange); !allowed {
+		log.Printf("⏸️ Skipping %s: %s", exchange, reason)
+		result.skipped = true
+		return result
+	}
+
+	log.Printf("Scraping %s...", exchange)
+
+	retries := 0
+	for attempt := 0; ; attempt++ {
+		result.opportunities, result.err = s.scrapeWithTimeout(scraper)
+		if result.err == nil || attempt >= s.runConfig.Retries || !isTransientError(result.err) {
+			break
+		}
+
+		backoff := s.runConfig.RetryBackoff << attempt
+		log.Printf("🔁 %s failed (%v), retrying in %s", exchange, result.err, backoff)
+		time.Sleep(backoff)
+		retries++
+	}
+
+	s.recordRun(exchange, start, retries, len(result.opportunities), result.err)
+
+	result.run.Found = len(result.opportunities)
+	result.run.Retries = retries
+
+	return result
+}
+
+// startRun створює запис ScraperRun зі статусом running
+func (s *Service) startRun(exchange, trigger string, jobID *uint, start time.Time) *models.ScraperRun {
+	run := &models.ScraperRun{
+		Exchange:  exchange,
+		Trigger:   trigger,
+		JobID:     jobID,
+		Status:    models.ScraperRunRunning,
+		StartedAt: start,
+	}
+
+	if s.runRepo != nil {
+		if err := s.runRepo.CreateRun(run); err != nil {
+			log.Printf("⚠️ Failed to save scraper run for %s: %v", exchange, err)
+		}
+	}
+
+	return run
+}
+
+// finishRun зберігає результат запуску в історії
+func (s *Service) finishRun(run *models.ScraperRun, status string, created, updated int, err error) {
+	now := s.now()
+
+	run.Status = status
+	run.FinishedAt = &now
+	run.DurationMs = now.Sub(run.StartedAt).Milliseconds()
+	run.New = created
+	run.Updated = updated
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	if s.runRepo == nil {
+		return
+	}
+
+	if err := s.runRepo.UpdateRun(run); err != nil {
+		log.Printf("⚠️ Failed to update scraper run for %s: %v", run.Exchange, err)
+	}
+}
+
+// errScrapeTimeout ScrapeAll не завершився за RunConfig.Timeout
+var errScrapeTimeout = errors.New("scraper timed out")
+
+// scrapeWithTimeout ScrapeAll з дедлайном. Скрапери не приймають context,
+// тому після дедлайну горутина дочекається HTTP таймауту клієнта у фоні;
+// поки вона працює, нові запуски біржі пропускаються (allowRun)
+func (s *Service) scrapeWithTimeout(scraper Scraper) ([]*models.Opportunity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.runConfig.Timeout)
+	defer cancel()
+
+	type output struct {
+		opportunities []*models.Opportunity
+		err           error
+	}
+
+	exchange := scraper.GetExchange()
+	s.setRunning(exchange, true)
+
+	done := make(chan output, 1)
+	go func() {
+		opportunities, err := scraper.ScrapeAll()
+		s.setRunning(exchange, false)
+		done <- output{opportunities, err}
+	}()
+
+	select {
+	case out := <-done:
+		return out.opportunities, out.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w after %s", errScrapeTimeout, s.runConfig.Timeout)
+	}
+}
+
+// setRunning позначає, що ScrapeAll біржі виконується або завершився
+func (s *Service) setRunning(exchange string, running bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if running {
+		s.running[exchange] = true
+	} else {
+		delete(s.running, exchange)
+	}
+}
+
+// allowRun чи можна запускати скрапер (інакше - причина пропуску). Запуск
+// займає біржу до завершення ScrapeAll, тому попередній запуск, покинутий
+// після таймауту, не перекривається новим. Відкритий circuit breaker після
+// ProbeInterval переходить у half_open і пропускає рівно один пробний запуск
+func (s *Service) allowRun(exchange string) (bool, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	health := s.health(exchange)
+
+	if s.running[exchange] {
+		health.TotalSkipped++
+		if health.State == models.ScraperCircuitHalfOpen {
+			return false, "probe run in progress"
+		}
+		return false, "previous run still in progress"
+	}
+
+	if health.State == models.ScraperCircuitOpen {
+		if health.NextProbeAt != nil && s.now().Before(*health.NextProbeAt) {
+			health.TotalSkipped++
+			return false, "circuit breaker open"
+		}
+
+		health.State = models.ScraperCircuitHalfOpen
+	}
+
+	s.running[exchange] = true
+	return true, ""
+}
+
+// recordRun оновлює статистику та стан circuit breaker після запуску
+func (s *Service) recordRun(exchange string, start time.Time, retries, found int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	health := s.health(exchange)
+
+	health.TotalRuns++
+	health.TotalRetries += int64(retries)
+	health.LastOpportunities = found
+	health.LastDurationMs = now.Sub(start).Milliseconds()
+	health.LastRunAt = &now
+
+	if err == nil {
+		health.State = models.ScraperCircuitClosed
+		health.ConsecutiveFailures = 0
+		health.LastSuccessAt = &now
+		health.OpenedAt = nil
+		health.NextProbeAt = nil
+		return
+	}
+
+	health.TotalFailures++
+	health.ConsecutiveFailures++
+	health.LastError = err.Error()
+	health.LastErrorAt = &now
+
+	// Невдала проба або поріг помилок - пауза до наступної проби
+	if health.State == models.ScraperCircuitHalfOpen || health.ConsecutiveFailures >= s.runConfig.FailureThreshold {
+		if health.OpenedAt == nil {
+			health.OpenedAt = &now
+			log.Printf("🔌 Circuit breaker opened for %s after %d failures", exchange, health.ConsecutiveFailures)
+		}
+
+		nextProbe := now.Add(s.runConfig.ProbeInterval)
+		health.State = models.ScraperCircuitOpen
+		health.NextProbeAt = &nextProbe
+	}
+}
+
+// health стан скрапера (викликати під s.mu)
+func (s *Service) health(exchange string) *models.ScraperHealth {
+	health, ok := s.states[exchange]
+	if !ok {
+		health = &models.ScraperHealth{
+			Exchange: exchange,
+			State:    models.ScraperCircuitClosed,
+		}
+		s.states[exchange] = health
+	}
+	return health
+}
+
+// Health знімок стану всіх зареєстрованих скраперів
+func (s *Service) Health() []*models.ScraperHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	health := make([]*models.ScraperHealth, 0, len(s.scrapers))
+	for _, scraper := range s.scrapers {
+		snapshot := *s.health(scraper.GetExchange())
+		snapshot.ReportedAt = now
+		health = append(health, &snapshot)
+	}
+
+	return health
+}
+
+// reportHealth зберігає стан скраперів для admin API
+func (s *Service) reportHealth() {
+	if s.healthRepo == nil {
+		return
+	}
+
+	for _, health := range s.Health() {
+		if err := s.healthRepo.Upsert(health); err != nil {
+			log.Printf("⚠️ Failed to save scraper health for %s: %v", health.Exchange, err)
+		}
+	}
+}
+
+// statusCodePattern статус з помилок "bad status code: 503"
+var statusCodePattern = regexp.MustCompile(`status code: (\d{3})`)
+
+// transientMarkers ознаки мережевих помилок у тексті (скрапери об'єднують
+// помилки через %v, тому тип помилки не завжди зберігається)
+var transientMarkers = []string{
+	"timeout",
+	"timed out",
+	"connection reset",
+	"connection refused",
+	"no such host",
+	"eof",
+	"tls handshake",
+}
+
+// isTransientError чи варто повторити запит: таймаути, мережеві помилки, 429 та 5xx
+func isTransientError(err error) bool {
+	// Горутина ScrapeAll після дедлайну ще працює - повтор запустив би другу паралельно
+	if errors.Is(err, errScrapeTimeout) {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+
+	for _, match := range statusCodePattern.FindAllStringSubmatch(message, -1) {
+		code, _ := strconv.Atoi(match[1])
+		if code == 429 || code >= 500 {
+			return true
+		}
+	}
+
+	for _, marker := range transientMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -22,17 +23,53 @@ type Scraper interface {
 
 type OpportunityCallback func(*models.Opportunity)
 
+// OpportunityUpdateCallback викликається для важливих змін існуючої можливості
+type OpportunityUpdateCallback func(*models.Opportunity, *models.OpportunityRevision)
+
 type Service struct {
 	scrapers                []Scraper
 	oppRepo                 repository.OpportunityRepository
+	healthRepo              repository.ScraperHealthRepository
+	runRepo                 repository.ScraperRunRepository
+	revisionRepo            repository.OpportunityRevisionRepository
+	projectRepo             repository.ProjectRepository
+	anomalyRepo             repository.ScraperAnomalyRepository
 	newOpportunityCallbacks []OpportunityCallback
+	updateCallbacks         []OpportunityUpdateCallback
+	anomalyCallbacks        []AnomalyCallback
+
+	runConfig RunConfig
+	runMu     sync.Mutex // Один запуск одночасно (cron + ручний запуск)
+
+	mu      sync.Mutex
+	states  map[string]*models.ScraperHealth // exchange -> стан circuit breaker
+	running map[string]bool                  // exchange -> ScrapeAll ще виконується (зокрема після таймауту)
+	now     func() time.Time
 }
 
-func NewScraperService(oppRepo repository.OpportunityRepository) *Service {
+func NewScraperService(
+	oppRepo repository.OpportunityRepository,
+	healthRepo repository.ScraperHealthRepository,
+	runRepo repository.ScraperRunRepository,
+	revisionRepo repository.OpportunityRevisionRepository,
+	projectRepo repository.ProjectRepository,
+	anomalyRepo repository.ScraperAnomalyRepository,
+) *Service {
 	return &Service{
 		scrapers:                []Scraper{},
 		oppRepo:                 oppRepo,
+		healthRepo:              healthRepo,
+		runRepo:                 runRepo,
+		revisionRepo:            revisionRepo,
+		projectRepo:             projectRepo,
+		anomalyRepo:             anomalyRepo,
 		newOpportunityCallbacks: []OpportunityCallback{},
+		updateCallbacks:         []OpportunityUpdateCallback{},
+		anomalyCallbacks:        []AnomalyCallback{},
+		runConfig:               DefaultRunConfig(),
+		states:                  make(map[string]*models.ScraperHealth),
+		running:                 make(map[string]bool),
+		now:                     time.Now,
 	}
 }
 
@@ -41,60 +78,261 @@ func (s *Service) RegisterScraper(scraper Scraper) {
 	log.Printf("Registered scraper: %s", scraper.GetExchange())
 }
 
+// SetRunConfig налаштовує паралельність, таймаути, повтори та circuit breaker
+// (нульові значення - за замовчуванням)
+func (s *Service) SetRunConfig(cfg RunConfig) {
+	s.runConfig = cfg.withDefaults()
+}
+
 func (s *Service) OnNewOpportunity(callback OpportunityCallback) {
 	s.newOpportunityCallbacks = append(s.newOpportunityCallbacks, callback)
 }
 
+// OnOpportunityUpdated реєструє callback для важливих змін існуючих можливостей
+func (s *Service) OnOpportunityUpdated(callback OpportunityUpdateCallback) {
+	s.updateCallbacks = append(s.updateCallbacks, callback)
+}
+
+// RunSummary підсумок запуску скраперів
+type RunSummary struct {
+	Scrapers int
+	Failed   int
+	Skipped  int
+	New      int
+	Updated  int
+}
+
+// RunAll запускає всі зареєстровані скрапери за розкладом
 func (s *Service) RunAll() error {
-	totalNew := 0
-	totalUpdated := 0
+	_, err := s.RunScrapers(nil, models.ScraperTriggerSchedule, nil)
+	return err
+}
 
-	for _, scraper := range s.scrapers {
-		log.Printf("Scraping %s...", scraper.GetExchange())
+// RunScrapers запускає скрапери бірж (nil - всі) паралельно, не більше
+// Concurrency одночасно. Результати зберігаються в БД послідовно, по мірі
+// завершення скраперів; кожен запуск записується в історію ScraperRun
+func (s *Service) RunScrapers(exchanges []string, trigger string, jobID *uint) (RunSummary, error) {
+	scrapers, err := s.selectScrapers(exchanges)
+	if err != nil {
+		return RunSummary{}, err
+	}
+
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	jobs := make(chan Scraper)
+	results := make(chan scrapeResult)
+
+	workers := min(s.runConfig.Concurrency, len(scrapers))
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for scraper := range jobs {
+				results <- s.runScraper(scraper, trigger, jobID)
+			}
+		}()
+	}
+
+	go func() {
+		for _, scraper := range scrapers {
+			jobs <- scraper
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	var summary RunSummary
 
-		opportunities, err := scraper.ScrapeAll()
-		if err != nil {
-			log.Printf("Error scraping %s: %v", scraper.GetExchange(), err)
+	for result := range results {
+		summary.Scrapers++
+
+		if result.skipped {
+			summary.Skipped++
+			s.finishRun(result.run, models.ScraperRunSkipped, 0, 0, nil)
 			continue
 		}
 
-		log.Printf("Found %d opportunities on %s", len(opportunities), scraper.GetExchange())
+		if result.err != nil {
+			log.Printf("Error scraping %s: %v", result.exchange, result.err)
+			summary.Failed++
+			s.finishRun(result.run, models.ScraperRunFailed, 0, 0, result.err)
+			continue
+		}
 
-		for _, opp := range opportunities {
-			existing, _ := s.oppRepo.GetByExternalID(opp.ExternalID)
+		log.Printf("Found %d opportunities on %s", len(result.opportunities), result.exchange)
 
-			if existing == nil {
-				if err := s.oppRepo.Create(opp); err != nil {
-					log.Printf("Error creating opportunity: %v", err)
-					continue
-				}
-				totalNew++
-				log.Printf("✅ New opportunity: %s - %s", opp.Exchange, opp.Title)
-
-				s.notifyNewOpportunity(opp)
-			} else {
-				existing.Title = opp.Title
-				existing.Description = opp.Description
-				existing.EstimatedROI = opp.EstimatedROI
-				existing.EndDate = opp.EndDate
-				existing.IsActive = opp.IsActive
-
-				if err := s.oppRepo.Update(existing); err != nil {
-					log.Printf("Error updating opportunity: %v", err)
+		created, updated := s.saveOpportunities(result.opportunities)
+		summary.New += created
+		summary.Updated += updated
+		s.finishRun(result.run, models.ScraperRunSuccess, created, updated, nil)
+		s.checkBaseline(result.exchange, result.opportunities)
+	}
+
+	log.Printf("Scraping completed: %d new, %d updated (%d failed, %d skipped)",
+		summary.New, summary.Updated, summary.Failed, summary.Skipped)
+
+	if err := s.oppRepo.DeactivateExpired(); err != nil {
+		log.Printf("Error deactivating expired: %v", err)
+	}
+
+	s.reportHealth()
+
+	return summary, nil
+}
+
+// Exchanges назви зареєстрованих скраперів
+func (s *Service) Exchanges() []string {
+	exchanges := make([]string, 0, len(s.scrapers))
+	for _, scraper := range s.scrapers {
+		exchanges = append(exchanges, scraper.GetExchange())
+	}
+	return exchanges
+}
+
+// selectScrapers зареєстровані скрапери за назвами бірж (nil - всі)
+func (s *Service) selectScrapers(exchanges []string) ([]Scraper, error) {
+	if len(exchanges) == 0 {
+		return s.scrapers, nil
+	}
+
+	selected := make([]Scraper, 0, len(exchanges))
+	for _, exchange := range exchanges {
+		found := false
+		for _, scraper := range s.scrapers {
+			if scraper.GetExchange() == exchange {
+				selected = append(selected, scraper)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("unknown scraper %q (registered: %s)", exchange, strings.Join(s.Exchanges(), ", "))
+		}
+	}
+
+	return selected, nil
+}
+
+// saveOpportunities створює нові можливості та оновлює існуючі, якщо
+// скрапер повернув змінені дані (незмінені не рахуються як updated).
+// Можливість без збігу по ExternalID (змінена назва) зіставляється зі
+// схожою збереженою; дублікати в межах запуску відкидаються
+func (s *Service) saveOpportunities(opportunities []*models.Opportunity) (int, int) {
+	totalNew := 0
+	totalUpdated := 0
+
+	batch := s.newDedupBatch()
+
+	// Спершу точні збіги, щоб нечіткий пошук не забрав їхні записи
+	stored := make([]*models.Opportunity, len(opportunities))
+	for i, opp := range opportunities {
+		stored[i], _ = s.oppRepo.GetByExternalID(opp.ExternalID)
+		if stored[i] != nil {
+			batch.claimed[stored[i].ID] = true
+		}
+	}
+
+	for i, opp := range opportunities {
+		existing := stored[i]
+
+		if existing == nil {
+			if duplicate := batch.findDuplicate(opp); duplicate != nil {
+				if batch.claimed[duplicate.ID] {
+					log.Printf("🔗 Skipping duplicate: %s - %s (same as #%d)", opp.Exchange, opp.Title, duplicate.ID)
 					continue
 				}
+
+				log.Printf("🔗 Merged %s - %q into #%d %q", opp.Exchange, opp.Title, duplicate.ID, duplicate.Title)
+				batch.claimed[duplicate.ID] = true
+				existing = duplicate
+			}
+		}
+
+		if existing == nil {
+			s.assignProject(opp)
+
+			if err := s.oppRepo.Create(opp); err != nil {
+				log.Printf("Error creating opportunity: %v", err)
+				continue
+			}
+			batch.add(opp)
+			totalNew++
+			log.Printf("✅ New opportunity: %s - %s", opp.Exchange, opp.Title)
+
+			s.notifyNewOpportunity(opp)
+		} else {
+			keepExtraction(existing, opp)
+			changes := diffOpportunity(existing, opp)
+
+			// Можливості, збережені до появи проєктів
+			linked := false
+			if existing.ProjectID == nil {
+				s.assignProject(existing)
+				linked = existing.ProjectID != nil
+			}
+
+			// Злита можливість (змінена назва) далі знаходиться точним збігом,
+			// а не лише нечітким пошуком у межах dedupWindow
+			rekeyed := existing.ExternalID != opp.ExternalID
+			if rekeyed {
+				existing.ExternalID = opp.ExternalID
+			}
+
+			if len(changes) == 0 && !linked && !rekeyed {
+				continue
+			}
+
+			applyScraped(existing, opp)
+
+			if err := s.oppRepo.Update(existing); err != nil {
+				log.Printf("Error updating opportunity: %v", err)
+				continue
+			}
+
+			if len(changes) > 0 {
 				totalUpdated++
+				s.saveRevision(existing, changes)
 			}
 		}
 	}
 
-	log.Printf("Scraping completed: %d new, %d updated", totalNew, totalUpdated)
+	return totalNew, totalUpdated
+}
+
+// saveRevision зберігає зміни в історії та повідомляє про важливі
+func (s *Service) saveRevision(opp *models.Opportunity, changes []models.FieldChange) {
+	revision := &models.OpportunityRevision{
+		OpportunityID: opp.ID,
+		Exchange:      opp.Exchange,
+		Changes:       changes,
+		DetectedAt:    s.now(),
+	}
+	for _, change := range changes {
+		if change.Material {
+			revision.Material = true
+			break
+		}
+	}
+
+	if s.revisionRepo != nil {
+		if err := s.revisionRepo.Create(revision); err != nil {
+			log.Printf("⚠️ Failed to save revision for opportunity %d: %v", opp.ID, err)
+		}
+	}
 
-	if err := s.oppRepo.DeactivateExpired(); err != nil {
-		log.Printf("Error deactivating expired: %v", err)
+	if !revision.Material {
+		return
 	}
 
-	return nil
+	log.Printf("📝 Opportunity changed: %s - %s (%d fields)", opp.Exchange, opp.Title, len(changes))
+
+	for _, callback := range s.updateCallbacks {
+		go callback(opp, revision)
+	}
 }
 
 func (s *Service) notifyNewOpportunity(opp *models.Opportunity) {
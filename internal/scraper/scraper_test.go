@@ -0,0 +1,911 @@
+package scraper
+
+import (
+	"crypto-opportunities-bot/internal/models"
+	"crypto-opportunities-bot/internal/repository"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeOppRepo struct {
+	repository.OpportunityRepository
+	mu      sync.Mutex
+	created []*models.Opportunity
+	updated int
+}
+
+func (r *fakeOppRepo) GetByExternalID(externalID string) (*models.Opportunity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, opp := range r.created {
+		if opp.ExternalID == externalID {
+			return opp, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (r *fakeOppRepo) Create(opp *models.Opportunity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.created = append(r.created, opp)
+	opp.ID = uint(len(r.created))
+	return nil
+}
+
+func (r *fakeOppRepo) ListRecentByExchangeAndType(exchange, oppType string, since time.Time) ([]*models.Opportunity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var opps []*models.Opportunity
+	for _, opp := range r.created {
+		if opp.Exchange == exchange && opp.Type == oppType && (opp.CreatedAt.IsZero() || !opp.CreatedAt.Before(since)) {
+			opps = append(opps, opp)
+		}
+	}
+	return opps, nil
+}
+
+func (r *fakeOppRepo) Update(opp *models.Opportunity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.updated++
+	return nil
+}
+
+func (r *fakeOppRepo) DeactivateExpired() error {
+	return nil
+}
+
+type fakeHealthRepo struct {
+	repository.ScraperHealthRepository
+	saved map[string]*models.ScraperHealth
+}
+
+func (r *fakeHealthRepo) Upsert(health *models.ScraperHealth) error {
+	r.saved[health.Exchange] = health
+	return nil
+}
+
+type fakeRunRepo struct {
+	repository.ScraperRunRepository
+	mu   sync.Mutex
+	runs []*models.ScraperRun
+	jobs []*models.ScraperJob
+}
+
+func (r *fakeRunRepo) CreateRun(run *models.ScraperRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.runs = append(r.runs, run)
+	run.ID = uint(len(r.runs))
+	return nil
+}
+
+func (r *fakeRunRepo) UpdateRun(run *models.ScraperRun) error {
+	return nil
+}
+
+func (r *fakeRunRepo) ClaimJob() (*models.ScraperJob, error) {
+	for _, job := range r.jobs {
+		if job.Status == models.ScraperJobPending {
+			job.Status = models.ScraperJobRunning
+			return job, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRunRepo) UpdateJob(job *models.ScraperJob) error {
+	return nil
+}
+
+func (r *fakeRunRepo) run(exchange string) *models.ScraperRun {
+	for _, run := range r.runs {
+		if run.Exchange == exchange {
+			return run
+		}
+	}
+	return nil
+}
+
+func (r *fakeRunRepo) lastRun(exchange string) *models.ScraperRun {
+	for i := len(r.runs) - 1; i >= 0; i-- {
+		if r.runs[i].Exchange == exchange {
+			return r.runs[i]
+		}
+	}
+	return nil
+}
+
+type fakeRevisionRepo struct {
+	repository.OpportunityRevisionRepository
+	revisions []*models.OpportunityRevision
+}
+
+func (r *fakeRevisionRepo) Create(revision *models.OpportunityRevision) error {
+	r.revisions = append(r.revisions, revision)
+	return nil
+}
+
+type fakeProjectRepo struct {
+	repository.ProjectRepository
+	projects []*models.Project
+}
+
+func (r *fakeProjectRepo) GetRecentBySymbol(symbol string, since time.Time) (*models.Project, error) {
+	for _, project := range r.projects {
+		if project.Symbol == symbol && !project.LastSeenAt.Before(since) {
+			return project, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeProjectRepo) Create(project *models.Project) error {
+	r.projects = append(r.projects, project)
+	project.ID = uint(len(r.projects))
+	return nil
+}
+
+func (r *fakeProjectRepo) Update(project *models.Project) error {
+	return nil
+}
+
+// fakeScraper повертає помилки зі списку по черзі, далі - одну можливість
+// (або версії з results по черзі, остання повторюється)
+type fakeScraper struct {
+	Scraper
+	exchange string
+	delay    time.Duration
+	errs     []error
+	results  [][]*models.Opportunity
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *fakeScraper) GetExchange() string {
+	return s.exchange
+}
+
+func (s *fakeScraper) ScrapeAll() ([]*models.Opportunity, error) {
+	s.mu.Lock()
+	call := s.calls
+	s.calls++
+	s.mu.Unlock()
+
+	time.Sleep(s.delay)
+
+	if call < len(s.errs) && s.errs[call] != nil {
+		return nil, s.errs[call]
+	}
+
+	if len(s.results) > 0 {
+		return s.results[min(call, len(s.results)-1)], nil
+	}
+
+	return []*models.Opportunity{{
+		ExternalID: GenerateExternalID(s.exchange, models.OpportunityTypeAirdrop, "test"),
+		Exchange:   s.exchange,
+		Type:       models.OpportunityTypeAirdrop,
+		Title:      s.exchange + " airdrop",
+	}}, nil
+}
+
+func (s *fakeScraper) callCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func newTestService(repo *fakeOppRepo, health *fakeHealthRepo, runs *fakeRunRepo) *Service {
+	service := NewScraperService(repo, health, runs, &fakeRevisionRepo{}, &fakeProjectRepo{}, nil)
+	service.SetRunConfig(RunConfig{
+		Concurrency:      3,
+		Timeout:          200 * time.Millisecond,
+		Retries:          2,
+		RetryBackoff:     time.Millisecond,
+		FailureThreshold: 2,
+		ProbeInterval:    time.Hour,
+	})
+	return service
+}
+
+func TestRunAllConcurrentWithTimeoutAndRetries(t *testing.T) {
+	repo := &fakeOppRepo{}
+	health := &fakeHealthRepo{saved: make(map[string]*models.ScraperHealth)}
+	runs := &fakeRunRepo{}
+	service := newTestService(repo, health, runs)
+
+	slow := &fakeScraper{exchange: "slow", delay: 150 * time.Millisecond}
+	hanging := &fakeScraper{exchange: "hanging", delay: time.Second}
+	flaky := &fakeScraper{exchange: "flaky", errs: []error{errors.New("bad status code: 503")}}
+	broken := &fakeScraper{exchange: "broken", errs: []error{errors.New("bad status code: 403")}}
+
+	for _, scraper := range []Scraper{slow, hanging, flaky, broken} {
+		service.RegisterScraper(scraper)
+	}
+
+	start := time.Now()
+	if err := service.RunAll(); err != nil {
+		t.Fatalf("RunAll failed: %v", err)
+	}
+
+	// Послідовно було б > 1с тільки через hanging; таймаут не повторюється
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("Expected concurrent run bounded by timeouts, took %s", elapsed)
+	}
+
+	if len(repo.created) != 2 {
+		t.Errorf("Expected opportunities from slow and flaky, got %d", len(repo.created))
+	}
+
+	if flaky.callCount() != 2 || health.saved["flaky"].TotalRetries != 1 {
+		t.Errorf("Expected flaky 503 retried once, got %d calls", flaky.callCount())
+	}
+	if broken.callCount() != 1 {
+		t.Errorf("Expected 403 not retried, got %d calls", broken.callCount())
+	}
+	if hanging.callCount() != 1 || health.saved["hanging"].ConsecutiveFailures != 1 || health.saved["hanging"].TotalRetries != 0 {
+		t.Errorf("Expected timeout not retried and recorded as failure, got %d calls", hanging.callCount())
+	}
+
+	if state := health.saved["slow"]; state.State != models.ScraperCircuitClosed || state.LastSuccessAt == nil || state.LastOpportunities != 1 {
+		t.Errorf("Expected healthy slow scraper, got %+v", state)
+	}
+
+	// Кожен запуск записаний в історію
+	if len(runs.runs) != 4 {
+		t.Fatalf("Expected 4 scraper runs, got %d", len(runs.runs))
+	}
+	if run := runs.run("flaky"); run.Status != models.ScraperRunSuccess || run.New != 1 || run.Retries != 1 || run.Trigger != models.ScraperTriggerSchedule {
+		t.Errorf("Expected successful flaky run with 1 new and 1 retry, got %s %d/%d", run.Status, run.New, run.Retries)
+	}
+	if run := runs.run("broken"); run.Status != models.ScraperRunFailed || run.Error == "" || run.FinishedAt == nil {
+		t.Errorf("Expected failed broken run with error, got %s", run.Status)
+	}
+
+	// Покинутий після таймауту ScrapeAll ще працює - новий запуск його не перекриває
+	if err := service.RunAll(); err != nil {
+		t.Fatalf("RunAll failed: %v", err)
+	}
+	if hanging.callCount() != 1 || runs.lastRun("hanging").Status != models.ScraperRunSkipped {
+		t.Errorf("Expected hanging scraper skipped while its abandoned run is in progress, got %d calls", hanging.callCount())
+	}
+	if slow.callCount() != 2 {
+		t.Errorf("Expected other scrapers to run again, got %d calls", slow.callCount())
+	}
+}
+
+func TestAllowRunSingleProbe(t *testing.T) {
+	service := newTestService(&fakeOppRepo{}, nil, nil)
+
+	past := time.Now().Add(-time.Minute)
+	service.states["okx"] = &models.ScraperHealth{Exchange: "okx", State: models.ScraperCircuitOpen, NextProbeAt: &past}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	admitted := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if allowed, _ := service.allowRun("okx"); allowed {
+				mu.Lock()
+				admitted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if admitted != 1 || service.states["okx"].State != models.ScraperCircuitHalfOpen {
+		t.Errorf("Expected exactly one half-open probe admitted, got %d", admitted)
+	}
+
+	// Після завершення проби біржа знову вільна
+	service.setRunning("okx", false)
+	if allowed, _ := service.allowRun("okx"); !allowed {
+		t.Error("Expected run allowed after the probe finished")
+	}
+}
+
+func TestRunAllCircuitBreaker(t *testing.T) {
+	repo := &fakeOppRepo{}
+	health := &fakeHealthRepo{saved: make(map[string]*models.ScraperHealth)}
+	runs := &fakeRunRepo{}
+	service := newTestService(repo, health, runs)
+
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	broken := &fakeScraper{exchange: "broken", errs: []error{
+		errors.New("invalid JSON"),
+		errors.New("invalid JSON"),
+		errors.New("invalid JSON"),
+	}}
+	service.RegisterScraper(broken)
+
+	service.RunAll()
+	if state := health.saved["broken"]; state.State != models.ScraperCircuitClosed || state.ConsecutiveFailures != 1 {
+		t.Fatalf("Expected closed breaker after 1 failure, got %s", state.State)
+	}
+
+	service.RunAll()
+	state := health.saved["broken"]
+	if state.State != models.ScraperCircuitOpen || state.OpenedAt == nil || !state.NextProbeAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("Expected breaker open until probe, got %s", state.State)
+	}
+
+	// Відкритий breaker - запуск пропускається
+	service.RunAll()
+	if broken.callCount() != 2 || health.saved["broken"].TotalSkipped != 1 {
+		t.Errorf("Expected skipped run while open, got %d calls", broken.callCount())
+	}
+
+	// Невдала проба - знову відкритий
+	now = now.Add(time.Hour)
+	service.RunAll()
+	if state := health.saved["broken"]; state.State != models.ScraperCircuitOpen || !state.NextProbeAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("Expected breaker reopened after failed probe, got %s", state.State)
+	}
+
+	// Успішна проба - закритий
+	now = now.Add(time.Hour)
+	service.RunAll()
+	state = health.saved["broken"]
+	if state.State != models.ScraperCircuitClosed || state.ConsecutiveFailures != 0 || state.OpenedAt != nil {
+		t.Errorf("Expected breaker closed after successful probe, got %s", state.State)
+	}
+	if state.TotalRuns != 4 || state.TotalFailures != 3 || len(repo.created) != 1 {
+		t.Errorf("Expected 4 runs with 3 failures, got %d/%d", state.TotalRuns, state.TotalFailures)
+	}
+	if len(runs.runs) != 5 || runs.runs[2].Status != models.ScraperRunSkipped {
+		t.Errorf("Expected skipped run recorded in history, got %d runs", len(runs.runs))
+	}
+}
+
+func TestJobRunner(t *testing.T) {
+	repo := &fakeOppRepo{}
+	health := &fakeHealthRepo{saved: make(map[string]*models.ScraperHealth)}
+	runs := &fakeRunRepo{}
+	service := newTestService(repo, health, runs)
+
+	service.RegisterScraper(&fakeScraper{exchange: "binance"})
+	service.RegisterScraper(&fakeScraper{exchange: "bybit"})
+
+	runs.jobs = []*models.ScraperJob{
+		{BaseModel: models.BaseModel{ID: 1}, Exchange: "bybit", Status: models.ScraperJobPending},
+		{BaseModel: models.BaseModel{ID: 2}, Exchange: "kraken", Status: models.ScraperJobPending},
+		{BaseModel: models.BaseModel{ID: 3}, Status: models.ScraperJobPending},
+	}
+
+	runner := NewJobRunner(service, runs)
+	runner.RegisterStandalone(&fakeScraper{exchange: "defi"})
+	runs.jobs = append(runs.jobs, &models.ScraperJob{BaseModel: models.BaseModel{ID: 4}, Exchange: "defi", Status: models.ScraperJobPending})
+	runner.Poll()
+
+	single, unknown, all, defi := runs.jobs[0], runs.jobs[1], runs.jobs[2], runs.jobs[3]
+
+	if single.Status != models.ScraperJobDone || single.Scrapers != 1 || single.New != 1 || single.FinishedAt == nil {
+		t.Errorf("Expected bybit job done with 1 new, got %s %d/%d", single.Status, single.Scrapers, single.New)
+	}
+	if unknown.Status != models.ScraperJobFailed || unknown.Error == "" {
+		t.Errorf("Expected unknown scraper job failed, got %s", unknown.Status)
+	}
+	// bybit повернув ту саму можливість - без змін не рахується як updated
+	if all.Status != models.ScraperJobDone || all.Scrapers != 2 || all.New != 1 || all.Updated != 0 {
+		t.Errorf("Expected all-scrapers job with 1 new and 0 updated, got %d/%d", all.New, all.Updated)
+	}
+	// DeFi зберігає результати сам і не входить до запусків Service
+	if defi.Status != models.ScraperJobDone || defi.Scrapers != 1 || defi.Failed != 0 {
+		t.Errorf("Expected standalone defi job done, got %s %d/%d", defi.Status, defi.Scrapers, defi.Failed)
+	}
+
+	if len(runs.runs) != 3 {
+		t.Fatalf("Expected 3 manual runs, got %d", len(runs.runs))
+	}
+	for _, run := range runs.runs {
+		if run.Trigger != models.ScraperTriggerManual || run.JobID == nil {
+			t.Errorf("Expected manual run linked to job, got %s %v", run.Trigger, run.JobID)
+		}
+	}
+}
+
+func TestDiffOpportunity(t *testing.T) {
+	end := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	stored := &models.Opportunity{
+		Title:        "XYZ Launchpool",
+		Reward:       "50,000 XYZ",
+		EstimatedROI: 10,
+		PoolSize:     50000,
+		EndDate:      &end,
+		IsActive:     true,
+	}
+
+	date := func(days int, hours int) *time.Time {
+		t := end.AddDate(0, 0, days).Add(time.Duration(hours) * time.Hour)
+		return &t
+	}
+
+	tests := []struct {
+		name     string
+		change   func(opp *models.Opportunity)
+		fields   string
+		material bool
+	}{
+		{"unchanged", func(opp *models.Opportunity) {}, "", false},
+		{"same day end", func(opp *models.Opportunity) { opp.EndDate = date(0, 6) }, "", false},
+		{"title only", func(opp *models.Opportunity) { opp.Title = "XYZ Launchpool (updated)" }, "title", false},
+		{"small roi", func(opp *models.Opportunity) { opp.EstimatedROI = 10.5 }, "estimated_roi", false},
+		{"large roi", func(opp *models.Opportunity) { opp.EstimatedROI = 8 }, "estimated_roi", true},
+		{"pool and reward", func(opp *models.Opportunity) { opp.PoolSize, opp.Reward = 100000, "100,000 XYZ" }, "reward,pool_size", true},
+		{"end earlier", func(opp *models.Opportunity) { opp.EndDate = date(-1, 0) }, "end_date", true},
+		{"end extended a bit", func(opp *models.Opportunity) { opp.EndDate = date(2, 0) }, "end_date", false},
+		{"end extended", func(opp *models.Opportunity) { opp.EndDate = date(3, 0) }, "end_date", true},
+		{"end removed", func(opp *models.Opportunity) { opp.EndDate = nil }, "end_date", true},
+		{"deactivated", func(opp *models.Opportunity) { opp.IsActive = false }, "is_active", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scraped := *stored
+			tt.change(&scraped)
+
+			changes := diffOpportunity(stored, &scraped)
+
+			fields := make([]string, 0, len(changes))
+			material := false
+			for _, change := range changes {
+				fields = append(fields, change.Field)
+				material = material || change.Material
+			}
+
+			if got := strings.Join(fields, ","); got != tt.fields || material != tt.material {
+				t.Errorf("Expected %q (material %v), got %q (material %v)", tt.fields, tt.material, got, material)
+			}
+		})
+	}
+}
+
+func TestRunAllRecordsRevisions(t *testing.T) {
+	repo := &fakeOppRepo{}
+	revisions := &fakeRevisionRepo{}
+	service := NewScraperService(repo, nil, nil, revisions, nil, nil)
+
+	version := func(title string, roi float64) []*models.Opportunity {
+		return []*models.Opportunity{{
+			ExternalID:   "xyz",
+			Exchange:     "binance",
+			Type:         models.OpportunityTypeLaunchpool,
+			Title:        title,
+			EstimatedROI: roi,
+			IsActive:     true,
+		}}
+	}
+
+	service.RegisterScraper(&fakeScraper{exchange: "binance", results: [][]*models.Opportunity{
+		version("XYZ Launchpool", 10),
+		version("XYZ Launchpool", 10),
+		version("XYZ Launchpool!", 10),
+		version("XYZ Launchpool!", 20),
+	}})
+
+	updates := make(chan *models.OpportunityRevision, 4)
+	service.OnOpportunityUpdated(func(opp *models.Opportunity, revision *models.OpportunityRevision) {
+		updates <- revision
+	})
+
+	for i := 0; i < 4; i++ {
+		service.RunAll()
+	}
+
+	if len(repo.created) != 1 || repo.updated != 2 {
+		t.Fatalf("Expected 1 created and 2 updates, got %d/%d", len(repo.created), repo.updated)
+	}
+
+	stored := repo.created[0]
+	if stored.Title != "XYZ Launchpool!" || stored.EstimatedROI != 20 {
+		t.Errorf("Expected stored opportunity updated, got %q %.0f", stored.Title, stored.EstimatedROI)
+	}
+
+	if len(revisions.revisions) != 2 {
+		t.Fatalf("Expected 2 revisions, got %d", len(revisions.revisions))
+	}
+	if revisions.revisions[0].Material {
+		t.Error("Expected title change to be non-material")
+	}
+
+	select {
+	case revision := <-updates:
+		changes := revision.MaterialChanges()
+		if len(changes) != 1 || changes[0].Old != "10" || changes[0].New != "20" {
+			t.Errorf("Expected material ROI change 10 -> 20, got %+v", changes)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected update callback for material change")
+	}
+
+	if len(updates) != 0 {
+		t.Error("Expected single update callback")
+	}
+}
+
+func TestExtractSymbol(t *testing.T) {
+	tests := []struct {
+		title  string
+		reward string
+		symbol string
+		name   string
+	}{
+		{"Binance Will List Pixels (PIXEL)", "", "PIXEL", "Pixels"},
+		{"Introducing Lorenzo Protocol (BANK) on Binance HODLer Airdrops!", "", "BANK", "Lorenzo Protocol"},
+		{"XYZ Airdrop: share 50,000 USDT", "", "XYZ", ""},
+		{"Trade to share the prize pool", "100,000 ABC", "ABC", ""},
+		{"Stake BNB (BNB) to earn USDT", "", "", ""},
+		{"Learn about blockchain basics", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		symbol, name := extractSymbol(&models.Opportunity{Title: tt.title, Reward: tt.reward})
+		if symbol != tt.symbol || name != tt.name {
+			t.Errorf("extractSymbol(%q) = %q, %q, want %q, %q", tt.title, symbol, name, tt.symbol, tt.name)
+		}
+	}
+}
+
+func TestDuplicateScore(t *testing.T) {
+	tests := []struct {
+		a, b      string
+		duplicate bool
+	}{
+		{"Introducing Pixels (PIXEL) on Binance Launchpool", "Introducing Pixels (PIXEL) on Binance Launchpool! Farm PIXEL by staking BNB", true},
+		{"XYZ Airdrop", "xyz airdrop!", true},
+		{"XYZ Airdrop", "ABC Airdrop", false},
+		{"Learn about staking and earn rewards", "Learn about bridges and earn rewards", false},
+		{"Learn about staking and earn rewards", "Learn about staking & earn rewards", true},
+	}
+
+	for _, tt := range tests {
+		score := duplicateScore(&models.Opportunity{Title: tt.a}, &models.Opportunity{Title: tt.b})
+		if (score > 0) != tt.duplicate {
+			t.Errorf("duplicateScore(%q, %q) = %.2f, want duplicate %v", tt.a, tt.b, score, tt.duplicate)
+		}
+	}
+}
+
+func TestSaveOpportunitiesDedup(t *testing.T) {
+	repo := &fakeOppRepo{}
+	revisions := &fakeRevisionRepo{}
+	projects := &fakeProjectRepo{}
+	service := NewScraperService(repo, nil, nil, revisions, projects, nil)
+
+	opportunity := func(exchange, oppType, title string) *models.Opportunity {
+		return &models.Opportunity{
+			ExternalID: GenerateExternalID(exchange, oppType, title),
+			Exchange:   exchange,
+			Type:       oppType,
+			Title:      title,
+			IsActive:   true,
+		}
+	}
+
+	const original = "Introducing Pixels (PIXEL) on Binance Launchpool"
+	const edited = "Introducing Pixels (PIXEL) on Binance Launchpool! Farm PIXEL by staking BNB"
+
+	created, _ := service.saveOpportunities([]*models.Opportunity{
+		opportunity("binance", models.OpportunityTypeLaunchpool, original),
+		opportunity("bybit", models.OpportunityTypeAirdrop, "PIXEL Airdrop: share 100,000 PIXEL"),
+		opportunity("bybit", models.OpportunityTypeAirdrop, "ABC Airdrop"),
+	})
+	if created != 3 || len(projects.projects) != 2 {
+		t.Fatalf("Expected 3 opportunities in 2 projects, got %d/%d", created, len(projects.projects))
+	}
+
+	binance, pixel, abc := repo.created[0], repo.created[1], repo.created[2]
+	if binance.ProjectID == nil || pixel.ProjectID == nil || *binance.ProjectID != *pixel.ProjectID {
+		t.Errorf("Expected PIXEL on Binance and Bybit linked to one project")
+	}
+	if abc.ProjectID == nil || *abc.ProjectID == *pixel.ProjectID {
+		t.Errorf("Expected ABC in separate project")
+	}
+	if projects.projects[0].DisplayName() != "Pixels (PIXEL)" {
+		t.Errorf("Expected project name from title, got %s", projects.projects[0].DisplayName())
+	}
+
+	// Змінена назва - новий ExternalID, але та сама можливість
+	created, updated := service.saveOpportunities([]*models.Opportunity{
+		opportunity("binance", models.OpportunityTypeLaunchpool, edited),
+	})
+	if created != 0 || updated != 1 || binance.Title != edited {
+		t.Fatalf("Expected title edit merged into existing, got %d new, %d updated", created, updated)
+	}
+	if len(revisions.revisions) != 1 || revisions.revisions[0].Changes[0].Field != models.RevisionFieldTitle {
+		t.Errorf("Expected title change recorded as revision")
+	}
+
+	if binance.ExternalID != GenerateExternalID("binance", models.OpportunityTypeLaunchpool, edited) {
+		t.Errorf("Expected merged opportunity to take the new ExternalID")
+	}
+
+	// Обидві версії в одному запуску - точний збіг має пріоритет, дубль відкидається
+	created, _ = service.saveOpportunities([]*models.Opportunity{
+		opportunity("binance", models.OpportunityTypeLaunchpool, edited),
+		opportunity("binance", models.OpportunityTypeLaunchpool, original),
+	})
+	if created != 0 || len(repo.created) != 3 || binance.Title != edited {
+		t.Errorf("Expected duplicate skipped and exact match kept, got %d new, title %q", created, binance.Title)
+	}
+}
+
+func TestSaveOpportunitiesRenamedAfterDedupWindow(t *testing.T) {
+	repo := &fakeOppRepo{}
+	service := NewScraperService(repo, nil, nil, nil, nil, nil)
+
+	now := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	opportunity := func(title string) *models.Opportunity {
+		return &models.Opportunity{
+			ExternalID: GenerateExternalID("okx", models.OpportunityTypeAirdrop, title),
+			Exchange:   "okx",
+			Type:       models.OpportunityTypeAirdrop,
+			Title:      title,
+			IsActive:   true,
+		}
+	}
+
+	const original = "OKX Wallet PIXEL Airdrop: 20,000 USDT Giveaway"
+	const renamed = "OKX Wallet PIXEL Airdrop: 20,000 USDT Giveaway (Extended)"
+
+	service.saveOpportunities([]*models.Opportunity{opportunity(original)})
+	repo.created[0].CreatedAt = now
+
+	now = now.Add(24 * time.Hour)
+	if created, updated := service.saveOpportunities([]*models.Opportunity{opportunity(renamed)}); created != 0 || updated != 1 {
+		t.Fatalf("Expected rename merged into existing, got %d new, %d updated", created, updated)
+	}
+
+	// Запис старший за dedupWindow - нечіткий пошук його вже не бачить
+	now = now.Add(dedupWindow + 24*time.Hour)
+	created, _ := service.saveOpportunities([]*models.Opportunity{opportunity(renamed)})
+	if created != 0 || len(repo.created) != 1 {
+		t.Errorf("Expected renamed opportunity matched by ExternalID after dedup window, got %d new", created)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errors.New("bad status code: 503"), true},
+		{errors.New("all scrapers failed: [launchpool: bad status code: 403 airdrops: bad status code: 429]"), true},
+		{errors.New("failed to fetch launchpool: Get \"https://x\": context deadline exceeded (Client.Timeout exceeded)"), true},
+		{errors.New("read tcp: connection reset by peer"), true},
+		{fmt.Errorf("%w after 1m0s", errScrapeTimeout), false},
+		{errors.New("bad status code: 404"), false},
+		{errors.New("failed to parse JSON: invalid character"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isTransientError(tt.err); got != tt.want {
+			t.Errorf("isTransientError(%q) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+type fakeAnomalyRepo struct {
+	repository.ScraperAnomalyRepository
+	baselines map[string]*models.ScraperBaseline
+	anomalies []*models.ScraperAnomaly
+}
+
+func (r *fakeAnomalyRepo) GetBaseline(exchange string) (*models.ScraperBaseline, error) {
+	return r.baselines[exchange], nil
+}
+
+func (r *fakeAnomalyRepo) SaveBaseline(baseline *models.ScraperBaseline) error {
+	r.baselines[baseline.Exchange] = baseline
+	return nil
+}
+
+func (r *fakeAnomalyRepo) CreateAnomaly(anomaly *models.ScraperAnomaly) error {
+	r.anomalies = append(r.anomalies, anomaly)
+	return nil
+}
+
+func (r *fakeAnomalyRepo) LastAnomaly(exchange, kind, field string) (*models.ScraperAnomaly, error) {
+	for i := len(r.anomalies) - 1; i >= 0; i-- {
+		a := r.anomalies[i]
+		if a.Exchange == exchange && a.Kind == kind && a.Field == field {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestCheckBaselineAnomalies(t *testing.T) {
+	anomalies := &fakeAnomalyRepo{baselines: make(map[string]*models.ScraperBaseline)}
+	service := NewScraperService(&fakeOppRepo{}, nil, nil, nil, nil, anomalies)
+
+	now := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	alerts := make(chan []*models.ScraperAnomaly, 10)
+	service.OnScraperAnomaly(func(exchange string, anomalies []*models.ScraperAnomaly) {
+		alerts <- anomalies
+	})
+
+	batch := func(n int, withEndDate bool) []*models.Opportunity {
+		opps := make([]*models.Opportunity, n)
+		for i := range opps {
+			opps[i] = &models.Opportunity{Title: "XYZ", URL: "https://x", EstimatedROI: 12}
+			if withEndDate {
+				end := now.Add(24 * time.Hour)
+				opps[i].EndDate = &end
+			}
+		}
+		return opps
+	}
+
+	for i := 0; i < baselineMinSamples; i++ {
+		service.checkBaseline("binance", batch(5, true))
+	}
+	if len(anomalies.anomalies) != 0 {
+		t.Fatalf("Expected no anomalies while learning baseline, got %d", len(anomalies.anomalies))
+	}
+
+	tests := []struct {
+		name   string
+		opps   []*models.Opportunity
+		kind   string
+		field  string
+		expect bool
+	}{
+		{"normal run", batch(6, true), "", "", false},
+		{"end date disappeared", batch(5, false), models.ScraperAnomalyFieldEmpty, models.RevisionFieldEndDate, true},
+		{"same field within cooldown", batch(5, false), "", "", false},
+		{"zero results", nil, models.ScraperAnomalyZeroResults, "", true},
+		{"spike", batch(40, true), models.ScraperAnomalySpike, "", true},
+	}
+
+	for _, tt := range tests {
+		before := len(anomalies.anomalies)
+		service.checkBaseline("binance", tt.opps)
+
+		added := anomalies.anomalies[before:]
+		if !tt.expect {
+			if len(added) != 0 {
+				t.Errorf("%s: expected no anomalies, got %s", tt.name, added[0].Message)
+			}
+			continue
+		}
+
+		if len(added) != 1 || added[0].Kind != tt.kind || added[0].Field != tt.field {
+			t.Errorf("%s: expected %s %s anomaly, got %d anomalies", tt.name, tt.kind, tt.field, len(added))
+			continue
+		}
+
+		select {
+		case reported := <-alerts:
+			if reported[0] != added[0] {
+				t.Errorf("%s: expected saved anomaly in callback", tt.name)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%s: expected anomaly callback", tt.name)
+		}
+	}
+
+	// Після інтервалу повтору та сама аномалія повідомляється знову
+	service.checkBaseline("binance", nil)
+	now = now.Add(anomalyRepeatInterval)
+	before := len(anomalies.anomalies)
+	service.checkBaseline("binance", nil)
+	if len(anomalies.anomalies) != before+1 {
+		t.Errorf("Expected repeated zero results anomaly after repeat interval")
+	}
+}
+
+func TestCheckBaselineSustainedDrop(t *testing.T) {
+	anomalies := &fakeAnomalyRepo{baselines: make(map[string]*models.ScraperBaseline)}
+	service := NewScraperService(&fakeOppRepo{}, nil, nil, nil, nil, anomalies)
+
+	now := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	batch := func(n int) []*models.Opportunity {
+		opps := make([]*models.Opportunity, n)
+		for i := range opps {
+			opps[i] = &models.Opportunity{Title: "XYZ", URL: "https://x"}
+		}
+		return opps
+	}
+
+	for i := 0; i < baselineMinSamples; i++ {
+		service.checkBaseline("okx", batch(8))
+	}
+
+	// Добу скрапер повертає нуль результатів щогодини
+	for i := 0; i < 24; i++ {
+		now = now.Add(time.Hour)
+		service.checkBaseline("okx", nil)
+	}
+
+	baseline := anomalies.baselines["okx"]
+	if baseline.AvgCount != 8 || baseline.Samples != baselineMinSamples {
+		t.Errorf("Expected baseline frozen during outage, got avg %.2f from %d samples", baseline.AvgCount, baseline.Samples)
+	}
+	if baseline.LastCount != 0 || !baseline.LastRunAt.Equal(now) {
+		t.Errorf("Expected last run recorded during outage, got %d at %s", baseline.LastCount, baseline.LastRunAt)
+	}
+
+	// Збій не стає нормою: аномалія повторюється кожні anomalyRepeatInterval
+	if len(anomalies.anomalies) != 4 {
+		t.Errorf("Expected zero results reported every 6 hours, got %d anomalies", len(anomalies.anomalies))
+	}
+	for _, anomaly := range anomalies.anomalies {
+		if anomaly.Kind != models.ScraperAnomalyZeroResults || anomaly.Expected != 8 {
+			t.Errorf("Expected zero results against frozen average 8, got %s %.2f", anomaly.Kind, anomaly.Expected)
+		}
+	}
+
+	// Після відновлення baseline знову оновлюється
+	service.checkBaseline("okx", batch(8))
+	if baseline := anomalies.baselines["okx"]; baseline.Samples != baselineMinSamples+1 || baseline.AnomalousSince != nil {
+		t.Errorf("Expected baseline updated after recovery, got %d samples", baseline.Samples)
+	}
+}
+
+func TestCheckBaselinePersistentShift(t *testing.T) {
+	anomalies := &fakeAnomalyRepo{baselines: make(map[string]*models.ScraperBaseline)}
+	service := NewScraperService(&fakeOppRepo{}, nil, nil, nil, nil, anomalies)
+
+	now := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	batch := func(n int) []*models.Opportunity {
+		opps := make([]*models.Opportunity, n)
+		for i := range opps {
+			opps[i] = &models.Opportunity{Title: "XYZ", URL: "https://x"}
+		}
+		return opps
+	}
+
+	for i := 0; i < baselineMinSamples; i++ {
+		service.checkBaseline("gateio", batch(5))
+	}
+
+	// Біржа назавжди почала віддавати більше кампаній
+	for i := 0; i < 30; i++ {
+		now = now.Add(time.Hour)
+		service.checkBaseline("gateio", batch(40))
+	}
+
+	// Сплеск повідомляється кожні 6 годин, поки не мине доба
+	if len(anomalies.anomalies) != 4 {
+		t.Errorf("Expected spike reported until the baseline is re-learned, got %d anomalies", len(anomalies.anomalies))
+	}
+
+	baseline := anomalies.baselines["gateio"]
+	if baseline.AvgCount != 40 || baseline.AnomalousSince != nil || baseline.Samples != 6 {
+		t.Errorf("Expected baseline re-learned at the new level, got avg %.2f from %d samples", baseline.AvgCount, baseline.Samples)
+	}
+
+	// Після повторного вивчення той самий рівень - норма
+	before := len(anomalies.anomalies)
+	now = now.Add(anomalyRepeatInterval)
+	service.checkBaseline("gateio", batch(40))
+	if len(anomalies.anomalies) != before {
+		t.Errorf("Expected new level accepted after re-learning, got %s", anomalies.anomalies[len(anomalies.anomalies)-1].Message)
+	}
+}